@@ -438,7 +438,7 @@ func (f *File) adjustFormulaRef(sheet, sheetN, formula string, keepRelative bool
 			definedNames = append(definedNames, definedName.Name)
 		}
 	}
-	for _, token := range ps.Parse(formula) {
+	for _, token := range mergeStructuredRefTokens(ps.Parse(formula)) {
 		if token.TType == efp.TokenTypeUnknown {
 			val = formula
 			break
@@ -449,7 +449,7 @@ func (f *File) adjustFormulaRef(sheet, sheetN, formula string, keepRelative bool
 				continue
 			}
 			if strings.ContainsAny(token.TValue, "[]") && !isExternalSheetReference(token.TValue) {
-				val += token.TValue
+				val += f.adjustStructuredRef(sheet, token.TValue, dir, num, offset)
 				continue
 			}
 			operand, err := f.adjustFormulaOperand(sheet, sheetN, keepRelative, token, dir, num, offset)
@@ -472,6 +472,29 @@ func (f *File) adjustFormulaRef(sheet, sheetN, formula string, keepRelative bool
 	return val, nil
 }
 
+// adjustStructuredRef returns the #REF! error instead of the given structured
+// reference when deleting the table columns which referenced by it.
+func (f *File) adjustStructuredRef(sheet, ref string, dir adjustDirection, num, offset int) string {
+	sr, ok := parseStructuredRef(ref)
+	if !ok || sr.table == "" || dir != columns || offset >= 0 {
+		return ref
+	}
+	tableSheet, t := f.getTable(sheet, "", sr.table)
+	if t == nil || tableSheet != sheet {
+		return ref
+	}
+	coordinates, err := rangeRefToCoordinates(t.Ref)
+	if err != nil {
+		return ref
+	}
+	for _, name := range sr.columns {
+		if col := t.getColumnNumber(coordinates[0], name); col >= num && col < num-offset {
+			return formulaErrorREF
+		}
+	}
+	return ref
+}
+
 // transformParenthesesToken returns formula part with parentheses by given
 // token.
 func transformParenthesesToken(token efp.Token) string {
@@ -693,6 +716,7 @@ func (f *File) adjustTable(ws *xlsxWorksheet, sheet string, dir adjustDirection,
 		table, _ := xml.Marshal(t)
 		f.saveFileList(tableXML, table)
 	}
+	f.tableRefs.Store(nil)
 	return nil
 }
 
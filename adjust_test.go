@@ -328,6 +328,49 @@ func TestAdjustTable(t *testing.T) {
 	assert.NoError(t, f.RemoveCol(sheetName, "H"))
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAdjustTable.xlsx")))
 
+	// Test adjust structured references when inserting or deleting rows and columns
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow(sheetName, "B1", &[]interface{}{"Item", "Qty", "Price"}))
+	for row := 2; row <= 5; row++ {
+		assert.NoError(t, f.SetSheetRow(sheetName, fmt.Sprintf("B%d", row), &[]interface{}{"a", row, row * 10}))
+	}
+	assert.NoError(t, f.AddTable(sheetName, &Table{Range: "B1:D5", Name: "Sales"}))
+	assert.NoError(t, f.SetCellFormula(sheetName, "F1", "SUM(Sales[Qty])"))
+	assert.NoError(t, f.SetCellFormula(sheetName, "F2", "SUM(Sales[Price])+COUNT(Sales[Price])"))
+	assert.NoError(t, f.SetCellFormula(sheetName, "F3", "SUM(Sales[[Item]:[Qty]])"))
+	assert.NoError(t, f.InsertRows(sheetName, 3, 1))
+	assert.NoError(t, f.SetCellValue(sheetName, "C3", 10))
+	result, err := f.CalcCellValue(sheetName, "F1")
+	assert.NoError(t, err)
+	assert.Equal(t, "24", result)
+	assert.NoError(t, f.RemoveCol(sheetName, "C"))
+	formula, err := f.GetCellFormula(sheetName, "E2")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(Sales[Price])+COUNT(Sales[Price])", formula)
+	formula, err = f.GetCellFormula(sheetName, "E4")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(#REF!)", formula)
+	tables, err := f.GetTables(sheetName)
+	assert.NoError(t, err)
+	assert.Equal(t, "B1:C6", tables[0].Range)
+	result, err = f.CalcCellValue(sheetName, "E2")
+	assert.NoError(t, err)
+	assert.Equal(t, "144", result)
+
+	// Test adjust table with the totals row and without header row
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow(sheetName, "A1", &[]interface{}{"Item", "Qty"}))
+	assert.NoError(t, f.AddTable(sheetName, &Table{Range: "A1:B5", Name: "Totals"}))
+	content, ok := f.Pkg.Load("xl/tables/table1.xml")
+	assert.True(t, ok)
+	f.Pkg.Store("xl/tables/table1.xml", []byte(strings.ReplaceAll(string(content.([]byte)), `ref="A1:B5"`, `ref="A1:B5" headerRowCount="0" totalsRowCount="1"`)))
+	assert.NoError(t, f.RemoveRow(sheetName, 5))
+	_, t1 := f.getTable(sheetName, "", "Totals")
+	assert.Equal(t, "A1:B4", t1.Ref)
+	assert.Equal(t, 0, t1.TotalsRowCount)
+	assert.Equal(t, "Item", t1.TableColumns.TableColumn[0].Name)
+	assert.Equal(t, "Qty", t1.TableColumns.TableColumn[1].Name)
+
 	f = NewFile()
 	assert.NoError(t, f.AddTable(sheetName, &Table{Range: "A1:D5"}))
 	// Test adjust table with non-table part
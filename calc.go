@@ -23,6 +23,7 @@ import (
 	"net/url"
 	"reflect"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -49,6 +50,9 @@ const (
 	formulaErrorSPILL       = "#SPILL!"
 	formulaErrorCALC        = "#CALC!"
 	formulaErrorGETTINGDATA = "#GETTING_DATA"
+	// maxLambdaDepth defined the maximum nesting depth of the LAMBDA function
+	// invocations
+	maxLambdaDepth = 1024
 	// Formula criteria condition enumeration
 	_ byte = iota
 	criteriaEq
@@ -101,6 +105,15 @@ const (
 )
 
 var (
+	// lambdaFuncs defined the list of functions which arguments will be
+	// evaluated lazily.
+	lambdaFuncs = []string{"BYCOL", "BYROW", "LAMBDA", "LET", "MAKEARRAY", "MAP", "REDUCE", "SCAN"}
+	// formulaErrors defined the list of supported formula error values.
+	formulaErrors = []string{
+		formulaErrorDIV, formulaErrorNAME, formulaErrorNA, formulaErrorNUM,
+		formulaErrorVALUE, formulaErrorREF, formulaErrorNULL, formulaErrorSPILL,
+		formulaErrorCALC, formulaErrorGETTINGDATA,
+	}
 	// wildcardTokenRE tokenizes an Excel wildcard pattern into tilde-escaped
 	// sequences, bare wildcards (* ?), or any other single character.
 	wildcardTokenRE = regexp.MustCompile(`~[*?~]|[*?]|[\s\S]`)
@@ -208,7 +221,10 @@ var (
 		},
 	}
 	formulaFnNameReplacer = strings.NewReplacer("_xlfn.", "", ".", "dot")
-	formulaFormats        = []*regexp.Regexp{
+	// formulaFnNames cache the results of checking whether the given names
+	// are the built-in formula functions.
+	formulaFnNames = sync.Map{}
+	formulaFormats = []*regexp.Regexp{
 		regexp.MustCompile(`^(\d+)$`),
 		regexp.MustCompile(`^=(.*)$`),
 		regexp.MustCompile(`^<>(.*)$`),
@@ -253,11 +269,60 @@ var (
 
 // calcContext defines the formula execution context.
 type calcContext struct {
-	mu                sync.Mutex
-	entry             string
-	maxCalcIterations uint
-	iterations        map[string]uint
-	iterationsCache   map[string]formulaArg
+	mu                   sync.Mutex
+	entry                string
+	dynamicArray         bool
+	maxCalcIterations    uint
+	iterations           map[string]uint
+	iterationsCache      map[string]formulaArg
+	scope                *formulaScope
+	operands             map[string]formulaArg
+	lambdaDepth          int
+	externalLinkResolver func(target string) (*File, error)
+	externalReferences   *sync.Map
+}
+
+// formulaScope defined the lexical scope of the names bound by the LET
+// function and the parameters of the LAMBDA function.
+type formulaScope struct {
+	parent *formulaScope
+	names  map[string]formulaArg
+}
+
+// formulaLambda defined the function value created by the LAMBDA function.
+type formulaLambda struct {
+	params []string
+	body   []efp.Token
+	scope  *formulaScope
+}
+
+// lookup returns the value bound to the given name in the scope or its
+// parent scopes.
+func (s *formulaScope) lookup(name string) (formulaArg, bool) {
+	for scope := s; scope != nil; scope = scope.parent {
+		if arg, ok := scope.names[formulaVarName(name)]; ok {
+			return arg, ok
+		}
+	}
+	return formulaArg{}, false
+}
+
+// inScope returns true if the formula is evaluating in the scope of the LET
+// or LAMBDA function.
+func (ctx *calcContext) inScope() bool {
+	return ctx != nil && ctx.scope != nil
+}
+
+// newScope returns a new child scope of the given scope.
+func newScope(parent *formulaScope) *formulaScope {
+	return &formulaScope{parent: parent, names: make(map[string]formulaArg)}
+}
+
+// isDynamicArrayEntry returns true if the given cell is the entry cell of
+// the dynamic array formula calculation, the array result of the formula in
+// this cell should not be reduced to a single value.
+func (ctx *calcContext) isDynamicArrayEntry(sheet, cell string) bool {
+	return ctx != nil && ctx.dynamicArray && ctx.entry == sheet+"!"+cell
 }
 
 // cellRef defines the structure of a cell reference.
@@ -291,8 +356,13 @@ const (
 	ArgMatrix
 	ArgError
 	ArgEmpty
+	ArgLambda
 )
 
+// FormulaArg is the argument of a formula or function, which used for the
+// custom formula functions registered by the RegisterFormulaFunction.
+type FormulaArg = formulaArg
+
 // formulaArg is the argument of a formula or function.
 type formulaArg struct {
 	SheetName            string
@@ -304,6 +374,7 @@ type formulaArg struct {
 	Error                string
 	Type                 ArgType
 	cellRefs, cellRanges *list.List
+	lambda               *formulaLambda
 }
 
 // Value returns a string data type of the formula argument.
@@ -413,9 +484,23 @@ func (fn *formulaFuncs) implicitIntersect(arg formulaArg) formulaArg {
 }
 
 // CalcCellValue provides a function to get calculated cell value. This feature
-// is currently in working processing. Iterative calculation, implicit
-// intersection, explicit intersection, array formula, table formula and some
-// other formulas are not supported currently.
+// is currently in working processing. Implicit intersection, explicit
+// intersection, array formula, table formula and some other formulas are not
+// supported currently.
+//
+// The formula cells in circular references will be calculated by iterative
+// calculation if it has been enabled by the Iterate field in the workbook
+// calculation properties, the calculation will be repeated until the values
+// change less than the IterateDelta, or reached the IterateCount. The value
+// of the last iteration will be returned with an error of the
+// ErrCalcNotConverged type if the values not converged. For example, enable
+// iterative calculation with at most 100 iterations:
+//
+//	iterate, iterateCount := true, uint(100)
+//	err := f.SetCalcProps(&excelize.CalcPropsOptions{
+//	    Iterate:      &iterate,
+//	    IterateCount: &iterateCount,
+//	})
 //
 // Supported formula functions:
 //
@@ -465,6 +550,8 @@ func (fn *formulaFuncs) implicitIntersect(arg formulaArg) formulaArg {
 //	BITOR
 //	BITRSHIFT
 //	BITXOR
+//	BYCOL
+//	BYROW
 //	CEILING
 //	CEILING.MATH
 //	CEILING.PRECISE
@@ -661,12 +748,14 @@ func (fn *formulaFuncs) implicitIntersect(arg formulaArg) formulaArg {
 //	ISREF
 //	ISTEXT
 //	KURT
+//	LAMBDA
 //	LARGE
 //	LCM
 //	LEFT
 //	LEFTB
 //	LEN
 //	LENB
+//	LET
 //	LN
 //	LOG
 //	LOG10
@@ -676,6 +765,8 @@ func (fn *formulaFuncs) implicitIntersect(arg formulaArg) formulaArg {
 //	LOGNORMDIST
 //	LOOKUP
 //	LOWER
+//	MAKEARRAY
+//	MAP
 //	MATCH
 //	MAX
 //	MAXA
@@ -763,6 +854,7 @@ func (fn *formulaFuncs) implicitIntersect(arg formulaArg) formulaArg {
 //	RANK.EQ
 //	RATE
 //	RECEIVED
+//	REDUCE
 //	REPLACE
 //	REPLACEB
 //	REPT
@@ -776,6 +868,7 @@ func (fn *formulaFuncs) implicitIntersect(arg formulaArg) formulaArg {
 //	ROWS
 //	RRI
 //	RSQ
+//	SCAN
 //	SEARCH
 //	SEARCHB
 //	SEC
@@ -893,40 +986,243 @@ func (f *File) CalcCellValue(sheet, cell string, opts ...Options) (result string
 			return cachedResult.(string), nil
 		}
 	}
-	if token, err = f.calcCellValue(&calcContext{
-		entry:             entry,
-		maxCalcIterations: options.MaxCalcIterations,
-		iterations:        make(map[string]uint),
-		iterationsCache:   make(map[string]formulaArg),
-	}, sheet, cell); err != nil {
-		result = token.String
-		return
+	token, iterative, iterErr := f.iterativeCellValue(sheet, cell, options)
+	if iterErr != nil && !iterative {
+		return "", iterErr
+	}
+	if iterative && token.Type == ArgError {
+		return token.String, errors.New(token.Error)
+	}
+	if !iterative {
+		if token, err = f.calcCellValue(&calcContext{
+			entry:                entry,
+			maxCalcIterations:    options.MaxCalcIterations,
+			iterations:           make(map[string]uint),
+			iterationsCache:      make(map[string]formulaArg),
+			externalLinkResolver: options.ExternalLinkResolver,
+		}, sheet, cell); err != nil {
+			result = token.String
+			return
+		}
 	}
 	if !rawCellValue {
 		styleIdx, _ = f.GetCellStyle(sheet, cell)
 	}
+	if result, err = f.formattedCalcResult(token, styleIdx, rawCellValue); err == nil {
+		if err = iterErr; err == nil {
+			f.storeCalcCache(entry, result, rawCellValue)
+		}
+	}
+	return
+}
+
+// formattedCalcResult provides a function to convert the calculated formula
+// argument to string with the given style index.
+func (f *File) formattedCalcResult(token formulaArg, styleIdx int, rawCellValue bool) (string, error) {
 	if token.Type == ArgNumber && !token.Boolean {
 		_, precision, decimal := isNumeric(token.Value())
 		if precision > 15 {
-			result, err = f.formattedValue(&xlsxC{S: styleIdx, V: strings.ToUpper(strconv.FormatFloat(decimal, 'G', 15, 64))}, rawCellValue, CellTypeNumber)
-			if err == nil {
-				f.storeCalcCache(entry, result, rawCellValue)
+			return f.formattedValue(&xlsxC{S: styleIdx, V: strings.ToUpper(strconv.FormatFloat(decimal, 'G', 15, 64))}, rawCellValue, CellTypeNumber)
+		}
+		return f.formattedValue(&xlsxC{S: styleIdx, V: strings.ToUpper(strconv.FormatFloat(decimal, 'f', -1, 64))}, rawCellValue, CellTypeNumber)
+	}
+	return f.formattedValue(&xlsxC{S: styleIdx, V: token.Value()}, rawCellValue, CellTypeInlineString)
+}
+
+// CalcCellValues provides a function to get calculated values of the dynamic
+// array formula in the cell. The result contains the entire spilled values of
+// the formula, the anchor cell value placed in the top-left corner. A scalar
+// result will be returned as one row with one column. If the spill range
+// overlaps with non-empty cells or merged cells, the "#SPILL!" error will be
+// returned. For example, get the unique values of the range "A1:A10" which
+// calculated by formula in the cell "B1" on "Sheet1":
+//
+//	formulaType, ref := excelize.STCellFormulaTypeArray, "B1"
+//	err := f.SetCellFormula("Sheet1", "B1", "_xlfn.UNIQUE(A1:A10)",
+//	    excelize.FormulaOpts{Type: &formulaType, Ref: &ref, DynamicArray: true})
+//	values, err := f.CalcCellValues("Sheet1", "B1")
+func (f *File) CalcCellValues(sheet, cell string, opts ...Options) ([][]string, error) {
+	options := f.getOptions(opts...)
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return nil, err
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, err
+	}
+	mtx, err := f.calcDynamicArray(sheet, cell, options)
+	if err != nil {
+		return [][]string{{mtx[0][0].String}}, err
+	}
+	if ws.isSpillBlocked(col, row, len(mtx[0]), len(mtx), ws.getSpillRef(col, row)) {
+		return [][]string{{formulaErrorSPILL}}, errors.New(formulaErrorSPILL)
+	}
+	results := make([][]string, len(mtx))
+	for r, values := range mtx {
+		results[r] = make([]string, len(values))
+		for c, value := range values {
+			styleIdx := 0
+			if !options.RawCellValue {
+				cellRef, _ := CoordinatesToCellName(col+c, row+r)
+				styleIdx, _ = f.GetCellStyle(sheet, cellRef)
 			}
-			return
+			if value.Type == ArgEmpty {
+				value = newNumberFormulaArg(0)
+			}
+			if results[r][c], err = f.formattedCalcResult(value, styleIdx, options.RawCellValue); err != nil {
+				return results, err
+			}
+		}
+	}
+	return results, err
+}
+
+// FormulaContext defines the context of calling the custom formula function,
+// which contains the workbook, the worksheet name and the cell reference of
+// the formula being calculated.
+type FormulaContext struct {
+	File  *File
+	Sheet string
+	Cell  string
+}
+
+// RegisterFormulaFunction provides a function to register a custom formula
+// function by given function name, which could be used in the formulas and
+// calculated by the CalcCellValue function alongside the built-in functions.
+// The registered function will be called with the evaluated arguments, and
+// takes precedence over the built-in function with the same name. The
+// function name is case-insensitive, and the "_xlfn." or "_xll." prefix of the
+// function name in formulas will be ignored, so the user-defined functions
+// provided by add-ins could be calculated. For example, register a function
+// named "DOUBLE" which returns twice of the given number:
+//
+//	err := f.RegisterFormulaFunction("DOUBLE",
+//	    func(ctx excelize.FormulaContext, args []excelize.FormulaArg) excelize.FormulaArg {
+//	        if len(args) != 1 {
+//	            return excelize.NewErrorFormulaArg("#VALUE!", "DOUBLE requires 1 argument")
+//	        }
+//	        num := args[0].ToNumber()
+//	        if num.Type != excelize.ArgNumber {
+//	            return num
+//	        }
+//	        return excelize.NewNumberFormulaArg(num.Number * 2)
+//	    })
+func (f *File) RegisterFormulaFunction(name string, fn func(ctx FormulaContext, args []FormulaArg) FormulaArg) error {
+	if name = customFuncName(name); name == "" || fn == nil {
+		return ErrParameterInvalid
+	}
+	f.customFuncs.Store(name, fn)
+	f.clearCalcCache()
+	return nil
+}
+
+// customFuncName returns the normalized name of the custom formula function.
+func customFuncName(name string) string {
+	name = strings.ToUpper(name)
+	for _, prefix := range []string{"_XLFN.", "_XLL."} {
+		name = strings.TrimPrefix(name, prefix)
+	}
+	return name
+}
+
+// callCustomFunc provides a function to call the registered custom formula
+// function by given worksheet name, cell reference, function name and
+// arguments. Returns false if the custom function has not been registered.
+func (f *File) callCustomFunc(sheet, cell, name string, argsList *list.List) (formulaArg, bool) {
+	fn, ok := f.customFuncs.Load(customFuncName(name))
+	if !ok {
+		return formulaArg{}, ok
+	}
+	args := make([]formulaArg, 0, argsList.Len())
+	for arg := argsList.Front(); arg != nil; arg = arg.Next() {
+		args = append(args, arg.Value.(formulaArg))
+	}
+	return fn.(func(ctx FormulaContext, args []FormulaArg) FormulaArg)(FormulaContext{File: f, Sheet: sheet, Cell: cell}, args), ok
+}
+
+// calcDynamicArray calculate the dynamic array formula by given worksheet
+// name and cell reference, and returns the rectangular result matrix of the
+// formula. The missing values in the ragged matrix will be filled with "#N/A"
+// error.
+func (f *File) calcDynamicArray(sheet, cell string, options *Options) ([][]formulaArg, error) {
+	token, err := f.calcCellValue(&calcContext{
+		entry:                sheet + "!" + cell,
+		dynamicArray:         true,
+		maxCalcIterations:    options.MaxCalcIterations,
+		iterations:           make(map[string]uint),
+		iterationsCache:      make(map[string]formulaArg),
+		externalLinkResolver: options.ExternalLinkResolver,
+	}, sheet, cell)
+	if err != nil || token.Type != ArgMatrix || len(token.Matrix) == 0 {
+		if err != nil && token.Type != ArgError {
+			token = newErrorFormulaArg(formulaErrorVALUE, err.Error())
 		}
-		if !strings.HasPrefix(result, "0") {
-			result, err = f.formattedValue(&xlsxC{S: styleIdx, V: strings.ToUpper(strconv.FormatFloat(decimal, 'f', -1, 64))}, rawCellValue, CellTypeNumber)
+		return [][]formulaArg{{token}}, err
+	}
+	var cols int
+	for _, row := range token.Matrix {
+		cols = max(cols, len(row))
+	}
+	if cols == 0 {
+		return [][]formulaArg{{newEmptyFormulaArg()}}, err
+	}
+	mtx := make([][]formulaArg, len(token.Matrix))
+	for r, row := range token.Matrix {
+		mtx[r] = append(mtx[r], row...)
+		for c := len(row); c < cols; c++ {
+			mtx[r] = append(mtx[r], newErrorFormulaArg(formulaErrorNA, formulaErrorNA))
 		}
-		if err == nil {
-			f.storeCalcCache(entry, result, rawCellValue)
+	}
+	return mtx, err
+}
+
+// getSpillRef returns the spill range reference of the dynamic array formula
+// by given anchor cell coordinates.
+func (ws *xlsxWorksheet) getSpillRef(col, row int) string {
+	if row <= len(ws.SheetData.Row) && col <= len(ws.SheetData.Row[row-1].C) {
+		if c := ws.SheetData.Row[row-1].C[col-1]; c.F != nil && c.F.T == STCellFormulaTypeArray {
+			return c.F.Ref
 		}
-		return
 	}
-	result, err = f.formattedValue(&xlsxC{S: styleIdx, V: token.Value()}, rawCellValue, CellTypeInlineString)
+	return ""
+}
+
+// isSpillBlocked provides a function to check if the spill range of the
+// dynamic array formula in the given anchor cell coordinates overlaps with
+// non-empty cells, merged cells or exceeds the worksheet boundary. The cells
+// in the previous spill range of the same formula will be ignored.
+func (ws *xlsxWorksheet) isSpillBlocked(col, row, cols, rows int, prevRef string) bool {
+	if col+cols-1 > MaxColumns || row+rows-1 > TotalRows {
+		return true
+	}
+	prev, err := rangeRefToCoordinates(prevRef)
 	if err == nil {
-		f.storeCalcCache(entry, result, rawCellValue)
+		_ = sortCoordinates(prev)
 	}
-	return
+	for r := row; r < row+rows && r <= len(ws.SheetData.Row); r++ {
+		for c := col; c < col+cols && c <= len(ws.SheetData.Row[r-1].C); c++ {
+			if (r == row && c == col) || (err == nil && c >= prev[0] && c <= prev[2] && r >= prev[1] && r <= prev[3]) {
+				continue
+			}
+			if cell := ws.SheetData.Row[r-1].C[c-1]; cell.V != "" || cell.F != nil || cell.IS != nil {
+				return true
+			}
+		}
+	}
+	if ws.MergeCells != nil {
+		for _, mergeCell := range ws.MergeCells.Cells {
+			coordinates, err := rangeRefToCoordinates(mergeCell.Ref)
+			if err != nil {
+				continue
+			}
+			_ = sortCoordinates(coordinates)
+			if coordinates[0] < col+cols && coordinates[2] >= col && coordinates[1] < row+rows && coordinates[3] >= row {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // storeCalcCache stores the calculated result in the cache with the given entry
@@ -944,6 +1240,10 @@ func (f *File) clearCalcCache() {
 	f.calcCache.Clear()
 	f.calcRawCache.Clear()
 	f.formulaArgCache.Clear()
+	f.lambdaCache.Clear()
+	f.condFmtCache.Clear()
+	f.calcGraph.Store(nil)
+	f.tableRefs.Store(nil)
 }
 
 // calcCellValue calculate cell value by given context, worksheet name and cell
@@ -953,12 +1253,17 @@ func (f *File) calcCellValue(ctx *calcContext, sheet, cell string) (result formu
 	if formula, err = f.getCellFormula(sheet, cell, true); err != nil {
 		return
 	}
-	ps := efp.ExcelParser()
-	tokens := ps.Parse(formula)
-	if tokens == nil {
+	tokens := f.parseFormulaTokens(sheet, cell, formula)
+	if len(tokens) == 0 {
 		return f.cellResolver(ctx, sheet, cell)
 	}
-	result, err = f.evalInfixExp(ctx, sheet, cell, tokens)
+	scope := ctx.scope
+	ctx.scope = nil
+	defer func() { ctx.scope = scope }()
+	if result, err = f.evalInfixExp(ctx, sheet, cell, tokens); err == nil && result.Type == ArgLambda {
+		result = newErrorFormulaArg(formulaErrorCALC, formulaErrorCALC)
+		err = errors.New(formulaErrorCALC)
+	}
 	return
 }
 
@@ -1017,6 +1322,42 @@ func newEmptyFormulaArg() formulaArg {
 	return formulaArg{Type: ArgEmpty}
 }
 
+// NewNumberFormulaArg constructs a number formula argument.
+func NewNumberFormulaArg(n float64) FormulaArg {
+	return newNumberFormulaArg(n)
+}
+
+// NewStringFormulaArg constructs a string formula argument.
+func NewStringFormulaArg(s string) FormulaArg {
+	return newStringFormulaArg(s)
+}
+
+// NewMatrixFormulaArg constructs a matrix formula argument.
+func NewMatrixFormulaArg(m [][]FormulaArg) FormulaArg {
+	return newMatrixFormulaArg(m)
+}
+
+// NewListFormulaArg constructs a list formula argument.
+func NewListFormulaArg(l []FormulaArg) FormulaArg {
+	return newListFormulaArg(l)
+}
+
+// NewBoolFormulaArg constructs a boolean formula argument.
+func NewBoolFormulaArg(b bool) FormulaArg {
+	return newBoolFormulaArg(b)
+}
+
+// NewErrorFormulaArg constructs an error formula argument of a given type
+// with a specified error message.
+func NewErrorFormulaArg(formulaError, msg string) FormulaArg {
+	return newErrorFormulaArg(formulaError, msg)
+}
+
+// NewEmptyFormulaArg constructs an empty formula argument.
+func NewEmptyFormulaArg() FormulaArg {
+	return newEmptyFormulaArg()
+}
+
 // evalInfixExp evaluate syntax analysis by given infix expression after
 // lexical analysis. Evaluate an infix expression containing formulas by
 // stacks:
@@ -1041,6 +1382,23 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 	for i := 0; i < len(tokens); i++ {
 		token := tokens[i]
 
+		// evaluate the functions with lazy evaluated arguments, and replace
+		// the function tokens with the operand of the result
+		if isFunctionStartToken(token) {
+			result, end, ok := f.evalLambdaFunc(ctx, sheet, cell, tokens, i)
+			if !ok && token.TValue == "ARRAY" && opfStack.Len() == 0 {
+				result, end, ok = f.evalInlineArray(ctx, sheet, cell, tokens, i)
+			}
+			if ok {
+				if result.Type == ArgMatrix && len(result.Matrix) > 0 && len(result.Matrix[0]) > 0 &&
+					opfStack.Len() == 0 && !ctx.isDynamicArrayEntry(sheet, cell) && !ctx.inScope() {
+					result = result.Matrix[0][0]
+				}
+				tokens = append(append(append([]efp.Token{}, tokens[:i]...), ctx.newOperand(result)), tokens[end+1:]...)
+				token = tokens[i]
+			}
+		}
+
 		// out of function stack
 		if opfStack.Len() == 0 {
 			if err = f.parseToken(ctx, sheet, token, opdStack, optStack); err != nil {
@@ -1074,12 +1432,8 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 			// current token is args or range, skip next token, order required: parse reference first
 			if token.TSubType == efp.TokenSubTypeRange {
 				if opftStack.Peek().(efp.Token) != opfStack.Peek().(efp.Token) {
-					refTo := f.getDefinedNameRefTo(token.TValue, sheet)
-					if refTo != "" {
-						token.TValue = refTo
-					}
 					// parse reference: must reference at here
-					result, err := f.parseReference(ctx, sheet, token.TValue)
+					result, err := f.parseRangeToken(ctx, sheet, token)
 					if err != nil {
 						return result, err
 					}
@@ -1088,11 +1442,7 @@ func (f *File) evalInfixExp(ctx *calcContext, sheet, cell string, tokens []efp.T
 				}
 				if nextToken.TType == efp.TokenTypeArgument || nextToken.TType == efp.TokenTypeFunction {
 					// parse reference: reference or range at here
-					refTo := f.getDefinedNameRefTo(token.TValue, sheet)
-					if refTo != "" {
-						token.TValue = refTo
-					}
-					result, err := f.parseReference(ctx, sheet, token.TValue)
+					result, err := f.parseRangeToken(ctx, sheet, token)
 					if err != nil {
 						return result, err
 					}
@@ -1170,9 +1520,12 @@ func (f *File) evalInfixExpFunc(ctx *calcContext, sheet, cell string, token, nex
 	}
 	prepareEvalInfixExp(opfStack, opftStack, opfdStack, argsStack)
 	// call formula function to evaluate
-	arg := callFuncByName(&formulaFuncs{f: f, sheet: sheet, cell: cell, ctx: ctx},
-		formulaFnNameReplacer.Replace(opfStack.Peek().(efp.Token).TValue),
-		[]reflect.Value{reflect.ValueOf(argsStack.Peek().(*list.List))})
+	arg, ok := f.callCustomFunc(sheet, cell, opfStack.Peek().(efp.Token).TValue, argsStack.Peek().(*list.List))
+	if !ok {
+		arg = callFuncByName(&formulaFuncs{f: f, sheet: sheet, cell: cell, ctx: ctx},
+			formulaFnNameReplacer.Replace(opfStack.Peek().(efp.Token).TValue),
+			[]reflect.Value{reflect.ValueOf(argsStack.Peek().(*list.List))})
+	}
 	if arg.Type == ArgError && opfStack.Len() == 1 {
 		return arg
 	}
@@ -1188,7 +1541,7 @@ func (f *File) evalInfixExpFunc(ctx *calcContext, sheet, cell string, token, nex
 		argsStack.Peek().(*list.List).PushBack(arg)
 		return newEmptyFormulaArg()
 	}
-	if arg.Type == ArgMatrix && len(arg.Matrix) > 0 && len(arg.Matrix[0]) > 0 {
+	if arg.Type == ArgMatrix && len(arg.Matrix) > 0 && len(arg.Matrix[0]) > 0 && !ctx.isDynamicArrayEntry(sheet, cell) && !ctx.inScope() {
 		opdStack.Push(arg.Matrix[0][0])
 		return newEmptyFormulaArg()
 	}
@@ -1237,6 +1590,751 @@ func prepareEvalInfixExp(opfStack, opftStack, opfdStack, argsStack *Stack) {
 	}
 }
 
+// formulaVarName returns the normalized name of the variable which bound by
+// the LET function or the parameter of the LAMBDA function.
+func formulaVarName(name string) string {
+	return strings.ToUpper(strings.TrimPrefix(strings.ToLower(name), "_xlpm."))
+}
+
+// newOperand provides a function to bind the given formula argument to a
+// placeholder operand token, which used to replace the tokens of the
+// evaluated expression.
+func (ctx *calcContext) newOperand(arg formulaArg) efp.Token {
+	if ctx.operands == nil {
+		ctx.operands = make(map[string]formulaArg)
+	}
+	name := fmt.Sprintf("\x00%d", len(ctx.operands))
+	ctx.operands[name] = arg
+	return efp.Token{TValue: name, TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeRange}
+}
+
+// parseRangeToken parse the range operand token by given context and
+// default sheet name. The placeholder operands and the names bound in the
+// current scope take precedence over the defined names and references.
+func (f *File) parseRangeToken(ctx *calcContext, sheet string, token efp.Token) (formulaArg, error) {
+	if ctx != nil {
+		if arg, ok := ctx.operands[token.TValue]; ok {
+			return arg, nil
+		}
+		if arg, ok := ctx.scope.lookup(token.TValue); ok {
+			return arg, nil
+		}
+	}
+	if refTo := f.getDefinedNameRefTo(token.TValue, sheet); refTo != "" {
+		fn := &formulaFuncs{f: f, sheet: sheet, ctx: ctx}
+		if lambda, ok := fn.parseDefinedLambda(refTo); ok {
+			return lambda, nil
+		}
+		if sr, ok := parseStructuredRef(refTo); ok {
+			ref, err := f.structuredRefToRange(sheet, "", sr)
+			if err != nil {
+				return newErrorFormulaArg(err.Error(), err.Error()), nil
+			}
+			refTo = ref
+		}
+		token.TValue = refTo
+	}
+	return f.parseReference(ctx, sheet, token.TValue)
+}
+
+// structuredRef directly maps the items of the structured reference, which
+// refers to the table by the table and column names instead of the cell
+// reference, such as Table1[[#Totals],[Sales]]. An empty table name refers to
+// the table which contains the formula.
+type structuredRef struct {
+	table                               string
+	all, headers, data, totals, thisRow bool
+	columns                             []string
+}
+
+// structuredRefDepth returns the unclosed brackets depth of the given
+// reference, the escaped brackets in the structured reference will be
+// skipped.
+func structuredRefDepth(ref string) int {
+	var depth int
+	for i := 0; i < len(ref); i++ {
+		switch ref[i] {
+		case '\'':
+			if depth > 0 {
+				i++
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+		}
+	}
+	return depth
+}
+
+// mergeStructuredRefTokens merges the tokens of the structured reference
+// which has been split by the tokenizer on the separators between the
+// special items and the columns, such as Table1[[#Totals],[Sales]].
+func mergeStructuredRefTokens(tokens []efp.Token) []efp.Token {
+	merged := make([]efp.Token, 0, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if token.TType == efp.TokenTypeOperand && token.TSubType == efp.TokenSubTypeRange {
+			for structuredRefDepth(token.TValue) > 0 && i+1 < len(tokens) {
+				i++
+				token.TValue += tokens[i].TValue
+			}
+		}
+		merged = append(merged, token)
+	}
+	return merged
+}
+
+// parseStructuredRef parse the structured reference by given reference, and
+// returns whether the reference is a valid structured reference.
+func parseStructuredRef(ref string) (structuredRef, bool) {
+	var sr structuredRef
+	idx := strings.Index(ref, "[")
+	if idx == -1 || !strings.HasSuffix(ref, "]") || strings.Contains(ref[:idx], "!") || structuredRefDepth(ref) != 0 {
+		return sr, false
+	}
+	sr.table, ref = ref[:idx], ref[idx+1:len(ref)-1]
+	if strings.HasPrefix(ref, "@") {
+		sr.thisRow, ref = true, ref[1:]
+	}
+	if !strings.HasPrefix(ref, "[") {
+		return sr, ref == "" || sr.addItem(ref, false)
+	}
+	var colRange bool
+	for i := 0; i < len(ref); i++ {
+		switch ref[i] {
+		case ' ', ',':
+		case ':':
+			colRange = true
+		case '[':
+			end := i + 1
+			for ; end < len(ref) && ref[end] != ']'; end++ {
+				if ref[end] == '\'' {
+					end++
+				}
+			}
+			if end >= len(ref) || !sr.addItem(ref[i+1:end], colRange) {
+				return sr, false
+			}
+			i, colRange = end, false
+		default:
+			return sr, false
+		}
+	}
+	return sr, !colRange
+}
+
+// addItem add the special item or the column name to the structured
+// reference, the colRange indicates whether the column is the end of the
+// columns range. Returns whether the item is valid.
+func (sr *structuredRef) addItem(item string, colRange bool) bool {
+	if strings.HasPrefix(item, "#") {
+		switch strings.ToLower(item) {
+		case "#all":
+			sr.all = true
+		case "#data":
+			sr.data = true
+		case "#headers":
+			sr.headers = true
+		case "#totals":
+			sr.totals = true
+		case "#this row":
+			sr.thisRow = true
+		default:
+			return false
+		}
+		return !colRange
+	}
+	if item == "" || len(sr.columns) > 1 || colRange != (len(sr.columns) == 1) {
+		return false
+	}
+	var name strings.Builder
+	for i := 0; i < len(item); i++ {
+		if item[i] == '\'' && i+1 < len(item) {
+			i++
+		}
+		name.WriteByte(item[i])
+	}
+	sr.columns = append(sr.columns, name.String())
+	return true
+}
+
+// structuredRefToRange provides a function to convert the structured
+// reference to the A1-style range reference by given worksheet name and cell
+// reference of the formula.
+func (f *File) structuredRefToRange(sheet, cell string, sr structuredRef) (string, error) {
+	tableSheet, t := f.getTable(sheet, cell, sr.table)
+	if t == nil {
+		return "", errors.New(formulaErrorREF)
+	}
+	coordinates, err := rangeRefToCoordinates(t.Ref)
+	if err != nil {
+		return "", errors.New(formulaErrorREF)
+	}
+	_ = sortCoordinates(coordinates)
+	x1, y1, x2, y2 := coordinates[0], coordinates[1], coordinates[2], coordinates[3]
+	headerRows := 1
+	if t.HeaderRowCount != nil {
+		headerRows = *t.HeaderRowCount
+	}
+	from, to := y1+headerRows, y2-t.TotalsRowCount
+	switch {
+	case sr.thisRow:
+		_, row, err := CellNameToCoordinates(cell)
+		if sr.all || sr.headers || sr.data || sr.totals {
+			return "", errors.New(formulaErrorREF)
+		}
+		if err != nil || !strings.EqualFold(tableSheet, sheet) || row < from || row > to {
+			return "", errors.New(formulaErrorVALUE)
+		}
+		from, to = row, row
+	case sr.all:
+		from, to = y1, y2
+	default:
+		if (sr.headers && headerRows == 0) || (sr.totals && t.TotalsRowCount == 0) || (sr.headers && sr.totals && !sr.data) {
+			return "", errors.New(formulaErrorREF)
+		}
+		if sr.headers {
+			if from = y1; !sr.data {
+				to = y1
+			}
+		}
+		if sr.totals {
+			if to = y2; !sr.data {
+				from = y2
+			}
+		}
+	}
+	if len(sr.columns) > 0 {
+		cols := make([]int, len(sr.columns))
+		for i, name := range sr.columns {
+			if cols[i] = t.getColumnNumber(x1, name); cols[i] == -1 {
+				return "", errors.New(formulaErrorREF)
+			}
+		}
+		x1, x2 = slices.Min(cols), slices.Max(cols)
+	}
+	if from > to {
+		return "", errors.New(formulaErrorREF)
+	}
+	fromCell, _ := CoordinatesToCellName(x1, from, true)
+	ref := escapeSheetName(tableSheet) + "!" + fromCell
+	if x1 != x2 || from != to {
+		toCell, _ := CoordinatesToCellName(x2, to, true)
+		ref += ":" + toCell
+	}
+	return ref, nil
+}
+
+// parseFormulaTokens provides a function to parse the formula to the tokens,
+// the structured references and table names in the formula will be converted
+// to the A1-style range references by given worksheet name and cell reference
+// of the formula.
+func (f *File) parseFormulaTokens(sheet, cell, formula string) []efp.Token {
+	ps := efp.ExcelParser()
+	tokens := mergeStructuredRefTokens(ps.Parse(formula))
+	for i, token := range tokens {
+		if token.TType != efp.TokenTypeOperand || token.TSubType != efp.TokenSubTypeRange {
+			continue
+		}
+		sr, ok := parseStructuredRef(token.TValue)
+		if !ok {
+			if !f.isTableName(sheet, token.TValue) {
+				continue
+			}
+			sr.table = token.TValue
+		}
+		ref, err := f.structuredRefToRange(sheet, cell, sr)
+		if err != nil {
+			tokens[i] = efp.Token{TValue: err.Error(), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeError}
+			continue
+		}
+		tokens[i].TValue = ref
+	}
+	return tokens
+}
+
+// isTableName returns whether the given operand is the name of a table in the
+// workbook.
+func (f *File) isTableName(sheet, name string) bool {
+	if strings.ContainsAny(name, "!:$[]") || checkDefinedName(name) != nil {
+		return false
+	}
+	if _, _, err := CellNameToCoordinates(name); err == nil {
+		return false
+	}
+	_, t := f.getTable(sheet, "", name)
+	return t != nil
+}
+
+// splitFuncArgs splits the argument tokens of the function or parentheses
+// which start at the given index of the tokens, and returns the arguments and
+// the index of the corresponding stop token.
+func splitFuncArgs(tokens []efp.Token, start int) ([][]efp.Token, int) {
+	var (
+		args  [][]efp.Token
+		arg   []efp.Token
+		depth int
+	)
+	for i := start + 1; i < len(tokens); i++ {
+		token := tokens[i]
+		switch {
+		case isFunctionStartToken(token) || isBeginParenthesesToken(token):
+			depth++
+		case isFunctionStopToken(token) || isEndParenthesesToken(token):
+			if depth == 0 {
+				if len(args) > 0 || len(arg) > 0 {
+					args = append(args, arg)
+				}
+				return args, i
+			}
+			depth--
+		case (token.TType == efp.TokenTypeArgument || token.TSubType == efp.TokenSubTypeUnion) && depth == 0:
+			args, arg = append(args, arg), nil
+			continue
+		}
+		arg = append(arg, token)
+	}
+	return append(args, arg), len(tokens) - 1
+}
+
+// evalLambdaFunc evaluate the LET, LAMBDA, the lambda helper functions and
+// the invocations of the lambda functions by given tokens and the index of
+// the function start token. The arguments of these functions will be
+// evaluated lazily. Returns the result, the index of the function stop token
+// and whether the function has been evaluated.
+func (f *File) evalLambdaFunc(ctx *calcContext, sheet, cell string, tokens []efp.Token, idx int) (formulaArg, int, bool) {
+	if ctx == nil {
+		return formulaArg{}, idx, false
+	}
+	var (
+		fn     = &formulaFuncs{f: f, sheet: sheet, cell: cell, ctx: ctx}
+		name   = strings.ToUpper(strings.TrimPrefix(strings.ToLower(tokens[idx].TValue), "_xlfn."))
+		lambda formulaArg
+		ok     = inStrSlice(lambdaFuncs, name, true) != -1 || (name == "IF" && ctx.inScope())
+	)
+	if !ok {
+		if lambda, ok = fn.getLambda(tokens[idx].TValue); !ok {
+			return lambda, idx, ok
+		}
+	}
+	args, end := splitFuncArgs(tokens, idx)
+	var result formulaArg
+	switch name {
+	case "BYCOL":
+		result = fn.evalBYCOL(args)
+	case "BYROW":
+		result = fn.evalBYROW(args)
+	case "IF":
+		if result, ok = fn.evalIF(args); !ok {
+			return result, idx, ok
+		}
+	case "LAMBDA":
+		result = fn.evalLAMBDA(args)
+	case "LET":
+		result = fn.evalLET(args)
+	case "MAKEARRAY":
+		result = fn.evalMAKEARRAY(args)
+	case "MAP":
+		result = fn.evalMAP(args)
+	case "REDUCE":
+		result = fn.evalREDUCE(args, false)
+	case "SCAN":
+		result = fn.evalREDUCE(args, true)
+	default:
+		result = fn.callLambda(lambda, fn.evalArgs(args)...)
+	}
+	for result.Type == ArgLambda && end+1 < len(tokens) && isBeginParenthesesToken(tokens[end+1]) {
+		args, end = splitFuncArgs(tokens, end+1)
+		result = fn.callLambda(result, fn.evalArgs(args)...)
+	}
+	return result, end, true
+}
+
+// evalInlineArray evaluate the inline array constant which out of the
+// function by given tokens and the index of the array start token. Returns
+// the matrix, the index of the array stop token and whether the array has
+// been evaluated.
+func (f *File) evalInlineArray(ctx *calcContext, sheet, cell string, tokens []efp.Token, idx int) (formulaArg, int, bool) {
+	var (
+		fn        = &formulaFuncs{f: f, sheet: sheet, cell: cell, ctx: ctx}
+		rows, end = splitFuncArgs(tokens, idx)
+		mtx       [][]formulaArg
+	)
+	for _, row := range rows {
+		if len(row) == 0 || row[0].TValue != "ARRAYROW" {
+			return formulaArg{}, idx, false
+		}
+		args, _ := splitFuncArgs(row, 0)
+		mtx = append(mtx, fn.evalArgs(args))
+	}
+	return newMatrixFormulaArg(mtx), end, true
+}
+
+// getLambda returns the lambda function by given function name, which bound
+// by the LET function, or defined by the workbook defined name.
+func (fn *formulaFuncs) getLambda(name string) (formulaArg, bool) {
+	if arg, ok := fn.ctx.scope.lookup(name); ok {
+		return arg, arg.Type == ArgLambda
+	}
+	if name == "ARRAY" || name == "ARRAYROW" || fn.isFormulaFunc(name) {
+		return formulaArg{}, false
+	}
+	if _, ok := fn.f.customFuncs.Load(customFuncName(name)); ok {
+		return formulaArg{}, false
+	}
+	key := fn.sheet + "!" + name
+	if cached, ok := fn.f.lambdaCache.Load(key); ok {
+		arg := cached.(formulaArg)
+		return arg, arg.Type == ArgLambda
+	}
+	var arg formulaArg
+	if refTo := fn.f.getDefinedNameRefTo(name, fn.sheet); refTo != "" {
+		arg, _ = fn.parseDefinedLambda(refTo)
+	}
+	fn.f.lambdaCache.Store(key, arg)
+	return arg, arg.Type == ArgLambda
+}
+
+// isFormulaFunc returns whether the given name is the built-in formula
+// function, the result will be cached for the function name.
+func (fn *formulaFuncs) isFormulaFunc(name string) bool {
+	if cached, ok := formulaFnNames.Load(name); ok {
+		return cached.(bool)
+	}
+	ok := reflect.ValueOf(fn).MethodByName(formulaFnNameReplacer.Replace(name)).IsValid()
+	formulaFnNames.Store(name, ok)
+	return ok
+}
+
+// parseDefinedLambda returns the lambda function by given formula of the
+// defined name, which starts with the LAMBDA function.
+func (fn *formulaFuncs) parseDefinedLambda(refTo string) (formulaArg, bool) {
+	ps := efp.ExcelParser()
+	tokens := ps.Parse(strings.TrimPrefix(refTo, "="))
+	if len(tokens) == 0 || !isFunctionStartToken(tokens[0]) ||
+		strings.ToUpper(strings.TrimPrefix(strings.ToLower(tokens[0].TValue), "_xlfn.")) != "LAMBDA" {
+		return formulaArg{}, false
+	}
+	arg := fn.evalScope(tokens, newScope(nil))
+	return arg, arg.Type == ArgLambda
+}
+
+// evalScope evaluate the given tokens in the given scope.
+func (fn *formulaFuncs) evalScope(tokens []efp.Token, scope *formulaScope) formulaArg {
+	if len(tokens) == 0 {
+		return newEmptyFormulaArg()
+	}
+	prev := fn.ctx.scope
+	fn.ctx.scope = scope
+	defer func() { fn.ctx.scope = prev }()
+	arg, err := fn.f.evalInfixExp(fn.ctx, fn.sheet, fn.cell, tokens)
+	if err != nil && arg.Type != ArgError {
+		if inStrSlice(formulaErrors, err.Error(), true) != -1 {
+			return newErrorFormulaArg(err.Error(), err.Error())
+		}
+		return newErrorFormulaArg(formulaErrorVALUE, err.Error())
+	}
+	return arg
+}
+
+// evalArgs evaluate the given arguments tokens in the current scope.
+func (fn *formulaFuncs) evalArgs(args [][]efp.Token) []formulaArg {
+	var results []formulaArg
+	for _, arg := range args {
+		results = append(results, fn.evalScope(arg, newScope(fn.ctx.scope)))
+	}
+	return results
+}
+
+// callLambda invoke the lambda function with the given arguments.
+func (fn *formulaFuncs) callLambda(lambda formulaArg, args ...formulaArg) formulaArg {
+	if lambda.Type == ArgError {
+		return lambda
+	}
+	if lambda.Type != ArgLambda {
+		return newErrorFormulaArg(formulaErrorVALUE, "the argument should be a LAMBDA function")
+	}
+	if len(args) != len(lambda.lambda.params) {
+		return newErrorFormulaArg(formulaErrorVALUE, "the number of arguments does not match the LAMBDA function parameters")
+	}
+	if fn.ctx.lambdaDepth >= maxLambdaDepth {
+		return newErrorFormulaArg(formulaErrorNUM, "LAMBDA function exceeds the maximum nesting depth")
+	}
+	scope := newScope(lambda.lambda.scope)
+	for i, name := range lambda.lambda.params {
+		scope.names[name] = args[i]
+	}
+	fn.ctx.lambdaDepth++
+	defer func() { fn.ctx.lambdaDepth-- }()
+	return fn.evalScope(lambda.lambda.body, scope)
+}
+
+// lambdaParamName returns the name of the variable or the parameter by given
+// tokens of the argument of the LET or LAMBDA function.
+func lambdaParamName(tokens []efp.Token) (string, bool) {
+	if len(tokens) != 1 || tokens[0].TType != efp.TokenTypeOperand || tokens[0].TSubType != efp.TokenSubTypeRange ||
+		strings.ContainsAny(tokens[0].TValue, "!:$") {
+		return "", false
+	}
+	return formulaVarName(tokens[0].TValue), true
+}
+
+// lambdaArgMatrix converts the formula argument to the matrix for the lambda
+// helper functions.
+func lambdaArgMatrix(arg formulaArg) [][]formulaArg {
+	switch arg.Type {
+	case ArgMatrix:
+		return arg.Matrix
+	case ArgList:
+		return [][]formulaArg{arg.List}
+	}
+	return [][]formulaArg{{arg}}
+}
+
+// lambdaScalar converts the result of the lambda function to the single
+// value of the result array of the lambda helper functions.
+func lambdaScalar(arg formulaArg) formulaArg {
+	switch arg.Type {
+	case ArgEmpty:
+		return newNumberFormulaArg(0)
+	case ArgMatrix:
+		if len(arg.Matrix) == 1 && len(arg.Matrix[0]) == 1 {
+			return lambdaScalar(arg.Matrix[0][0])
+		}
+		return newErrorFormulaArg(formulaErrorCALC, "nested arrays are not supported")
+	case ArgLambda:
+		return newErrorFormulaArg(formulaErrorCALC, formulaErrorCALC)
+	}
+	return arg
+}
+
+// evalLambdaHelperArgs evaluate the arguments of the lambda helper function,
+// returns the evaluated arguments except the last one, the lambda function
+// of the last argument, and the error formula argument if the arguments are
+// invalid.
+func (fn *formulaFuncs) evalLambdaHelperArgs(name string, args [][]efp.Token, minArgs int) ([]formulaArg, formulaArg, formulaArg) {
+	if len(args) < minArgs {
+		return nil, formulaArg{}, newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("%s requires at least %d arguments", name, minArgs))
+	}
+	values := fn.evalArgs(args[:len(args)-1])
+	for _, value := range values {
+		if value.Type == ArgError {
+			return values, formulaArg{}, value
+		}
+	}
+	lambda := fn.evalScope(args[len(args)-1], newScope(fn.ctx.scope))
+	if lambda.Type == ArgError {
+		return values, lambda, lambda
+	}
+	if lambda.Type != ArgLambda {
+		return values, lambda, newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("%s requires a LAMBDA function as the last argument", name))
+	}
+	return values, lambda, formulaArg{}
+}
+
+// evalIF evaluate the IF function in the scope of the LET or LAMBDA function,
+// only the selected value argument will be evaluated, which makes the
+// recursive lambda functions could be terminated. Returns false if the
+// number of arguments is invalid.
+func (fn *formulaFuncs) evalIF(args [][]efp.Token) (formulaArg, bool) {
+	if len(args) < 2 || len(args) > 3 {
+		return formulaArg{}, false
+	}
+	argsList := list.New()
+	argsList.PushBack(fn.evalScope(args[0], newScope(fn.ctx.scope)))
+	cond := fn.IF(argsList)
+	if cond.Type == ArgError {
+		return cond, true
+	}
+	if cond.Number == 1 {
+		argsList.PushBack(fn.evalScope(args[1], newScope(fn.ctx.scope)))
+		return fn.IF(argsList), true
+	}
+	argsList.PushBack(newEmptyFormulaArg())
+	if len(args) == 3 {
+		argsList.PushBack(fn.evalScope(args[2], newScope(fn.ctx.scope)))
+	}
+	return fn.IF(argsList), true
+}
+
+// evalLET function assigns names to calculation results, and returns the
+// result of the calculation which could use these names. The syntax of the
+// function is:
+//
+//	LET(name1,name_value1,calculation_or_name2,[name_value2,calculation_or_name3...])
+func (fn *formulaFuncs) evalLET(args [][]efp.Token) formulaArg {
+	if len(args) < 3 || len(args)%2 == 0 {
+		return newErrorFormulaArg(formulaErrorVALUE, "LET requires an odd number of arguments and at least 3 arguments")
+	}
+	scope := newScope(fn.ctx.scope)
+	for i := 0; i < len(args)-1; i += 2 {
+		name, ok := lambdaParamName(args[i])
+		if !ok {
+			return newErrorFormulaArg(formulaErrorNAME, "LET requires valid names")
+		}
+		scope.names[name] = fn.evalScope(args[i+1], scope)
+	}
+	return fn.evalScope(args[len(args)-1], scope)
+}
+
+// evalLAMBDA function creates a custom and reusable function which could be
+// called by the name defined in the LET function or workbook defined names.
+// The syntax of the function is:
+//
+//	LAMBDA([parameter1,parameter2,...],calculation)
+func (fn *formulaFuncs) evalLAMBDA(args [][]efp.Token) formulaArg {
+	if len(args) == 0 || len(args[len(args)-1]) == 0 {
+		return newErrorFormulaArg(formulaErrorVALUE, "LAMBDA requires a calculation argument")
+	}
+	lambda := &formulaLambda{body: args[len(args)-1], scope: fn.ctx.scope}
+	for _, arg := range args[:len(args)-1] {
+		name, ok := lambdaParamName(arg)
+		if !ok {
+			return newErrorFormulaArg(formulaErrorVALUE, "LAMBDA requires valid parameter names")
+		}
+		lambda.params = append(lambda.params, name)
+	}
+	return formulaArg{Type: ArgLambda, lambda: lambda}
+}
+
+// evalMAP function returns an array formed by mapping each value in the
+// arrays to a new value by applying the LAMBDA function. The syntax of the
+// function is:
+//
+//	MAP(array1,[array2,...],lambda)
+func (fn *formulaFuncs) evalMAP(args [][]efp.Token) formulaArg {
+	values, lambda, errArg := fn.evalLambdaHelperArgs("MAP", args, 2)
+	if errArg.Type == ArgError {
+		return errArg
+	}
+	var arrays [][][]formulaArg
+	for _, value := range values {
+		arrays = append(arrays, lambdaArgMatrix(value))
+	}
+	mtx := make([][]formulaArg, len(arrays[0]))
+	for r, row := range arrays[0] {
+		for c := range row {
+			var params []formulaArg
+			for _, array := range arrays {
+				param := newErrorFormulaArg(formulaErrorNA, formulaErrorNA)
+				if r < len(array) && c < len(array[r]) {
+					param = array[r][c]
+				}
+				params = append(params, param)
+			}
+			mtx[r] = append(mtx[r], lambdaScalar(fn.callLambda(lambda, params...)))
+		}
+	}
+	return newMatrixFormulaArg(mtx)
+}
+
+// evalREDUCE function reduces an array to an accumulated value by applying
+// the LAMBDA function to each value, the SCAN function returns an array of
+// each intermediate accumulated values. The syntax of the functions are:
+//
+//	REDUCE([initial_value],array,lambda(accumulator,value))
+//	SCAN([initial_value],array,lambda(accumulator,value))
+func (fn *formulaFuncs) evalREDUCE(args [][]efp.Token, scan bool) formulaArg {
+	name := "REDUCE"
+	if scan {
+		name = "SCAN"
+	}
+	if len(args) != 3 {
+		return newErrorFormulaArg(formulaErrorVALUE, fmt.Sprintf("%s requires 3 arguments", name))
+	}
+	values, lambda, errArg := fn.evalLambdaHelperArgs(name, args, 3)
+	if errArg.Type == ArgError {
+		return errArg
+	}
+	acc, array := values[0], lambdaArgMatrix(values[1])
+	mtx := make([][]formulaArg, len(array))
+	for r, row := range array {
+		for _, value := range row {
+			if acc = fn.callLambda(lambda, acc, value); scan {
+				mtx[r] = append(mtx[r], lambdaScalar(acc))
+			}
+		}
+	}
+	if scan {
+		return newMatrixFormulaArg(mtx)
+	}
+	return acc
+}
+
+// evalBYROW function applies the LAMBDA function to each row of the array,
+// and returns an array of the results. The syntax of the function is:
+//
+//	BYROW(array,lambda(row))
+func (fn *formulaFuncs) evalBYROW(args [][]efp.Token) formulaArg {
+	if len(args) != 2 {
+		return newErrorFormulaArg(formulaErrorVALUE, "BYROW requires 2 arguments")
+	}
+	values, lambda, errArg := fn.evalLambdaHelperArgs("BYROW", args, 2)
+	if errArg.Type == ArgError {
+		return errArg
+	}
+	var mtx [][]formulaArg
+	for _, row := range lambdaArgMatrix(values[0]) {
+		mtx = append(mtx, []formulaArg{lambdaScalar(fn.callLambda(lambda, newMatrixFormulaArg([][]formulaArg{row})))})
+	}
+	return newMatrixFormulaArg(mtx)
+}
+
+// evalBYCOL function applies the LAMBDA function to each column of the
+// array, and returns an array of the results. The syntax of the function is:
+//
+//	BYCOL(array,lambda(column))
+func (fn *formulaFuncs) evalBYCOL(args [][]efp.Token) formulaArg {
+	if len(args) != 2 {
+		return newErrorFormulaArg(formulaErrorVALUE, "BYCOL requires 2 arguments")
+	}
+	values, lambda, errArg := fn.evalLambdaHelperArgs("BYCOL", args, 2)
+	if errArg.Type == ArgError {
+		return errArg
+	}
+	var results []formulaArg
+	for _, col := range transposeFormulaArgsMatrix(lambdaArgMatrix(values[0])) {
+		var column [][]formulaArg
+		for _, value := range col {
+			column = append(column, []formulaArg{value})
+		}
+		results = append(results, lambdaScalar(fn.callLambda(lambda, newMatrixFormulaArg(column))))
+	}
+	return newMatrixFormulaArg([][]formulaArg{results})
+}
+
+// evalMAKEARRAY function returns an array of the specified number of rows
+// and columns, which calculated by applying the LAMBDA function with the row
+// and column index of each value, the number of the values in the array
+// can't exceed 1048576. The syntax of the function is:
+//
+//	MAKEARRAY(rows,cols,lambda(row,col))
+func (fn *formulaFuncs) evalMAKEARRAY(args [][]efp.Token) formulaArg {
+	if len(args) != 3 {
+		return newErrorFormulaArg(formulaErrorVALUE, "MAKEARRAY requires 3 arguments")
+	}
+	values, lambda, errArg := fn.evalLambdaHelperArgs("MAKEARRAY", args, 3)
+	if errArg.Type == ArgError {
+		return errArg
+	}
+	rows, cols := values[0].ToNumber(), values[1].ToNumber()
+	if rows.Type != ArgNumber || cols.Type != ArgNumber {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	if rows.Number < 1 || cols.Number < 1 || rows.Number > TotalRows || cols.Number > MaxColumns ||
+		rows.Number*cols.Number > maxCalcArrayElements {
+		return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+	}
+	mtx := make([][]formulaArg, int(rows.Number))
+	for r := range mtx {
+		for c := 1; c <= int(cols.Number); c++ {
+			mtx[r] = append(mtx[r], lambdaScalar(fn.callLambda(lambda, newNumberFormulaArg(float64(r+1)), newNumberFormulaArg(float64(c)))))
+		}
+	}
+	return newMatrixFormulaArg(mtx)
+}
+
 // calcPow evaluate exponentiation arithmetic operations.
 func calcPow(rOpd, lOpd formulaArg, opdStack *Stack) error {
 	lOpdVal := lOpd.ToNumber()
@@ -1529,7 +2627,7 @@ func isOperatorPrefixToken(token efp.Token) bool {
 
 // isOperand determine if the token is parse operand.
 func isOperand(token efp.Token) bool {
-	return token.TType == efp.TokenTypeOperand && (token.TSubType == efp.TokenSubTypeNumber || token.TSubType == efp.TokenSubTypeText || token.TSubType == efp.TokenSubTypeLogical)
+	return token.TType == efp.TokenTypeOperand && (token.TSubType == efp.TokenSubTypeNumber || token.TSubType == efp.TokenSubTypeText || token.TSubType == efp.TokenSubTypeLogical || token.TSubType == efp.TokenSubTypeError)
 }
 
 // tokenToFormulaArg create a formula argument by given token.
@@ -1540,6 +2638,8 @@ func tokenToFormulaArg(token efp.Token) formulaArg {
 	case efp.TokenSubTypeNumber:
 		num, _ := strconv.ParseFloat(token.TValue, 64)
 		return newNumberFormulaArg(num)
+	case efp.TokenSubTypeError:
+		return newErrorFormulaArg(token.TValue, token.TValue)
 	default:
 		return newStringFormulaArg(token.TValue)
 	}
@@ -1550,11 +2650,7 @@ func tokenToFormulaArg(token efp.Token) formulaArg {
 func (f *File) parseToken(ctx *calcContext, sheet string, token efp.Token, opdStack, optStack *Stack) error {
 	// parse reference: must reference at here
 	if token.TSubType == efp.TokenSubTypeRange {
-		refTo := f.getDefinedNameRefTo(token.TValue, sheet)
-		if refTo != "" {
-			token.TValue = refTo
-		}
-		result, err := f.parseReference(ctx, sheet, token.TValue)
+		result, err := f.parseRangeToken(ctx, sheet, token)
 		if err != nil {
 			return errors.New(formulaErrorNAME)
 		}
@@ -1649,6 +2745,9 @@ func (cr *cellRange) prepareCellRange(col, row bool, cellRef cellRef) error {
 // characters and default sheet name.
 func (f *File) parseReference(ctx *calcContext, sheet, reference string) (formulaArg, error) {
 	reference = strings.ReplaceAll(reference, "$", "")
+	if book, ref, ok := splitExternalReference(reference); ok {
+		return f.parseExternalReference(ctx, book, ref)
+	}
 	if parts := split3DReference(reference); len(parts) == 3 {
 		return f.parse3DReference(ctx, parts)
 	}
@@ -1809,9 +2908,8 @@ func prepareValueRef(cr cellRef, valueRange []int) {
 // cellResolver calc cell value by given worksheet name, cell reference and context.
 func (f *File) cellResolver(ctx *calcContext, sheet, cell string) (formulaArg, error) {
 	var (
-		arg   formulaArg
-		value string
-		err   error
+		arg formulaArg
+		err error
 	)
 	ref := sheet + "!" + cell
 	if cached, ok := f.formulaArgCache.Load(ref); ok {
@@ -1834,6 +2932,17 @@ func (f *File) cellResolver(ctx *calcContext, sheet, cell string) (formulaArg, e
 		}
 		ctx.mu.Unlock()
 	}
+	if arg, err = f.cellValueToArg(sheet, cell); err == nil {
+		f.formulaArgCache.Store(ref, arg)
+	}
+	return arg, err
+}
+
+// cellValueToArg provides a function to convert the value of the cell to the
+// formula argument by given worksheet name and cell reference, the formula
+// in the cell will not be calculated.
+func (f *File) cellValueToArg(sheet, cell string) (arg formulaArg, err error) {
+	var value string
 	if value, err = f.GetCellValue(sheet, cell, Options{RawCellValue: true}); err != nil {
 		return arg, err
 	}
@@ -1862,7 +2971,6 @@ func (f *File) cellResolver(ctx *calcContext, sheet, cell string) (formulaArg, e
 	default:
 		arg = newErrorFormulaArg(value, value)
 	}
-	f.formulaArgCache.Store(ref, arg)
 	return arg, err
 }
 
@@ -15137,6 +16245,9 @@ func (fn *formulaFuncs) ANCHORARRAY(argsList *list.List) formulaArg {
 		return newEmptyFormulaArg()
 	}
 	coordinates, err := rangeRefToCoordinates(cell.F.Ref)
+	if err != nil && cell.F.Ref != "" && !strings.Contains(cell.F.Ref, ":") {
+		coordinates, err = cellRefsToCoordinates(cell.F.Ref, cell.F.Ref)
+	}
 	if err != nil {
 		return newErrorFormulaArg(formulaErrorVALUE, err.Error())
 	}
@@ -16061,35 +17172,19 @@ func (fn *formulaFuncs) INDIRECT(argsList *list.List) formulaArg {
 			return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
 		}
 	}
-	R1C1ToA1 := func(ref string) (cell string, err error) {
-		parts := strings.Split(strings.TrimLeft(ref, "R"), "C")
-		if len(parts) != 2 {
-			return
-		}
-		row, err := strconv.Atoi(parts[0])
-		if err != nil {
-			return
-		}
-		col, err := strconv.Atoi(parts[1])
-		if err != nil {
-			return
-		}
-		cell, err = CoordinatesToCellName(col, row)
-		return
-	}
 	refs := strings.Split(refText, ":")
 	fromRef, toRef := refs[0], ""
 	if len(refs) == 2 {
 		toRef = refs[1]
 	}
 	if a1.Number == 0 {
-		from, err := R1C1ToA1(refs[0])
+		from, err := r1c1ToA1(refs[0])
 		if err != nil {
 			return newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
 		}
 		fromRef = from
 		if len(refs) == 2 {
-			to, err := R1C1ToA1(refs[1])
+			to, err := r1c1ToA1(refs[1])
 			if err != nil {
 				return newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
 			}
@@ -16107,6 +17202,25 @@ func (fn *formulaFuncs) INDIRECT(argsList *list.List) formulaArg {
 	return arg
 }
 
+// r1c1ToA1 convert the R1C1-style cell reference to the A1-style cell
+// reference.
+func r1c1ToA1(ref string) (cell string, err error) {
+	parts := strings.Split(strings.TrimLeft(ref, "R"), "C")
+	if len(parts) != 2 {
+		return
+	}
+	row, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return
+	}
+	col, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return
+	}
+	cell, err = CoordinatesToCellName(col, row)
+	return
+}
+
 // LOOKUP function performs an approximate match lookup in a one-column or
 // one-row range, and returns the corresponding value from another one-column
 // or one-row range. The syntax of the function is:
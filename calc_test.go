@@ -7200,3 +7200,448 @@ func TestCalc3DRef(t *testing.T) {
 	assert.Empty(t, split3DReference(":Sheet1!A1"))
 	assert.Empty(t, split3DReference("!A1"))
 }
+
+func TestCalcCellValues(t *testing.T) {
+	f := prepareCalcData([][]interface{}{{"a", 1}, {"b", 2}, {"a", 3}, {"c", 4}})
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "_xlfn.UNIQUE(A1:A4)", FormulaOpts{DynamicArray: true}))
+	result, err := f.CalcCellValues("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"a"}, {"b"}, {"c"}}, result)
+	// Test get values of the spilled cells
+	for cell, expected := range map[string]string{"C1": "a", "C2": "b", "C3": "c", "C4": ""} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	formula, err := f.GetCellFormula("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "_xlfn.UNIQUE(A1:A4)", formula)
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	anchor := ws.(*xlsxWorksheet).SheetData.Row[0].C[2]
+	assert.Equal(t, "C1:C3", anchor.F.Ref)
+	assert.Equal(t, uint(1), *anchor.Cm)
+	for _, c := range []xlsxC{anchor, ws.(*xlsxWorksheet).SheetData.Row[1].C[2]} {
+		assert.Equal(t, "str", c.T, c.R)
+		assert.Nil(t, c.IS, c.R)
+	}
+	assert.Equal(t, "b", ws.(*xlsxWorksheet).SheetData.Row[1].C[2].V)
+	// Test calculate spilled values which referenced by the ANCHORARRAY function
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "COUNTA(_xlfn.ANCHORARRAY(C1))"))
+	value, err := f.CalcCellValue("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", value)
+
+	// Test calculate two-dimensional dynamic array formula
+	assert.NoError(t, f.SetCellFormula("Sheet1", "G1", "A1:B2", FormulaOpts{DynamicArray: true}))
+	result, err = f.CalcCellValues("Sheet1", "G1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"a", "1"}, {"b", "2"}}, result)
+	value, err = f.GetCellValue("Sheet1", "H2")
+	assert.NoError(t, err)
+	assert.Equal(t, "2", value)
+
+	// Test calculate scalar formula
+	assert.NoError(t, f.SetCellFormula("Sheet1", "J1", "SUM(B1:B4)"))
+	result, err = f.CalcCellValues("Sheet1", "J1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"10"}}, result)
+
+	// Test spill range is occupied
+	assert.NoError(t, f.SetCellValue("Sheet1", "C4", "x"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A5", "d"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "_xlfn.UNIQUE(A1:A5)", FormulaOpts{DynamicArray: true}))
+	result, err = f.CalcCellValues("Sheet1", "C1")
+	assert.EqualError(t, err, formulaErrorSPILL)
+	assert.Equal(t, [][]string{{formulaErrorSPILL}}, result)
+	value, err = f.GetCellValue("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, formulaErrorSPILL, value)
+	value, err = f.GetCellValue("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Empty(t, value)
+
+	// Test spill range overlaps with merged cells
+	assert.NoError(t, f.SetCellValue("Sheet1", "C4", nil))
+	assert.NoError(t, f.MergeCell("Sheet1", "C4", "D4"))
+	result, err = f.CalcCellValues("Sheet1", "C1")
+	assert.EqualError(t, err, formulaErrorSPILL)
+	assert.Equal(t, [][]string{{formulaErrorSPILL}}, result)
+	assert.NoError(t, f.UnmergeCell("Sheet1", "C4", "D4"))
+	result, err = f.CalcCellValues("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"a"}, {"b"}, {"c"}, {"d"}}, result)
+
+	// Test replace the dynamic array formula with normal formula
+	assert.NoError(t, f.SetCellFormula("Sheet1", "G1", "A1"))
+	value, err = f.GetCellValue("Sheet1", "H2")
+	assert.NoError(t, err)
+	assert.Empty(t, value)
+
+	// Test calculate formula with error result
+	assert.NoError(t, f.SetCellFormula("Sheet1", "L1", "1/0", FormulaOpts{DynamicArray: true}))
+	value, err = f.GetCellValue("Sheet1", "L1")
+	assert.NoError(t, err)
+	assert.Equal(t, formulaErrorDIV, value)
+	result, err = f.CalcCellValues("Sheet1", "L1")
+	assert.EqualError(t, err, formulaErrorDIV)
+	assert.Equal(t, [][]string{{formulaErrorDIV}}, result)
+	// Test calculate with invalid worksheet name and cell reference
+	_, err = f.CalcCellValues("Sheet:1", "A1")
+	assert.EqualError(t, err, ErrSheetNameInvalid.Error())
+	_, err = f.CalcCellValues("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestCalcCellValues.xlsx")))
+
+	// Test open workbook with dynamic array formulas
+	f, err = OpenFile(filepath.Join("test", "TestCalcCellValues.xlsx"))
+	assert.NoError(t, err)
+	result, err = f.CalcCellValues("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"a"}, {"b"}, {"c"}, {"d"}}, result)
+	value, err = f.CalcCellValue("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "a", value)
+	assert.NoError(t, f.Close())
+}
+
+func TestIsSpillBlocked(t *testing.T) {
+	ws := &xlsxWorksheet{}
+	assert.True(t, ws.isSpillBlocked(MaxColumns, 1, 2, 1, ""))
+	assert.True(t, ws.isSpillBlocked(1, TotalRows, 1, 2, ""))
+	ws.MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A"}}}
+	assert.False(t, ws.isSpillBlocked(1, 1, 1, 2, ""))
+}
+
+func TestCalcLAMBDA(t *testing.T) {
+	cellData := [][]interface{}{
+		{1, 2, 3},
+		{4, 5, 6},
+		{nil, "a", true},
+	}
+	f := prepareCalcData(cellData)
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "DOUBLE", RefersTo: "_xlfn.LAMBDA(_xlpm.x,_xlpm.x*2)"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "FACT2", RefersTo: "LAMBDA(n,IF(n<2,1,n*FACT2(n-1)))"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "ENDLESS", RefersTo: "LAMBDA(n,ENDLESS(n))"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "VALUES", RefersTo: "Sheet1!$A$1:$C$2"}))
+	formulaList := map[string]string{
+		// LET
+		"_xlfn.LET(_xlpm.x,2,_xlpm.x+1)":                 "3",
+		"LET(x,2,y,x*3,x+y)":                             "8",
+		"LET(x,A1:C2,SUM(x))":                            "21",
+		"LET(x,{1;2;3},SUM(x))":                          "6",
+		"LET(x,1,LET(x,x+1,x*10))":                       "20",
+		"LET(x,1,y,LET(x,5,x),x+y)":                      "6",
+		"LET(a,2,a*A1)":                                  "2",
+		"SUM(LET(x,1,x),LET(x,2,x))*2":                   "6",
+		"LET(x,\"a\",CONCAT(x,\"b\"))":                   "ab",
+		"LET(x,{1;2;3},x)":                               "1",
+		"{3,2,1}":                                        "3",
+		"LET(x,1/0,IFERROR(x,0))":                        "0",
+		"LET(f,LAMBDA(x,x+1),f(2))":                      "3",
+		"LET(n,3,f,LAMBDA(x,x*n),f(2))":                  "6",
+		"LET(f,LAMBDA(x,y,x*y),g,LAMBDA(x,f(x,x)),g(4))": "16",
+		// LAMBDA
+		"LAMBDA(x,x+1)(2)":             "3",
+		"LAMBDA(x,y,x&y)(\"a\",\"b\")": "ab",
+		"LAMBDA(1)()":                  "1",
+		"DOUBLE(21)":                   "42",
+		"DOUBLE(A2)+1":                 "9",
+		"FACT2(5)":                     "120",
+		"SUM(DOUBLE(1),DOUBLE(2))":     "6",
+		"IF(TRUE,DOUBLE(2))":           "4",
+		// MAP
+		"SUM(MAP(A1:C2,LAMBDA(x,x*2)))":           "42",
+		"SUM(MAP(A1:C1,A2:C2,LAMBDA(x,y,x*y)))":   "32",
+		"MAP(A1:C2,DOUBLE)":                       "2",
+		"SUM(MAP(VALUES,DOUBLE))":                 "42",
+		"SUM(MAP({1,2,3},LAMBDA(x,IF(x>1,x,0))))": "5",
+		"MAP(5,LAMBDA(x,x+1))":                    "6",
+		// REDUCE
+		"REDUCE(0,A1:C2,LAMBDA(a,b,a+b))":          "21",
+		"REDUCE(1,A1:C1,LAMBDA(a,b,a*b))":          "6",
+		"REDUCE(,A1:A2,LAMBDA(a,b,a+b))":           "5",
+		"REDUCE(\"\",A1:C1,LAMBDA(a,b,a&b))":       "123",
+		"REDUCE(0,A1:C2,LAMBDA(a,b,MAX(a,b)))":     "6",
+		"INDEX(SCAN(0,A1:C2,LAMBDA(a,b,a+b)),2,3)": "21",
+		"INDEX(SCAN(0,A1:C2,LAMBDA(a,b,a+b)),1,3)": "6",
+		// BYROW and BYCOL
+		"INDEX(BYROW(A1:C2,LAMBDA(r,SUM(r))),2,1)": "15",
+		"SUM(BYROW(A1:C2,LAMBDA(r,MAX(r))))":       "9",
+		"INDEX(BYCOL(A1:C2,LAMBDA(c,SUM(c))),1,3)": "9",
+		"SUM(BYCOL(A1:C2,LAMBDA(c,PRODUCT(c))))":   "32",
+		// MAKEARRAY
+		"SUM(MAKEARRAY(2,3,LAMBDA(r,c,r*c)))":                                "18",
+		"INDEX(MAKEARRAY(3,3,LAMBDA(r,c,r&c)),3,2)":                          "32",
+		"COUNT(MAKEARRAY(4,2,LAMBDA(r,c,0)))":                                "8",
+		"_xlfn.MAKEARRAY(1,1,_xlfn.LAMBDA(_xlpm.r,_xlpm.c,_xlpm.r+_xlpm.c))": "2",
+	}
+	for formula, expected := range formulaList {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	calcError := map[string][]string{
+		"LET(x,1)":                           {"#VALUE!", "LET requires an odd number of arguments and at least 3 arguments"},
+		"LET(x,1,y)":                         {"#NAME?", "#NAME?"},
+		"LET(A1:B1,1,2)":                     {"#NAME?", "LET requires valid names"},
+		"LET(x,1/0,x)":                       {"#DIV/0!", "#DIV/0!"},
+		"LAMBDA(x,x+1)":                      {"#CALC!", "#CALC!"},
+		"LET(f,LAMBDA(x,x),f)":               {"#CALC!", "#CALC!"},
+		"LAMBDA()":                           {"#VALUE!", "LAMBDA requires a calculation argument"},
+		"MAP(1,LAMBDA(1,2))":                 {"#VALUE!", "LAMBDA requires valid parameter names"},
+		"LAMBDA(x,x)(1,2)":                   {"#VALUE!", "the number of arguments does not match the LAMBDA function parameters"},
+		"LET(x,1,x(2))":                      {"#VALUE!", "not support x function"},
+		"ENDLESS(1)":                         {"#NUM!", "LAMBDA function exceeds the maximum nesting depth"},
+		"MAP(A1:C2)":                         {"#VALUE!", "MAP requires at least 2 arguments"},
+		"MAP(1/0,LAMBDA(x,x))":               {"#DIV/0!", "#DIV/0!"},
+		"MAP(A1:C2,1/0)":                     {"#DIV/0!", "#DIV/0!"},
+		"MAP(A1:C2,1)":                       {"#VALUE!", "MAP requires a LAMBDA function as the last argument"},
+		"MAP(A1:C2,LAMBDA(x,y,x))":           {"#VALUE!", "the number of arguments does not match the LAMBDA function parameters"},
+		"MAP(A1:B1,LAMBDA(x,{1;2}))":         {"#CALC!", "nested arrays are not supported"},
+		"MAP(A1:B1,LAMBDA(x,LAMBDA(y,y)))":   {"#CALC!", "#CALC!"},
+		"REDUCE(0,A1:C2)":                    {"#VALUE!", "REDUCE requires 3 arguments"},
+		"SCAN(0,A1:C2,1)":                    {"#VALUE!", "SCAN requires a LAMBDA function as the last argument"},
+		"BYROW(A1:C2)":                       {"#VALUE!", "BYROW requires 2 arguments"},
+		"BYROW(1/0,LAMBDA(r,r))":             {"#DIV/0!", "#DIV/0!"},
+		"BYCOL(A1:C2)":                       {"#VALUE!", "BYCOL requires 2 arguments"},
+		"BYCOL(1/0,LAMBDA(c,c))":             {"#DIV/0!", "#DIV/0!"},
+		"MAKEARRAY(1,1)":                     {"#VALUE!", "MAKEARRAY requires 3 arguments"},
+		"MAKEARRAY(1/0,1,LAMBDA(r,c,r))":     {"#DIV/0!", "#DIV/0!"},
+		"MAKEARRAY(\"a\",1,LAMBDA(r,c,r))":   {"#VALUE!", "#VALUE!"},
+		"MAKEARRAY(0,1,LAMBDA(r,c,r))":       {"#VALUE!", "#VALUE!"},
+		"MAKEARRAY(1048576,2,LAMBDA(r,c,r))": {"#VALUE!", "#VALUE!"},
+		"LET(x,1,IF(1/0,x))":                 {"#DIV/0!", "#DIV/0!"},
+	}
+	for formula, expected := range calcError {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "E1", formula))
+		result, err := f.CalcCellValue("Sheet1", "E1")
+		assert.EqualError(t, err, expected[1], formula)
+		assert.Equal(t, expected[0], result, formula)
+	}
+	// Test lazy IF function with invalid number of arguments
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "LET(x,1,IF(x))"))
+	result, err := f.CalcCellValue("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, "TRUE", result)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "LET(x,0,IF(x,1))"))
+	result, err = f.CalcCellValue("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+
+	// Test calculate the defined name lambda function after it changed
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "DOUBLE(2)"))
+	result, err = f.CalcCellValue("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, "4", result)
+	assert.NoError(t, f.DeleteDefinedName(&DefinedName{Name: "DOUBLE"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "DOUBLE", RefersTo: "LAMBDA(x,x*3)"}))
+	result, err = f.CalcCellValue("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, "6", result)
+
+	// Test dynamic array formula with lambda helper functions
+	formulaType, ref := STCellFormulaTypeArray, "E1"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "_xlfn.MAP(A1:C2,_xlfn.LAMBDA(_xlpm.x,_xlpm.x*10))", FormulaOpts{Type: &formulaType, Ref: &ref, DynamicArray: true}))
+	values, err := f.CalcCellValues("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"10", "20", "30"}, {"40", "50", "60"}}, values)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "LET(x,{1;2},x)", FormulaOpts{Type: &formulaType, Ref: &ref, DynamicArray: true}))
+	values, err = f.CalcCellValues("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"1"}, {"2"}}, values)
+}
+
+func TestSplitFuncArgs(t *testing.T) {
+	ps := efp.ExcelParser()
+	tokens := ps.Parse("SUM(1,(2+3)")
+	args, end := splitFuncArgs(tokens, 0)
+	assert.Len(t, args, 2)
+	assert.Equal(t, len(tokens)-1, end)
+}
+
+func TestRegisterFormulaFunction(t *testing.T) {
+	f := prepareCalcData([][]interface{}{{1, 2, 3}, {"a", "b", "c"}})
+	assert.NoError(t, f.RegisterFormulaFunction("DOUBLE", func(ctx FormulaContext, args []FormulaArg) FormulaArg {
+		if len(args) != 1 {
+			return NewErrorFormulaArg(formulaErrorVALUE, "DOUBLE requires 1 argument")
+		}
+		num := args[0].ToNumber()
+		if num.Type != ArgNumber {
+			return num
+		}
+		return NewNumberFormulaArg(num.Number * 2)
+	}))
+	assert.NoError(t, f.RegisterFormulaFunction("_xll.Company.Join", func(ctx FormulaContext, args []FormulaArg) FormulaArg {
+		var values []string
+		for _, arg := range args {
+			for _, value := range arg.ToList() {
+				values = append(values, value.Value())
+			}
+		}
+		return NewStringFormulaArg(ctx.Sheet + "!" + ctx.Cell + ":" + strings.Join(values, "-"))
+	}))
+	// Test override the built-in function
+	assert.NoError(t, f.RegisterFormulaFunction("ABS", func(ctx FormulaContext, args []FormulaArg) FormulaArg {
+		return NewMatrixFormulaArg([][]formulaArg{{NewBoolFormulaArg(true)}})
+	}))
+	for formula, expected := range map[string]string{
+		"DOUBLE(A1)":                     "2",
+		"double(2)+1":                    "5",
+		"_xlfn.DOUBLE(SUM(A1:C1))":       "12",
+		"SUM(DOUBLE(A1),DOUBLE(B1))":     "6",
+		"_xll.COMPANY.JOIN(A1:C2,1)":     "Sheet1!D1:1-2-3-a-b-c-1",
+		"Company.Join(\"x\")":            "Sheet1!D1:x",
+		"ABS(-1)":                        "TRUE",
+		"LET(x,3,DOUBLE(x))":             "6",
+		"MAP(A1:C1,LAMBDA(x,DOUBLE(x)))": "2",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "D1", formula))
+		result, err := f.CalcCellValue("Sheet1", "D1")
+		assert.NoError(t, err, formula)
+		assert.Equal(t, expected, result, formula)
+	}
+	for formula, expected := range map[string]string{
+		"DOUBLE()":   "DOUBLE requires 1 argument",
+		"DOUBLE(A2)": "strconv.ParseFloat: parsing \"a\": invalid syntax",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "D1", formula))
+		result, err := f.CalcCellValue("Sheet1", "D1")
+		assert.EqualError(t, err, expected, formula)
+		assert.Equal(t, formulaErrorVALUE, result, formula)
+	}
+	// Test register custom function with invalid parameters
+	assert.Equal(t, ErrParameterInvalid, f.RegisterFormulaFunction("", func(ctx FormulaContext, args []FormulaArg) FormulaArg { return NewEmptyFormulaArg() }))
+	assert.Equal(t, ErrParameterInvalid, f.RegisterFormulaFunction("_xll.", func(ctx FormulaContext, args []FormulaArg) FormulaArg { return NewEmptyFormulaArg() }))
+	assert.Equal(t, ErrParameterInvalid, f.RegisterFormulaFunction("FN", nil))
+}
+
+func TestCalcStructuredReference(t *testing.T) {
+	f := NewFile()
+	for cell, value := range map[string]interface{}{
+		"A1": "Item", "B1": "Qty", "C1": "Unit Price", "D1": "Amount", "E1": "Check",
+		"A2": "a", "B2": 1, "C2": 10,
+		"A3": "b", "B3": 2, "C3": 20,
+		"A4": "c", "B4": 3, "C4": 30,
+		"A5": "Total", "B5": 6, "C5": 60,
+	} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:E5", Name: "Sales"}))
+	// Test the table with totals row
+	content, ok := f.Pkg.Load("xl/tables/table1.xml")
+	assert.True(t, ok)
+	f.Pkg.Store("xl/tables/table1.xml", []byte(strings.ReplaceAll(string(content.([]byte)), `ref="A1:E5"`, `ref="A1:E5" totalsRowCount="1"`)))
+	_, err := f.NewSheet("Sheet 2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Quantity", RefersTo: "Sales[Qty]"}))
+	for formula, expected := range map[string]string{
+		"SUM(Sales[Qty])":                           "6",
+		"SUM(sales[qty])":                           "6",
+		"SUM(Sales[[#Totals],[Qty]])":               "6",
+		"Sales[[#Totals],[Unit Price]]":             "60",
+		"Sales[[#Headers],[Unit Price]]":            "Unit Price",
+		"ROWS(Sales[#All])":                         "5",
+		"ROWS(Sales[#Headers])":                     "1",
+		"ROWS(Sales[#Totals])":                      "1",
+		"ROWS(Sales[[#Headers],[#Data],[Qty]])":     "4",
+		"ROWS(Sales[[#Data],[#Totals]])":            "4",
+		"SUM(Sales[[Qty]:[Unit Price]])":            "66",
+		"SUM(Sales[[Unit Price]:[Qty]])":            "66",
+		"COLUMNS(Sales[[#All],[Qty]:[Unit Price]])": "2",
+		"ROWS(Sales)*COLUMNS(Sales[])":              "15",
+		"SUM(Quantity)":                             "6",
+		"LET(x,Sales[Qty],SUM(x))":                  "6",
+		"Sales[Missing]":                            formulaErrorREF,
+		"Missing[Qty]":                              formulaErrorREF,
+		"[@Qty]":                                    formulaErrorREF,
+		"Sales[[#Headers],[#Totals]]":               formulaErrorREF,
+		"Sales[@Qty]":                               formulaErrorVALUE,
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet 2", "A1", formula))
+		result, _ := f.CalcCellValue("Sheet 2", "A1")
+		assert.Equal(t, expected, result, formula)
+	}
+	for cell, formula := range map[string]string{
+		"D3": "[@Qty]*[@[Unit Price]]",
+		"E3": "Sales[[#This Row],[Qty]]+Sales[@Qty]",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	for cell, expected := range map[string]string{"D3": "40", "E3": "4"} {
+		result, err := f.CalcCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result, cell)
+	}
+	assert.Equal(t, []cellRange{
+		{From: cellRef{Sheet: "Sheet1", Col: 2, Row: 2}, To: cellRef{Sheet: "Sheet1", Col: 2, Row: 4}},
+		{From: cellRef{Sheet: "Sheet1", Col: 3, Row: 3}, To: cellRef{Sheet: "Sheet1", Col: 3, Row: 3}},
+	}, f.getFormulaRanges("Sheet1", "D3", "SUM(Sales[Qty])+[@[Unit Price]]+Sales[Missing]"))
+	// Test the structured reference in the rows outside the table data
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B6", "[@Qty]"))
+	result, _ := f.CalcCellValue("Sheet1", "B6")
+	assert.Equal(t, formulaErrorREF, result)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B6", "Sales[@Qty]"))
+	result, _ = f.CalcCellValue("Sheet1", "B6")
+	assert.Equal(t, formulaErrorVALUE, result)
+	// Test the table definitions will be cached until clear calculation cache
+	refs := f.tableRefs.Load()
+	assert.NotNil(t, refs)
+	assert.Len(t, *refs, 1)
+	f.Pkg.Store("xl/tables/table1.xml", []byte(strings.ReplaceAll(string(content.([]byte)), `ref="A1:E5"`, `ref="A2:E4" headerRowCount="0"`)))
+	_, tbl := f.getTable("Sheet 2", "", "Sales")
+	assert.Equal(t, "A1:E5", tbl.Ref)
+	// Test the structured reference with the table without header row
+	f.clearCalcCache()
+	assert.Nil(t, f.tableRefs.Load())
+	assert.NoError(t, f.SetCellFormula("Sheet 2", "A1", "Sales[#Headers]"))
+	result, _ = f.CalcCellValue("Sheet 2", "A1")
+	assert.Equal(t, formulaErrorREF, result)
+	assert.NoError(t, f.SetCellFormula("Sheet 2", "A1", "ROWS(Sales[#Data])"))
+	result, err = f.CalcCellValue("Sheet 2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "3", result)
+	// Test the structured reference with invalid table range reference
+	f.Pkg.Store("xl/tables/table1.xml", []byte(`<table name="Sales" ref="A1"/>`))
+	assert.NoError(t, f.SetCellFormula("Sheet 2", "A1", "Sales[Qty]"))
+	result, _ = f.CalcCellValue("Sheet 2", "A1")
+	assert.Equal(t, formulaErrorREF, result)
+	// Test the structured reference with unsupported charset table
+	f.Pkg.Store("xl/tables/table1.xml", MacintoshCyrillicCharset)
+	f.clearCalcCache()
+	result, _ = f.CalcCellValue("Sheet 2", "A1")
+	assert.Equal(t, formulaErrorREF, result)
+}
+
+func TestParseStructuredRef(t *testing.T) {
+	for ref, expected := range map[string]structuredRef{
+		"Sales[]":                  {table: "Sales"},
+		"[@]":                      {thisRow: true},
+		"Sales[@Qty]":              {table: "Sales", thisRow: true, columns: []string{"Qty"}},
+		"Sales[Price'[USD']]":      {table: "Sales", columns: []string{"Price[USD]"}},
+		"Sales[[#This Row],[A]]":   {table: "Sales", thisRow: true, columns: []string{"A"}},
+		"Sales[@[A]:[B]]":          {table: "Sales", thisRow: true, columns: []string{"A", "B"}},
+		"Sales[[#Headers], [A]]":   {table: "Sales", headers: true, columns: []string{"A"}},
+		"Sales[[#Data],[#Totals]]": {table: "Sales", data: true, totals: true},
+		"Sales[#ALL]":              {table: "Sales", all: true},
+	} {
+		sr, ok := parseStructuredRef(ref)
+		assert.True(t, ok, ref)
+		assert.Equal(t, expected, sr, ref)
+	}
+	for _, ref := range []string{
+		"Sales", "Sales[A", "Sheet1!Sales[A]", "[1]Sheet1!A1", "Sales[#Invalid]",
+		"Sales[[A],[B]]", "Sales[[A]:[#All]]", "Sales[[A]:[B]:[C]]", "Sales[[A]:]",
+		"Sales[[A]x]", "Sales[[]]", "Sales[[A]",
+	} {
+		_, ok := parseStructuredRef(ref)
+		assert.False(t, ok, ref)
+	}
+	ps := efp.ExcelParser()
+	assert.Equal(t, []efp.Token{
+		{TValue: "SUM", TType: efp.TokenTypeFunction, TSubType: efp.TokenSubTypeStart},
+		{TValue: "Sales[[#Totals],[Qty]]", TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeRange},
+		{TValue: "", TType: efp.TokenTypeFunction, TSubType: efp.TokenSubTypeStop},
+	}, mergeStructuredRefTokens(ps.Parse("SUM(Sales[[#Totals],[Qty]])")))
+}
@@ -15,6 +15,11 @@ import (
 	"bytes"
 	"encoding/xml"
 	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/xuri/efp"
 )
 
 // calcChainReader provides a function to get the pointer to the structure
@@ -117,3 +122,932 @@ func (vt *xlsxVolTypes) deleteVolTopicRef(i1, i2, i3, i4 int) {
 		}
 	}
 }
+
+// calcNode defined the formula cell in the dependency graph of the workbook
+// recalculation.
+type calcNode struct {
+	sheet, cell  string
+	formula      string
+	col, row     int
+	dynamicArray bool
+	area         []int
+	ranges       []cellRange
+	deps         []int
+}
+
+// calcGraph defined the dependency graph of the formula cells in the
+// workbook, the edges point from the formula cells to their precedents.
+type calcGraph struct {
+	nodes   []*calcNode
+	cells   map[string]int
+	cols    map[string]map[int][]int
+	spills  map[string][]int
+	refs    []calcRef
+	refCols map[string]map[int][]int
+	refRows map[string][]int
+}
+
+// calcRef defined the cell range referenced by the formula cell in the
+// dependency graph of the workbook recalculation.
+type calcRef struct {
+	node int
+	cr   cellRange
+}
+
+// RecalculateAll provides a function to recalculate all formulas in the
+// workbook and store the calculated results as the cached values of the
+// formula cells, so that the workbook could be consumed by applications
+// without calculation engine. The formula cells will be evaluated in the
+// order of their dependencies. If there are circular references in the
+// workbook, the cached values of the cells in the circular references will
+// not be updated, and an error of the ErrCircularReference type will be
+// returned with these cells. For example:
+//
+//	if err := f.RecalculateAll(); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) RecalculateAll(opts ...Options) error {
+	return f.recalculate(nil, opts...)
+}
+
+// RecalculateSheet provides a function to recalculate formulas in the given
+// worksheet and store the calculated results as the cached values of the
+// formula cells. The precedent formula cells in other worksheets will be
+// calculated as needed, but their cached values will not be updated. For
+// example, recalculate formulas in the worksheet named "Sheet1":
+//
+//	if err := f.RecalculateSheet("Sheet1"); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) RecalculateSheet(sheet string, opts ...Options) error {
+	if _, err := f.workSheetReader(sheet); err != nil {
+		return err
+	}
+	return f.recalculate([]string{sheet}, opts...)
+}
+
+// recalculate provides a function to recalculate formulas in the given
+// worksheets, all worksheets will be recalculated if the sheets list is
+// empty.
+func (f *File) recalculate(sheets []string, opts ...Options) error {
+	options := f.getOptions(opts...)
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	f.clearCalcCache()
+	graph, err := f.prepareCalcGraph()
+	if err != nil {
+		return err
+	}
+	roots, err := f.calcGraphRoots(graph, sheets)
+	if err != nil {
+		return err
+	}
+	var (
+		circular, diverged []string
+		write              = func(node *calcNode) bool {
+			return len(sheets) == 0 || inStrSlice(sheets, node.sheet, false) != -1
+		}
+	)
+	for _, scc := range graph.sort(roots) {
+		if !graph.isCircular(scc) {
+			f.recalculateCell(graph.nodes[scc[0]], write(graph.nodes[scc[0]]), options)
+			continue
+		}
+		cells := graph.cellRefs(scc)
+		if wb.CalcPr == nil || !wb.CalcPr.Iterate {
+			circular = append(circular, cells...)
+			continue
+		}
+		if !f.iterateCircularCells(graph, scc, write, wb.CalcPr, options) {
+			diverged = append(diverged, cells...)
+		}
+	}
+	if len(circular) > 0 {
+		return ErrCircularReference{Cells: circular}
+	}
+	if len(diverged) > 0 {
+		return ErrCalcNotConverged{Cells: diverged}
+	}
+	return nil
+}
+
+// prepareCalcGraph provides a function to transform the array formulas and
+// build the dependency graph of all formula cells in the workbook. The graph
+// will be cached until the calculation cache is cleared.
+func (f *File) prepareCalcGraph() (*calcGraph, error) {
+	if graph := f.calcGraph.Load(); graph != nil {
+		return graph, nil
+	}
+	if !f.formulaChecked {
+		if err := f.setArrayFormulaCells(); err != nil {
+			return nil, err
+		}
+		f.formulaChecked = true
+	}
+	graph, err := f.newCalcGraph()
+	if err == nil {
+		f.calcGraph.Store(graph)
+	}
+	return graph, err
+}
+
+// iterateCircularCells provides a function to calculate the formula cells in
+// the circular references by iterative calculation with the given
+// calculation properties. The calculation will be repeated until the maximum
+// change of the values between two iterations less than the maximum change
+// value, or reached the maximum number of iterations. The calculated values
+// will be stored as the cached values of the formula cells, and returns
+// whether the calculation converged.
+func (f *File) iterateCircularCells(graph *calcGraph, scc []int, write func(node *calcNode) bool, calcPr *xlsxCalcPr, options *Options) bool {
+	count, delta := defaultIterateCount, defaultIterateDelta
+	if calcPr.IterateCount > 0 {
+		count = calcPr.IterateCount
+	}
+	if calcPr.IterateDelta > 0 {
+		delta = calcPr.IterateDelta
+	}
+	for _, idx := range scc {
+		node := graph.nodes[idx]
+		arg, _ := f.cellValueToArg(node.sheet, node.cell)
+		f.formulaArgCache.Store(node.sheet+"!"+node.cell, arg)
+	}
+	converged := false
+	for i := 0; i < count && !converged; i++ {
+		converged = true
+		for _, idx := range scc {
+			node := graph.nodes[idx]
+			prev, _ := f.formulaArgCache.Load(node.sheet + "!" + node.cell)
+			if arg := f.recalculateCell(node, false, options); !isIterateConverged(prev.(formulaArg), arg, delta) {
+				converged = false
+			}
+		}
+	}
+	for _, idx := range scc {
+		if node := graph.nodes[idx]; write(node) {
+			arg, _ := f.formulaArgCache.Load(node.sheet + "!" + node.cell)
+			ws, _ := f.workSheetReader(node.sheet)
+			ws.prepareSheetXML(node.col, node.row)
+			ws.SheetData.Row[node.row-1].C[node.col-1].setCachedValue(arg.(formulaArg))
+		}
+	}
+	return converged
+}
+
+// isIterateConverged returns true if the change between the values of the
+// formula cell in two iterations less than the given maximum change value.
+func isIterateConverged(prev, arg formulaArg, delta float64) bool {
+	if prev.Type == ArgEmpty {
+		prev = newNumberFormulaArg(0)
+	}
+	if arg.Type == ArgEmpty {
+		arg = newNumberFormulaArg(0)
+	}
+	if prev.Type == ArgNumber && arg.Type == ArgNumber {
+		return math.Abs(arg.Number-prev.Number) < delta
+	}
+	return prev.Type == arg.Type && prev.Value() == arg.Value()
+}
+
+// iterativeCellValue provides a function to calculate the formula cell in
+// circular references by iterative calculation if the iterative calculation
+// has been enabled in the workbook calculation properties. The returned
+// boolean value is false if the iterative calculation is disabled or the
+// cell is not in circular references. An error of the ErrCalcNotConverged
+// type will be returned with the calculated value if the iterative
+// calculation of the circular references not converged.
+func (f *File) iterativeCellValue(sheet, cell string, options *Options) (formulaArg, bool, error) {
+	wb, err := f.workbookReader()
+	if err != nil || wb.CalcPr == nil || !wb.CalcPr.Iterate {
+		return formulaArg{}, false, err
+	}
+	graph, err := f.prepareCalcGraph()
+	if err != nil {
+		return formulaArg{}, false, err
+	}
+	idx, ok := graph.cells[strings.ToLower(sheet)+"!"+cell]
+	if !ok {
+		return formulaArg{}, false, err
+	}
+	var (
+		circular bool
+		diverged []string
+	)
+	for _, scc := range graph.sort([]int{idx}) {
+		if circular = graph.isCircular(scc); circular {
+			if !f.iterateCircularCells(graph, scc, func(node *calcNode) bool { return false }, wb.CalcPr, options) {
+				diverged = append(diverged, graph.cellRefs(scc)...)
+			}
+			continue
+		}
+		if scc[0] != idx {
+			f.recalculateCell(graph.nodes[scc[0]], false, options)
+		}
+	}
+	if !circular {
+		return formulaArg{}, false, err
+	}
+	if len(diverged) > 0 {
+		err = ErrCalcNotConverged{Cells: diverged}
+	}
+	arg, _ := f.formulaArgCache.Load(graph.nodes[idx].sheet + "!" + graph.nodes[idx].cell)
+	return arg.(formulaArg), true, err
+}
+
+// recalculateCell provides a function to calculate the formula of the given
+// node in the dependency graph, and store the result as the cached value of
+// the formula cell if the write parameter is true. The calculated result will
+// be returned.
+func (f *File) recalculateCell(node *calcNode, write bool, options *Options) formulaArg {
+	ws, _ := f.workSheetReader(node.sheet)
+	ref := node.sheet + "!" + node.cell
+	if node.dynamicArray && write {
+		arg := f.updateDynamicArray(ws, node.sheet, node.col, node.row, ws.getSpillRef(node.col, node.row), options)
+		f.formulaArgCache.Store(ref, arg)
+		return arg
+	}
+	arg, err := f.calcCellValue(&calcContext{
+		entry:                ref,
+		maxCalcIterations:    options.MaxCalcIterations,
+		iterations:           make(map[string]uint),
+		iterationsCache:      make(map[string]formulaArg),
+		externalLinkResolver: options.ExternalLinkResolver,
+	}, node.sheet, node.cell)
+	if err != nil && arg.Type != ArgError {
+		arg = newErrorFormulaArg(formulaErrorVALUE, err.Error())
+	}
+	if arg.Type == ArgMatrix {
+		args := arg.ToList()
+		if arg = newEmptyFormulaArg(); len(args) > 0 {
+			arg = args[0]
+		}
+	}
+	f.formulaArgCache.Store(ref, arg)
+	if write {
+		ws.prepareSheetXML(node.col, node.row)
+		ws.SheetData.Row[node.row-1].C[node.col-1].setCachedValue(arg)
+	}
+	return arg
+}
+
+// newCalcGraph provides a function to build the dependency graph of all
+// formula cells in the workbook.
+func (f *File) newCalcGraph() (*calcGraph, error) {
+	metadata, err := f.metadataReader()
+	if err != nil {
+		return nil, err
+	}
+	graph := &calcGraph{
+		cells: make(map[string]int), cols: make(map[string]map[int][]int), spills: make(map[string][]int),
+		refCols: make(map[string]map[int][]int), refRows: make(map[string][]int),
+	}
+	for _, sheet := range f.GetSheetList() {
+		ws, err := f.workSheetReader(sheet)
+		if err != nil {
+			if err.Error() == newNotWorksheetError(sheet).Error() {
+				continue
+			}
+			return nil, err
+		}
+		key := strings.ToLower(sheet)
+		graph.cols[key] = make(map[int][]int)
+		for _, row := range ws.SheetData.Row {
+			for _, c := range row.C {
+				if c.F == nil && c.f == "" {
+					continue
+				}
+				col, r, err := CellNameToCoordinates(c.R)
+				if err != nil {
+					return nil, err
+				}
+				node := &calcNode{sheet: sheet, cell: c.R, col: col, row: r, area: []int{col, r, col, r}}
+				if node.dynamicArray = metadata.isDynamicArray(c.Cm); node.dynamicArray {
+					if coordinates, err := rangeRefToCoordinates(c.F.Ref); err == nil {
+						_ = sortCoordinates(coordinates)
+						node.area = coordinates
+						graph.spills[key] = append(graph.spills[key], len(graph.nodes))
+					}
+				}
+				graph.cells[key+"!"+c.R] = len(graph.nodes)
+				graph.cols[key][col] = append(graph.cols[key][col], len(graph.nodes))
+				graph.nodes = append(graph.nodes, node)
+			}
+		}
+	}
+	for i, node := range graph.nodes {
+		if node.formula, err = f.getCellFormula(node.sheet, node.cell, true); err != nil {
+			return nil, err
+		}
+		node.ranges = f.getFormulaRanges(node.sheet, node.cell, node.formula)
+		deps := make(map[int]struct{})
+		for _, cr := range node.ranges {
+			graph.indexRef(i, cr)
+			for _, idx := range graph.lookup(cr) {
+				deps[idx] = struct{}{}
+			}
+		}
+		for idx := range deps {
+			node.deps = append(node.deps, idx)
+		}
+		sort.Ints(node.deps)
+	}
+	return graph, nil
+}
+
+// calcGraphRoots provides a function to get the indexes of the nodes in the
+// dependency graph for the formula cells in the given worksheets as the
+// starting points of the graph traversal. The cells in the calculation chain
+// will be placed first, and followed by other formula cells in worksheet
+// order.
+func (f *File) calcGraphRoots(graph *calcGraph, sheets []string) ([]int, error) {
+	calc, err := f.calcChainReader()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		roots   []int
+		sheetID int
+		names   = f.GetSheetMap()
+		visited = make(map[int]bool)
+		target  = func(idx int) bool {
+			return len(sheets) == 0 || inStrSlice(sheets, graph.nodes[idx].sheet, false) != -1
+		}
+	)
+	for _, c := range calc.C {
+		if c.I != 0 {
+			sheetID = c.I
+		}
+		if idx, ok := graph.cells[strings.ToLower(names[sheetID])+"!"+c.R]; ok && !visited[idx] && target(idx) {
+			roots, visited[idx] = append(roots, idx), true
+		}
+	}
+	for idx := range graph.nodes {
+		if !visited[idx] && target(idx) {
+			roots = append(roots, idx)
+		}
+	}
+	return roots, err
+}
+
+// lookup provides a function to get the indexes of the formula cells nodes
+// in the given cell range, including the dynamic array formula cells which
+// spill range overlaps with the cell range.
+func (g *calcGraph) lookup(cr cellRange) []int {
+	var (
+		results []int
+		key     = strings.ToLower(cr.From.Sheet)
+	)
+	if cr.From.Col == cr.To.Col && cr.From.Row == cr.To.Row {
+		cell, _ := CoordinatesToCellName(cr.From.Col, cr.From.Row)
+		if idx, ok := g.cells[key+"!"+cell]; ok {
+			results = append(results, idx)
+		}
+	} else {
+		for col, indexes := range g.cols[key] {
+			if col < cr.From.Col || col > cr.To.Col {
+				continue
+			}
+			for _, idx := range indexes {
+				if row := g.nodes[idx].row; row >= cr.From.Row && row <= cr.To.Row {
+					results = append(results, idx)
+				}
+			}
+		}
+	}
+	for _, idx := range g.spills[key] {
+		if area := g.nodes[idx].area; area[0] <= cr.To.Col && area[2] >= cr.From.Col && area[1] <= cr.To.Row && area[3] >= cr.From.Row {
+			results = append(results, idx)
+		}
+	}
+	return results
+}
+
+// indexRef provides a function to add the cell range referenced by the
+// formula cell into the reverse index of the dependency graph. The ranges of
+// entire rows will be indexed by worksheet, and other ranges will be indexed
+// by worksheet and column.
+func (g *calcGraph) indexRef(node int, cr cellRange) {
+	key := strings.ToLower(cr.From.Sheet)
+	g.refs = append(g.refs, calcRef{node: node, cr: cr})
+	if cr.From.Col == 1 && cr.To.Col == MaxColumns {
+		g.refRows[key] = append(g.refRows[key], len(g.refs)-1)
+		return
+	}
+	if g.refCols[key] == nil {
+		g.refCols[key] = make(map[int][]int)
+	}
+	for col := cr.From.Col; col <= cr.To.Col; col++ {
+		g.refCols[key][col] = append(g.refCols[key][col], len(g.refs)-1)
+	}
+}
+
+// lookupRefs provides a function to get the indexes of the cell ranges
+// referenced by the formula cells which overlap with the given cell range,
+// the indexes will be returned in the order of the formula cells.
+func (g *calcGraph) lookupRefs(area cellRange) []int {
+	var (
+		results []int
+		key     = strings.ToLower(area.From.Sheet)
+		visited = make(map[int]bool)
+		check   = func(indexes []int) {
+			for _, idx := range indexes {
+				if cr := g.refs[idx].cr; !visited[idx] && cr.From.Row <= area.To.Row && cr.To.Row >= area.From.Row {
+					results, visited[idx] = append(results, idx), true
+				}
+			}
+		}
+	)
+	check(g.refRows[key])
+	if cols := g.refCols[key]; area.To.Col-area.From.Col < len(cols) {
+		for col := area.From.Col; col <= area.To.Col; col++ {
+			check(cols[col])
+		}
+	} else {
+		for col, indexes := range cols {
+			if col >= area.From.Col && col <= area.To.Col {
+				check(indexes)
+			}
+		}
+	}
+	sort.Ints(results)
+	return results
+}
+
+// isCircular provides a function to check if the given strongly connected
+// component of the dependency graph is circular references, which contains
+// multiple formula cells or the formula cell references itself.
+func (g *calcGraph) isCircular(scc []int) bool {
+	if len(scc) > 1 {
+		return true
+	}
+	for _, dep := range g.nodes[scc[0]].deps {
+		if dep == scc[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// cellRefs provides a function to get the sorted cell references of the
+// given nodes in the dependency graph.
+func (g *calcGraph) cellRefs(scc []int) []string {
+	var cells []string
+	sort.Ints(scc)
+	for _, idx := range scc {
+		cells = append(cells, g.nodes[idx].sheet+"!"+g.nodes[idx].cell)
+	}
+	return cells
+}
+
+// sort provides a function to get the strongly connected components of the
+// dependency graph which reachable from the given root nodes by Tarjan's
+// algorithm. The components are returned in topological order, the
+// precedents are placed before their dependents.
+func (g *calcGraph) sort(roots []int) [][]int {
+	type frame struct{ node, next int }
+	var (
+		index   int
+		stack   []int
+		sccs    [][]int
+		indexes = make([]int, len(g.nodes))
+		lowLink = make([]int, len(g.nodes))
+		onStack = make([]bool, len(g.nodes))
+		visit   = func(idx int) {
+			index++
+			indexes[idx], lowLink[idx], onStack[idx] = index, index, true
+			stack = append(stack, idx)
+		}
+	)
+	for _, root := range roots {
+		if indexes[root] != 0 {
+			continue
+		}
+		visit(root)
+		frames := []frame{{node: root}}
+		for len(frames) > 0 {
+			top := &frames[len(frames)-1]
+			node := g.nodes[top.node]
+			if top.next < len(node.deps) {
+				dep := node.deps[top.next]
+				top.next++
+				if indexes[dep] == 0 {
+					visit(dep)
+					frames = append(frames, frame{node: dep})
+				} else if onStack[dep] {
+					lowLink[top.node] = min(lowLink[top.node], indexes[dep])
+				}
+				continue
+			}
+			if lowLink[top.node] == indexes[top.node] {
+				var scc []int
+				for {
+					idx := stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					onStack[idx] = false
+					if scc = append(scc, idx); idx == top.node {
+						break
+					}
+				}
+				sccs = append(sccs, scc)
+			}
+			if frames = frames[:len(frames)-1]; len(frames) > 0 {
+				parent := frames[len(frames)-1].node
+				lowLink[parent] = min(lowLink[parent], lowLink[top.node])
+			}
+		}
+	}
+	return sccs
+}
+
+// getFormulaRanges provides a function to get the cell ranges referenced by
+// the formula of the given cell, the defined names, structured references and
+// 3D references in the formula will be expanded to the cell ranges, and the
+// references built by the INDIRECT and OFFSET functions with constant
+// arguments will be resolved. The names bound by the LET function and the
+// parameters of the LAMBDA function will be skipped inside the function.
+func (f *File) getFormulaRanges(sheet, cell, formula string) []cellRange {
+	var (
+		ranges []cellRange
+		scopes []lambdaScope
+	)
+	tokens := f.parseFormulaTokens(sheet, cell, formula)
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		for len(scopes) > 0 && i > scopes[len(scopes)-1].stop {
+			scopes = scopes[:len(scopes)-1]
+		}
+		if isFunctionStartToken(token) {
+			if scope, ok := getLambdaScope(tokens, i); ok {
+				scopes = append(scopes, scope)
+				continue
+			}
+			if crs, stop, ok := f.getStaticFuncRanges(sheet, cell, tokens, i); ok {
+				ranges, i = append(ranges, crs...), stop
+			}
+			continue
+		}
+		if token.TType == efp.TokenTypeOperand && token.TSubType == efp.TokenSubTypeRange &&
+			!inLambdaScopes(scopes, token.TValue) {
+			ranges = append(ranges, f.getReferenceRanges(sheet, token.TValue)...)
+		}
+	}
+	return ranges
+}
+
+// lambdaScope defined the names bound by the LET function or the parameters
+// of the LAMBDA function, and the index of the function stop token.
+type lambdaScope struct {
+	names map[string]struct{}
+	stop  int
+}
+
+// getLambdaScope returns the names bound by the LET function or the
+// parameters of the LAMBDA function which start at the given index of the
+// tokens, and whether the function is the LET or LAMBDA function.
+func getLambdaScope(tokens []efp.Token, idx int) (lambdaScope, bool) {
+	name := strings.ToUpper(strings.TrimPrefix(strings.ToLower(tokens[idx].TValue), "_xlfn."))
+	if name != "LET" && name != "LAMBDA" {
+		return lambdaScope{}, false
+	}
+	args, stop := splitFuncArgs(tokens, idx)
+	scope := lambdaScope{names: make(map[string]struct{}), stop: stop}
+	for i := 0; i < len(args)-1; i++ {
+		if name == "LET" && i%2 == 1 {
+			continue
+		}
+		if param, ok := lambdaParamName(args[i]); ok {
+			scope.names[param] = struct{}{}
+		}
+	}
+	return scope, true
+}
+
+// inLambdaScopes returns whether the given operand is the name bound by the
+// LET function or the parameter of the LAMBDA function in the given scopes.
+func inLambdaScopes(scopes []lambdaScope, operand string) bool {
+	if strings.ContainsAny(operand, "!:$") {
+		return false
+	}
+	name := formulaVarName(operand)
+	for _, scope := range scopes {
+		if _, ok := scope.names[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// getReferenceRanges provides a function to get the cell ranges by given
+// reference, the defined names and 3D references will be expanded to the cell
+// ranges.
+func (f *File) getReferenceRanges(sheet, reference string) []cellRange {
+	var ranges []cellRange
+	if refTo := f.getDefinedNameRefTo(reference, sheet); refTo != "" {
+		reference = refTo
+	}
+	reference = strings.ReplaceAll(reference, "$", "")
+	if parts := split3DReference(reference); len(parts) == 3 {
+		sheets, err := f.expand3DSheetRange(parts[0], parts[1])
+		if err != nil {
+			return ranges
+		}
+		for _, name := range sheets {
+			if cr, err := parseCellRange(name, parts[2]); err == nil {
+				ranges = append(ranges, cr)
+			}
+		}
+		return ranges
+	}
+	if cr, err := parseCellRange(sheet, reference); err == nil {
+		ranges = append(ranges, cr)
+	}
+	return ranges
+}
+
+// getStaticFuncRanges provides a function to get the cell ranges referenced
+// by the INDIRECT or OFFSET function which start at the given index of the
+// tokens when the arguments of the function are constants. Returns the cell
+// ranges, the index of the function stop token and whether the reference of
+// the function has been resolved.
+func (f *File) getStaticFuncRanges(sheet, cell string, tokens []efp.Token, idx int) ([]cellRange, int, bool) {
+	name := strings.ToUpper(strings.TrimPrefix(strings.ToLower(tokens[idx].TValue), "_xlfn."))
+	if name != "INDIRECT" && name != "OFFSET" {
+		return nil, idx, false
+	}
+	args, stop := splitFuncArgs(tokens, idx)
+	var values []formulaArg
+	for i, arg := range args {
+		if name == "OFFSET" && i == 0 {
+			continue
+		}
+		value, ok := f.evalStaticArg(sheet, cell, arg)
+		if !ok {
+			return nil, idx, false
+		}
+		values = append(values, value)
+	}
+	if name == "INDIRECT" {
+		if len(values) != 1 && len(values) != 2 {
+			return nil, idx, false
+		}
+		reference := values[0].Value()
+		if len(values) == 2 {
+			if a1 := values[1].ToBool(); a1.Type != ArgNumber || a1.Number == 0 {
+				var refs []string
+				for _, ref := range strings.Split(reference, ":") {
+					cell, err := r1c1ToA1(ref)
+					if err != nil || cell == "" {
+						return nil, idx, false
+					}
+					refs = append(refs, cell)
+				}
+				reference = strings.Join(refs, ":")
+			}
+		}
+		ranges := f.getReferenceRanges(sheet, reference)
+		return ranges, stop, len(ranges) > 0
+	}
+	if len(args) < 3 || len(args) > 5 || len(args[0]) != 1 || args[0][0].TSubType != efp.TokenSubTypeRange {
+		return nil, idx, false
+	}
+	base := f.getReferenceRanges(sheet, args[0][0].TValue)
+	if len(base) != 1 {
+		return nil, idx, false
+	}
+	cr := base[0]
+	offsets := []int{0, 0, cr.To.Row - cr.From.Row + 1, cr.To.Col - cr.From.Col + 1}
+	for i, value := range values {
+		if value.Type == ArgEmpty {
+			continue
+		}
+		num := value.ToNumber()
+		if num.Type != ArgNumber {
+			return nil, idx, false
+		}
+		offsets[i] = int(num.Number)
+	}
+	cr.From.Row, cr.From.Col = cr.From.Row+offsets[0], cr.From.Col+offsets[1]
+	cr.To.Row, cr.To.Col = cr.From.Row+offsets[2]-1, cr.From.Col+offsets[3]-1
+	if offsets[2] < 1 || offsets[3] < 1 || cr.From.Row < 1 || cr.From.Col < 1 || cr.To.Row > TotalRows || cr.To.Col > MaxColumns {
+		return nil, idx, false
+	}
+	return []cellRange{cr}, stop, true
+}
+
+// evalStaticArg evaluate the function argument tokens which doesn't contain
+// any references, and returns whether the argument has been evaluated.
+func (f *File) evalStaticArg(sheet, cell string, tokens []efp.Token) (formulaArg, bool) {
+	if len(tokens) == 0 {
+		return newEmptyFormulaArg(), true
+	}
+	for _, token := range tokens {
+		if token.TSubType == efp.TokenSubTypeRange {
+			return newEmptyFormulaArg(), false
+		}
+	}
+	arg, err := f.evalInfixExp(&calcContext{
+		entry:           sheet + "!" + cell,
+		iterations:      make(map[string]uint),
+		iterationsCache: make(map[string]formulaArg),
+	}, sheet, cell, tokens)
+	return arg, err == nil && arg.Type != ArgError
+}
+
+// parseCellRange provides a function to convert the given reference to the
+// cell range, the default sheet name will be used if the reference does not
+// contain a worksheet name.
+func parseCellRange(sheet, reference string) (cellRange, error) {
+	var cr cellRange
+	for i, ref := range strings.Split(reference, ":") {
+		cellRef, col, row, err := parseRef(ref)
+		if err != nil {
+			return cr, err
+		}
+		if i == 0 {
+			if cellRef.Sheet == "" {
+				cellRef.Sheet = sheet
+			}
+			from, to := cellRef, cellRef
+			if col {
+				from.Row, to.Row = 1, TotalRows
+			}
+			if row {
+				from.Col, to.Col = 1, MaxColumns
+			}
+			cr.From, cr.To = from, to
+			continue
+		}
+		if err = cr.prepareCellRange(col, row, cellRef); err != nil {
+			return cr, err
+		}
+	}
+	return cr, nil
+}
+
+// GetCellPrecedents provides a function to get the formula dependency graph
+// of the cells which the given cell depends on directly or indirectly. The
+// ranges, cross worksheet references, defined names and structured references
+// in the formulas will be resolved, and the references built by the INDIRECT
+// and OFFSET functions will be resolved when their arguments are constants.
+// The referenced cell ranges will be placed in the graph as the nodes between
+// the formula cells inside the ranges and the cells which reference them. For
+// example, print the precedents of the cell A1 on Sheet1:
+//
+//	graph, err := f.GetCellPrecedents("Sheet1", "A1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, edge := range graph.Edges {
+//	    from, to := graph.Nodes[edge.From], graph.Nodes[edge.To]
+//	    fmt.Printf("%s!%s -> %s!%s\n", from.Sheet, from.Ref, to.Sheet, to.Ref)
+//	}
+func (f *File) GetCellPrecedents(sheet, cell string) (*DependencyGraph, error) {
+	graph, calc, err := f.newDependencyGraph(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	for queue := []int{0}; len(queue) > 0; queue = queue[1:] {
+		idx, cr := queue[0], graph.ranges[queue[0]]
+		single := cr.From.Col == cr.To.Col && cr.From.Row == cr.To.Row
+		if n, ok := calc.cells[graph.key(cr)]; ok && single {
+			for _, rng := range calc.nodes[n].ranges {
+				from, ok := graph.addNode(calc, rng)
+				if graph.addEdge(from, idx); ok {
+					queue = append(queue, from)
+				}
+			}
+		}
+		precedents := calc.lookup(cr)
+		sort.Ints(precedents)
+		for _, n := range precedents {
+			node := calc.nodes[n]
+			if single && node.col == cr.From.Col && node.row == cr.From.Row {
+				continue
+			}
+			from, ok := graph.addNode(calc, calcNodeRange(node))
+			if graph.addEdge(from, idx); ok {
+				queue = append(queue, from)
+			}
+		}
+	}
+	return graph, err
+}
+
+// GetCellDependents provides a function to get the formula dependency graph
+// of the formula cells which depend on the given cell directly or indirectly.
+// The references in the formulas will be resolved in the same way as the
+// GetCellPrecedents function, and the referenced cell ranges will be placed
+// in the graph as the nodes between the cells inside the ranges and the
+// formula cells which reference them. For example, print the formula cells
+// which depend on the cell A1 on Sheet1:
+//
+//	graph, err := f.GetCellDependents("Sheet1", "A1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, node := range graph.Nodes[1:] {
+//	    if node.Formula != "" {
+//	        fmt.Printf("%s!%s: %s\n", node.Sheet, node.Ref, node.Formula)
+//	    }
+//	}
+func (f *File) GetCellDependents(sheet, cell string) (*DependencyGraph, error) {
+	graph, calc, err := f.newDependencyGraph(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	for queue := []int{0}; len(queue) > 0; queue = queue[1:] {
+		idx, area := queue[0], graph.ranges[queue[0]]
+		if n, ok := calc.cells[graph.key(area)]; ok {
+			area.To.Col, area.To.Row = calc.nodes[n].area[2], calc.nodes[n].area[3]
+		}
+		for _, ref := range calc.lookupRefs(area) {
+			cr := calc.refs[ref].cr
+			to, ok := graph.addNode(calc, calcNodeRange(calc.nodes[calc.refs[ref].node]))
+			if ok {
+				queue = append(queue, to)
+			}
+			if rng, _ := graph.addNode(calc, cr); rng != idx {
+				graph.addEdge(idx, rng)
+				graph.addEdge(rng, to)
+				continue
+			}
+			graph.addEdge(idx, to)
+		}
+	}
+	return graph, err
+}
+
+// newDependencyGraph provides a function to create the formula dependency
+// graph with the given cell as the first node, and returns the graph and the
+// dependency graph of all formula cells in the workbook.
+func (f *File) newDependencyGraph(sheet, cell string) (*DependencyGraph, *calcGraph, error) {
+	if _, err := f.workSheetReader(sheet); err != nil {
+		return nil, nil, err
+	}
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, nil, err
+	}
+	calc, err := f.prepareCalcGraph()
+	if err != nil {
+		return nil, nil, err
+	}
+	graph := &DependencyGraph{index: make(map[string]int), sheets: make(map[string]string), edges: make(map[DependencyEdge]bool)}
+	for _, name := range f.GetSheetList() {
+		graph.sheets[strings.ToLower(name)] = name
+	}
+	graph.addNode(calc, cellRange{From: cellRef{Col: col, Row: row, Sheet: sheet}, To: cellRef{Col: col, Row: row, Sheet: sheet}})
+	return graph, calc, err
+}
+
+// calcNodeRange returns the cell range of the formula cell by given node of
+// the dependency graph.
+func calcNodeRange(node *calcNode) cellRange {
+	cr := cellRef{Col: node.col, Row: node.row, Sheet: node.sheet}
+	return cellRange{From: cr, To: cr}
+}
+
+// key returns the key of the cell or cell range in the formula dependency
+// graph by given cell range.
+func (g *DependencyGraph) key(cr cellRange) string {
+	ref, _ := CoordinatesToCellName(cr.From.Col, cr.From.Row)
+	if cr.From.Col != cr.To.Col || cr.From.Row != cr.To.Row {
+		to, _ := CoordinatesToCellName(cr.To.Col, cr.To.Row)
+		ref += ":" + to
+	}
+	return strings.ToLower(cr.From.Sheet) + "!" + ref
+}
+
+// addNode provides a function to add the cell or cell range as a node in the
+// formula dependency graph if it doesn't exist, and returns the index of the
+// node and whether the node has been added.
+func (g *DependencyGraph) addNode(calc *calcGraph, cr cellRange) (int, bool) {
+	key := g.key(cr)
+	if idx, ok := g.index[key]; ok {
+		return idx, false
+	}
+	node := DependencyNode{Sheet: cr.From.Sheet, Ref: key[strings.LastIndex(key, "!")+1:]}
+	if name, ok := g.sheets[strings.ToLower(node.Sheet)]; ok {
+		node.Sheet = name
+	}
+	if idx, ok := calc.cells[key]; ok {
+		node.Formula = calc.nodes[idx].formula
+	}
+	g.index[key] = len(g.Nodes)
+	g.ranges = append(g.ranges, cr)
+	g.Nodes = append(g.Nodes, node)
+	return len(g.Nodes) - 1, true
+}
+
+// addEdge provides a function to add the edge from the precedent node to the
+// dependent node in the formula dependency graph if it doesn't exist.
+func (g *DependencyGraph) addEdge(from, to int) {
+	edge := DependencyEdge{From: from, To: to}
+	if !g.edges[edge] {
+		g.edges[edge] = true
+		g.Edges = append(g.Edges, edge)
+	}
+}
@@ -1,9 +1,12 @@
 package excelize
 
 import (
+	"path/filepath"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/xuri/efp"
 )
 
 func TestCalcChainReader(t *testing.T) {
@@ -44,3 +47,367 @@ func TestDeleteCalcChain(t *testing.T) {
 	f.Pkg.Store(defaultXMLPathContentTypes, MacintoshCyrillicCharset)
 	assert.EqualError(t, f.deleteCalcChain(1, "A1"), "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestRecalculateAll(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	for cell, value := range map[string]interface{}{"A1": 1, "A2": 2, "A3": 3, "B1": "x"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	for cell, formula := range map[string]string{
+		"C1": "C2*2",
+		"C2": "SUM(A1:A3)",
+		"C3": "Sheet2!A1&B1",
+		"C4": "1/0",
+		"C5": "A1>0",
+		"D1": "SUM(Sheet1:Sheet2!A1)",
+		"D2": "SUM(Total)",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Total", RefersTo: "Sheet1!$C$1:$C$2"}))
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "Sheet1!C1+1"))
+	assert.NoError(t, f.RecalculateAll())
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	for cell, expected := range map[string][]string{
+		"C1": {"", "12"}, "C2": {"", "6"}, "C3": {"str", "13x"}, "C4": {"e", "#DIV/0!"},
+		"C5": {"b", "1"}, "D1": {"", "14"}, "D2": {"", "18"},
+	} {
+		col, row, err := CellNameToCoordinates(cell)
+		assert.NoError(t, err)
+		c := ws.SheetData.Row[row-1].C[col-1]
+		assert.Equal(t, expected, []string{c.T, c.V}, cell)
+	}
+	value, err := f.GetCellValue("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "13", value)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRecalculateAll.xlsx")))
+
+	// Test recalculate with dynamic array formula
+	formulaType, ref := STCellFormulaTypeArray, "E1"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "_xlfn.UNIQUE(A1:A3)", FormulaOpts{Type: &formulaType, Ref: &ref, DynamicArray: true}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F1", "SUM(E1:E3)"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A3", 4))
+	assert.NoError(t, f.RecalculateSheet("Sheet1"))
+	for cell, expected := range map[string]string{"E3": "4", "F1": "7", "C2": "7"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	// Test recalculate the worksheet without updating other worksheets
+	value, err = f.GetCellValue("Sheet2", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "13", value)
+
+	// Test recalculate with circular references
+	assert.NoError(t, f.SetCellFormula("Sheet1", "G1", "G2+1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "G2", "G1+1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "G3", "G3"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 5))
+	err = f.RecalculateAll()
+	assert.Equal(t, ErrCircularReference{Cells: []string{"Sheet1!G1", "Sheet1!G2", "Sheet1!G3"}}, err)
+	assert.EqualError(t, err, "circular reference detected in cells Sheet1!G1, Sheet1!G2, Sheet1!G3")
+	value, err = f.GetCellValue("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, "11", value)
+
+	// Test recalculate with calculation chain and array formula
+	f.CalcChain = &xlsxCalcChain{C: []xlsxCalcChainC{{R: "C2", I: 1}, {R: "C1"}, {R: "A1", I: 2}}}
+	formulaType, ref = STCellFormulaTypeArray, "H1:H2"
+	assert.NoError(t, f.SetCellFormula("Sheet1", "H1", "A1:A2*2", FormulaOpts{Type: &formulaType, Ref: &ref}))
+	assert.Error(t, f.RecalculateAll())
+	for cell, expected := range map[string]string{"H1": "10", "H2": "4"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	// Test recalculate on not exists worksheet
+	assert.EqualError(t, f.RecalculateSheet("SheetN"), "sheet SheetN does not exist")
+	// Test recalculate with unsupported charset calculation chain
+	f.CalcChain = nil
+	f.Pkg.Store(defaultXMLPathCalcChain, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RecalculateAll(), "XML syntax error on line 1: invalid UTF-8")
+	// Test recalculate with unsupported charset workbook metadata
+	f = NewFile()
+	f.Pkg.Store(defaultXMLMetadata, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.RecalculateAll(), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestGetFormulaRanges(t *testing.T) {
+	f := NewFile()
+	assert.Equal(t, []cellRange{
+		{From: cellRef{Col: 1, Row: 1, Sheet: "Sheet1"}, To: cellRef{Col: 1, Row: TotalRows, Sheet: "Sheet1"}},
+		{From: cellRef{Col: 1, Row: 2, Sheet: "Sheet1"}, To: cellRef{Col: MaxColumns, Row: 3, Sheet: "Sheet1"}},
+	}, f.getFormulaRanges("Sheet1", "A1", "SUM(A:A,2:3,Sheet2:Sheet3!A1,A1:Sheet2!B1)"))
+	// Test get formula ranges with the names bound by the LET and LAMBDA functions
+	for formula, expected := range map[string][]cellRange{
+		"_xlfn.LET(_xlpm.x,2,_xlpm.x*3)":       nil,
+		"_xlfn.MAP({1,2},_xlfn.LAMBDA(a,a*2))": nil,
+		"X1+_xlfn.LET(x,B1,x)":                 {{From: cellRef{Col: 24, Row: 1, Sheet: "Sheet1"}, To: cellRef{Col: 24, Row: 1, Sheet: "Sheet1"}}, {From: cellRef{Col: 2, Row: 1, Sheet: "Sheet1"}, To: cellRef{Col: 2, Row: 1, Sheet: "Sheet1"}}},
+		"_xlfn.LAMBDA(a,a+$A$1)(A:A)":          {{From: cellRef{Col: 1, Row: 1, Sheet: "Sheet1"}, To: cellRef{Col: 1, Row: 1, Sheet: "Sheet1"}}, {From: cellRef{Col: 1, Row: 1, Sheet: "Sheet1"}, To: cellRef{Col: 1, Row: TotalRows, Sheet: "Sheet1"}}},
+	} {
+		assert.Equal(t, expected, f.getFormulaRanges("Sheet1", "C1", formula), formula)
+	}
+}
+
+func TestRecalculateLambda(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "X2", 100))
+	for cell, formula := range map[string]string{
+		"X1": "_xlfn.LET(x,2,x*3)",
+		"A2": "SUM(_xlfn.MAP({1,2},_xlfn.LAMBDA(a,a*2)))",
+		"B2": "_xlfn.LET(x,X1,x+X2)",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	assert.NoError(t, f.RecalculateAll())
+	for cell, expected := range map[string]string{"X1": "6", "A2": "6", "B2": "106"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestRecalculateIterative(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", 1000))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B1", "0.1*(A1+C1)/2"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "A1+B1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "D1", "ROUND(C1,2)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "E1+1"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "F1", "IF(F1=\"\",\"x\",F1)"))
+	// Test recalculate with circular references without iterative calculation
+	assert.EqualError(t, f.RecalculateAll(), "circular reference detected in cells Sheet1!B1, Sheet1!C1, Sheet1!E1, Sheet1!F1")
+
+	iterate, iterateCount := true, uint(10)
+	assert.NoError(t, f.SetCalcProps(&CalcPropsOptions{Iterate: &iterate, IterateCount: &iterateCount}))
+	err := f.RecalculateAll()
+	assert.Equal(t, ErrCalcNotConverged{Cells: []string{"Sheet1!E1"}}, err)
+	assert.EqualError(t, err, "iterative calculation did not converge in cells Sheet1!E1")
+	// Test iterative calculation start with the cached values of the cells
+	for cell, expected := range map[string]string{"C1": "1105.26", "D1": "1105.26", "E1": "20", "F1": "x"} {
+		result, err := f.CalcCellValue("Sheet1", cell, Options{RawCellValue: true})
+		if cell == "E1" {
+			assert.Equal(t, ErrCalcNotConverged{Cells: []string{"Sheet1!E1"}}, err)
+		} else {
+			assert.NoError(t, err, cell)
+		}
+		if cell == "C1" {
+			result = result[:7]
+		}
+		assert.Equal(t, expected, result, cell)
+	}
+	// Test the dependency graph will be reused until the calculation cache
+	// is cleared
+	graph := f.calcGraph.Load()
+	assert.NotNil(t, graph)
+	_, err = f.CalcCellValue("Sheet1", "D1")
+	assert.NoError(t, err)
+	assert.Equal(t, graph, f.calcGraph.Load())
+	assert.NoError(t, f.SetCellFormula("Sheet1", "G1", "D1"))
+	assert.Nil(t, f.calcGraph.Load())
+	value, err := f.GetCellValue("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, "10", value)
+
+	assert.EqualError(t, f.RecalculateSheet("Sheet1"), "iterative calculation did not converge in cells Sheet1!E1")
+	value, err = f.GetCellValue("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Equal(t, "20", value)
+
+	// Test iterative calculation with formula error
+	assert.NoError(t, f.SetCellFormula("Sheet1", "E1", "E1/0"))
+	result, err := f.CalcCellValue("Sheet1", "E1")
+	assert.EqualError(t, err, "#DIV/0!")
+	assert.Equal(t, "#DIV/0!", result)
+	// Test iterative calculation on the cell without formula
+	result, err = f.CalcCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1000", result)
+
+	// Test iterative calculation with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.CalcCellValue("Sheet1", "B1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	f.WorkBook = nil
+	assert.EqualError(t, f.RecalculateAll(), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestIsIterateConverged(t *testing.T) {
+	assert.True(t, isIterateConverged(newEmptyFormulaArg(), newNumberFormulaArg(0.0001), 0.001))
+	assert.False(t, isIterateConverged(newNumberFormulaArg(1), newEmptyFormulaArg(), 0.001))
+	assert.True(t, isIterateConverged(newStringFormulaArg("a"), newStringFormulaArg("a"), 0.001))
+	assert.False(t, isIterateConverged(newStringFormulaArg("1"), newNumberFormulaArg(1), 0.001))
+}
+
+func TestGetCellPrecedents(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	for cell, value := range map[string]interface{}{"A1": 1, "A2": 2, "D1": "A2"} {
+		assert.NoError(t, f.SetCellValue("Sheet1", cell, value))
+	}
+	for cell, formula := range map[string]string{
+		"A3": "SUM(A1:A2)",
+		"B1": "A3*2",
+		"B2": "sheet2!A1+Total",
+		"C1": "INDIRECT(\"A\"&1)+OFFSET(A1,1,0)",
+		"C2": "INDIRECT(D1)",
+		"E1": "E2",
+		"E2": "E1",
+		"F1": "_xlfn.MAP({1,2},_xlfn.LAMBDA(a,a*2))",
+		"F2": "_xlfn.LET(x,5,x+1)",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "Sheet1!A1+1"))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Total", RefersTo: "Sheet1!$B$1"}))
+
+	graph, err := f.GetCellPrecedents("Sheet1", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, []DependencyNode{
+		{Sheet: "Sheet1", Ref: "B2", Formula: "sheet2!A1+Total"},
+		{Sheet: "Sheet2", Ref: "A1", Formula: "Sheet1!A1+1"},
+		{Sheet: "Sheet1", Ref: "B1", Formula: "A3*2"},
+		{Sheet: "Sheet1", Ref: "A1"},
+		{Sheet: "Sheet1", Ref: "A3", Formula: "SUM(A1:A2)"},
+		{Sheet: "Sheet1", Ref: "A1:A2"},
+	}, graph.Nodes)
+	assert.Equal(t, []DependencyEdge{{From: 1, To: 0}, {From: 2, To: 0}, {From: 3, To: 1}, {From: 4, To: 2}, {From: 5, To: 4}}, graph.Edges)
+
+	graph, err = f.GetCellPrecedents("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, []DependencyNode{
+		{Sheet: "Sheet1", Ref: "C1", Formula: "INDIRECT(\"A\"&1)+OFFSET(A1,1,0)"},
+		{Sheet: "Sheet1", Ref: "A1"},
+		{Sheet: "Sheet1", Ref: "A2"},
+	}, graph.Nodes)
+
+	graph, err = f.GetCellPrecedents("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, []DependencyNode{{Sheet: "Sheet1", Ref: "C2", Formula: "INDIRECT(D1)"}, {Sheet: "Sheet1", Ref: "D1"}}, graph.Nodes)
+
+	// Test get precedents of the circular references
+	graph, err = f.GetCellPrecedents("Sheet1", "E1")
+	assert.NoError(t, err)
+	assert.Len(t, graph.Nodes, 2)
+	assert.Equal(t, []DependencyEdge{{From: 1, To: 0}, {From: 0, To: 1}}, graph.Edges)
+
+	// Test get precedents of the formulas with the names bound by the LET and
+	// LAMBDA functions
+	for cell, formula := range map[string]string{"F1": "_xlfn.MAP({1,2},_xlfn.LAMBDA(a,a*2))", "F2": "_xlfn.LET(x,5,x+1)"} {
+		graph, err = f.GetCellPrecedents("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, []DependencyNode{{Sheet: "Sheet1", Ref: cell, Formula: formula}}, graph.Nodes)
+		assert.Empty(t, graph.Edges)
+	}
+
+	// Test get precedents and dependents with invalid parameters
+	_, err = f.GetCellPrecedents("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	_, err = f.GetCellDependents("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	f.Pkg.Store(defaultXMLMetadata, MacintoshCyrillicCharset)
+	f.clearCalcCache()
+	_, err = f.GetCellPrecedents("Sheet1", "A1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestGetCellDependents(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	for cell, formula := range map[string]string{
+		"A3": "SUM(A1:A2)",
+		"B1": "A3*2",
+		"B2": "Sheet2!A1+Total",
+		"C1": "INDIRECT(\"A1\")+OFFSET(A1,1,0)",
+		"D1": "_xlfn.MAP({1,2},_xlfn.LAMBDA(a,a*2))",
+		"D2": "_xlfn.LET(a,5,a+1)",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet2", "A1", "Sheet1!A1+1"))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Total", RefersTo: "Sheet1!$B$1"}))
+	graph, err := f.GetCellDependents("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, []DependencyNode{
+		{Sheet: "Sheet1", Ref: "A1"},
+		{Sheet: "Sheet1", Ref: "C1", Formula: "INDIRECT(\"A1\")+OFFSET(A1,1,0)"},
+		{Sheet: "Sheet1", Ref: "A3", Formula: "SUM(A1:A2)"},
+		{Sheet: "Sheet1", Ref: "A1:A2"},
+		{Sheet: "Sheet2", Ref: "A1", Formula: "Sheet1!A1+1"},
+		{Sheet: "Sheet1", Ref: "B1", Formula: "A3*2"},
+		{Sheet: "Sheet1", Ref: "B2", Formula: "Sheet2!A1+Total"},
+	}, graph.Nodes)
+	assert.Equal(t, []DependencyEdge{
+		{From: 0, To: 1}, {From: 0, To: 3}, {From: 3, To: 2}, {From: 0, To: 4},
+		{From: 2, To: 5}, {From: 4, To: 6}, {From: 5, To: 6},
+	}, graph.Edges)
+	_, err = f.GetCellDependents("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+
+	// Test get cell dependents with the reverse index of the formula ranges
+	f = NewFile()
+	for r := 1; r <= 20000; r++ {
+		assert.NoError(t, f.SetCellFormula("Sheet1", "B"+strconv.Itoa(r), "$A$1*"+strconv.Itoa(r)))
+	}
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "SUM(1:1)"))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C2", "SUM(sheet1!A1:Z2)"))
+	graph, err = f.GetCellDependents("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Len(t, graph.Nodes, 20005)
+	assert.Equal(t, DependencyNode{Sheet: "Sheet1", Ref: "B1", Formula: "$A$1*1"}, graph.Nodes[1])
+	calc := f.calcGraph.Load()
+	assert.Len(t, calc.refRows["sheet1"], 1)
+	lookupRefCells := func(area cellRange) []string {
+		var cells []string
+		for _, ref := range calc.lookupRefs(area) {
+			cells = append(cells, calc.nodes[calc.refs[ref].node].cell)
+		}
+		return cells
+	}
+	for _, area := range []cellRange{
+		{From: cellRef{Col: 4, Row: 1, Sheet: "SHEET1"}, To: cellRef{Col: 4, Row: 1, Sheet: "SHEET1"}},
+		{From: cellRef{Col: 4, Row: 1, Sheet: "Sheet1"}, To: cellRef{Col: MaxColumns, Row: 1, Sheet: "Sheet1"}},
+	} {
+		assert.Equal(t, []string{"C1", "C2"}, lookupRefCells(area))
+	}
+	assert.Equal(t, []string{"C2"}, lookupRefCells(cellRange{
+		From: cellRef{Col: 26, Row: 2, Sheet: "Sheet1"}, To: cellRef{Col: 26, Row: 2, Sheet: "Sheet1"},
+	}))
+	assert.Empty(t, lookupRefCells(cellRange{
+		From: cellRef{Col: 1, Row: 1, Sheet: "Sheet2"}, To: cellRef{Col: 1, Row: 1, Sheet: "Sheet2"},
+	}))
+	assert.NoError(t, f.Close())
+}
+
+func TestGetStaticFuncRanges(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Area", RefersTo: "Sheet1!$A$1:$A$2,Sheet1!$B$1"}))
+	for formula, expected := range map[string][]cellRange{
+		"INDIRECT(\"R2C1:R3C2\",FALSE)": {{From: cellRef{Col: 1, Row: 2, Sheet: "Sheet1"}, To: cellRef{Col: 2, Row: 3, Sheet: "Sheet1"}}},
+		"OFFSET(B2,-1,-1,,2)":           {{From: cellRef{Col: 1, Row: 1, Sheet: "Sheet1"}, To: cellRef{Col: 2, Row: 1, Sheet: "Sheet1"}}},
+		"OFFSET(Sheet2!A1,1,1,2,2)":     {{From: cellRef{Col: 2, Row: 2, Sheet: "Sheet2"}, To: cellRef{Col: 3, Row: 3, Sheet: "Sheet2"}}},
+		"INDIRECT(\"RC\",FALSE)":        nil,
+		"INDIRECT(\"A1\",\"x\")":        nil,
+		"INDIRECT()":                    nil,
+		"INDIRECT(\"A1\",1/0)":          nil,
+		"OFFSET(A1,1)":                  nil,
+		"OFFSET(Area,1,1)":              nil,
+		"OFFSET(A1,\"x\",1)":            nil,
+		"OFFSET(A1,-1,0)":               nil,
+		"OFFSET(A1,0,0,0)":              nil,
+	} {
+		ps := efp.ExcelParser()
+		ranges, _, ok := f.getStaticFuncRanges("Sheet1", "C1", ps.Parse(formula), 0)
+		assert.Equal(t, expected != nil, ok, formula)
+		assert.Equal(t, expected, ranges, formula)
+	}
+}
@@ -12,6 +12,7 @@
 package excelize
 
 import (
+	"bufio"
 	"encoding/xml"
 	"fmt"
 	"math"
@@ -460,7 +461,9 @@ func (f *File) setCellString(value string) (t, v string, err error) {
 }
 
 // sharedStringsLoader load shared string table from system temporary file to
-// memory, and reset shared string table for reader.
+// memory, and reset shared string table for reader. The string items which
+// spilled into the system temporary file by the stream writer will be added
+// into the shared string table in memory.
 func (f *File) sharedStringsLoader() (err error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -473,6 +476,9 @@ func (f *File) sharedStringsLoader() (err error) {
 		f.SharedStrings = nil
 	}
 	if f.sharedStringTemp != nil {
+		if err = f.loadSharedStringItems(); err != nil {
+			return
+		}
 		if err := f.sharedStringTemp.Close(); err != nil {
 			return err
 		}
@@ -483,6 +489,87 @@ func (f *File) sharedStringsLoader() (err error) {
 	return
 }
 
+// loadSharedStringItems provides a function to add the string items which
+// spilled into the system temporary file by the stream writer, and not exist
+// in memory into the shared string table.
+func (f *File) loadSharedStringItems() error {
+	sst := f.SharedStrings
+	if sst == nil || len(f.sharedStringItem) <= len(sst.SI) {
+		return nil
+	}
+	sst.mu.Lock()
+	defer sst.mu.Unlock()
+	for i := len(sst.SI); i < len(f.sharedStringItem); i++ {
+		offsetRange := f.sharedStringItem[i]
+		buf := make([]byte, offsetRange[1]-offsetRange[0])
+		if _, err := f.sharedStringTemp.ReadAt(buf, int64(offsetRange[0])); err != nil {
+			return err
+		}
+		t := xlsxT{Val: string(buf)}
+		_, t.Space = trimCellValue(t.Val, false)
+		sst.SI = append(sst.SI, xlsxSI{T: &t})
+		if _, ok := f.sharedStringsMap[t.Val]; !ok {
+			f.sharedStringsMap[t.Val] = i
+		}
+	}
+	sst.Count = len(sst.SI)
+	sst.UniqueCount = sst.Count
+	return nil
+}
+
+// spillSharedStrings provides a function to create the system temporary file
+// for the string items of the shared string table, and write the string items
+// in memory into it, so the new string items could be added into the system
+// temporary file instead of memory.
+func (f *File) spillSharedStrings() error {
+	tempFile, err := os.CreateTemp(f.options.TmpDir, "excelize-")
+	if err != nil {
+		return err
+	}
+	f.sharedStringTemp, f.sharedStringItem = tempFile, [][]uint{}
+	f.tempFiles.Store(defaultTempFileSST, tempFile.Name())
+	var offset uint
+	buf := bufio.NewWriter(tempFile)
+	for _, si := range f.SharedStrings.SI {
+		n, _ := buf.WriteString(si.String())
+		f.sharedStringItem = append(f.sharedStringItem, []uint{offset, offset + uint(n)})
+		offset += uint(n)
+	}
+	return buf.Flush()
+}
+
+// setSharedStringItem provides a function to add string to the shared string
+// table for the stream writer. The new string item will be spilled into the
+// system temporary file instead of memory, and it will be indexed for the
+// deduplication only if the given index argument is true. This function
+// returns the index of the string item and if the new string item has been
+// indexed.
+func (f *File) setSharedStringItem(val string, index bool) (int, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if i, ok := f.sharedStringsMap[val]; ok {
+		return i, false, nil
+	}
+	if f.sharedStringTemp == nil {
+		if err := f.spillSharedStrings(); err != nil {
+			return 0, false, err
+		}
+	}
+	var offset uint
+	if count := len(f.sharedStringItem); count > 0 {
+		offset = f.sharedStringItem[count-1][1]
+	}
+	n, err := f.sharedStringTemp.WriteString(val)
+	if err != nil {
+		return 0, false, err
+	}
+	f.sharedStringItem = append(f.sharedStringItem, []uint{offset, offset + uint(n)})
+	if index {
+		f.sharedStringsMap[val] = len(f.sharedStringItem) - 1
+	}
+	return len(f.sharedStringItem) - 1, index, nil
+}
+
 // setSharedString provides a function to add string to the share string table.
 func (f *File) setSharedString(val string) (int, error) {
 	if err := f.sharedStringsLoader(); err != nil {
@@ -618,7 +705,7 @@ func (c *xlsxC) getValueFrom(f *File, d *xlsxSST, raw bool) (string, error) {
 	case "s":
 		if c.V != "" {
 			xlsxSI, _ := strconv.Atoi(strings.TrimSpace(c.V))
-			if _, ok := f.tempFiles.Load(defaultXMLPathSharedStrings); ok {
+			if _, ok := f.tempFiles.Load(defaultXMLPathSharedStrings); ok || f.sharedStringTemp != nil {
 				val, err := f.getFromStringItem(xlsxSI)
 				if err != nil {
 					return "", err
@@ -705,8 +792,9 @@ func (f *File) getCellFormula(sheet, cell string, transformed bool) (string, err
 
 // FormulaOpts can be passed to SetCellFormula to use other formula types.
 type FormulaOpts struct {
-	Type *string // Formula type
-	Ref  *string // Shared formula ref
+	Type         *string // Formula type
+	Ref          *string // Shared formula ref
+	DynamicArray bool    // Dynamic array formula
 }
 
 // SetCellFormula provides a function to set formula on the cell is taken
@@ -751,7 +839,16 @@ type FormulaOpts struct {
 //	err := f.SetCellFormula("Sheet1", "C1", "A1+B1",
 //	    excelize.FormulaOpts{Ref: &ref, Type: &formulaType})
 //
-// Example 7, set table formula "SUM(Table1[[A]:[B]])" for the cell "C2"
+// Example 7, set dynamic array formula "UNIQUE(A1:A10)" for the cell "B1" on
+// "Sheet1", the formula will be calculated and the result will spill into
+// the neighbouring cells, the spill range will be stored as the formula
+// reference, and the "#SPILL!" error will be stored in the cell if the spill
+// range is occupied:
+//
+//	err := f.SetCellFormula("Sheet1", "B1", "_xlfn.UNIQUE(A1:A10)",
+//	    excelize.FormulaOpts{DynamicArray: true})
+//
+// Example 8, set table formula "SUM(Table1[[A]:[B]])" for the cell "C2"
 // on "Sheet1":
 //
 //	package main
@@ -801,6 +898,10 @@ func (f *File) SetCellFormula(sheet, cell, formula string, opts ...FormulaOpts)
 		return err
 	}
 	f.clearCalcCache()
+	if c.Cm != nil && c.F != nil && c.F.T == STCellFormulaTypeArray {
+		ws.clearSpilledCells(c.F.Ref)
+		c.Cm = nil
+	}
 	if formula == "" {
 		ws.deleteSharedFormula(c)
 		c.F = nil
@@ -811,6 +912,11 @@ func (f *File) SetCellFormula(sheet, cell, formula string, opts ...FormulaOpts)
 		return err
 	}
 	ws.deleteSharedFormula(c)
+	for _, opt := range opts {
+		if opt.DynamicArray {
+			return f.setDynamicArrayFormula(ws, sheet, c, formula)
+		}
+	}
 	c.F = &xlsxF{Content: formula}
 
 	for _, opt := range opts {
@@ -837,6 +943,208 @@ func (f *File) SetCellFormula(sheet, cell, formula string, opts ...FormulaOpts)
 	return err
 }
 
+// setDynamicArrayFormula provides a function to set the dynamic array formula
+// for the cell. The formula will be calculated to get the spill range, the
+// calculated values will be stored in the spilled cells, and the values of
+// the cells in the previous spill range will be cleared.
+func (f *File) setDynamicArrayFormula(ws *xlsxWorksheet, sheet string, c *xlsxC, formula string) error {
+	col, row, err := CellNameToCoordinates(c.R)
+	if err != nil {
+		return err
+	}
+	cm, err := f.setDynamicArrayMetadata()
+	if err != nil {
+		return err
+	}
+	prevRef := ws.getSpillRef(col, row)
+	c.F, c.Cm, c.f = &xlsxF{Content: formula, T: STCellFormulaTypeArray, Ref: c.R}, &cm, ""
+	f.updateDynamicArray(ws, sheet, col, row, prevRef, f.options)
+	f.clearCalcCache()
+	return nil
+}
+
+// updateDynamicArray provides a function to calculate the dynamic array
+// formula in the given anchor cell coordinates, and store the calculated
+// values in the spilled cells. The values of the cells in the previous spill
+// range will be cleared. The value of the anchor cell will be returned.
+func (f *File) updateDynamicArray(ws *xlsxWorksheet, sheet string, col, row int, prevRef string, options *Options) formulaArg {
+	cell, _ := CoordinatesToCellName(col, row)
+	// The calculation error is ignored, because it has been converted to the
+	// error value of the formula result, and will be stored as the cached
+	// value of the anchor cell, which is consistent with the spreadsheet
+	// application that allows setting the formula evaluated as an error.
+	mtx, _ := f.calcDynamicArray(sheet, cell, options)
+	if ws.isSpillBlocked(col, row, len(mtx[0]), len(mtx), prevRef) {
+		mtx = [][]formulaArg{{newErrorFormulaArg(formulaErrorSPILL, formulaErrorSPILL)}}
+	}
+	ws.clearSpilledCells(prevRef)
+	for r, values := range mtx {
+		for x, value := range values {
+			ws.prepareSheetXML(col+x, row+r)
+			c := &ws.SheetData.Row[row+r-1].C[col+x-1]
+			if value.Type == ArgString {
+				// Store the string result of the spilled cell as the cached
+				// formula string, same as the anchor cell
+				c.setStr(value.String)
+				continue
+			}
+			c.setCachedValue(value)
+		}
+	}
+	anchor := &ws.SheetData.Row[row-1].C[col-1]
+	if anchor.F.Ref = cell; len(mtx) > 1 || len(mtx[0]) > 1 {
+		ref, _ := CoordinatesToCellName(col+len(mtx[0])-1, row+len(mtx)-1)
+		anchor.F.Ref = cell + ":" + ref
+	}
+	return mtx[0][0]
+}
+
+// clearSpilledCells provides a function to clear the values of the cells
+// without formula in the given spill range of the dynamic array formula.
+func (ws *xlsxWorksheet) clearSpilledCells(ref string) {
+	coordinates, err := rangeRefToCoordinates(ref)
+	if err != nil {
+		return
+	}
+	_ = sortCoordinates(coordinates)
+	for r := coordinates[1]; r <= coordinates[3] && r <= len(ws.SheetData.Row); r++ {
+		for c := coordinates[0]; c <= coordinates[2] && c <= len(ws.SheetData.Row[r-1].C); c++ {
+			if cell := &ws.SheetData.Row[r-1].C[c-1]; cell.F == nil {
+				cell.T, cell.V, cell.IS = "", "", nil
+			}
+		}
+	}
+}
+
+// setCachedValue provides a function to set the cached value of the cell by
+// given calculated formula argument.
+func (c *xlsxC) setCachedValue(arg formulaArg) {
+	c.IS = nil
+	switch arg.Type {
+	case ArgNumber:
+		if arg.Boolean {
+			c.T, c.V = "b", "0"
+			if arg.Number == 1 {
+				c.V = "1"
+			}
+			return
+		}
+		c.T, c.V = "", strconv.FormatFloat(arg.Number, 'f', -1, 64)
+	case ArgString:
+		c.setCellValue(arg.String)
+	case ArgMatrix:
+		if args := arg.ToList(); len(args) > 0 {
+			c.setCachedValue(args[0])
+			return
+		}
+		c.T, c.V = "", "0"
+	case ArgError:
+		c.T, c.V = "e", arg.String
+		if inStrSlice(formulaErrors, arg.String, true) == -1 {
+			c.V = formulaErrorVALUE
+		}
+	default:
+		c.T, c.V = "", "0"
+	}
+}
+
+// setDynamicArrayMetadata provides a function to add the dynamic array
+// properties into the workbook metadata part if not exist, and returns the
+// index of the cell metadata record for the dynamic array formula cells.
+func (f *File) setDynamicArrayMetadata() (uint, error) {
+	metadata, err := f.metadataReader()
+	if err != nil {
+		return 0, err
+	}
+	if metadata.MetadataTypes == nil {
+		metadata.MetadataTypes = &xlsxMetadataTypes{}
+	}
+	typeIdx, blockIdx := metadata.getMetadataTypeIdx("XLDAPR"), -1
+	if typeIdx == 0 {
+		metadata.MetadataTypes.MetadataType = append(metadata.MetadataTypes.MetadataType, xlsxMetadataType{
+			Name: "XLDAPR", MinSupportedVersion: 120000, Copy: true, PasteAll: true,
+			PasteValues: true, Merge: true, SplitFirst: true, RowColShift: true,
+			ClearFormats: true, ClearComments: true, Assign: true, Coerce: true, CellMeta: true,
+		})
+		typeIdx = len(metadata.MetadataTypes.MetadataType)
+	}
+	metadata.MetadataTypes.Count = len(metadata.MetadataTypes.MetadataType)
+	for i, futureMetadata := range metadata.FutureMetadata {
+		if futureMetadata.Name == "XLDAPR" && len(futureMetadata.Bk) > 0 {
+			blockIdx = 0
+			break
+		}
+		if futureMetadata.Name == "XLDAPR" {
+			metadata.FutureMetadata = append(metadata.FutureMetadata[:i], metadata.FutureMetadata[i+1:]...)
+			break
+		}
+	}
+	if blockIdx == -1 {
+		blockIdx = 0
+		metadata.FutureMetadata = append(metadata.FutureMetadata, xlsxFutureMetadata{
+			Name: "XLDAPR", Count: 1, Bk: []xlsxFutureMetadataBlock{{ExtLst: &xlsxInnerXML{
+				Content: `<ext uri="` + ExtURIDynamicArrayProperties + `"><xda:dynamicArrayProperties fDynamic="1" fCollapsed="0"/></ext>`,
+			}}},
+		})
+	}
+	if metadata.CellMetadata == nil {
+		metadata.CellMetadata = &xlsxMetadataBlocks{}
+	}
+	cm := -1
+	for i, bk := range metadata.CellMetadata.Bk {
+		if len(bk.Rc) == 1 && bk.Rc[0].T == typeIdx && bk.Rc[0].V == blockIdx {
+			cm = i
+			break
+		}
+	}
+	if cm == -1 {
+		metadata.CellMetadata.Bk = append(metadata.CellMetadata.Bk, xlsxMetadataBlock{
+			Rc: []xlsxMetadataRecord{{T: typeIdx, V: blockIdx}},
+		})
+		cm = len(metadata.CellMetadata.Bk) - 1
+	}
+	metadata.CellMetadata.Count = len(metadata.CellMetadata.Bk)
+	metadata.XMLNS, metadata.XMLNSXlrd, metadata.XMLNSXda = NameSpaceSpreadSheet.Value, NameSpaceSpreadSheetXlrd.Value, NameSpaceSpreadSheetXda.Value
+	output, err := xml.Marshal(metadata)
+	if err != nil {
+		return 0, err
+	}
+	f.saveFileList(defaultXMLMetadata, output)
+	if err = f.addContentTypePart(0, "metadata"); err != nil {
+		return 0, err
+	}
+	f.addRels(f.getWorkbookRelsPath(), SourceRelationshipSheetMetadata, "/xl/metadata.xml", "")
+	return uint(cm + 1), err
+}
+
+// getMetadataTypeIdx returns the 1-based index of the metadata type by given
+// metadata type name, and returns 0 if the metadata type not exist.
+func (m *xlsxMetadata) getMetadataTypeIdx(name string) int {
+	if m.MetadataTypes != nil {
+		for i, metadataType := range m.MetadataTypes.MetadataType {
+			if metadataType.Name == name {
+				return i + 1
+			}
+		}
+	}
+	return 0
+}
+
+// isDynamicArray returns true if the given cell metadata index refers to the
+// dynamic array properties.
+func (m *xlsxMetadata) isDynamicArray(cm *uint) bool {
+	if cm == nil || *cm == 0 || m.CellMetadata == nil || int(*cm) > len(m.CellMetadata.Bk) {
+		return false
+	}
+	typeIdx := m.getMetadataTypeIdx("XLDAPR")
+	for _, rc := range m.CellMetadata.Bk[*cm-1].Rc {
+		if typeIdx != 0 && rc.T == typeIdx {
+			return true
+		}
+	}
+	return false
+}
+
 // sharedFormulaRefToCoordinates provides a function to convert shared formula
 // reference to coordinates.
 func sharedFormulaRefToCoordinates(opts ...FormulaOpts) ([]int, error) {
@@ -895,6 +1203,10 @@ func (ws *xlsxWorksheet) setArrayFormula(sheet string, formula *xlsxF, definedNa
 // formula as the normal formula.
 func (f *File) setArrayFormulaCells() error {
 	definedNames := f.GetDefinedName()
+	metadata, err := f.metadataReader()
+	if err != nil {
+		return err
+	}
 	for _, sheetN := range f.GetSheetList() {
 		ws, err := f.workSheetReader(sheetN)
 		if err != nil {
@@ -905,7 +1217,7 @@ func (f *File) setArrayFormulaCells() error {
 		}
 		for _, row := range ws.SheetData.Row {
 			for _, cell := range row.C {
-				if cell.F != nil && cell.F.T == STCellFormulaTypeArray {
+				if cell.F != nil && cell.F.T == STCellFormulaTypeArray && !metadata.isDynamicArray(cell.Cm) {
 					if err = ws.setArrayFormula(sheetN, cell.F, definedNames); err != nil {
 						return err
 					}
@@ -12,8 +12,13 @@
 package excelize
 
 import (
+	"bytes"
+	"cmp"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"math"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -1149,6 +1154,12 @@ func (opts *Chart) parseTitle() error {
 // The 'Overlap' property is optional. The default width is 0, and the value
 // should be great or equal than -100 and less or equal than 100.
 //
+// The 'Cell' property is the cell reference of the top-left corner of the
+// chart, which will be filled by the GetCharts function, and it will be
+// ignored when adding the chart. The 'Combo' property specifies the charts
+// combined with this chart, which will be used if the 'combo' parameter is
+// omitted.
+//
 // combo: Specifies the create a chart that combines two or more chart types in
 // a single chart. For example, create a clustered column - line chart with
 // data Sheet1!$E$1:$L$15:
@@ -1278,7 +1289,7 @@ func (f *File) AddChart(sheet, cell string, chart *Chart, combo ...*Chart) error
 	if err != nil {
 		return err
 	}
-	f.addChart(opts, comboCharts)
+	f.addChart("xl/charts/chart"+strconv.Itoa(chartID)+".xml", opts, comboCharts)
 	if err = f.addContentTypePart(chartID, "chart"); err != nil {
 		return err
 	}
@@ -1330,7 +1341,7 @@ func (f *File) AddChartSheet(sheet string, chart *Chart, combo ...*Chart) error
 	if err = f.addSheetDrawingChart(drawingXML, drawingRID, &opts.Format); err != nil {
 		return err
 	}
-	f.addChart(opts, comboCharts)
+	f.addChart("xl/charts/chart"+strconv.Itoa(chartID)+".xml", opts, comboCharts)
 	if err = f.addContentTypePart(chartID, "chart"); err != nil {
 		return err
 	}
@@ -1354,6 +1365,9 @@ func (f *File) getChartOptions(opts *Chart, combo []*Chart) (*Chart, []*Chart, e
 	if err != nil {
 		return options, comboCharts, err
 	}
+	if len(combo) == 0 {
+		combo = options.Combo
+	}
 	for _, comboFormat := range combo {
 		comboChart, err := parseChartOptions(comboFormat)
 		if err != nil {
@@ -1391,6 +1405,868 @@ func (f *File) DeleteChart(sheet, cell string) error {
 	return err
 }
 
+// GetCharts provides a function to get the format settings of the charts on
+// the worksheet or chartsheet by given sheet name, the charts will be sorted
+// by the cell references of the top-left corners. The 'Cell' field of the
+// chart will be set to the cell reference of the top-left corner of the
+// chart, and it will be empty for the chart on the chartsheet. The charts
+// combined in a combo chart will be returned by the 'Combo' field. For
+// example, get the charts on the worksheet named Sheet1:
+//
+//	charts, err := f.GetCharts("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, chart := range charts {
+//	    fmt.Println(chart.Cell, chart.Type, len(chart.Series))
+//	}
+func (f *File) GetCharts(sheet string) ([]Chart, error) {
+	anchors, err := f.getSheetCharts(sheet)
+	if err != nil {
+		return nil, err
+	}
+	path, _ := f.getSheetXMLPath(sheet)
+	var charts []Chart
+	for _, anchor := range anchors {
+		chart, err := f.extractChart(anchor)
+		if err != nil {
+			return charts, err
+		}
+		if !strings.HasPrefix(path, "xl/chartsheets") {
+			chart.Cell, _ = CoordinatesToCellName(anchor.col, anchor.row)
+		}
+		charts = append(charts, *chart)
+	}
+	return charts, err
+}
+
+// SetChart provides a function to update the series of the chart by given
+// worksheet name, cell reference of the top-left corner of the chart and chart
+// format set. For the chartsheet, the cell reference will be ignored. The
+// reference formulas of the name, categories, values and bubble sizes of the
+// series will be updated in place, and the cached values of the series will
+// be refreshed from the cells. The name, categories and values given as
+// literal values instead of references in the existing chart are returned as
+// empty by the GetCharts function, and will be kept. Other content of the
+// existing chart, such as the formatting, trendlines, error bars and extension
+// lists, will be kept.
+// The series of the chart and combo charts must be given in the same order
+// and count as returned by the GetCharts function, use the ReplaceChart
+// function to change other format settings or the number of series. For
+// example, change the data range of the first series of the chart anchored on
+// the cell E1 of the worksheet named Sheet1:
+//
+//	charts, err := f.GetCharts("Sheet1")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, chart := range charts {
+//	    if chart.Cell != "E1" {
+//	        continue
+//	    }
+//	    chart.Series[0].Values = "Sheet1!$B$2:$D$4"
+//	    if err := f.SetChart("Sheet1", chart.Cell, &chart, chart.Combo...); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	}
+func (f *File) SetChart(sheet, cell string, chart *Chart, combo ...*Chart) error {
+	if chart == nil {
+		return ErrParameterInvalid
+	}
+	anchor, err := f.getCellChart(sheet, cell)
+	if err != nil {
+		return err
+	}
+	content := namespaceStrictToTransitional(f.readXML(anchor.path))
+	cs := new(decodeChartSpace)
+	if err = f.xmlNewDecoder(bytes.NewReader(content)).Decode(cs); err != nil && err != io.EOF {
+		return err
+	}
+	var existing []*decodeChartSeries
+	if cs.Chart.PlotArea != nil {
+		for _, item := range getChartGroups(cs.Chart.PlotArea) {
+			existing = append(existing, item.group.Ser...)
+		}
+	}
+	series := slices.Clone(chart.Series)
+	for _, opts := range combo {
+		if opts != nil {
+			series = append(series, opts.Series...)
+		}
+	}
+	if len(series) != len(existing) {
+		return ErrChartSeriesMismatch
+	}
+	seriesMap := make(map[int]ChartSeries, len(series))
+	for i, ser := range existing {
+		seriesMap[chartInt(ser.IDx, 0)] = series[i]
+	}
+	if content, err = f.setChartSeriesRefs(content, seriesMap); err != nil {
+		return err
+	}
+	f.saveFileList(anchor.path, content)
+	return err
+}
+
+// ReplaceChart provides a function to replace the format settings of the
+// chart by given worksheet name, cell reference of the top-left corner of the
+// chart and chart format set, the position and size of the chart will be
+// kept. For the chartsheet, the cell reference will be ignored. The chart
+// format set is the same as the AddChart function, so the format settings
+// returned by the GetCharts function can be modified and used to replace the
+// chart.
+//
+// Note that the chart part will be rewritten entirely from the given format
+// settings, any content of the existing chart which is not covered by the
+// Chart data type, such as trendlines, error bars, data labels of specific
+// points and extension lists, will be lost after calling this function. Use
+// the SetChart function to update the series references of the chart only.
+func (f *File) ReplaceChart(sheet, cell string, chart *Chart, combo ...*Chart) error {
+	anchor, err := f.getCellChart(sheet, cell)
+	if err != nil {
+		return err
+	}
+	opts, comboCharts, err := f.getChartOptions(chart, combo)
+	if err != nil {
+		return err
+	}
+	f.addChart(anchor.path, opts, comboCharts)
+	return err
+}
+
+// chartSeriesPart directly maps the position of the series element and the
+// child elements of the series in the chart part.
+type chartSeriesPart struct {
+	group, prefix string
+	idx           int
+	elements      map[string]*chartSeriesData
+}
+
+// chartSeriesData directly maps the position of the child element of the
+// series in the chart part, and the reference formula of the data source
+// element, such as c:tx, c:cat and c:val.
+type chartSeriesData struct {
+	start, end           int64
+	ref, formula         string
+	formatCode           string
+	fStart, fEnd         int64
+	cacheStart, cacheEnd int64
+}
+
+// chartPartEdit defined the replacement of the byte range in the chart part.
+type chartPartEdit struct {
+	start, end int64
+	data       string
+}
+
+// setChartSeriesRefs provides a function to update the reference formulas and
+// the cached values of the series data source elements in the chart part by
+// given chart part content and series format sets indexed by the series
+// index. The content of the chart part out of the updated elements will be
+// kept as-is.
+func (f *File) setChartSeriesRefs(content []byte, series map[int]ChartSeries) ([]byte, error) {
+	var (
+		edits []chartPartEdit
+		names []string
+		part  *chartSeriesPart
+		data  *chartSeriesData
+	)
+	// level returns the name of the element which is the given levels above
+	// the current element, such as the series element is at the level 3 of
+	// the f element in the path ser/val/numRef/f
+	level := func(n int) string {
+		if len(names) > n {
+			return names[len(names)-1-n]
+		}
+		return ""
+	}
+	d := f.xmlNewDecoder(bytes.NewReader(content))
+	for {
+		offset := d.InputOffset()
+		token, err := d.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return content, err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			names = append(names, element.Name.Local)
+			switch {
+			case element.Name.Local == "ser" && len(names) > 1:
+				part = &chartSeriesPart{group: level(1), prefix: element.Name.Space, elements: make(map[string]*chartSeriesData)}
+			case part == nil:
+			case level(1) == "ser":
+				data = &chartSeriesData{start: offset}
+				part.elements[element.Name.Local] = data
+				if element.Name.Local == "idx" {
+					for _, attr := range element.Attr {
+						if attr.Name.Local == "val" {
+							part.idx, _ = strconv.Atoi(attr.Value)
+						}
+					}
+				}
+			case level(2) == "ser" && strings.HasSuffix(element.Name.Local, "Ref"):
+				data.ref = element.Name.Local
+			case level(3) == "ser" && element.Name.Local == "f":
+				data.fStart = offset
+			case level(3) == "ser" && strings.HasSuffix(element.Name.Local, "Cache"):
+				data.cacheStart = offset
+			}
+		case xml.CharData:
+			if part != nil && level(3) == "ser" && level(0) == "f" {
+				data.formula += string(element)
+			}
+			if part != nil && level(4) == "ser" && level(0) == "formatCode" {
+				data.formatCode += string(element)
+			}
+		case xml.EndElement:
+			switch {
+			case part == nil:
+			case element.Name.Local == "ser" && level(0) == "ser":
+				if ser, ok := series[part.idx]; ok {
+					serEdits, err := f.getChartSeriesEdits(part, ser)
+					if err != nil {
+						return content, err
+					}
+					edits = append(edits, serEdits...)
+				}
+				part = nil
+			case level(1) == "ser":
+				data.end = d.InputOffset()
+			case level(3) == "ser" && element.Name.Local == "f":
+				data.fEnd = d.InputOffset()
+			case level(3) == "ser" && strings.HasSuffix(element.Name.Local, "Cache"):
+				data.cacheEnd = d.InputOffset()
+			}
+			if len(names) > 0 {
+				names = names[:len(names)-1]
+			}
+		}
+	}
+	slices.SortStableFunc(edits, func(a, b chartPartEdit) int {
+		return cmp.Or(cmp.Compare(a.start, b.start), cmp.Compare(a.end, b.end))
+	})
+	var (
+		buf  bytes.Buffer
+		last int64
+	)
+	for _, edit := range edits {
+		buf.Write(content[last:edit.start])
+		buf.WriteString(edit.data)
+		last = edit.end
+	}
+	buf.Write(content[last:])
+	return buf.Bytes(), nil
+}
+
+// getChartSeriesEdits provides a function to get the replacements of the data
+// source elements of the series in the chart part by given series position
+// and series format set. The data source element with reference will be
+// removed if the reference formula is empty, the data source element with
+// literal values will be kept as-is, and the data source element which not
+// exists will be inserted after or before the adjacent element of the series.
+func (f *File) getChartSeriesEdits(part *chartSeriesPart, ser ChartSeries) ([]chartPartEdit, error) {
+	type source struct {
+		name, formula, ref string
+		after, before      string
+	}
+	sources := []source{{name: "tx", formula: ser.Name, ref: "strRef", after: "order"}}
+	switch part.group {
+	case "scatterChart", "bubbleChart":
+		sources = append(sources,
+			source{name: "xVal", formula: ser.Categories, ref: "strRef", before: "yVal"},
+			source{name: "yVal", formula: ser.Values, ref: "numRef", after: "xVal"},
+		)
+		if part.group == "bubbleChart" {
+			sizes := ser.Sizes
+			if sizes == "" {
+				sizes = ser.Values
+			}
+			sources = append(sources, source{name: "bubbleSize", formula: sizes, ref: "numRef", after: "yVal"})
+		}
+	default:
+		sources = append(sources,
+			source{name: "cat", formula: ser.Categories, ref: "strRef", before: "val"},
+			source{name: "val", formula: ser.Values, ref: "numRef", after: "cat"},
+		)
+	}
+	prefix := part.prefix
+	if prefix != "" {
+		prefix += ":"
+	}
+	var edits []chartPartEdit
+	for _, src := range sources {
+		data := part.elements[src.name]
+		if data != nil && (data.ref == "" || data.formula == src.formula) {
+			continue
+		}
+		if src.formula == "" {
+			if data != nil {
+				edits = append(edits, chartPartEdit{start: data.start, end: data.end})
+			}
+			continue
+		}
+		if data != nil {
+			edits = append(edits, chartPartEdit{
+				start: data.fStart, end: data.fEnd,
+				data: "<" + prefix + "f>" + chartEscapeText(src.formula) + "</" + prefix + "f>",
+			})
+			cache := f.getChartCache(prefix, data.ref, src.formula, data.formatCode)
+			if data.cacheEnd > 0 {
+				edits = append(edits, chartPartEdit{start: data.cacheStart, end: data.cacheEnd, data: cache})
+				continue
+			}
+			edits = append(edits, chartPartEdit{start: data.fEnd, end: data.fEnd, data: cache})
+			continue
+		}
+		element := "<" + prefix + src.name + "><" + prefix + src.ref + "><" + prefix + "f>" + chartEscapeText(src.formula) +
+			"</" + prefix + "f>" + f.getChartCache(prefix, src.ref, src.formula, "") + "</" + prefix + src.ref + "></" + prefix + src.name + ">"
+		if adjacent, ok := part.elements[src.after]; ok {
+			edits = append(edits, chartPartEdit{start: adjacent.end, end: adjacent.end, data: element})
+			continue
+		}
+		if adjacent, ok := part.elements[src.before]; ok {
+			edits = append(edits, chartPartEdit{start: adjacent.start, end: adjacent.start, data: element})
+			continue
+		}
+		return nil, ErrChartSeriesMismatch
+	}
+	return edits, nil
+}
+
+// getChartCache provides a function to get the cached values element of the
+// series data source by given namespace prefix, reference element name,
+// reference formula and number format code of the cached values. The cached
+// values will be read from the cells referenced by the formula, returns empty
+// if the reference can't be resolved.
+func (f *File) getChartCache(prefix, ref, formula, formatCode string) string {
+	values, ok := f.getChartRefValues(formula)
+	if !ok || (ref != "numRef" && ref != "strRef") {
+		return ""
+	}
+	var sb strings.Builder
+	name := "strCache"
+	if ref == "numRef" {
+		name = "numCache"
+		if formatCode == "" {
+			formatCode = "General"
+		}
+	}
+	sb.WriteString("<" + prefix + name + ">")
+	if ref == "numRef" {
+		sb.WriteString("<" + prefix + "formatCode>" + chartEscapeText(formatCode) + "</" + prefix + "formatCode>")
+	}
+	sb.WriteString("<" + prefix + "ptCount val=\"" + strconv.Itoa(len(values)) + "\"/>")
+	for idx, value := range values {
+		text := value.text
+		if ref == "numRef" {
+			if math.IsNaN(value.number) {
+				continue
+			}
+			text = strconv.FormatFloat(value.number, 'f', -1, 64)
+		}
+		if text == "" {
+			continue
+		}
+		sb.WriteString("<" + prefix + "pt idx=\"" + strconv.Itoa(idx) + "\"><" + prefix + "v>" +
+			chartEscapeText(text) + "</" + prefix + "v></" + prefix + "pt>")
+	}
+	sb.WriteString("</" + prefix + name + ">")
+	return sb.String()
+}
+
+// chartEscapeText provides a function to escape the text for the element of
+// the chart part.
+func chartEscapeText(text string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(text))
+	return buf.String()
+}
+
+// extractChart provides a function to extract the chart format set from the
+// chart part by given chart anchor.
+func (f *File) extractChart(anchor chartAnchor) (*Chart, error) {
+	cs := new(decodeChartSpace)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(anchor.path)))).
+		Decode(cs); err != nil && err != io.EOF {
+		return nil, err
+	}
+	chart := &Chart{
+		Format: anchor.format,
+		Dimension: ChartDimension{
+			Width:  uint(math.Round(anchor.width)),
+			Height: uint(math.Round(anchor.height)),
+		},
+		Legend:       ChartLegend{Position: "none"},
+		ShowBlanksAs: chartString(cs.Chart.DispBlanksAs, defaultChartShowBlanksAs),
+	}
+	if cs.SpPr != nil {
+		chart.Fill = extractChartFill(cs.SpPr)
+		chart.Border = extractChartLn(cs.SpPr.Ln)
+	}
+	if cs.Chart.Title != nil {
+		chart.Title = extractChartTitle(cs.Chart.Title)
+	}
+	if legend := cs.Chart.Legend; legend != nil {
+		chart.Legend.Position = defaultChartLegendPosition
+		for name, pos := range chartLegendPosition {
+			if pos == chartString(legend.LegendPos, "r") {
+				chart.Legend.Position = name
+			}
+		}
+		chart.Legend.Font = extractChartTextFont(legend.TxPr)
+	}
+	pa := cs.Chart.PlotArea
+	if pa == nil {
+		return chart, nil
+	}
+	chart.PlotArea.Fill = extractChartFill(pa.SpPr)
+	if pa.DTable != nil {
+		chart.PlotArea.ShowDataTable = true
+		chart.PlotArea.ShowDataTableKeys = chartBool(pa.DTable.ShowKeys, false)
+	}
+	groups := getChartGroups(pa)
+	axes := make(map[int]*decodeChartAxis)
+	for _, ax := range slices.Concat(pa.CatAx, pa.DateAx, pa.ValAx, pa.SerAx) {
+		axes[chartInt(ax.AxID, 0)] = ax
+	}
+	valAxID := func(g *decodeChartGroup) int {
+		if len(g.AxID) > 1 {
+			return chartInt(g.AxID[1], 0)
+		}
+		return 0
+	}
+	for idx, item := range groups {
+		opts := chart
+		if idx > 0 {
+			opts = &Chart{}
+			chart.Combo = append(chart.Combo, opts)
+		}
+		f.extractChartGroup(opts, item.kind, item.group, axes, cs.Chart.Legend)
+		if idx > 0 && valAxID(item.group) != valAxID(groups[0].group) {
+			opts.XAxis.None, opts.YAxis.Secondary = false, true
+		}
+	}
+	return chart, nil
+}
+
+// chartGroupItem directly maps the chart group element such as c:barChart in
+// the plot area of the chart, and the minimum order of the series in the
+// group.
+type chartGroupItem struct {
+	kind  string
+	group *decodeChartGroup
+	order int
+}
+
+// getChartGroups provides a function to get the chart groups in the plot area
+// of the chart, the groups are sorted by the order of the series, and the
+// first group is the primary chart.
+func getChartGroups(pa *decodeChartPlot) []chartGroupItem {
+	var groups []chartGroupItem
+	for _, item := range []struct {
+		kind   string
+		groups []*decodeChartGroup
+	}{
+		{"areaChart", pa.AreaChart}, {"area3DChart", pa.Area3DChart}, {"barChart", pa.BarChart},
+		{"bar3DChart", pa.Bar3DChart}, {"bubbleChart", pa.BubbleChart}, {"doughnutChart", pa.DoughnutChart},
+		{"lineChart", pa.LineChart}, {"line3DChart", pa.Line3DChart}, {"stockChart", pa.StockChart},
+		{"pieChart", pa.PieChart}, {"pie3DChart", pa.Pie3DChart}, {"ofPieChart", pa.OfPieChart},
+		{"radarChart", pa.RadarChart}, {"scatterChart", pa.ScatterChart},
+		{"surface3DChart", pa.Surface3DChart}, {"surfaceChart", pa.SurfaceChart},
+	} {
+		for _, g := range item.groups {
+			order := math.MaxInt
+			for _, ser := range g.Ser {
+				order = min(order, chartInt(ser.Order, chartInt(ser.IDx, 0)))
+			}
+			groups = append(groups, chartGroupItem{kind: item.kind, group: g, order: order})
+		}
+	}
+	slices.SortStableFunc(groups, func(a, b chartGroupItem) int {
+		return cmp.Compare(a.order, b.order)
+	})
+	return groups
+}
+
+// extractChartGroup provides a function to extract the chart type, series,
+// axes and plot area settings from the chart group element such as c:barChart
+// by given chart format set, chart group element name, chart group element,
+// axes of the plot area and legend element.
+func (f *File) extractChartGroup(opts *Chart, kind string, g *decodeChartGroup, axes map[int]*decodeChartAxis, legend *decodeChartLegend) {
+	opts.Type = f.extractChartType(kind, g)
+	if g.VaryColors != nil {
+		opts.VaryColors = boolPtr(chartBool(g.VaryColors, true))
+	}
+	if g.GapWidth != nil {
+		gapWidth := uint(chartInt(g.GapWidth, 150))
+		opts.GapWidth = &gapWidth
+	}
+	if g.Overlap != nil {
+		overlap := chartInt(g.Overlap, 0)
+		opts.Overlap = &overlap
+	}
+	if g.BubbleScale != nil && g.BubbleScale.Val != nil {
+		opts.BubbleSize = int(*g.BubbleScale.Val)
+	}
+	opts.HoleSize = chartInt(g.HoleSize, 0)
+	opts.PlotArea.SecondPlotValues = chartInt(g.SplitPos, 0)
+	dLbls := g.DLbls
+	if dLbls == nil && len(g.Ser) > 0 {
+		dLbls = g.Ser[0].DLbls
+	}
+	if dLbls != nil {
+		opts.Legend.ShowLegendKey = chartBool(dLbls.ShowLegendKey, false)
+		opts.PlotArea.ShowBubbleSize = chartBool(dLbls.ShowBubbleSize, false)
+		opts.PlotArea.ShowCatName = chartBool(dLbls.ShowCatName, false)
+		opts.PlotArea.ShowLeaderLines = chartBool(dLbls.ShowLeaderLines, false)
+		opts.PlotArea.ShowPercent = chartBool(dLbls.ShowPercent, false)
+		opts.PlotArea.ShowSerName = chartBool(dLbls.ShowSerName, false)
+		opts.PlotArea.ShowVal = chartBool(dLbls.ShowVal, false)
+		if dLbls.NumFmt != nil {
+			opts.PlotArea.NumFmt = ChartNumFmt{CustomNumFmt: dLbls.NumFmt.FormatCode, SourceLinked: dLbls.NumFmt.SourceLinked}
+		}
+	}
+	if g.UpDownBars != nil {
+		opts.PlotArea.UpBars = extractChartUpDownBar(g.UpDownBars.UpBars)
+		opts.PlotArea.DownBars = extractChartUpDownBar(g.UpDownBars.DownBars)
+	}
+	if len(g.AxID) > 1 {
+		opts.XAxis = extractChartAxis(axes[chartInt(g.AxID[0], 0)], "General")
+		opts.YAxis = extractChartAxis(axes[chartInt(g.AxID[1], 0)], chartValAxNumFmtFormatCode[opts.Type])
+	}
+	opts.XAxis.DropLines = g.DropLines != nil
+	opts.XAxis.HighLowLines = g.HiLowLines != nil
+	for _, ser := range g.Ser {
+		opts.Series = append(opts.Series, extractChartSeries(ser, legend))
+	}
+}
+
+// extractChartType provides a function to get the chart type by given chart
+// group element name and chart group element.
+func (f *File) extractChartType(kind string, g *decodeChartGroup) ChartType {
+	switch kind {
+	case "doughnutChart":
+		return Doughnut
+	case "lineChart":
+		return Line
+	case "line3DChart":
+		return Line3D
+	case "pieChart":
+		return Pie
+	case "pie3DChart":
+		return Pie3D
+	case "ofPieChart":
+		if chartString(g.OfPieType, "pie") == "bar" {
+			return BarOfPie
+		}
+		return PieOfPie
+	case "radarChart":
+		return Radar
+	case "scatterChart":
+		return Scatter
+	case "surface3DChart":
+		if chartBool(g.Wireframe, false) {
+			return WireframeSurface3D
+		}
+		return Surface3D
+	case "surfaceChart":
+		if chartBool(g.Wireframe, false) {
+			return WireframeContour
+		}
+		return Contour
+	case "bubbleChart":
+		for _, ser := range g.Ser {
+			if chartBool(ser.Bubble3D, false) {
+				return Bubble3D
+			}
+		}
+		return Bubble
+	case "stockChart":
+		if g.UpDownBars != nil {
+			return StockOpenHighLowClose
+		}
+		return StockHighLowClose
+	}
+	is3D, barDir := strings.HasSuffix(kind, "3DChart"), chartString(g.BarDir, "")
+	chartType := map[string]ChartType{
+		"areaChart": Area, "area3DChart": Area3D, "barChart": Col, "bar3DChart": Col3DClustered,
+	}[kind]
+	if barDir == "bar" {
+		chartType = map[bool]ChartType{false: Bar, true: Bar3DClustered}[is3D]
+	}
+	for t := Area; t <= Col3DCylinderPercentStacked; t++ {
+		shape := "box"
+		if val := f.drawChartShape(&Chart{Type: t}); val != nil {
+			shape = *val.Val
+		}
+		if plotAreaChartBarDir[t] == barDir && plotAreaChartGrouping[t] == chartString(g.Grouping, "") &&
+			shape == chartString(g.Shape, "box") && (chartView3DRotX[t] != 0) == is3D {
+			return t
+		}
+	}
+	return chartType
+}
+
+// extractChartSeries provides a function to extract the chart series format
+// set by given series element and legend element.
+func extractChartSeries(ser *decodeChartSeries, legend *decodeChartLegend) ChartSeries {
+	series := ChartSeries{
+		Categories: extractChartDataRef(ser.Cat),
+		Values:     extractChartDataRef(ser.Val),
+		Sizes:      extractChartDataRef(ser.BubbleSize),
+	}
+	if ser.Tx != nil && ser.Tx.StrRef != nil {
+		series.Name = ser.Tx.StrRef.F
+	}
+	if ser.XVal != nil {
+		series.Categories = extractChartDataRef(ser.XVal)
+	}
+	if ser.YVal != nil {
+		series.Values = extractChartDataRef(ser.YVal)
+	}
+	if spPr := ser.SpPr; spPr != nil {
+		series.Fill = extractChartFill(spPr)
+		series.Line = extractChartLn(spPr.Ln)
+		if spPr.SolidFill == nil && spPr.NoFill == nil {
+			series.Fill, series.Line.Fill = series.Line.Fill, Fill{}
+		}
+	}
+	series.Line.Smooth = chartBool(ser.Smooth, false)
+	if marker := ser.Marker; marker != nil {
+		series.Marker.Symbol = chartString(marker.Symbol, "")
+		series.Marker.Size = chartInt(marker.Size, 0)
+		if marker.SpPr != nil {
+			series.Marker.Fill = extractChartFill(marker.SpPr)
+			series.Marker.Border = extractChartLn(marker.SpPr.Ln)
+		}
+	}
+	for _, dPt := range ser.DPt {
+		if fill := extractChartFill(dPt.SpPr); fill.Type != "" {
+			series.DataPoint = append(series.DataPoint, ChartDataPoint{Index: chartInt(dPt.IDx, 0), Fill: fill})
+		}
+	}
+	if dLbls := ser.DLbls; dLbls != nil {
+		for typ, pos := range chartDataLabelsPositionTypes {
+			if pos == chartString(dLbls.DLblPos, "") {
+				series.DataLabelPosition = typ
+			}
+		}
+		series.DataLabel.Fill = extractChartFill(dLbls.SpPr)
+		if font := extractChartTextFont(dLbls.TxPr); font != nil {
+			series.DataLabel.Font = *font
+		}
+	}
+	if legend != nil {
+		for _, entry := range legend.LegendEntry {
+			if chartInt(entry.IDx, -1) == chartInt(ser.IDx, 0) {
+				series.Legend.Font = extractChartTextFont(entry.TxPr)
+			}
+		}
+	}
+	return series
+}
+
+// extractChartDataRef provides a function to get the reference formula of the
+// chart data source.
+func extractChartDataRef(data *decodeChartData) string {
+	if data == nil {
+		return ""
+	}
+	if data.StrRef != nil {
+		return data.StrRef.F
+	}
+	if data.NumRef != nil {
+		return data.NumRef.F
+	}
+	return ""
+}
+
+// extractChartAxis provides a function to extract the chart axis format set
+// by given axis element and default number format code of the axis.
+func extractChartAxis(ax *decodeChartAxis, numFmt string) ChartAxis {
+	var axis ChartAxis
+	if ax == nil {
+		return axis
+	}
+	axis.None = chartBool(ax.Delete, false)
+	axis.MajorGridLines = ax.MajorGridlines != nil
+	axis.MinorGridLines = ax.MinorGridlines != nil
+	if ax.MajorUnit != nil && ax.MajorUnit.Val != nil {
+		axis.MajorUnit = *ax.MajorUnit.Val
+	}
+	for typ, pos := range tickLblPosVal {
+		if pos == chartString(ax.TickLblPos, "nextTo") {
+			axis.TickLabelPosition = typ
+		}
+	}
+	axis.TickLabelSkip = chartInt(ax.TickLblSkip, 0)
+	if scaling := ax.Scaling; scaling != nil {
+		axis.ReverseOrder = chartString(scaling.Orientation, "minMax") == "maxMin"
+		if scaling.Max != nil {
+			axis.Maximum = scaling.Max.Val
+		}
+		if scaling.Min != nil {
+			axis.Minimum = scaling.Min.Val
+		}
+		if scaling.LogBase != nil && scaling.LogBase.Val != nil {
+			axis.LogBase = *scaling.LogBase.Val
+		}
+	}
+	if ax.NumFmt != nil && (ax.NumFmt.FormatCode != numFmt || ax.NumFmt.SourceLinked) {
+		axis.NumFmt = ChartNumFmt{CustomNumFmt: ax.NumFmt.FormatCode, SourceLinked: ax.NumFmt.SourceLinked}
+	}
+	if ax.TxPr != nil {
+		if bodyPr := ax.TxPr.BodyPr; bodyPr != nil {
+			if rot := bodyPr.Rot / 60000; -90 <= rot && rot <= 90 {
+				axis.Alignment.TextRotation = rot
+			}
+			if bodyPr.Vert != "horz" {
+				axis.Alignment.Vertical = bodyPr.Vert
+			}
+		}
+		if font := extractChartTextFont(ax.TxPr); font != nil {
+			axis.Font = *font
+		}
+	}
+	if ax.Title != nil {
+		axis.Title = extractChartTitle(ax.Title)
+	}
+	return axis
+}
+
+// extractChartTitle provides a function to extract the chart title format set
+// by given title element.
+func extractChartTitle(t *decodeChartTitle) ChartTitle {
+	title := ChartTitle{Overlay: chartBool(t.Overlay, false)}
+	if t.Tx != nil && t.Tx.StrRef != nil {
+		title.Formula = t.Tx.StrRef.F
+		title.Font = extractChartTextFont(t.TxPr)
+	}
+	if t.Tx != nil && t.Tx.Rich != nil {
+		for _, p := range t.Tx.Rich.P {
+			for _, r := range p.R {
+				title.Paragraph = append(title.Paragraph, RichTextRun{Text: r.T, Font: extractChartFont(r.RPr)})
+			}
+		}
+	}
+	if t.Layout != nil && t.Layout.ManualLayout != nil {
+		percent := func(val *attrValFloat) int {
+			if val == nil || val.Val == nil {
+				return 0
+			}
+			return int(math.Round(*val.Val * 100))
+		}
+		layout := t.Layout.ManualLayout
+		title.OffsetX, title.OffsetY = percent(layout.X), percent(layout.Y)
+		title.Width, title.Height = percent(layout.W), percent(layout.H)
+	}
+	if t.SpPr != nil {
+		title.Fill = extractChartFill(t.SpPr)
+		title.Border = extractChartLn(t.SpPr.Ln)
+	}
+	return title
+}
+
+// extractChartTextFont provides a function to extract the font settings from
+// the default run properties of the first paragraph by given text element.
+func extractChartTextFont(text *decodeChartText) *Font {
+	if text == nil || len(text.P) == 0 || text.P[0].PPr == nil {
+		return nil
+	}
+	return extractChartFont(text.P[0].PPr.DefRPr)
+}
+
+// extractChartFont provides a function to extract the font settings by given
+// run properties element, returns nil if no font setting is specified.
+func extractChartFont(rPr *decodeChartRPr) *Font {
+	if rPr == nil {
+		return nil
+	}
+	font := Font{Size: rPr.Sz / 100, Strike: rPr.Strike != "" && rPr.Strike != "noStrike"}
+	if rPr.B != nil {
+		font.Bold = *rPr.B
+	}
+	if rPr.I != nil {
+		font.Italic = *rPr.I
+	}
+	if rPr.U != "none" {
+		font.Underline = rPr.U
+	}
+	if rPr.SolidFill != nil && rPr.SolidFill.SrgbClr != nil {
+		font.Color = rPr.SolidFill.SrgbClr.Val
+	}
+	if rPr.Latin != nil && !strings.HasPrefix(rPr.Latin.Typeface, "+") {
+		font.Family = rPr.Latin.Typeface
+	}
+	if font == (Font{}) {
+		return nil
+	}
+	return &font
+}
+
+// extractChartFill provides a function to extract the fill settings by given
+// shape properties element.
+func extractChartFill(spPr *decodeChartSpPr) Fill {
+	if spPr == nil {
+		return Fill{}
+	}
+	if spPr.NoFill != nil {
+		return Fill{Type: "pattern"}
+	}
+	return extractChartSolidFill(spPr.SolidFill)
+}
+
+// extractChartSolidFill provides a function to extract the fill settings by
+// given solid fill element, only the RGB color will be extracted.
+func extractChartSolidFill(solidFill *decodeChartSolidFill) Fill {
+	if solidFill == nil || solidFill.SrgbClr == nil {
+		return Fill{}
+	}
+	fill := Fill{Type: "pattern", Pattern: 1, Color: []string{solidFill.SrgbClr.Val}}
+	if alpha := solidFill.SrgbClr.Alpha; alpha != nil {
+		fill.Transparency = 100 - chartInt(alpha, 100000)/1000
+	}
+	return fill
+}
+
+// extractChartLn provides a function to extract the line settings by given
+// outline element.
+func extractChartLn(ln *decodeChartLn) LineOptions {
+	var opts LineOptions
+	if ln == nil {
+		return opts
+	}
+	opts.Width = float64(ln.W) / 12700
+	for typ, dash := range LineDashTypes {
+		if dash == chartString(ln.PrstDash, "") {
+			opts.Dash = typ
+		}
+	}
+	if ln.NoFill != nil {
+		opts.Type = LineNone
+		return opts
+	}
+	if ln.SolidFill != nil {
+		opts.Type = LineSolid
+		opts.Fill = extractChartSolidFill(ln.SolidFill)
+	}
+	return opts
+}
+
+// extractChartUpDownBar provides a function to extract the up bars or down
+// bars format set of the stock chart by given bars element.
+func extractChartUpDownBar(bars *decodeChartLines) ChartUpDownBar {
+	if bars == nil || bars.SpPr == nil {
+		return ChartUpDownBar{}
+	}
+	return ChartUpDownBar{Fill: extractChartFill(bars.SpPr), Border: extractChartLn(bars.SpPr.Ln)}
+}
+
 // countCharts provides a function to get chart files count storage in the
 // folder xl/charts.
 func (f *File) countCharts() int {
@@ -5,6 +5,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -547,6 +548,351 @@ func TestDeleteChart(t *testing.T) {
 	assert.NoError(t, f.Close())
 }
 
+func TestGetCharts(t *testing.T) {
+	f := NewFile()
+	series := []ChartSeries{
+		{
+			Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2",
+			Fill:              Fill{Type: "pattern", Pattern: 1, Color: []string{"FF0000"}, Transparency: 20},
+			DataLabelPosition: ChartDataLabelsPositionOutsideEnd,
+		},
+		{
+			Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3",
+			Legend: ChartLegend{Font: &Font{Bold: true, Color: "00FF00"}},
+		},
+	}
+	format := GraphicOptions{
+		Name: "Chart 1", AltText: "chart", ScaleX: defaultDrawingScale, ScaleY: defaultDrawingScale,
+		OffsetX: 15, OffsetY: 10, PrintObject: boolPtr(true), Locked: boolPtr(false),
+	}
+	chart := &Chart{
+		Type: Col, Series: series, Format: format,
+		Dimension: ChartDimension{Width: 400, Height: 300},
+		Legend:    ChartLegend{Position: "left", Font: &Font{Italic: true, Size: 11}},
+		Title: ChartTitle{
+			Paragraph: []RichTextRun{{Text: "Fruit", Font: &Font{Bold: true, Family: "Calibri", Underline: "sng"}}, {Text: "Sales"}},
+			Overlay:   true, OffsetX: 10, Width: 50,
+		},
+		XAxis: ChartAxis{
+			ReverseOrder: true, TickLabelSkip: 2, Font: Font{Size: 10, Strike: true},
+			Title: ChartTitle{Formula: "Sheet1!$A$1", Font: &Font{Color: "0000FF"}},
+		},
+		YAxis: ChartAxis{
+			MajorGridLines: true, MinorGridLines: true, MajorUnit: 2, Maximum: float64Ptr(10), Minimum: float64Ptr(-10),
+			TickLabelPosition: ChartTickLabelLow, NumFmt: ChartNumFmt{CustomNumFmt: "0.0"}, Alignment: Alignment{TextRotation: 45, Vertical: "vert"},
+		},
+		PlotArea: ChartPlotArea{
+			ShowCatName: true, ShowDataTable: true, ShowDataTableKeys: true, ShowVal: true,
+			Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"EEEEEE"}}, NumFmt: ChartNumFmt{CustomNumFmt: "0%"},
+		},
+		Fill:         Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFFCC"}},
+		Border:       LineOptions{Type: LineSolid, Dash: LineDashDot, Width: 2, Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"333333"}}},
+		ShowBlanksAs: "zero", GapWidth: uintPtr(80), Overlap: intPtr(-20), VaryColors: boolPtr(false),
+	}
+	combo := &Chart{
+		Type: Line, YAxis: ChartAxis{Secondary: true},
+		Series: []ChartSeries{{
+			Name: "Sheet1!$A$4", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$4:$D$4",
+			Fill:   Fill{Type: "pattern", Pattern: 1, Color: []string{"0000FF"}},
+			Line:   LineOptions{Type: LineSolid, Dash: LineDashDash, Smooth: true, Width: 1.5},
+			Marker: ChartMarker{Symbol: "square", Size: 7, Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"00FFFF"}}},
+		}},
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "F2", chart, combo))
+	assert.NoError(t, f.AddChart("Sheet1", "F30", &Chart{
+		Type: Doughnut, HoleSize: 50, Legend: ChartLegend{Position: "none"},
+		Series: []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2",
+			DataPoint: []ChartDataPoint{{Index: 1, Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"FF00FF"}}}},
+		}},
+	}))
+	assert.NoError(t, f.AddChart("Sheet1", "A20", &Chart{
+		Type: Bubble3D, BubbleSize: 75,
+		Series: []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2", Sizes: "Sheet1!$B$3:$D$3"}},
+	}))
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type: StockOpenHighLowClose, Series: series,
+		PlotArea: ChartPlotArea{
+			UpBars:   ChartUpDownBar{Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"00B050"}}},
+			DownBars: ChartUpDownBar{Border: LineOptions{Type: LineNone}},
+		},
+	}))
+
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 3)
+	assert.Equal(t, "F2", charts[0].Cell)
+	assert.Equal(t, "A20", charts[1].Cell)
+	assert.Equal(t, "F30", charts[2].Cell)
+	// Test get the format settings of the combo chart
+	assert.Equal(t, Col, charts[0].Type)
+	assert.Equal(t, format, charts[0].Format)
+	assert.Equal(t, chart.Dimension, charts[0].Dimension)
+	assert.Equal(t, series[0], charts[0].Series[0])
+	assert.Equal(t, series[1].Legend, charts[0].Series[1].Legend)
+	assert.Equal(t, ChartLegend{Position: "left", Font: &Font{Italic: true, Size: 11}}, charts[0].Legend)
+	chart.Title.Font = nil
+	assert.Equal(t, chart.Title, charts[0].Title)
+	assert.Equal(t, chart.XAxis.Title, charts[0].XAxis.Title)
+	assert.True(t, charts[0].XAxis.ReverseOrder)
+	assert.Equal(t, 2, charts[0].XAxis.TickLabelSkip)
+	assert.Equal(t, Font{Size: 10, Strike: true}, charts[0].XAxis.Font)
+	chart.YAxis.Font.Size, chart.YAxis.axID = 9, 0
+	assert.Equal(t, chart.YAxis, charts[0].YAxis)
+	assert.Equal(t, chart.PlotArea, charts[0].PlotArea)
+	assert.Equal(t, chart.Fill, charts[0].Fill)
+	assert.Equal(t, chart.Border, charts[0].Border)
+	assert.Equal(t, "zero", charts[0].ShowBlanksAs)
+	assert.Equal(t, uintPtr(80), charts[0].GapWidth)
+	assert.Equal(t, intPtr(-20), charts[0].Overlap)
+	assert.Equal(t, boolPtr(false), charts[0].VaryColors)
+	assert.Len(t, charts[0].Combo, 1)
+	assert.Equal(t, Line, charts[0].Combo[0].Type)
+	assert.True(t, charts[0].Combo[0].YAxis.Secondary)
+	assert.Equal(t, combo.Series[0].Name, charts[0].Combo[0].Series[0].Name)
+	assert.Equal(t, combo.Series[0].Fill, charts[0].Combo[0].Series[0].Fill)
+	assert.Equal(t, combo.Series[0].Line, charts[0].Combo[0].Series[0].Line)
+	assert.Equal(t, combo.Series[0].Marker, charts[0].Combo[0].Series[0].Marker)
+	// Test get the format settings of the bubble and doughnut chart
+	assert.Equal(t, Bubble3D, charts[1].Type)
+	assert.Equal(t, 75, charts[1].BubbleSize)
+	assert.Equal(t, "Sheet1!$B$1:$D$1", charts[1].Series[0].Categories)
+	assert.Equal(t, "Sheet1!$B$2:$D$2", charts[1].Series[0].Values)
+	assert.Equal(t, "Sheet1!$B$3:$D$3", charts[1].Series[0].Sizes)
+	assert.Equal(t, Doughnut, charts[2].Type)
+	assert.Equal(t, 50, charts[2].HoleSize)
+	assert.Equal(t, "none", charts[2].Legend.Position)
+	assert.Equal(t, []ChartDataPoint{{Index: 1, Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"FF00FF"}}}}, charts[2].Series[0].DataPoint)
+	// Test get the format settings of the chart on the chartsheet
+	charts, err = f.GetCharts("Chart1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 1)
+	assert.Empty(t, charts[0].Cell)
+	assert.Equal(t, StockOpenHighLowClose, charts[0].Type)
+	assert.Equal(t, ChartUpDownBar{Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"00B050"}}}, charts[0].PlotArea.UpBars)
+	assert.Equal(t, LineNone, charts[0].PlotArea.DownBars.Border.Type)
+
+	// Test get charts and add the charts to another worksheet
+	charts, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	for _, chart := range charts {
+		assert.NoError(t, f.AddChart("Sheet2", chart.Cell, &chart))
+	}
+	charts, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	copied, err := f.GetCharts("Sheet2")
+	assert.NoError(t, err)
+	for i := range charts {
+		charts[i].Format.Name, copied[i].Format.Name = "", ""
+	}
+	assert.Equal(t, charts, copied)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestGetCharts.xlsx")))
+
+	// Test get charts on the worksheet without chart
+	charts, err = f.GetCharts("Sheet3")
+	assert.EqualError(t, err, "sheet Sheet3 does not exist")
+	assert.Nil(t, charts)
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	charts, err = f.GetCharts("Sheet3")
+	assert.NoError(t, err)
+	assert.Empty(t, charts)
+	// Test get charts with invalid sheet name
+	_, err = f.GetCharts("Sheet:1")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test get charts with unsupported charset chart part
+	f.Pkg.Store("xl/charts/chart1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetCharts("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestSetChart(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{nil, "Apple", "Orange", "Pear"}, {"Small", 2, 3, 3}, {"Normal", 5, 2, 4}, {"Large", 6, 7, 8},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "F1", &Chart{
+		Type: Col,
+		Series: []ChartSeries{
+			{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+			{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"},
+		},
+		Title: ChartTitle{Paragraph: []RichTextRun{{Text: "Fruit"}}},
+	}, &Chart{
+		Type:   Line,
+		Series: []ChartSeries{{Name: "Sheet1!$A$4", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$4:$D$4"}},
+	}))
+	// Add the trendline which not supported by the chart format set into the
+	// chart part, it should be kept after updating the series
+	trendline := "<trendline><trendlineType val=\"linear\"></trendlineType></trendline>"
+	content := string(f.readXML("xl/charts/chart1.xml"))
+	f.Pkg.Store("xl/charts/chart1.xml", []byte(strings.Replace(content, "<cat>", trendline+"<cat>", 1)))
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 1)
+	chart := charts[0]
+	chart.Series[0].Name, chart.Series[0].Values = "Sheet1!$A$4", "Sheet1!$B$4:$D$4"
+	chart.Series[1].Categories = ""
+	chart.Combo[0].Series[0].Values = "Sheet1!$B$2:$D$2"
+	// Test the format settings out of the series should be ignored
+	chart.Type, chart.Title = Bar, ChartTitle{}
+	assert.NoError(t, f.SetChart("Sheet1", chart.Cell, &chart, chart.Combo...))
+	content = string(f.readXML("xl/charts/chart1.xml"))
+	assert.Contains(t, content, trendline)
+	assert.Contains(t, content, "<tx><strRef><f>Sheet1!$A$4</f><strCache><ptCount val=\"1\"/><pt idx=\"0\"><v>Large</v></pt></strCache></strRef></tx>")
+	assert.Contains(t, content, "<val><numRef><f>Sheet1!$B$4:$D$4</f><numCache><formatCode>General</formatCode><ptCount val=\"3\"/>"+
+		"<pt idx=\"0\"><v>6</v></pt><pt idx=\"1\"><v>7</v></pt><pt idx=\"2\"><v>8</v></pt></numCache></numRef></val>")
+	assert.Equal(t, 2, strings.Count(content, "<cat>"))
+	charts, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, Col, charts[0].Type)
+	assert.Equal(t, "Fruit", charts[0].Title.Paragraph[0].Text)
+	assert.Equal(t, []ChartSeries{
+		{Name: "Sheet1!$A$4", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$4:$D$4"},
+		{Name: "Sheet1!$A$3", Values: "Sheet1!$B$3:$D$3"},
+	}, []ChartSeries{
+		{Name: charts[0].Series[0].Name, Categories: charts[0].Series[0].Categories, Values: charts[0].Series[0].Values},
+		{Name: charts[0].Series[1].Name, Categories: charts[0].Series[1].Categories, Values: charts[0].Series[1].Values},
+	})
+	assert.Equal(t, "Sheet1!$B$2:$D$2", charts[0].Combo[0].Series[0].Values)
+	// Test add the categories into the series without them, and keep the
+	// literal name of the series
+	content = string(f.readXML("xl/charts/chart1.xml"))
+	f.Pkg.Store("xl/charts/chart1.xml", []byte(strings.Replace(content, "<tx><strRef><f>Sheet1!$A$3</f></strRef></tx>", "<tx><v>Literal Name</v></tx>", 1)))
+	charts, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	chart = charts[0]
+	assert.Empty(t, chart.Series[1].Name)
+	chart.Series[1].Categories = "Sheet1!$B$1:$D$1"
+	assert.NoError(t, f.SetChart("Sheet1", chart.Cell, &chart, chart.Combo...))
+	content = string(f.readXML("xl/charts/chart1.xml"))
+	assert.Contains(t, content, "<cat><strRef><f>Sheet1!$B$1:$D$1</f><strCache><ptCount val=\"3\"/><pt idx=\"0\"><v>Apple</v></pt>"+
+		"<pt idx=\"1\"><v>Orange</v></pt><pt idx=\"2\"><v>Pear</v></pt></strCache></strRef></cat><val><numRef><f>Sheet1!$B$3:$D$3</f>")
+	assert.Contains(t, content, "<tx><v>Literal Name</v></tx>")
+	// Test keep the literal name, categories and values of the series after
+	// changing the values only
+	strLit := "<cat><strLit><ptCount val=\"1\"/><pt idx=\"0\"><v>Literal</v></pt></strLit></cat>"
+	numLit := "<val><numLit><ptCount val=\"1\"/><pt idx=\"0\"><v>1</v></pt></numLit></val>"
+	start, end := strings.Index(content, "<val><numRef><f>Sheet1!$B$3:$D$3</f>"), strings.LastIndex(content, "<cat>")
+	content = content[:start] + numLit + content[start+strings.Index(content[start:], "</val>")+len("</val>"):end] +
+		strLit + content[strings.LastIndex(content, "</cat>")+len("</cat>"):]
+	f.Pkg.Store("xl/charts/chart1.xml", []byte(content))
+	charts, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	chart = charts[0]
+	assert.Empty(t, chart.Series[1].Values)
+	assert.Empty(t, chart.Combo[0].Series[0].Categories)
+	chart.Combo[0].Series[0].Values = "Sheet1!$B$3:$D$3"
+	assert.NoError(t, f.SetChart("Sheet1", chart.Cell, &chart, chart.Combo...))
+	content = string(f.readXML("xl/charts/chart1.xml"))
+	for _, element := range []string{"<tx><v>Literal Name</v></tx>", numLit, strLit, "<val><numRef><f>Sheet1!$B$3:$D$3</f>"} {
+		assert.Contains(t, content, element)
+	}
+	img, err := f.RenderChart("Sheet1", "F1", "svg")
+	assert.NoError(t, err)
+	assert.Contains(t, string(img), ">Large</text>")
+
+	// Test set chart on the chartsheet with bubble chart
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{
+		Type:   Bubble,
+		Series: []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}},
+	}))
+	charts, err = f.GetCharts("Chart1")
+	assert.NoError(t, err)
+	charts[0].Series[0].Values, charts[0].Series[0].Sizes = "Sheet1!$B$3:$D$3", "Sheet1!$B$4:$D$4"
+	assert.NoError(t, f.SetChart("Chart1", "", &charts[0]))
+	charts, err = f.GetCharts("Chart1")
+	assert.NoError(t, err)
+	assert.Equal(t, Bubble, charts[0].Type)
+	assert.Equal(t, "Sheet1!$B$3:$D$3", charts[0].Series[0].Values)
+	assert.Equal(t, "Sheet1!$B$4:$D$4", charts[0].Series[0].Sizes)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSetChart.xlsx")))
+
+	// Test set chart with the series not match the existing chart
+	chart.Series = chart.Series[:1]
+	assert.Equal(t, ErrChartSeriesMismatch, f.SetChart("Sheet1", "F1", &chart, chart.Combo...))
+	assert.Equal(t, ErrChartSeriesMismatch, f.SetChart("Sheet1", "F1", &chart))
+	// Test set chart with the series without adjacent element of the new name
+	content = string(f.readXML("xl/charts/chart2.xml"))
+	f.Pkg.Store("xl/charts/chart2.xml", []byte(strings.NewReplacer("<order val=\"0\"></order>", "", "<tx>", "<tx_>", "</tx>", "</tx_>").Replace(content)))
+	assert.Equal(t, ErrChartSeriesMismatch, f.SetChart("Chart1", "", &charts[0]))
+	// Test set chart on not exists chart
+	assert.Equal(t, newNoExistChartError("Sheet1!A1"), f.SetChart("Sheet1", "A1", &chart))
+	// Test set chart on not exists worksheet
+	assert.EqualError(t, f.SetChart("SheetN", "F1", &chart), "sheet SheetN does not exist")
+	// Test set chart with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.SetChart("Sheet1", "A", &chart))
+	// Test set chart with invalid chart options
+	assert.Equal(t, ErrParameterInvalid, f.SetChart("Sheet1", "F1", nil))
+	// Test set chart with unsupported charset chart part
+	f.Pkg.Store("xl/charts/chart1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.SetChart("Sheet1", "F1", &chart), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestReplaceChart(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{nil, "Apple", "Orange", "Pear"}, {"Small", 2, 3, 3}, {"Normal", 5, 2, 4}, {"Large", 6, 7, 8},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	assert.NoError(t, f.AddChart("Sheet1", "F1", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}},
+		Title:  ChartTitle{Paragraph: []RichTextRun{{Text: "Fruit"}}},
+	}))
+	charts, err := f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 1)
+	chart := charts[0]
+	chart.Series[0].Values = "Sheet1!$B$4:$D$4"
+	chart.Series = append(chart.Series, ChartSeries{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3"})
+	assert.NoError(t, f.ReplaceChart("Sheet1", chart.Cell, &chart, &Chart{
+		Type: Line, Series: []ChartSeries{{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"}},
+	}))
+	charts, err = f.GetCharts("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, charts, 1)
+	assert.Equal(t, "F1", charts[0].Cell)
+	assert.Equal(t, chart.Format, charts[0].Format)
+	assert.Equal(t, chart.Dimension, charts[0].Dimension)
+	assert.Len(t, charts[0].Series, 2)
+	assert.Equal(t, "Sheet1!$B$4:$D$4", charts[0].Series[0].Values)
+	assert.Equal(t, Line, charts[0].Combo[0].Type)
+	img, err := f.RenderChart("Sheet1", "F1", "svg")
+	assert.NoError(t, err)
+	assert.Contains(t, string(img), ">Normal</text>")
+	assert.Equal(t, 1, f.countCharts())
+	// Test replace chart on the chartsheet
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{Type: Pie, Series: chart.Series[:1]}))
+	assert.NoError(t, f.ReplaceChart("Chart1", "", &Chart{Type: Doughnut, Series: chart.Series[1:]}))
+	charts, err = f.GetCharts("Chart1")
+	assert.NoError(t, err)
+	assert.Equal(t, Doughnut, charts[0].Type)
+	assert.Equal(t, chart.Series[1].Values, charts[0].Series[0].Values)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestReplaceChart.xlsx")))
+
+	// Test replace chart on not exists chart
+	assert.Equal(t, newNoExistChartError("Sheet1!A1"), f.ReplaceChart("Sheet1", "A1", &chart))
+	// Test replace chart on not exists worksheet
+	assert.EqualError(t, f.ReplaceChart("SheetN", "F1", &chart), "sheet SheetN does not exist")
+	// Test replace chart with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.ReplaceChart("Sheet1", "A", &chart))
+	// Test replace chart with invalid chart options
+	assert.Equal(t, ErrParameterInvalid, f.ReplaceChart("Sheet1", "F1", nil))
+	assert.Equal(t, newUnsupportedChartType(0xFF), f.ReplaceChart("Sheet1", "F1", &Chart{Type: 0xFF}))
+	assert.NoError(t, f.Close())
+}
+
 func TestChartWithLogarithmicBase(t *testing.T) {
 	// Create test workbook with data
 	f := NewFile()
@@ -0,0 +1,2386 @@
+// Copyright 2016 - 2026 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.25.0 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+const (
+	// chartPixelsPerPoint defined the number of screen pixels per point.
+	chartPixelsPerPoint = 96.0 / 72.0
+	// chartPadding defined the padding in pixels between the border of the
+	// chart and the chart elements.
+	chartPadding = 8.0
+	// chartTextColor defined the default color of the chart text.
+	chartTextColor = "595959"
+	// chartLineColor defined the default color of the axis lines and
+	// gridlines.
+	chartLineColor = "D9D9D9"
+)
+
+var (
+	// chartSchemeColors defined the theme color index of the scheme colors
+	// used in the chart part.
+	chartSchemeColors = map[string]int{
+		"lt1": 0, "bg1": 0, "dk1": 1, "tx1": 1, "lt2": 2, "bg2": 2, "dk2": 3, "tx2": 3,
+		"accent1": 4, "accent2": 5, "accent3": 6, "accent4": 7, "accent5": 8, "accent6": 9,
+	}
+	// chartDefaultThemeColors defined the colors of the default Office theme,
+	// which will be used if the workbook doesn't have a theme part.
+	chartDefaultThemeColors = [10]string{
+		"FFFFFF", "000000", "E7E6E6", "44546A", "4472C4", "ED7D31", "A5A5A5", "FFC000", "5B9BD5", "70AD47",
+	}
+	// chartAutoMarkers defined the marker symbols used for the series with
+	// automatic markers in order.
+	chartAutoMarkers = []string{"diamond", "square", "triangle", "x", "star", "circle", "plus", "dash"}
+	// chartTrueTypeFonts defined the TrueType fonts used for rendering the
+	// text of the chart as PNG image.
+	chartTrueTypeFonts struct {
+		once          sync.Once
+		regular, bold *opentype.Font
+	}
+)
+
+// chartCanvas defines the drawing operations used for rendering the chart,
+// the coordinates are in pixels from the top-left corner of the chart. The
+// text will be drawn from the given position of the baseline, the anchor
+// specifies the horizontal alignment of the text with one of "start",
+// "middle" and "end", and the text will be rotated 90 degrees
+// counterclockwise around the position if the rotate is true.
+type chartCanvas interface {
+	fill(points [][2]float64, color string)
+	stroke(points [][2]float64, width float64, color string, dash bool)
+	text(x, y float64, text string, font *chartFont, anchor string, rotate bool)
+	measure(text string, font *chartFont) float64
+}
+
+// chartAnchor directly maps the chart anchored on the worksheet, the column
+// and row numbers of the anchor cell and the bottom-right cell are 1-based,
+// the offsets and size are in pixels.
+type chartAnchor struct {
+	col, row         int
+	endCol, endRow   int
+	offsetX, offsetY float64
+	width, height    float64
+	path             string
+	format           GraphicOptions
+}
+
+// chartFont directly maps the font settings of the chart text, the font size
+// is in pixels.
+type chartFont struct {
+	size  float64
+	bold  bool
+	color string
+}
+
+// chartRect directly maps the rectangle area of the chart.
+type chartRect struct {
+	x, y, w, h float64
+}
+
+// chartValue directly maps the value of the chart data, the number will be
+// NaN if the value is not numeric.
+type chartValue struct {
+	text   string
+	number float64
+}
+
+// chartAxis directly maps the axis of the chart and the computed scale and
+// position of the axis.
+type chartAxis struct {
+	id             int
+	kind           string
+	deleted        bool
+	noLabels       bool
+	reverse        bool
+	midCat         bool
+	between        bool
+	vertical       bool
+	side           string
+	fixedMin       *float64
+	fixedMax       *float64
+	majorUnit      float64
+	tickSkip       int
+	numFmt         string
+	title          *decodeChartTitle
+	gridlines      string
+	line           string
+	font           *chartFont
+	percent        bool
+	hasData        bool
+	dataMin        float64
+	dataMax        float64
+	min, max, step float64
+	start, end     float64
+	count          int
+	labels         []string
+}
+
+// chartSeries directly maps the series of the chart with the values read
+// from the cells and the resolved formats.
+type chartSeries struct {
+	idx        int
+	name       string
+	categories []string
+	x          []float64
+	values     []chartValue
+	fill       string
+	line       string
+	lineWidth  float64
+	dash       bool
+	marker     string
+	markerSize float64
+	markerFill string
+	markerLine string
+	smooth     bool
+	points     map[int]string
+	dLbls      *decodeChartDLbls
+}
+
+// chartGroup directly maps the chart group in the plot area, such as the bar
+// chart and line chart of a combo chart.
+type chartGroup struct {
+	kind       string
+	horizontal bool
+	grouping   string
+	style      string
+	varyColors bool
+	gapWidth   float64
+	overlap    float64
+	holeSize   float64
+	firstAngle float64
+	catAx      *chartAxis
+	valAx      *chartAxis
+	series     []*chartSeries
+}
+
+// chartLabel directly maps the data label to be rendered on the plot area,
+// the position is the center of the label.
+type chartLabel struct {
+	x, y float64
+	text string
+	font *chartFont
+}
+
+// chartLegendEntry directly maps the entry of the chart legend.
+type chartLegendEntry struct {
+	text       string
+	fill       string
+	line       string
+	lineWidth  float64
+	marker     string
+	markerFill string
+	markerLine string
+	lineKind   bool
+}
+
+// chartRender directly maps the chart part and the settings used for
+// rendering the chart.
+type chartRender struct {
+	f             *File
+	cs            *decodeChartSpace
+	canvas        chartCanvas
+	width, height float64
+	fontSize      float64
+	textColor     string
+	blanksAs      string
+	axes          map[int]*chartAxis
+	groups        []*chartGroup
+	labels        []chartLabel
+}
+
+// chartSVGCanvas directly maps the SVG document of the chart.
+type chartSVGCanvas struct {
+	buf bytes.Buffer
+}
+
+// chartPNGCanvas directly maps the raster image of the chart.
+type chartPNGCanvas struct {
+	img   *image.RGBA
+	z     *vector.Rasterizer
+	faces map[chartFont]font.Face
+}
+
+// RenderChart provides a function to render the chart as image by given
+// worksheet name, cell reference of the top-left corner of the chart and
+// image format, returns the image data. The supported image formats are "svg"
+// and "png". The series values, categories and names will be read from the
+// cells referenced by the chart, and the cached values in the chart part will
+// be used if the references can't be resolved. The area, bar, column,
+// doughnut, line, pie, radar and scatter charts, including the stacked,
+// percent stacked and combo charts, will be rendered with the titles, axes,
+// gridlines, legends and data labels. The 3D charts will be rendered as 2D
+// charts, and the plot of other chart types will be omitted. For the
+// chartsheet, the cell reference will be ignored. For example, render the
+// chart anchored on the cell E1 of the worksheet named Sheet1 as PNG image:
+//
+//	img, err := f.RenderChart("Sheet1", "E1", "png")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	if err := os.WriteFile("chart.png", img, 0o644); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) RenderChart(sheet, cell, format string) ([]byte, error) {
+	format = strings.ToLower(strings.TrimPrefix(format, "."))
+	if format != "svg" && format != "png" {
+		return nil, ErrChartImageFormat
+	}
+	chart, err := f.getCellChart(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	if format == "png" {
+		canvas := newChartPNGCanvas(chart.width, chart.height)
+		if err = f.renderChart(canvas, chart); err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		err = png.Encode(&buf, canvas.img)
+		return buf.Bytes(), err
+	}
+	canvas := &chartSVGCanvas{}
+	fmt.Fprintf(&canvas.buf, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%s\" height=\"%s\" viewBox=\"0 0 %s %s\" font-family=\"Arial, Helvetica, sans-serif\">",
+		pdfNumber(chart.width), pdfNumber(chart.height), pdfNumber(chart.width), pdfNumber(chart.height))
+	if err = f.renderChart(canvas, chart); err != nil {
+		return nil, err
+	}
+	canvas.buf.WriteString("</svg>")
+	return canvas.buf.Bytes(), err
+}
+
+// getCellChart provides a function to get the chart anchored on the cell of
+// the worksheet by given sheet name and cell reference. For the chartsheet,
+// the cell reference will be ignored.
+func (f *File) getCellChart(sheet, cell string) (chartAnchor, error) {
+	charts, err := f.getSheetCharts(sheet)
+	if err != nil {
+		return chartAnchor{}, err
+	}
+	name := sheet
+	if path, _ := f.getSheetXMLPath(sheet); !strings.HasPrefix(path, "xl/chartsheets") {
+		col, row, err := CellNameToCoordinates(cell)
+		if err != nil {
+			return chartAnchor{}, err
+		}
+		charts = slices.DeleteFunc(charts, func(chart chartAnchor) bool {
+			return chart.col != col || chart.row != row
+		})
+		name += "!" + cell
+	}
+	if len(charts) == 0 {
+		return chartAnchor{}, newNoExistChartError(name)
+	}
+	return charts[0], err
+}
+
+// getSheetCharts provides a function to get the charts anchored on the
+// worksheet or chartsheet by given sheet name, the charts will be sorted by
+// the anchor cells. The chart of the chartsheet will be anchored on the first
+// cell with the default size of the chartsheet.
+func (f *File) getSheetCharts(sheet string) ([]chartAnchor, error) {
+	if err := checkSheetName(sheet); err != nil {
+		return nil, err
+	}
+	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return nil, ErrSheetNotExist{sheet}
+	}
+	if strings.HasPrefix(sheetXMLPath, "xl/chartsheets") {
+		return f.getChartSheetCharts(sheetXMLPath), nil
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil || ws.Drawing == nil {
+		return nil, err
+	}
+	target := f.getSheetRelationshipsTargetByID(sheet, ws.Drawing.RID)
+	drawingXML := strings.TrimPrefix(strings.ReplaceAll(target, "..", "xl"), "/")
+	drawingRelationships := strings.ReplaceAll(
+		strings.ReplaceAll(drawingXML, "xl/drawings", "xl/drawings/_rels"), ".xml", ".xml.rels")
+	wsDr, _, err := f.drawingParser(drawingXML)
+	if err != nil {
+		return nil, err
+	}
+	wsDr.mu.Lock()
+	defer wsDr.mu.Unlock()
+	var charts []chartAnchor
+	for _, anchor := range slices.Concat(wsDr.TwoCellAnchor, wsDr.OneCellAnchor) {
+		deCellAnchor := new(decodeCellAnchor)
+		if err = f.xmlNewDecoder(strings.NewReader("<decodeCellAnchor>" + anchor.GraphicFrame + "</decodeCellAnchor>")).
+			Decode(deCellAnchor); err != nil && err != io.EOF {
+			return nil, err
+		}
+		if anchor.From != nil {
+			from := decodeFrom(*anchor.From)
+			deCellAnchor.From = &from
+		}
+		if anchor.To != nil {
+			to := decodeTo(*anchor.To)
+			deCellAnchor.To = &to
+		}
+		if anchor.Ext != nil {
+			deCellAnchor.Ext = &decodePositiveSize2D{Cx: anchor.Ext.Cx, Cy: anchor.Ext.Cy}
+		}
+		frame := deCellAnchor.GraphicFrame
+		if deCellAnchor.From == nil || frame == nil || frame.Graphic == nil || frame.Graphic.GraphicData.Chart == nil {
+			continue
+		}
+		drawRel := f.getDrawingRelationships(drawingRelationships, frame.Graphic.GraphicData.Chart.RID)
+		if drawRel == nil {
+			continue
+		}
+		from := deCellAnchor.From
+		chart := chartAnchor{
+			col: from.Col + 1, row: from.Row + 1,
+			offsetX: float64(from.ColOff) / EMU, offsetY: float64(from.RowOff) / EMU,
+			path: strings.TrimPrefix(strings.ReplaceAll(drawRel.Target, "..", "xl"), "/"),
+			format: GraphicOptions{
+				AltText:     frame.NvGraphicFramePr.CNvPr.Descr,
+				Name:        frame.NvGraphicFramePr.CNvPr.Name,
+				OffsetX:     from.ColOff / EMU,
+				OffsetY:     from.RowOff / EMU,
+				Positioning: anchor.EditAs,
+				ScaleX:      defaultDrawingScale,
+				ScaleY:      defaultDrawingScale,
+			},
+		}
+		if anchor.ClientData != nil {
+			chart.format.Locked = boolPtr(anchor.ClientData.FLocksWithSheet)
+			chart.format.PrintObject = boolPtr(anchor.ClientData.FPrintsWithSheet)
+		}
+		if to := deCellAnchor.To; to != nil {
+			chart.endCol, chart.endRow = to.Col+1, to.Row+1
+			for col := from.Col; col < to.Col; col++ {
+				chart.width += float64(f.getColWidth(sheet, col+1))
+			}
+			for row := from.Row; row < to.Row; row++ {
+				chart.height += float64(f.getRowHeight(sheet, row+1))
+			}
+			chart.width += float64(to.ColOff)/EMU - chart.offsetX
+			chart.height += float64(to.RowOff)/EMU - chart.offsetY
+		} else if deCellAnchor.Ext != nil {
+			chart.width, chart.height = float64(deCellAnchor.Ext.Cx)/EMU, float64(deCellAnchor.Ext.Cy)/EMU
+			chart.endCol, chart.endRow = chart.col, chart.row
+			for width := chart.offsetX + chart.width; chart.endCol < MaxColumns; chart.endCol++ {
+				if width -= float64(f.getColWidth(sheet, chart.endCol)); width <= 0 {
+					break
+				}
+			}
+			for height := chart.offsetY + chart.height; chart.endRow < TotalRows; chart.endRow++ {
+				if height -= float64(f.getRowHeight(sheet, chart.endRow)); height <= 0 {
+					break
+				}
+			}
+		}
+		if chart.width > 0 && chart.height > 0 {
+			charts = append(charts, chart)
+		}
+	}
+	slices.SortStableFunc(charts, func(a, b chartAnchor) int {
+		return cmp.Or(a.row-b.row, a.col-b.col)
+	})
+	return charts, nil
+}
+
+// getChartSheetCharts provides a function to get the chart of the chartsheet
+// by given chartsheet part path.
+func (f *File) getChartSheetCharts(sheetXMLPath string) []chartAnchor {
+	sheetRels := "xl/chartsheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/chartsheets/") + ".rels"
+	getTarget := func(rels, relType string) string {
+		if relationships, _ := f.relsReader(rels); relationships != nil {
+			relationships.mu.Lock()
+			defer relationships.mu.Unlock()
+			for _, rel := range relationships.Relationships {
+				if rel.Type == relType {
+					return strings.TrimPrefix(strings.ReplaceAll(rel.Target, "..", "xl"), "/")
+				}
+			}
+		}
+		return ""
+	}
+	drawingXML := getTarget(sheetRels, SourceRelationshipDrawingML)
+	if drawingXML == "" {
+		return nil
+	}
+	drawingRelationships := strings.ReplaceAll(
+		strings.ReplaceAll(drawingXML, "xl/drawings", "xl/drawings/_rels"), ".xml", ".xml.rels")
+	if path := getTarget(drawingRelationships, SourceRelationshipChart); path != "" {
+		return []chartAnchor{{
+			col: 1, row: 1, endCol: 1, endRow: 1, width: 9280533.0 / EMU, height: 6051719.0 / EMU, path: path,
+			format: GraphicOptions{ScaleX: defaultDrawingScale, ScaleY: defaultDrawingScale},
+		}}
+	}
+	return nil
+}
+
+// renderChart provides a function to render the chart on the canvas by given
+// chart anchor.
+func (f *File) renderChart(canvas chartCanvas, chart chartAnchor) error {
+	cs := new(decodeChartSpace)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(chart.path)))).
+		Decode(cs); err != nil && err != io.EOF {
+		return err
+	}
+	cr := &chartRender{
+		f: f, cs: cs, canvas: canvas, width: chart.width, height: chart.height,
+		fontSize: 10 * chartPixelsPerPoint, textColor: chartTextColor, axes: make(map[int]*chartAxis),
+	}
+	cr.prepare()
+	cr.render()
+	return nil
+}
+
+// getChartData provides a function to get the values of the chart data by
+// given chart data source. The values will be read from the cells referenced
+// by the formula, and the cached values will be used if the reference can't
+// be resolved.
+func (f *File) getChartData(data *decodeChartData) []chartValue {
+	if data == nil {
+		return nil
+	}
+	var (
+		formula string
+		pts     []*cPt
+		count   *attrValInt
+	)
+	switch {
+	case data.NumRef != nil:
+		if formula = data.NumRef.F; data.NumRef.NumCache != nil {
+			pts, count = data.NumRef.NumCache.Pt, data.NumRef.NumCache.PtCount
+		}
+	case data.StrRef != nil:
+		if formula = data.StrRef.F; data.StrRef.StrCache != nil {
+			pts, count = data.StrRef.StrCache.Pt, data.StrRef.StrCache.PtCount
+		}
+	case data.NumLit != nil:
+		pts, count = data.NumLit.Pt, data.NumLit.PtCount
+	case data.StrLit != nil:
+		pts, count = data.StrLit.Pt, data.StrLit.PtCount
+	}
+	if values, ok := f.getChartRefValues(formula); ok {
+		return values
+	}
+	size := 0
+	if count != nil && count.Val != nil {
+		size = *count.Val
+	}
+	for _, pt := range pts {
+		size = max(size, pt.IDx+1)
+	}
+	values := make([]chartValue, size)
+	for i := range values {
+		values[i].number = math.NaN()
+	}
+	for _, pt := range pts {
+		if pt.IDx >= 0 && pt.V != nil {
+			values[pt.IDx] = chartValue{text: *pt.V, number: parseChartNumber(*pt.V)}
+		}
+	}
+	return values
+}
+
+// getChartRefValues provides a function to read the values of the cells by
+// given reference formula of the chart data, such as Sheet1!$A$1:$A$5. The
+// range will be clamped to the used range of the worksheet. Returns false if
+// the reference can't be resolved.
+func (f *File) getChartRefValues(formula string) ([]chartValue, bool) {
+	formula = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(formula, "="), "("), ")")
+	if formula == "" {
+		return nil, false
+	}
+	var values []chartValue
+	sizes := make(map[string][2]int)
+	for _, ref := range splitRefs(formula) {
+		idx := strings.LastIndex(ref, "!")
+		if idx == -1 {
+			return nil, false
+		}
+		sheet := strings.ReplaceAll(strings.TrimSuffix(strings.TrimPrefix(ref[:idx], "'"), "'"), "''", "'")
+		cells := strings.ReplaceAll(ref[idx+1:], "$", "")
+		if !strings.Contains(cells, ":") {
+			cells += ":" + cells
+		}
+		coordinates, err := rangeRefToCoordinates(cells)
+		if err != nil {
+			return nil, false
+		}
+		_ = sortCoordinates(coordinates)
+		size, ok := sizes[sheet]
+		if !ok {
+			if size[0], size[1], err = f.getUsedSize(sheet); err != nil {
+				return nil, false
+			}
+			sizes[sheet] = size
+		}
+		for row := coordinates[1]; row <= min(coordinates[3], size[1]); row++ {
+			for col := coordinates[0]; col <= min(coordinates[2], size[0]); col++ {
+				cell, _ := CoordinatesToCellName(col, row)
+				var raw string
+				text, err := f.getCellStringFunc(sheet, cell, func(x *xlsxWorksheet, c *xlsxC) (string, bool, error) {
+					sst, err := f.sharedStringsReader()
+					if err != nil {
+						return "", true, err
+					}
+					if raw, err = c.getValueFrom(f, sst, true); err != nil {
+						return "", true, err
+					}
+					val, err := c.getValueFrom(f, sst, false)
+					return val, true, err
+				})
+				if err != nil {
+					return nil, false
+				}
+				values = append(values, chartValue{text: text, number: parseChartNumber(raw)})
+			}
+		}
+	}
+	return values, true
+}
+
+// getUsedSize provides a function to get the maximum column and row number
+// of the cells in the worksheet by given worksheet name.
+func (f *File) getUsedSize(sheet string) (int, int, error) {
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return 0, 0, err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	var maxCol, maxRow int
+	for _, row := range ws.SheetData.Row {
+		maxRow = max(maxRow, row.R)
+		for _, c := range row.C {
+			if col, _, err := CellNameToCoordinates(c.R); err == nil {
+				maxCol = max(maxCol, col)
+			}
+		}
+	}
+	return maxCol, maxRow, err
+}
+
+// parseChartNumber provides a function to parse the numeric value of the
+// chart data, returns NaN if the value is not numeric.
+func parseChartNumber(value string) float64 {
+	if num, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && value != "" {
+		return num
+	}
+	return math.NaN()
+}
+
+// formatChartNumber provides a function to format the number of the chart by
+// given number format code.
+func formatChartNumber(num float64, code string) string {
+	num, _ = strconv.ParseFloat(strconv.FormatFloat(num, 'g', 12, 64), 64)
+	value := strconv.FormatFloat(num, 'f', -1, 64)
+	if code == "" || strings.EqualFold(code, "General") {
+		return value
+	}
+	return format(value, code, false, CellTypeNumber, nil)
+}
+
+// chartBool provides a function to get the boolean value of the element by
+// given default value if the element doesn't exist.
+func chartBool(val *attrValBool, defaultVal bool) bool {
+	if val == nil || val.Val == nil {
+		return defaultVal
+	}
+	return *val.Val
+}
+
+// chartString provides a function to get the string value of the element by
+// given default value if the element doesn't exist.
+func chartString(val *attrValString, defaultVal string) string {
+	if val == nil || val.Val == nil {
+		return defaultVal
+	}
+	return *val.Val
+}
+
+// chartInt provides a function to get the integer value of the element by
+// given default value if the element doesn't exist.
+func chartInt(val *attrValInt, defaultVal int) int {
+	if val == nil || val.Val == nil {
+		return defaultVal
+	}
+	return *val.Val
+}
+
+// getColor provides a function to get the RGB hex color by given solid fill
+// of the chart element, returns empty string if the color can't be resolved.
+func (cr *chartRender) getColor(fill *decodeChartSolidFill) string {
+	if fill == nil {
+		return ""
+	}
+	var (
+		clr  *decodeChartColor
+		base string
+	)
+	switch {
+	case fill.SrgbClr != nil:
+		clr, base = fill.SrgbClr, strings.ToUpper(fill.SrgbClr.Val)
+	case fill.SchemeClr != nil:
+		clr = fill.SchemeClr
+		idx, ok := chartSchemeColors[clr.Val]
+		if !ok {
+			return ""
+		}
+		if base = cr.f.getThemeColor(&xlsxColor{Theme: intPtr(idx)}); base == "" {
+			base = chartDefaultThemeColors[idx]
+		}
+	default:
+		return ""
+	}
+	rgb, err := strconv.ParseUint(base, 16, 32)
+	if err != nil || len(base) != 6 {
+		return ""
+	}
+	if clr.LumMod == nil && clr.LumOff == nil {
+		return base
+	}
+	h, s, l := RGBToHSL(uint8(rgb>>16), uint8(rgb>>8), uint8(rgb))
+	l = l*float64(chartInt(clr.LumMod, 100000))/100000 + float64(chartInt(clr.LumOff, 0))/100000
+	r, g, b := HSLToRGB(h, s, math.Min(math.Max(l, 0), 1))
+	return fmt.Sprintf("%02X%02X%02X", r, g, b)
+}
+
+// getAutoColor provides a function to get the automatic color of the series
+// or data point by given index, the accent colors of the theme will be used
+// in order, and be darkened or lightened for the index more than six.
+func (cr *chartRender) getAutoColor(idx int) string {
+	clr := &decodeChartColor{Val: "accent" + strconv.Itoa(idx%6+1)}
+	switch idx / 6 % 3 {
+	case 1:
+		clr.LumMod = &attrValInt{Val: intPtr(60000)}
+	case 2:
+		clr.LumMod, clr.LumOff = &attrValInt{Val: intPtr(80000)}, &attrValInt{Val: intPtr(20000)}
+	}
+	return cr.getColor(&decodeChartSolidFill{SchemeClr: clr})
+}
+
+// getLineColor provides a function to get the outline color by given shape
+// properties and default color, returns empty string if the outline is
+// disabled.
+func (cr *chartRender) getLineColor(spPr *decodeChartSpPr, defaultColor string) string {
+	if spPr == nil || spPr.Ln == nil {
+		return defaultColor
+	}
+	if spPr.Ln.NoFill != nil {
+		return ""
+	}
+	if clr := cr.getColor(spPr.Ln.SolidFill); clr != "" {
+		return clr
+	}
+	return defaultColor
+}
+
+// getFont provides a function to get the font of the chart text by given
+// text properties, scale of the default font size, default color and bold.
+func (cr *chartRender) getFont(text *decodeChartText, scale float64, color string, bold bool) *chartFont {
+	font := &chartFont{size: cr.fontSize * scale, bold: bold, color: color}
+	var rPr *decodeChartRPr
+	if text != nil && len(text.P) > 0 {
+		if p := text.P[0]; p.PPr != nil && p.PPr.DefRPr != nil {
+			rPr = p.PPr.DefRPr
+		}
+		for _, r := range text.P[0].R {
+			if r.RPr != nil {
+				rPr = r.RPr
+				break
+			}
+		}
+	}
+	if rPr != nil {
+		if rPr.Sz > 0 {
+			font.size = rPr.Sz / 100 * chartPixelsPerPoint
+		}
+		if rPr.B != nil {
+			font.bold = *rPr.B
+		}
+		if clr := cr.getColor(rPr.SolidFill); clr != "" {
+			font.color = clr
+		}
+	}
+	return font
+}
+
+// getText provides a function to get the text of the chart title or series
+// name by given chart text.
+func (cr *chartRender) getText(tx *decodeChartTx) string {
+	if tx == nil {
+		return ""
+	}
+	if tx.Rich != nil {
+		var lines []string
+		for _, p := range tx.Rich.P {
+			var line strings.Builder
+			for _, r := range p.R {
+				line.WriteString(r.T)
+			}
+			lines = append(lines, line.String())
+		}
+		return strings.Join(lines, "\n")
+	}
+	if tx.StrRef != nil {
+		var texts []string
+		for _, value := range cr.f.getChartData(&decodeChartData{StrRef: tx.StrRef}) {
+			if value.text != "" {
+				texts = append(texts, value.text)
+			}
+		}
+		if len(texts) == 0 && !strings.Contains(tx.StrRef.F, "!") {
+			return strings.Trim(tx.StrRef.F, "\"")
+		}
+		return strings.Join(texts, " ")
+	}
+	return tx.V
+}
+
+// prepare provides a function to read the text properties, axes, chart
+// groups and series of the chart part.
+func (cr *chartRender) prepare() {
+	if cr.cs.TxPr != nil {
+		font := cr.getFont(cr.cs.TxPr, 1, chartTextColor, false)
+		cr.fontSize, cr.textColor = font.size, font.color
+	}
+	cr.blanksAs = chartString(cr.cs.Chart.DispBlanksAs, "gap")
+	pa := cr.cs.Chart.PlotArea
+	if pa == nil {
+		return
+	}
+	for kind, axes := range map[string][]*decodeChartAxis{"cat": pa.CatAx, "date": pa.DateAx, "val": pa.ValAx, "ser": pa.SerAx} {
+		for _, ax := range axes {
+			axis := cr.newAxis(ax, kind)
+			cr.axes[axis.id] = axis
+		}
+	}
+	for _, item := range []struct {
+		kind   string
+		groups []*decodeChartGroup
+	}{
+		{"area", pa.AreaChart}, {"area", pa.Area3DChart}, {"bar", pa.BarChart}, {"bar", pa.Bar3DChart},
+		{"pie", pa.PieChart}, {"pie", pa.Pie3DChart}, {"pie", pa.OfPieChart}, {"doughnut", pa.DoughnutChart},
+		{"radar", pa.RadarChart}, {"line", pa.LineChart}, {"line", pa.Line3DChart}, {"line", pa.StockChart},
+		{"scatter", pa.ScatterChart}, {"scatter", pa.BubbleChart},
+	} {
+		for _, g := range item.groups {
+			cr.addGroup(item.kind, g)
+		}
+	}
+}
+
+// newAxis provides a function to create the chart axis by given axis element
+// and axis type.
+func (cr *chartRender) newAxis(ax *decodeChartAxis, kind string) *chartAxis {
+	axis := &chartAxis{kind: kind, numFmt: "General", font: cr.getFont(nil, 0.9, cr.textColor, false)}
+	if kind != "val" {
+		axis.line = chartLineColor
+	}
+	if ax == nil {
+		return axis
+	}
+	axis.id = chartInt(ax.AxID, 0)
+	axis.deleted = chartBool(ax.Delete, false)
+	axis.noLabels = chartString(ax.TickLblPos, "nextTo") == "none"
+	if ax.Scaling != nil {
+		axis.reverse = chartString(ax.Scaling.Orientation, "minMax") == "maxMin"
+		if ax.Scaling.Min != nil {
+			axis.fixedMin = ax.Scaling.Min.Val
+		}
+		if ax.Scaling.Max != nil {
+			axis.fixedMax = ax.Scaling.Max.Val
+		}
+	}
+	if ax.MajorUnit != nil && ax.MajorUnit.Val != nil {
+		axis.majorUnit = *ax.MajorUnit.Val
+	}
+	if ax.NumFmt != nil && ax.NumFmt.FormatCode != "" && !ax.NumFmt.SourceLinked {
+		axis.numFmt = ax.NumFmt.FormatCode
+	}
+	if ax.MajorGridlines != nil {
+		axis.gridlines = cr.getLineColor(ax.MajorGridlines.SpPr, chartLineColor)
+	}
+	axis.midCat = chartString(ax.CrossBetween, "between") == "midCat"
+	axis.tickSkip = chartInt(ax.TickLblSkip, 0)
+	axis.title = ax.Title
+	axis.line = cr.getLineColor(ax.SpPr, axis.line)
+	axis.font = cr.getFont(ax.TxPr, 0.9, cr.textColor, false)
+	return axis
+}
+
+// addGroup provides a function to add the chart group by given chart type
+// and chart group element.
+func (cr *chartRender) addGroup(kind string, g *decodeChartGroup) {
+	group := &chartGroup{
+		kind: kind, grouping: chartString(g.Grouping, "standard"), varyColors: chartBool(g.VaryColors, false),
+		gapWidth: float64(chartInt(g.GapWidth, 150)), holeSize: float64(chartInt(g.HoleSize, 50)),
+		firstAngle: float64(chartInt(g.FirstSliceAng, 0)),
+		style:      chartString(g.ScatterStyle, chartString(g.RadarStyle, "marker")),
+	}
+	if kind == "bar" {
+		group.horizontal = chartString(g.BarDir, "col") == "bar"
+		group.grouping = chartString(g.Grouping, "clustered")
+	}
+	if group.isStacked() {
+		group.overlap = 100
+	}
+	group.overlap = float64(chartInt(g.Overlap, int(group.overlap)))
+	if kind != "pie" && kind != "doughnut" {
+		if len(g.AxID) > 1 {
+			group.catAx, group.valAx = cr.axes[chartInt(g.AxID[0], 0)], cr.axes[chartInt(g.AxID[1], 0)]
+		}
+		if group.catAx == nil {
+			group.catAx = cr.newAxis(nil, "cat")
+		}
+		if group.valAx == nil {
+			group.valAx = cr.newAxis(nil, "val")
+		}
+	}
+	for i, ser := range g.Ser {
+		group.series = append(group.series, cr.prepareSeries(group, i, ser, g.DLbls))
+	}
+	cr.groups = append(cr.groups, group)
+}
+
+// isStacked provides a function to check if the chart group is stacked or
+// percent stacked.
+func (g *chartGroup) isStacked() bool {
+	return g.grouping == "stacked" || g.grouping == "percentStacked"
+}
+
+// isLine provides a function to check if the series of the chart group are
+// rendered as lines.
+func (g *chartGroup) isLine() bool {
+	return g.kind == "line" || g.kind == "scatter" || g.kind == "radar" && g.style != "filled"
+}
+
+// varyPoints provides a function to check if the data points of the chart
+// group will be rendered in different colors.
+func (g *chartGroup) varyPoints() bool {
+	return g.varyColors && (g.kind == "pie" || g.kind == "doughnut" || g.kind == "bar" && len(g.series) == 1)
+}
+
+// pointColor provides a function to get the fill color of the data point by
+// given chart series and index of the data point.
+func (cr *chartRender) pointColor(g *chartGroup, s *chartSeries, i int) string {
+	if clr, ok := s.points[i]; ok {
+		return clr
+	}
+	if g.varyPoints() {
+		return cr.getAutoColor(i)
+	}
+	return s.fill
+}
+
+// prepareSeries provides a function to read the values and formats of the
+// series by given chart group, series index, series element and data labels
+// of the chart group.
+func (cr *chartRender) prepareSeries(g *chartGroup, i int, ser *decodeChartSeries, dLbls *decodeChartDLbls) *chartSeries {
+	s := &chartSeries{idx: chartInt(ser.IDx, i), points: make(map[int]string), dLbls: dLbls, lineWidth: 1}
+	if s.name = cr.getText(ser.Tx); s.name == "" {
+		s.name = "Series" + strconv.Itoa(s.idx+1)
+	}
+	values, categories := ser.Val, ser.Cat
+	if g.kind == "scatter" {
+		if ser.YVal != nil {
+			values = ser.YVal
+		}
+		if ser.XVal != nil {
+			categories = ser.XVal
+		}
+	}
+	s.values = cr.f.getChartData(values)
+	for _, value := range cr.f.getChartData(categories) {
+		s.categories = append(s.categories, value.text)
+		s.x = append(s.x, value.number)
+	}
+	if slices.ContainsFunc(s.x, math.IsNaN) || len(s.x) == 0 {
+		s.x = s.x[:0]
+		for idx := range s.values {
+			s.x = append(s.x, float64(idx+1))
+		}
+	}
+	auto := cr.getAutoColor(s.idx)
+	s.fill = auto
+	switch {
+	case g.isLine():
+		s.line, s.lineWidth = auto, 28575.0/EMU
+		if g.kind == "scatter" && !strings.Contains(g.style, "line") && !strings.HasPrefix(g.style, "smooth") {
+			s.line = ""
+		}
+	case g.kind == "pie" || g.kind == "doughnut":
+		s.line = cr.getColor(&decodeChartSolidFill{SchemeClr: &decodeChartColor{Val: "lt1"}})
+	}
+	if spPr := ser.SpPr; spPr != nil {
+		if spPr.NoFill != nil {
+			s.fill = ""
+		} else if clr := cr.getColor(spPr.SolidFill); clr != "" {
+			s.fill = clr
+		}
+		if ln := spPr.Ln; ln != nil {
+			if ln.NoFill != nil {
+				s.line = ""
+			} else if clr := cr.getColor(ln.SolidFill); clr != "" {
+				s.line = clr
+			}
+			if ln.W > 0 {
+				s.lineWidth = float64(ln.W) / EMU
+			}
+			s.dash = chartString(ln.PrstDash, "solid") != "solid"
+		}
+	}
+	if g.isLine() {
+		cr.prepareSeriesMarker(g, s, ser.Marker)
+	}
+	for _, dPt := range ser.DPt {
+		if dPt.IDx != nil && dPt.IDx.Val != nil && dPt.SpPr != nil {
+			if clr := cr.getColor(dPt.SpPr.SolidFill); clr != "" {
+				s.points[*dPt.IDx.Val] = clr
+			}
+		}
+	}
+	if ser.DLbls != nil {
+		s.dLbls = ser.DLbls
+	}
+	s.smooth = chartBool(ser.Smooth, false)
+	return s
+}
+
+// prepareSeriesMarker provides a function to read the marker settings of the
+// series by given chart group, chart series and marker element.
+func (cr *chartRender) prepareSeriesMarker(g *chartGroup, s *chartSeries, marker *decodeChartMarker) {
+	symbol := "none"
+	if marker != nil || g.kind == "scatter" && strings.Contains(strings.ToLower(g.style), "marker") ||
+		g.kind == "radar" && g.style == "marker" {
+		symbol = "auto"
+	}
+	s.markerSize = 5 * chartPixelsPerPoint
+	s.markerFill, s.markerLine = s.line, s.line
+	if s.line == "" {
+		s.markerFill, s.markerLine = s.fill, s.fill
+	}
+	if marker != nil {
+		symbol = chartString(marker.Symbol, symbol)
+		s.markerSize = float64(chartInt(marker.Size, 5)) * chartPixelsPerPoint
+		if spPr := marker.SpPr; spPr != nil {
+			if spPr.NoFill != nil {
+				s.markerFill = ""
+			} else if clr := cr.getColor(spPr.SolidFill); clr != "" {
+				s.markerFill = clr
+			}
+			s.markerLine = cr.getLineColor(spPr, s.markerLine)
+		}
+	}
+	if symbol == "auto" {
+		symbol = chartAutoMarkers[s.idx%len(chartAutoMarkers)]
+	}
+	s.marker = symbol
+}
+
+// render provides a function to render the chart area, title, legend and
+// plot area of the chart on the canvas.
+func (cr *chartRender) render() {
+	background, border := "FFFFFF", chartLineColor
+	if spPr := cr.cs.SpPr; spPr != nil {
+		if spPr.NoFill != nil {
+			background = ""
+		} else if clr := cr.getColor(spPr.SolidFill); clr != "" {
+			background = clr
+		}
+		border = cr.getLineColor(spPr, border)
+	}
+	if background != "" {
+		cr.canvas.fill(chartRectPoints(0, 0, cr.width, cr.height), background)
+	}
+	if border != "" {
+		cr.canvas.stroke(append(chartRectPoints(0.5, 0.5, cr.width-1, cr.height-1), [2]float64{0.5, 0.5}), 1, border, false)
+	}
+	rect := chartRect{chartPadding, chartPadding, cr.width - 2*chartPadding, cr.height - 2*chartPadding}
+	cr.renderTitle(&rect)
+	cr.renderLegend(&rect)
+	if len(cr.groups) == 0 || rect.w <= 0 || rect.h <= 0 {
+		return
+	}
+	switch cr.groups[0].kind {
+	case "pie", "doughnut":
+		cr.renderPie(rect)
+	case "radar":
+		cr.renderRadar(rect)
+	default:
+		cr.renderPlot(rect)
+	}
+	for _, label := range cr.labels {
+		cr.canvas.text(label.x, label.y+label.font.size*0.35, label.text, label.font, "middle", false)
+	}
+}
+
+// renderTitle provides a function to render the chart title on the top of
+// the chart area, and reduce the area used by the title.
+func (cr *chartRender) renderTitle(rect *chartRect) {
+	title := cr.cs.Chart.Title
+	if title == nil {
+		return
+	}
+	text := cr.getText(title.Tx)
+	if text == "" {
+		if text = "Chart Title"; len(cr.groups) == 1 && len(cr.groups[0].series) == 1 {
+			text = cr.groups[0].series[0].name
+		}
+	}
+	textPr := title.TxPr
+	if title.Tx != nil && title.Tx.Rich != nil {
+		textPr = title.Tx.Rich
+	}
+	font := cr.getFont(textPr, 1.4, cr.textColor, false)
+	lines := strings.Split(text, "\n")
+	y := rect.y + font.size
+	for _, line := range lines {
+		cr.canvas.text(rect.x+rect.w/2, y, line, font, "middle", false)
+		y += font.size * 1.2
+	}
+	if !chartBool(title.Overlay, false) {
+		height := font.size*1.2*float64(len(lines)) + chartPadding/2
+		rect.y, rect.h = rect.y+height, rect.h-height
+	}
+}
+
+// getLegendEntries provides a function to get the entries of the chart
+// legend, the categories will be used as the entries for the chart with
+// varied colors data points.
+func (cr *chartRender) getLegendEntries() []chartLegendEntry {
+	var entries []chartLegendEntry
+	if g := cr.groups[0]; g.varyPoints() && len(g.series) > 0 {
+		s := g.series[0]
+		for i := range s.values {
+			text := strconv.Itoa(i + 1)
+			if i < len(s.categories) {
+				text = s.categories[i]
+			}
+			entries = append(entries, chartLegendEntry{text: text, fill: cr.pointColor(g, s, i), line: s.line, lineWidth: s.lineWidth})
+		}
+		return entries
+	}
+	for _, g := range cr.groups {
+		for _, s := range g.series {
+			entries = append(entries, chartLegendEntry{
+				text: s.name, fill: s.fill, line: s.line, lineWidth: s.lineWidth, marker: s.marker,
+				markerFill: s.markerFill, markerLine: s.markerLine, lineKind: g.isLine(),
+			})
+		}
+	}
+	return entries
+}
+
+// renderLegend provides a function to render the chart legend by the legend
+// position, and reduce the area used by the legend.
+func (cr *chartRender) renderLegend(rect *chartRect) {
+	legend := cr.cs.Chart.Legend
+	if legend == nil || len(cr.groups) == 0 {
+		return
+	}
+	var entries []chartLegendEntry
+	for idx, entry := range cr.getLegendEntries() {
+		if !slices.ContainsFunc(legend.LegendEntry, func(e decodeChartLegendEntry) bool {
+			return chartInt(e.IDx, -1) == idx && chartBool(e.Delete, false)
+		}) {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) == 0 {
+		return
+	}
+	font := cr.getFont(legend.TxPr, 0.9, cr.textColor, false)
+	key, lineHeight, spacing := font.size*0.75, font.size*1.6, font.size
+	width := func(entry chartLegendEntry) float64 {
+		if entry.lineKind {
+			return key*2.5 + 4 + cr.canvas.measure(entry.text, font)
+		}
+		return key + 4 + cr.canvas.measure(entry.text, font)
+	}
+	pos, overlay := chartString(legend.LegendPos, "r"), chartBool(legend.Overlay, false)
+	if pos == "t" || pos == "b" {
+		var rows [][]chartLegendEntry
+		var rowWidths []float64
+		for _, entry := range entries {
+			w := width(entry)
+			if len(rows) == 0 || rowWidths[len(rows)-1]+spacing+w > rect.w {
+				rows, rowWidths = append(rows, nil), append(rowWidths, -spacing)
+			}
+			rows[len(rows)-1] = append(rows[len(rows)-1], entry)
+			rowWidths[len(rows)-1] += spacing + w
+		}
+		height := lineHeight * float64(len(rows))
+		y := rect.y
+		if pos == "b" {
+			y = rect.y + rect.h - height
+		}
+		for i, row := range rows {
+			x := rect.x + (rect.w-rowWidths[i])/2
+			for _, entry := range row {
+				cr.renderLegendEntry(x, y+lineHeight*(float64(i)+0.5), entry, font, key)
+				x += width(entry) + spacing
+			}
+		}
+		if !overlay {
+			if pos == "t" {
+				rect.y += height + chartPadding/2
+			}
+			rect.h -= height + chartPadding/2
+		}
+		return
+	}
+	var legendWidth float64
+	for _, entry := range entries {
+		legendWidth = max(legendWidth, width(entry))
+	}
+	legendWidth = min(legendWidth, rect.w/2)
+	height := lineHeight * float64(len(entries))
+	x, y := rect.x+rect.w-legendWidth, rect.y+(rect.h-height)/2
+	if pos == "l" {
+		x = rect.x
+	}
+	if pos == "tr" {
+		y = rect.y
+	}
+	for i, entry := range entries {
+		cr.renderLegendEntry(x, y+lineHeight*(float64(i)+0.5), entry, font, key)
+	}
+	if !overlay {
+		if pos == "l" {
+			rect.x += legendWidth + chartPadding
+		}
+		rect.w -= legendWidth + chartPadding
+	}
+}
+
+// renderLegendEntry provides a function to render the legend key and text of
+// the legend entry by given position of the left and vertical center of the
+// entry.
+func (cr *chartRender) renderLegendEntry(x, y float64, entry chartLegendEntry, font *chartFont, key float64) {
+	textX := x + key + 4
+	if entry.lineKind {
+		if entry.line != "" {
+			cr.canvas.stroke([][2]float64{{x, y}, {x + key*2.5, y}}, min(entry.lineWidth, 3), entry.line, false)
+		}
+		cr.renderMarker(x+key*1.25, y, entry.marker, key, entry.markerFill, entry.markerLine)
+		textX = x + key*2.5 + 4
+	} else {
+		if entry.fill != "" {
+			cr.canvas.fill(chartRectPoints(x, y-key/2, key, key), entry.fill)
+		} else if entry.line != "" {
+			cr.canvas.stroke(append(chartRectPoints(x, y-key/2, key, key), [2]float64{x, y - key/2}), 1, entry.line, false)
+		}
+	}
+	cr.canvas.text(textX, y+font.size*0.35, entry.text, font, "start", false)
+}
+
+// renderMarker provides a function to render the marker of the data point by
+// given center position, marker symbol, size, fill and outline color.
+func (cr *chartRender) renderMarker(x, y float64, symbol string, size float64, fill, line string) {
+	r := size / 2
+	var points [][2]float64
+	switch symbol {
+	case "circle":
+		points = chartArcPoints(x, y, r, 0, 360)
+	case "dot":
+		points = chartArcPoints(x, y, r/2, 0, 360)
+	case "square":
+		points = chartRectPoints(x-r, y-r, size, size)
+	case "diamond":
+		points = [][2]float64{{x, y - r}, {x + r, y}, {x, y + r}, {x - r, y}}
+	case "triangle":
+		points = [][2]float64{{x, y - r}, {x + r, y + r}, {x - r, y + r}}
+	case "dash":
+		points = chartRectPoints(x-r, y-r/4, size, r/2)
+	case "x", "star", "plus":
+		if line == "" {
+			line = fill
+		}
+		if line == "" {
+			return
+		}
+		if symbol != "plus" {
+			cr.canvas.stroke([][2]float64{{x - r, y - r}, {x + r, y + r}}, 1.5, line, false)
+			cr.canvas.stroke([][2]float64{{x - r, y + r}, {x + r, y - r}}, 1.5, line, false)
+		}
+		if symbol != "x" {
+			cr.canvas.stroke([][2]float64{{x, y - r}, {x, y + r}}, 1.5, line, false)
+		}
+		if symbol == "plus" {
+			cr.canvas.stroke([][2]float64{{x - r, y}, {x + r, y}}, 1.5, line, false)
+		}
+		return
+	default:
+		return
+	}
+	if fill != "" {
+		cr.canvas.fill(points, fill)
+	}
+	if line != "" {
+		cr.canvas.stroke(append(points, points[0]), 1, line, false)
+	}
+}
+
+// getDataLabel provides a function to get the text of the data label by
+// given chart series, index of the data point and the percentage of the data
+// point in the pie and doughnut chart.
+func (cr *chartRender) getDataLabel(g *chartGroup, s *chartSeries, i int, percent float64) string {
+	d := s.dLbls
+	if d == nil || chartBool(d.Delete, false) || i >= len(s.values) {
+		return ""
+	}
+	var parts []string
+	if chartBool(d.ShowSerName, false) {
+		parts = append(parts, s.name)
+	}
+	if chartBool(d.ShowCatName, false) {
+		category := strconv.Itoa(i + 1)
+		if i < len(s.categories) {
+			category = s.categories[i]
+		}
+		parts = append(parts, category)
+	}
+	if chartBool(d.ShowVal, false) {
+		value := s.values[i]
+		switch {
+		case d.NumFmt != nil && d.NumFmt.FormatCode != "" && !d.NumFmt.SourceLinked:
+			parts = append(parts, formatChartNumber(value.number, d.NumFmt.FormatCode))
+		case value.text != "":
+			parts = append(parts, value.text)
+		default:
+			parts = append(parts, formatChartNumber(value.number, ""))
+		}
+	}
+	if chartBool(d.ShowPercent, false) && (g.kind == "pie" || g.kind == "doughnut") {
+		parts = append(parts, formatChartNumber(percent, "0%"))
+	}
+	separator := ", "
+	if d.Separator != nil {
+		separator = *d.Separator
+	}
+	return strings.Join(parts, separator)
+}
+
+// addDataLabel provides a function to add the data label of the data point
+// by given chart series, index of the data point, the anchor position of the
+// data point and the default label position.
+func (cr *chartRender) addDataLabel(g *chartGroup, s *chartSeries, i int, x, y float64, defaultPos string) {
+	text := cr.getDataLabel(g, s, i, 0)
+	if text == "" {
+		return
+	}
+	font := cr.getFont(s.dLbls.TxPr, 0.9, "404040", false)
+	width, height := cr.canvas.measure(text, font)+s.markerSize, font.size*1.2+s.markerSize
+	switch chartString(s.dLbls.DLblPos, defaultPos) {
+	case "t":
+		y -= height/2 + 4
+	case "b":
+		y += height/2 + 4
+	case "l":
+		x -= width/2 + 4
+	case "r":
+		x += width/2 + 4
+	}
+	cr.labels = append(cr.labels, chartLabel{x: x, y: y, text: text, font: font})
+}
+
+// prepareScale provides a function to compute the minimum, maximum and major
+// unit of the value axis by given length of the axis in pixels. The
+// automatic minimum will be zero if the range of the data is wide enough,
+// and the automatic maximum will be increased by one major unit.
+func (a *chartAxis) prepareScale(length float64) {
+	low, high := a.dataMin, a.dataMax
+	if !a.hasData {
+		low, high = 0, 1
+	}
+	if low > 0 && low < high*5/6 {
+		low = 0
+	}
+	if high < 0 && high > low*5/6 {
+		high = 0
+	}
+	if low == high {
+		switch {
+		case low == 0:
+			high = 1
+		case low > 0:
+			low = 0
+		default:
+			high = 0
+		}
+	}
+	if a.fixedMin != nil {
+		low = *a.fixedMin
+	}
+	if a.fixedMax != nil {
+		high = *a.fixedMax
+	}
+	if high <= low {
+		high = low + 1
+	}
+	step := a.majorUnit
+	if step <= 0 {
+		ticks := math.Max(2, math.Min(10, math.Floor(length/(a.font.size*2.5))))
+		raw := (high - low) / ticks
+		exp := math.Pow(10, math.Floor(math.Log10(raw)))
+		step = 10 * exp
+		for _, nice := range []float64{1, 2, 5} {
+			if raw <= nice*exp {
+				step = nice * exp
+				break
+			}
+		}
+	}
+	if a.fixedMin == nil {
+		if low = math.Floor(low/step) * step; low < 0 && low == a.dataMin && !a.percent {
+			low -= step
+		}
+	}
+	if a.fixedMax == nil {
+		if high = math.Ceil(high/step) * step; high > 0 && high == a.dataMax && !a.percent {
+			high += step
+		}
+	}
+	a.min, a.max, a.step = low, high, step
+}
+
+// ticks provides a function to get the values of the major tick marks of the
+// value axis.
+func (a *chartAxis) ticks() []float64 {
+	var ticks []float64
+	for i := 0; i <= 1000; i++ {
+		value, _ := strconv.ParseFloat(strconv.FormatFloat(a.min+float64(i)*a.step, 'g', 12, 64), 64)
+		if value > a.max+a.step*1e-9 {
+			break
+		}
+		ticks = append(ticks, value)
+	}
+	return ticks
+}
+
+// tickLabels provides a function to get the text of the tick labels of the
+// axis.
+func (a *chartAxis) tickLabels() []string {
+	if a.kind != "val" {
+		labels := make([]string, a.count)
+		for i := range labels {
+			if labels[i] = strconv.Itoa(i + 1); i < len(a.labels) {
+				labels[i] = a.labels[i]
+			}
+		}
+		return labels
+	}
+	numFmt := a.numFmt
+	if a.percent && numFmt == "General" {
+		numFmt = "0%"
+	}
+	var labels []string
+	for _, tick := range a.ticks() {
+		labels = append(labels, formatChartNumber(tick, numFmt))
+	}
+	return labels
+}
+
+// extend provides a function to extend the data range of the value axis by
+// given value.
+func (a *chartAxis) extend(value float64) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return
+	}
+	if !a.hasData {
+		a.dataMin, a.dataMax, a.hasData = value, value, true
+		return
+	}
+	a.dataMin, a.dataMax = min(a.dataMin, value), max(a.dataMax, value)
+}
+
+// pos provides a function to get the pixel position on the value axis by
+// given value.
+func (a *chartAxis) pos(value float64) float64 {
+	value = math.Min(math.Max(value, a.min), a.max)
+	return a.start + (value-a.min)/(a.max-a.min)*(a.end-a.start)
+}
+
+// catPos provides a function to get the pixel position of the category on the
+// category axis by given index of the category.
+func (a *chartAxis) catPos(i int) float64 {
+	if a.kind == "val" {
+		return a.pos(float64(i + 1))
+	}
+	if a.between || a.count < 2 {
+		return a.start + (float64(i)+0.5)*(a.end-a.start)/float64(max(a.count, 1))
+	}
+	return a.start + float64(i)*(a.end-a.start)/float64(a.count-1)
+}
+
+// getTotals provides a function to get the sums of the absolute values of
+// each category of the chart group.
+func (g *chartGroup) getTotals(count int) []float64 {
+	totals := make([]float64, count)
+	for _, s := range g.series {
+		for i, value := range s.values {
+			if i < count && !math.IsNaN(value.number) {
+				totals[i] += math.Abs(value.number)
+			}
+		}
+	}
+	return totals
+}
+
+// getValue provides a function to get the value of the data point by given
+// chart series, index of the data point and sums of the absolute values of
+// each category. Returns false if the data point is blank and should be
+// skipped.
+func (cr *chartRender) getValue(g *chartGroup, s *chartSeries, i int, totals []float64) (float64, bool) {
+	value := math.NaN()
+	if i < len(s.values) {
+		value = s.values[i].number
+	}
+	if math.IsNaN(value) {
+		if cr.blanksAs != "zero" && g.kind != "area" && !g.isStacked() {
+			return value, false
+		}
+		value = 0
+	}
+	if g.grouping == "percentStacked" {
+		if totals[i] == 0 {
+			return 0, true
+		}
+		value /= totals[i]
+	}
+	return value, true
+}
+
+// point provides a function to get the position of the data point by given
+// position on the category axis and value axis of the chart group.
+func (g *chartGroup) point(cat, val float64) [2]float64 {
+	if g.catAx.vertical {
+		return [2]float64{val, cat}
+	}
+	return [2]float64{cat, val}
+}
+
+// prepareAxes provides a function to compute the data range of the axes,
+// the number of categories and category labels of the chart groups in the
+// plot area.
+func (cr *chartRender) prepareAxes(groups []*chartGroup) []*chartAxis {
+	var axes []*chartAxis
+	for _, g := range groups {
+		g.catAx.between = false
+		if g.kind == "scatter" {
+			g.catAx.kind = "val"
+		}
+		for _, axis := range []*chartAxis{g.catAx, g.valAx} {
+			if !slices.Contains(axes, axis) {
+				axes = append(axes, axis)
+			}
+		}
+	}
+	for _, g := range groups {
+		g.catAx.vertical, g.valAx.vertical = g.horizontal, !g.horizontal
+		if g.kind == "bar" || !g.valAx.midCat {
+			g.catAx.between = true
+		}
+		count := 0
+		for _, s := range g.series {
+			count = max(count, len(s.values))
+			if len(g.catAx.labels) == 0 && len(s.categories) > 0 {
+				g.catAx.labels = s.categories
+			}
+			if g.kind == "scatter" {
+				for i, x := range s.x {
+					if i < len(s.values) && !math.IsNaN(s.values[i].number) {
+						g.catAx.extend(x)
+					}
+				}
+			}
+		}
+		g.catAx.count = max(g.catAx.count, count)
+		totals := g.getTotals(count)
+		if g.grouping == "percentStacked" {
+			g.valAx.percent = true
+		}
+		positive, negative := make([]float64, count), make([]float64, count)
+		for _, s := range g.series {
+			for i := range count {
+				value, ok := cr.getValue(g, s, i, totals)
+				if !ok {
+					continue
+				}
+				if !g.isStacked() {
+					g.valAx.extend(value)
+					continue
+				}
+				if value >= 0 {
+					positive[i] += value
+					g.valAx.extend(positive[i])
+					continue
+				}
+				negative[i] += value
+				g.valAx.extend(negative[i])
+			}
+		}
+	}
+	sides := map[bool][]string{false: {"b", "t"}, true: {"l", "r"}}
+	for _, axis := range axes {
+		axis.side = sides[axis.vertical][0]
+		if len(sides[axis.vertical]) > 1 {
+			sides[axis.vertical] = sides[axis.vertical][1:]
+		}
+	}
+	return axes
+}
+
+// axisTitle provides a function to get the text and font of the axis title,
+// returns nil font if the axis has no title.
+func (cr *chartRender) axisTitle(axis *chartAxis) (string, *chartFont) {
+	if axis.title == nil || axis.deleted {
+		return "", nil
+	}
+	text := cr.getText(axis.title.Tx)
+	if text == "" {
+		text = "Axis Title"
+	}
+	textPr := axis.title.TxPr
+	if axis.title.Tx != nil && axis.title.Tx.Rich != nil {
+		textPr = axis.title.Tx.Rich
+	}
+	return strings.ReplaceAll(text, "\n", " "), cr.getFont(textPr, 1, cr.textColor, true)
+}
+
+// axisExtent provides a function to get the size in pixels of the tick
+// labels and title of the axis perpendicular to the axis.
+func (cr *chartRender) axisExtent(axis *chartAxis) float64 {
+	var extent float64
+	if !axis.deleted && !axis.noLabels {
+		if extent = axis.font.size*1.2 + 4; axis.vertical {
+			extent = 4
+			for _, label := range axis.tickLabels() {
+				extent = max(extent, cr.canvas.measure(label, axis.font)+4)
+			}
+		}
+	}
+	if _, font := cr.axisTitle(axis); font != nil {
+		extent += font.size*1.2 + 4
+	}
+	return extent
+}
+
+// renderPlot provides a function to render the plot area of the area, bar,
+// line and scatter charts by given rectangle area of the plot area.
+func (cr *chartRender) renderPlot(rect chartRect) {
+	var groups []*chartGroup
+	for _, g := range cr.groups {
+		if slices.Contains([]string{"area", "bar", "line", "scatter"}, g.kind) {
+			groups = append(groups, g)
+		}
+	}
+	if len(groups) == 0 {
+		return
+	}
+	axes := cr.prepareAxes(groups)
+	for _, axis := range axes {
+		if axis.kind == "val" {
+			length := rect.w
+			if axis.vertical {
+				length = rect.h
+			}
+			axis.prepareScale(length)
+		}
+	}
+	margins := map[string]float64{"l": 0, "r": chartPadding, "t": chartPadding, "b": 0}
+	for _, axis := range axes {
+		margins[axis.side] += cr.axisExtent(axis)
+		if labels := axis.tickLabels(); axis.kind == "val" && !axis.vertical && len(labels) > 0 && !axis.deleted {
+			margins["l"] = max(margins["l"], cr.canvas.measure(labels[0], axis.font)/2)
+			margins["r"] = max(margins["r"], cr.canvas.measure(labels[len(labels)-1], axis.font)/2)
+		}
+	}
+	plot := chartRect{
+		rect.x + margins["l"], rect.y + margins["t"],
+		rect.w - margins["l"] - margins["r"], rect.h - margins["t"] - margins["b"],
+	}
+	if plot.w <= 0 || plot.h <= 0 {
+		return
+	}
+	for _, axis := range axes {
+		if axis.start, axis.end = plot.x, plot.x+plot.w; axis.vertical {
+			axis.start, axis.end = plot.y+plot.h, plot.y
+		}
+		if axis.kind == "val" {
+			length := plot.w
+			if axis.vertical {
+				length = plot.h
+			}
+			axis.prepareScale(length)
+		}
+		if axis.reverse {
+			axis.start, axis.end = axis.end, axis.start
+		}
+	}
+	if pa := cr.cs.Chart.PlotArea; pa.SpPr != nil && pa.SpPr.NoFill == nil {
+		if clr := cr.getColor(pa.SpPr.SolidFill); clr != "" {
+			cr.canvas.fill(chartRectPoints(plot.x, plot.y, plot.w, plot.h), clr)
+		}
+	}
+	for _, axis := range axes {
+		cr.renderGridlines(axis, plot)
+	}
+	for _, g := range groups {
+		switch g.kind {
+		case "area":
+			cr.renderAreas(g)
+		case "bar":
+			cr.renderBars(g)
+		default:
+			cr.renderLines(g)
+		}
+	}
+	for _, axis := range axes {
+		cr.renderAxis(axis, groups, plot, rect)
+	}
+}
+
+// renderGridlines provides a function to render the major gridlines of the
+// axis by given rectangle area of the plot.
+func (cr *chartRender) renderGridlines(axis *chartAxis, plot chartRect) {
+	if axis.gridlines == "" {
+		return
+	}
+	var positions []float64
+	if axis.kind == "val" {
+		for _, tick := range axis.ticks() {
+			positions = append(positions, axis.pos(tick))
+		}
+	} else {
+		for i := 0; i <= axis.count; i++ {
+			positions = append(positions, axis.start+float64(i)*(axis.end-axis.start)/float64(max(axis.count, 1)))
+		}
+	}
+	for _, pos := range positions {
+		if axis.vertical {
+			cr.canvas.stroke([][2]float64{{plot.x, pos}, {plot.x + plot.w, pos}}, 1, axis.gridlines, false)
+			continue
+		}
+		cr.canvas.stroke([][2]float64{{pos, plot.y}, {pos, plot.y + plot.h}}, 1, axis.gridlines, false)
+	}
+}
+
+// renderAxis provides a function to render the axis line, tick labels and
+// title of the axis by given chart groups, rectangle area of the plot and the
+// plot area.
+func (cr *chartRender) renderAxis(axis *chartAxis, groups []*chartGroup, plot, rect chartRect) {
+	if axis.deleted {
+		return
+	}
+	cross := map[string]float64{"l": plot.x, "r": plot.x + plot.w, "t": plot.y, "b": plot.y + plot.h}[axis.side]
+	if axis.kind != "val" {
+		for _, g := range groups {
+			if g.catAx == axis {
+				cross = g.valAx.pos(0)
+				break
+			}
+		}
+	}
+	if axis.line != "" {
+		if axis.vertical {
+			cr.canvas.stroke([][2]float64{{cross, plot.y}, {cross, plot.y + plot.h}}, 1, axis.line, false)
+		} else {
+			cr.canvas.stroke([][2]float64{{plot.x, cross}, {plot.x + plot.w, cross}}, 1, axis.line, false)
+		}
+	}
+	labels, font := axis.tickLabels(), axis.font
+	positions := make([]float64, len(labels))
+	for i := range labels {
+		positions[i] = axis.catPos(i)
+	}
+	step := max(axis.tickSkip, 1)
+	if axis.kind == "val" {
+		for i, tick := range axis.ticks() {
+			positions[i] = axis.pos(tick)
+		}
+	} else if axis.tickSkip == 0 && axis.count > 0 {
+		slot := math.Abs(axis.end-axis.start) / float64(axis.count)
+		size := font.size * 1.2
+		if !axis.vertical {
+			for _, label := range labels {
+				size = max(size, cr.canvas.measure(label, font)+font.size/2)
+			}
+		}
+		step = max(int(math.Ceil(size/slot)), 1)
+	}
+	if !axis.noLabels {
+		for i := 0; i < len(labels); i += step {
+			switch axis.side {
+			case "l":
+				cr.canvas.text(plot.x-4, positions[i]+font.size*0.35, labels[i], font, "end", false)
+			case "r":
+				cr.canvas.text(plot.x+plot.w+4, positions[i]+font.size*0.35, labels[i], font, "start", false)
+			case "t":
+				cr.canvas.text(positions[i], plot.y-4-font.size*0.2, labels[i], font, "middle", false)
+			default:
+				cr.canvas.text(positions[i], plot.y+plot.h+4+font.size, labels[i], font, "middle", false)
+			}
+		}
+	}
+	text, titleFont := cr.axisTitle(axis)
+	if titleFont == nil {
+		return
+	}
+	switch axis.side {
+	case "l":
+		cr.canvas.text(rect.x+titleFont.size, plot.y+plot.h/2, text, titleFont, "middle", true)
+	case "r":
+		cr.canvas.text(rect.x+rect.w-titleFont.size*0.25, plot.y+plot.h/2, text, titleFont, "middle", true)
+	case "t":
+		cr.canvas.text(plot.x+plot.w/2, rect.y+titleFont.size, text, titleFont, "middle", false)
+	default:
+		cr.canvas.text(plot.x+plot.w/2, rect.y+rect.h-titleFont.size*0.25, text, titleFont, "middle", false)
+	}
+}
+
+// renderBars provides a function to render the bars of the bar or column
+// chart group.
+func (cr *chartRender) renderBars(g *chartGroup) {
+	count := g.catAx.count
+	if count == 0 || len(g.series) == 0 {
+		return
+	}
+	bars := float64(len(g.series))
+	if g.isStacked() {
+		bars = 1
+	}
+	overlap, direction := g.overlap/100, 1.0
+	if g.catAx.end < g.catAx.start {
+		direction = -1
+	}
+	slot := math.Abs(g.catAx.end-g.catAx.start) / float64(count)
+	width := slot / (bars - (bars-1)*overlap + g.gapWidth/100)
+	groupWidth := width * (bars - (bars-1)*overlap)
+	totals := g.getTotals(count)
+	positive, negative := make([]float64, count), make([]float64, count)
+	defaultPos := "outEnd"
+	if g.isStacked() {
+		defaultPos = "ctr"
+	}
+	for k, s := range g.series {
+		for i := range count {
+			value, ok := cr.getValue(g, s, i, totals)
+			if !ok || i >= len(s.values) {
+				continue
+			}
+			from, to := 0.0, value
+			if g.isStacked() {
+				if value >= 0 {
+					from, positive[i] = positive[i], positive[i]+value
+					to = positive[i]
+				} else {
+					from, negative[i] = negative[i], negative[i]+value
+					to = negative[i]
+				}
+				k = 0
+			}
+			c0 := g.catAx.catPos(i) - direction*groupWidth/2 + direction*float64(k)*width*(1-overlap)
+			c1 := c0 + direction*width
+			v0, v1 := g.valAx.pos(from), g.valAx.pos(to)
+			p0, p1 := g.point(c0, v0), g.point(c1, v1)
+			points := chartRectPoints(min(p0[0], p1[0]), min(p0[1], p1[1]), math.Abs(p1[0]-p0[0]), math.Abs(p1[1]-p0[1]))
+			if fill := cr.pointColor(g, s, i); fill != "" {
+				cr.canvas.fill(points, fill)
+			}
+			if s.line != "" {
+				cr.canvas.stroke(append(points, points[0]), s.lineWidth, s.line, s.dash)
+			}
+			if s.dLbls == nil {
+				continue
+			}
+			font := cr.getFont(s.dLbls.TxPr, 0.9, "404040", false)
+			text := cr.getDataLabel(g, s, i, 0)
+			half := font.size*0.6 + 3
+			if g.horizontal {
+				half = cr.canvas.measure(text, font)/2 + 3
+			}
+			sign := 1.0
+			if v1 < v0 {
+				sign = -1
+			}
+			center := (c0 + c1) / 2
+			label := map[string]float64{
+				"ctr": (v0 + v1) / 2, "inEnd": v1 - sign*half, "inBase": v0 + sign*half, "outEnd": v1 + sign*half,
+			}
+			pos, ok := label[chartString(s.dLbls.DLblPos, defaultPos)]
+			if !ok {
+				pos = label[defaultPos]
+			}
+			if p := g.point(center, pos); text != "" {
+				cr.labels = append(cr.labels, chartLabel{x: p[0], y: p[1], text: text, font: font})
+			}
+		}
+	}
+}
+
+// renderAreas provides a function to render the areas of the area chart
+// group.
+func (cr *chartRender) renderAreas(g *chartGroup) {
+	count := g.catAx.count
+	if count == 0 {
+		return
+	}
+	totals, bases := g.getTotals(count), make([]float64, count)
+	for _, s := range g.series {
+		tops := make([]float64, count)
+		for i := range count {
+			value, _ := cr.getValue(g, s, i, totals)
+			if tops[i] = value; g.isStacked() {
+				tops[i] += bases[i]
+			}
+		}
+		var points [][2]float64
+		for i := range count {
+			points = append(points, g.point(g.catAx.catPos(i), g.valAx.pos(tops[i])))
+		}
+		for i := count - 1; i >= 0; i-- {
+			base := 0.0
+			if g.isStacked() {
+				base = bases[i]
+			}
+			points = append(points, g.point(g.catAx.catPos(i), g.valAx.pos(base)))
+		}
+		if s.fill != "" {
+			cr.canvas.fill(points, s.fill)
+		}
+		if s.line != "" {
+			cr.canvas.stroke(append(points, points[0]), s.lineWidth, s.line, s.dash)
+		}
+		for i := range count {
+			base := 0.0
+			if g.isStacked() {
+				base = bases[i]
+			}
+			p := g.point(g.catAx.catPos(i), (g.valAx.pos(tops[i])+g.valAx.pos(base))/2)
+			cr.addDataLabel(g, s, i, p[0], p[1], "ctr")
+		}
+		if g.isStacked() {
+			bases = tops
+		}
+	}
+}
+
+// renderLines provides a function to render the lines and markers of the
+// line and scatter chart group.
+func (cr *chartRender) renderLines(g *chartGroup) {
+	count := g.catAx.count
+	totals, stacks := g.getTotals(count), make([]float64, count)
+	for _, s := range g.series {
+		var (
+			segments [][][2]float64
+			segment  [][2]float64
+			markers  = map[int][2]float64{}
+		)
+		for i := range count {
+			value, ok := cr.getValue(g, s, i, totals)
+			if !ok {
+				if cr.blanksAs != "span" && len(segment) > 0 {
+					segments, segment = append(segments, segment), nil
+				}
+				continue
+			}
+			if g.isStacked() {
+				stacks[i] += value
+				value = stacks[i]
+			}
+			cat := g.catAx.catPos(i)
+			if g.kind == "scatter" {
+				if i >= len(s.x) {
+					continue
+				}
+				cat = g.catAx.pos(s.x[i])
+			}
+			markers[i] = g.point(cat, g.valAx.pos(value))
+			segment = append(segment, markers[i])
+		}
+		if len(segment) > 0 {
+			segments = append(segments, segment)
+		}
+		if s.line != "" {
+			for _, segment := range segments {
+				if s.smooth || strings.HasPrefix(g.style, "smooth") && g.kind == "scatter" {
+					segment = smoothChartPoints(segment)
+				}
+				cr.canvas.stroke(segment, s.lineWidth, s.line, s.dash)
+			}
+		}
+		for i := range count {
+			if p, ok := markers[i]; ok {
+				cr.renderMarker(p[0], p[1], s.marker, s.markerSize, s.markerFill, s.markerLine)
+				cr.addDataLabel(g, s, i, p[0], p[1], "r")
+			}
+		}
+	}
+}
+
+// renderPie provides a function to render the pie or doughnut chart by given
+// rectangle area of the plot area, the series of the doughnut chart will be
+// rendered as the rings from inside to outside.
+func (cr *chartRender) renderPie(rect chartRect) {
+	g := cr.groups[0]
+	if len(g.series) == 0 {
+		return
+	}
+	rings := g.series[:1]
+	radius := min(rect.w, rect.h)/2 - 4
+	var hole float64
+	if g.kind == "doughnut" {
+		rings, hole = g.series, radius*g.holeSize/100
+	}
+	cx, cy := rect.x+rect.w/2, rect.y+rect.h/2
+	thickness := (radius - hole) / float64(len(rings))
+	for k, s := range rings {
+		inner, outer := hole+thickness*float64(k), hole+thickness*float64(k+1)
+		var total float64
+		for _, value := range s.values {
+			if !math.IsNaN(value.number) {
+				total += math.Abs(value.number)
+			}
+		}
+		angle := g.firstAngle
+		for i, value := range s.values {
+			if total == 0 || math.IsNaN(value.number) || value.number == 0 {
+				continue
+			}
+			sweep := math.Abs(value.number) / total * 360
+			points := chartArcPoints(cx, cy, outer, angle, angle+sweep)
+			if inner > 0 {
+				arc := chartArcPoints(cx, cy, inner, angle, angle+sweep)
+				slices.Reverse(arc)
+				points = append(points, arc...)
+			} else {
+				points = append(points, [2]float64{cx, cy})
+			}
+			if fill := cr.pointColor(g, s, i); fill != "" {
+				cr.canvas.fill(points, fill)
+			}
+			if s.line != "" {
+				cr.canvas.stroke(append(points, points[0]), s.lineWidth, s.line, false)
+			}
+			if text := cr.getDataLabel(g, s, i, math.Abs(value.number)/total); text != "" {
+				font := cr.getFont(s.dLbls.TxPr, 0.9, "404040", false)
+				distance := inner + (outer-inner)*0.65
+				if inner > 0 {
+					distance = (inner + outer) / 2
+				}
+				switch chartString(s.dLbls.DLblPos, "bestFit") {
+				case "ctr":
+					distance = (inner + outer) / 2
+				case "inEnd":
+					distance = outer - font.size
+				case "outEnd":
+					distance = outer + cr.canvas.measure(text, font)/2 + 6
+				}
+				theta := (angle + sweep/2 - 90) * math.Pi / 180
+				cr.labels = append(cr.labels, chartLabel{
+					x: cx + distance*math.Cos(theta), y: cy + distance*math.Sin(theta), text: text, font: font,
+				})
+			}
+			angle += sweep
+		}
+	}
+}
+
+// renderRadar provides a function to render the radar chart by given
+// rectangle area of the plot area.
+func (cr *chartRender) renderRadar(rect chartRect) {
+	var groups []*chartGroup
+	for _, g := range cr.groups {
+		if g.kind == "radar" {
+			groups = append(groups, g)
+		}
+	}
+	axes := cr.prepareAxes(groups)
+	catAx, valAx := groups[0].catAx, groups[0].valAx
+	if catAx.count == 0 {
+		return
+	}
+	labels, font := catAx.tickLabels(), catAx.font
+	var labelWidth float64
+	for _, label := range labels {
+		labelWidth = max(labelWidth, cr.canvas.measure(label, font))
+	}
+	if catAx.deleted || catAx.noLabels {
+		labelWidth = 0
+	}
+	radius := min(rect.w/2-labelWidth-6, rect.h/2-font.size*1.2-6)
+	if radius <= 0 {
+		return
+	}
+	cx, cy := rect.x+rect.w/2, rect.y+rect.h/2
+	for _, axis := range axes {
+		if axis.kind == "val" {
+			axis.start, axis.end = 0, radius
+			if axis.prepareScale(radius); axis.reverse {
+				axis.start, axis.end = radius, 0
+			}
+		}
+	}
+	spoke := func(i int, distance float64) [2]float64 {
+		theta := (float64(i)*360/float64(catAx.count) - 90) * math.Pi / 180
+		return [2]float64{cx + distance*math.Cos(theta), cy + distance*math.Sin(theta)}
+	}
+	ring := func(distance float64) [][2]float64 {
+		var points [][2]float64
+		for i := 0; i <= catAx.count; i++ {
+			points = append(points, spoke(i%catAx.count, distance))
+		}
+		return points
+	}
+	if valAx.gridlines != "" {
+		for _, tick := range valAx.ticks() {
+			cr.canvas.stroke(ring(valAx.pos(tick)), 1, valAx.gridlines, false)
+		}
+	}
+	if catAx.line != "" {
+		cr.canvas.stroke(ring(radius), 1, catAx.line, false)
+		for i := range catAx.count {
+			cr.canvas.stroke([][2]float64{{cx, cy}, spoke(i, radius)}, 1, catAx.line, false)
+		}
+	}
+	for _, g := range groups {
+		for _, s := range g.series {
+			var points [][2]float64
+			markers := map[int][2]float64{}
+			for i := range catAx.count {
+				value, ok := cr.getValue(g, s, i, nil)
+				if !ok {
+					continue
+				}
+				markers[i] = spoke(i, g.valAx.pos(value))
+				points = append(points, markers[i])
+			}
+			if len(points) == 0 {
+				continue
+			}
+			if g.style == "filled" && s.fill != "" {
+				cr.canvas.fill(points, s.fill)
+			}
+			if s.line != "" {
+				cr.canvas.stroke(append(points, points[0]), s.lineWidth, s.line, s.dash)
+			}
+			for i := range catAx.count {
+				if p, ok := markers[i]; ok {
+					cr.renderMarker(p[0], p[1], s.marker, s.markerSize, s.markerFill, s.markerLine)
+					cr.addDataLabel(g, s, i, p[0], p[1], "t")
+				}
+			}
+		}
+	}
+	if !catAx.deleted && !catAx.noLabels {
+		for i, label := range labels {
+			p := spoke(i, radius+6)
+			theta := (float64(i)*360/float64(catAx.count) - 90) * math.Pi / 180
+			anchor, y := "middle", p[1]+font.size*0.35
+			if cos := math.Cos(theta); cos > 0.3 {
+				anchor = "start"
+			} else if cos < -0.3 {
+				anchor = "end"
+			}
+			if sin := math.Sin(theta); sin > 0.3 {
+				y = p[1] + font.size
+			} else if sin < -0.3 {
+				y = p[1] - font.size*0.2
+			}
+			cr.canvas.text(p[0], y, label, font, anchor, false)
+		}
+	}
+	if !valAx.deleted && !valAx.noLabels {
+		for i, label := range valAx.tickLabels() {
+			cr.canvas.text(cx-4, cy-valAx.pos(valAx.ticks()[i])+valAx.font.size*0.35, label, valAx.font, "end", false)
+		}
+	}
+}
+
+// chartRectPoints provides a function to get the corner points of the
+// rectangle by given position and size.
+func chartRectPoints(x, y, w, h float64) [][2]float64 {
+	return [][2]float64{{x, y}, {x + w, y}, {x + w, y + h}, {x, y + h}}
+}
+
+// chartArcPoints provides a function to get the points on the arc by given
+// center position, radius, start and end angles in degrees clockwise from
+// the 12 o'clock position.
+func chartArcPoints(cx, cy, radius, start, end float64) [][2]float64 {
+	steps := max(int(math.Ceil(math.Abs(end-start)/3)), 1)
+	points := make([][2]float64, 0, steps+1)
+	for i := 0; i <= steps; i++ {
+		theta := (start + (end-start)*float64(i)/float64(steps) - 90) * math.Pi / 180
+		points = append(points, [2]float64{cx + radius*math.Cos(theta), cy + radius*math.Sin(theta)})
+	}
+	return points
+}
+
+// smoothChartPoints provides a function to get the points on the smoothed
+// curve passing through the given points by the Catmull-Rom spline.
+func smoothChartPoints(points [][2]float64) [][2]float64 {
+	if len(points) < 3 {
+		return points
+	}
+	result := [][2]float64{points[0]}
+	for i := 0; i < len(points)-1; i++ {
+		p0, p1, p2, p3 := points[max(i-1, 0)], points[i], points[i+1], points[min(i+2, len(points)-1)]
+		c1 := [2]float64{p1[0] + (p2[0]-p0[0])/6, p1[1] + (p2[1]-p0[1])/6}
+		c2 := [2]float64{p2[0] - (p3[0]-p1[0])/6, p2[1] - (p3[1]-p1[1])/6}
+		for step := 1; step <= 8; step++ {
+			t := float64(step) / 8
+			a, b, c, d := (1-t)*(1-t)*(1-t), 3*(1-t)*(1-t)*t, 3*(1-t)*t*t, t*t*t
+			result = append(result, [2]float64{
+				a*p1[0] + b*c1[0] + c*c2[0] + d*p2[0], a*p1[1] + b*c1[1] + c*c2[1] + d*p2[1],
+			})
+		}
+	}
+	return result
+}
+
+// svgPoints provides a function to format the points as the value of the
+// points attribute of the SVG element.
+func svgPoints(points [][2]float64) string {
+	var buf strings.Builder
+	for i, p := range points {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(pdfNumber(p[0]) + "," + pdfNumber(p[1]))
+	}
+	return buf.String()
+}
+
+// fill provides a function to fill the polygon on the SVG canvas.
+func (c *chartSVGCanvas) fill(points [][2]float64, color string) {
+	fmt.Fprintf(&c.buf, "<polygon points=\"%s\" fill=\"#%s\"/>", svgPoints(points), color)
+}
+
+// stroke provides a function to stroke the polyline on the SVG canvas.
+func (c *chartSVGCanvas) stroke(points [][2]float64, width float64, color string, dash bool) {
+	fmt.Fprintf(&c.buf, "<polyline points=\"%s\" fill=\"none\" stroke=\"#%s\" stroke-width=\"%s\" stroke-linejoin=\"round\"",
+		svgPoints(points), color, pdfNumber(width))
+	if dash {
+		fmt.Fprintf(&c.buf, " stroke-dasharray=\"%s,%s\"", pdfNumber(width*4), pdfNumber(width*3))
+	}
+	c.buf.WriteString("/>")
+}
+
+// text provides a function to draw the text on the SVG canvas.
+func (c *chartSVGCanvas) text(x, y float64, text string, font *chartFont, anchor string, rotate bool) {
+	fmt.Fprintf(&c.buf, "<text x=\"%s\" y=\"%s\" font-size=\"%s\" fill=\"#%s\"", pdfNumber(x), pdfNumber(y), pdfNumber(font.size), font.color)
+	if font.bold {
+		c.buf.WriteString(" font-weight=\"bold\"")
+	}
+	if anchor != "start" {
+		fmt.Fprintf(&c.buf, " text-anchor=\"%s\"", anchor)
+	}
+	if rotate {
+		fmt.Fprintf(&c.buf, " transform=\"rotate(-90 %s %s)\"", pdfNumber(x), pdfNumber(y))
+	}
+	c.buf.WriteString(">")
+	_ = xml.EscapeText(&c.buf, []byte(text))
+	c.buf.WriteString("</text>")
+}
+
+// measure provides a function to get the width of the text on the SVG
+// canvas by the glyph widths of the Helvetica font.
+func (c *chartSVGCanvas) measure(text string, font *chartFont) float64 {
+	return getPDFFont(&Font{Bold: font.bold}).measure(text, font.size)
+}
+
+// newChartPNGCanvas provides a function to create the PNG canvas by given
+// size in pixels of the chart.
+func newChartPNGCanvas(width, height float64) *chartPNGCanvas {
+	w, h := max(int(math.Ceil(width)), 1), max(int(math.Ceil(height)), 1)
+	return &chartPNGCanvas{
+		img:   image.NewRGBA(image.Rect(0, 0, w, h)),
+		z:     vector.NewRasterizer(w, h),
+		faces: make(map[chartFont]font.Face),
+	}
+}
+
+// chartColor provides a function to convert the RGB hex color to the color
+// of the raster image, returns black for the invalid color.
+func chartColor(hex string) color.RGBA {
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || len(hex) != 6 {
+		return color.RGBA{A: 0xFF}
+	}
+	return color.RGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 0xFF}
+}
+
+// fill provides a function to fill the polygon on the PNG canvas.
+func (c *chartPNGCanvas) fill(points [][2]float64, color string) {
+	if len(points) < 3 {
+		return
+	}
+	bounds := c.img.Bounds()
+	c.z.Reset(bounds.Dx(), bounds.Dy())
+	c.z.MoveTo(float32(points[0][0]), float32(points[0][1]))
+	for _, p := range points[1:] {
+		c.z.LineTo(float32(p[0]), float32(p[1]))
+	}
+	c.z.ClosePath()
+	c.z.Draw(c.img, bounds, image.NewUniform(chartColor(color)), image.Point{})
+}
+
+// stroke provides a function to stroke the polyline on the PNG canvas, each
+// segment of the polyline will be filled as a quadrilateral.
+func (c *chartPNGCanvas) stroke(points [][2]float64, width float64, color string, dash bool) {
+	var segments [][2][2]float64
+	for i := 1; i < len(points); i++ {
+		segments = append(segments, [2][2]float64{points[i-1], points[i]})
+	}
+	if dash {
+		segments = dashChartSegments(segments, width*4, width*3)
+	}
+	half := math.Max(width, 1) / 2
+	for _, segment := range segments {
+		dx, dy := segment[1][0]-segment[0][0], segment[1][1]-segment[0][1]
+		length := math.Hypot(dx, dy)
+		if length == 0 {
+			continue
+		}
+		nx, ny := -dy/length*half, dx/length*half
+		c.fill([][2]float64{
+			{segment[0][0] + nx, segment[0][1] + ny}, {segment[1][0] + nx, segment[1][1] + ny},
+			{segment[1][0] - nx, segment[1][1] - ny}, {segment[0][0] - nx, segment[0][1] - ny},
+		}, color)
+		if half > 1 {
+			c.fill(chartArcPoints(segment[1][0], segment[1][1], half, 0, 360), color)
+		}
+	}
+}
+
+// dashChartSegments provides a function to split the line segments into the
+// dashes by given length of the dashes and gaps.
+func dashChartSegments(segments [][2][2]float64, dash, gap float64) [][2][2]float64 {
+	if dash <= 0 || gap <= 0 {
+		return segments
+	}
+	var result [][2][2]float64
+	on, remain := true, dash
+	for _, segment := range segments {
+		dx, dy := segment[1][0]-segment[0][0], segment[1][1]-segment[0][1]
+		length := math.Hypot(dx, dy)
+		for pos := 0.0; pos < length; {
+			step, next := remain, pos+remain
+			if remain >= length-pos {
+				step, next = length-pos, length
+			}
+			if on {
+				start, end := pos/length, next/length
+				result = append(result, [2][2]float64{
+					{segment[0][0] + dx*start, segment[0][1] + dy*start}, {segment[0][0] + dx*end, segment[0][1] + dy*end},
+				})
+			}
+			pos = next
+			if remain -= step; remain <= 1e-9 {
+				if on = !on; on {
+					remain = dash
+				} else {
+					remain = gap
+				}
+			}
+		}
+	}
+	return result
+}
+
+// face provides a function to get the font face used for drawing the text on
+// the PNG canvas by given font settings.
+func (c *chartPNGCanvas) face(f *chartFont) font.Face {
+	key := chartFont{size: math.Max(math.Round(f.size*2)/2, 1), bold: f.bold}
+	if face, ok := c.faces[key]; ok {
+		return face
+	}
+	chartTrueTypeFonts.once.Do(func() {
+		chartTrueTypeFonts.regular, _ = opentype.Parse(goregular.TTF)
+		chartTrueTypeFonts.bold, _ = opentype.Parse(gobold.TTF)
+	})
+	ttf := chartTrueTypeFonts.regular
+	if key.bold {
+		ttf = chartTrueTypeFonts.bold
+	}
+	var face font.Face = basicfont.Face7x13
+	if ttf != nil {
+		if ttfFace, err := opentype.NewFace(ttf, &opentype.FaceOptions{Size: key.size, DPI: 72}); err == nil {
+			face = ttfFace
+		}
+	}
+	c.faces[key] = face
+	return face
+}
+
+// text provides a function to draw the text on the PNG canvas.
+func (c *chartPNGCanvas) text(x, y float64, text string, f *chartFont, anchor string, rotate bool) {
+	face := c.face(f)
+	width := c.measure(text, f)
+	offset := map[string]float64{"middle": width / 2, "end": width}[anchor]
+	src := image.NewUniform(chartColor(f.color))
+	if !rotate {
+		d := &font.Drawer{Dst: c.img, Src: src, Face: face, Dot: fixed.Point26_6{
+			X: fixed.Int26_6((x - offset) * 64), Y: fixed.Int26_6(y * 64),
+		}}
+		d.DrawString(text)
+		return
+	}
+	metrics := face.Metrics()
+	ascent, height, length := metrics.Ascent.Ceil(), metrics.Ascent.Ceil()+metrics.Descent.Ceil(), int(math.Ceil(width))
+	if length == 0 || height == 0 {
+		return
+	}
+	mask := image.NewAlpha(image.Rect(0, 0, length, height))
+	d := &font.Drawer{Dst: mask, Src: image.Opaque, Face: face, Dot: fixed.P(0, ascent)}
+	d.DrawString(text)
+	rotated := image.NewAlpha(image.Rect(0, 0, height, length))
+	for my := range height {
+		for mx := range length {
+			rotated.SetAlpha(my, length-1-mx, mask.AlphaAt(mx, my))
+		}
+	}
+	left, top := int(math.Round(x))-ascent, int(math.Round(y+offset))-length
+	draw.DrawMask(c.img, image.Rect(left, top, left+height, top+length), src, image.Point{}, rotated, image.Point{}, draw.Over)
+}
+
+// measure provides a function to get the width of the text on the PNG
+// canvas.
+func (c *chartPNGCanvas) measure(text string, f *chartFont) float64 {
+	return float64(font.MeasureString(c.face(f), text)) / 64
+}
@@ -0,0 +1,182 @@
+package excelize
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderChart(t *testing.T) {
+	f := NewFile()
+	for idx, row := range [][]interface{}{
+		{nil, "Apple", "Orange", "Pear"}, {"Small", 2, 3, 3}, {"Normal", 5, 2, 4}, {"Large", 6, -7, nil},
+	} {
+		cell, err := CoordinatesToCellName(1, idx+1)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &row))
+	}
+	series := []ChartSeries{
+		{Name: "Sheet1!$A$2", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$2:$D$2"},
+		{Name: "Sheet1!$A$3", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$3:$D$3", Line: LineOptions{Dash: LineDashDash}},
+		{Name: "Sheet1!$A$4", Categories: "Sheet1!$B$1:$D$1", Values: "Sheet1!$B$4:$D$4", Marker: ChartMarker{Symbol: "x"}},
+	}
+	for _, chartType := range []ChartType{
+		Area, AreaStacked, AreaPercentStacked, Bar, BarStacked, BarPercentStacked, Col, ColStacked,
+		Col3DClustered, Doughnut, Line, Pie, Pie3D, Radar, Scatter,
+	} {
+		assert.NoError(t, f.AddChart("Sheet1", "F1", &Chart{
+			Type: chartType, Series: series,
+			Title:        ChartTitle{Paragraph: []RichTextRun{{Text: "Fruit & Sizes"}}},
+			Legend:       ChartLegend{Position: "bottom"},
+			PlotArea:     ChartPlotArea{ShowVal: true, ShowCatName: true, ShowPercent: true},
+			XAxis:        ChartAxis{Title: ChartTitle{Paragraph: []RichTextRun{{Text: "Fruit"}}}},
+			YAxis:        ChartAxis{MajorGridLines: true, Title: ChartTitle{Paragraph: []RichTextRun{{Text: "Amount"}}}},
+			ShowBlanksAs: "span",
+		}))
+		img, err := f.RenderChart("Sheet1", "F1", "svg")
+		assert.NoError(t, err)
+		svg := string(img)
+		assert.True(t, strings.HasPrefix(svg, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"480\" height=\"260\""))
+		assert.True(t, strings.HasSuffix(svg, "</svg>"))
+		assert.Contains(t, svg, ">Fruit &amp; Sizes</text>")
+		assert.Contains(t, svg, ">Orange")
+		img, err = f.RenderChart("Sheet1", "F1", ".PNG")
+		assert.NoError(t, err)
+		png, err := png.Decode(bytes.NewReader(img))
+		assert.NoError(t, err)
+		assert.Equal(t, 480, png.Bounds().Dx())
+		assert.Equal(t, 260, png.Bounds().Dy())
+		assert.NoError(t, f.DeleteChart("Sheet1", "F1"))
+	}
+
+	// Test render combo chart with secondary axis and the chart on the chartsheet
+	assert.NoError(t, f.AddChart("Sheet1", "F1", &Chart{
+		Type: Col, Series: series[:1], YAxis: ChartAxis{ReverseOrder: true, NumFmt: ChartNumFmt{CustomNumFmt: "0.0"}},
+		Dimension: ChartDimension{Width: 320, Height: 240},
+	}, &Chart{Type: Line, Series: series[1:], YAxis: ChartAxis{Secondary: true}}))
+	img, err := f.RenderChart("Sheet1", "F1", "svg")
+	assert.NoError(t, err)
+	assert.Contains(t, string(img), "width=\"320\" height=\"240\"")
+	assert.Contains(t, string(img), ">3.0</text>")
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{Type: Pie, Series: series[:1]}))
+	img, err = f.RenderChart("Chart1", "", "svg")
+	assert.NoError(t, err)
+	assert.Contains(t, string(img), ">Apple</text>")
+
+	// Test render chart with the cached values if the references can't be resolved
+	f.Pkg.Store("xl/charts/chart99.xml", []byte(`<chartSpace xmlns="http://schemas.openxmlformats.org/drawingml/2006/chart"><chart><plotArea><barChart><ser><tx><v>Cached</v></tx><cat><strLit><ptCount val="2"/><pt idx="0"><v>A</v></pt></strLit></cat><val><numRef><f>SheetN!$A$1:$A$2</f><numCache><ptCount val="2"/><pt idx="1"><v>3</v></pt></numCache></numRef></val></ser></barChart></plotArea><legend/></chart></chartSpace>`))
+	canvas := &chartSVGCanvas{}
+	assert.NoError(t, f.renderChart(canvas, chartAnchor{width: 200, height: 100, path: "xl/charts/chart99.xml"}))
+	assert.Contains(t, canvas.buf.String(), ">Cached</text>")
+	assert.Contains(t, canvas.buf.String(), ">A</text>")
+
+	// Test render chart with unsupported image format
+	_, err = f.RenderChart("Sheet1", "F1", "bmp")
+	assert.Equal(t, ErrChartImageFormat, err)
+	// Test render chart on not exists chart
+	_, err = f.RenderChart("Sheet1", "A1", "svg")
+	assert.Equal(t, newNoExistChartError("Sheet1!A1"), err)
+	// Test render chart on not exists worksheet
+	_, err = f.RenderChart("SheetN", "F1", "svg")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test render chart with invalid sheet name
+	_, err = f.RenderChart("Sheet:1", "F1", "svg")
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test render chart with invalid cell reference
+	_, err = f.RenderChart("Sheet1", "A", "svg")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test render chart with unsupported charset chart part
+	f.Pkg.Store("xl/charts/chart16.xml", MacintoshCyrillicCharset)
+	_, err = f.RenderChart("Sheet1", "F1", "png")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.RenderChart("Sheet1", "F1", "svg")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test render chart on the worksheet without chart
+	f = NewFile()
+	_, err = f.RenderChart("Sheet1", "A1", "svg")
+	assert.Equal(t, newNoExistChartError("Sheet1!A1"), err)
+	// Test render chart with unsupported charset drawing part
+	assert.NoError(t, f.AddChart("Sheet1", "A1", &Chart{Type: Col, Series: series[:1]}))
+	f.Drawings.Delete("xl/drawings/drawing1.xml")
+	f.Pkg.Store("xl/drawings/drawing1.xml", MacintoshCyrillicCharset)
+	_, err = f.RenderChart("Sheet1", "A1", "svg")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestRenderChartWithWorkbook(t *testing.T) {
+	f, err := OpenFile(filepath.Join("test", "Book1.xlsx"))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.NoError(t, f.AddChart("Sheet2", "P1", &Chart{
+		Type:   Scatter,
+		Series: []ChartSeries{{Name: "Sheet2!$A$1", Categories: "Sheet2!$B$1:$B$3", Values: "Sheet2!$C$1:$C$3"}},
+	}))
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRenderChartWithWorkbook.xlsx")))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(filepath.Join("test", "TestRenderChartWithWorkbook.xlsx"))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	img, err := f.RenderChart("Sheet2", "P1", "svg")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(img), "<svg"))
+	var buf bytes.Buffer
+	assert.NoError(t, f.WritePDF(&buf, PDFOptions{Sheets: []string{"Sheet2"}}))
+	assert.Contains(t, strings.Join(pdfContents(t, buf.Bytes()), ""), " Tm (")
+	assert.NoError(t, f.Close())
+}
+
+func TestChartRenderHelpers(t *testing.T) {
+	assert.True(t, math.IsNaN(parseChartNumber("")))
+	assert.Equal(t, 1.5, parseChartNumber(" 1.5"))
+	assert.Equal(t, "0.3", formatChartNumber(0.1+0.2, "General"))
+	assert.Equal(t, "25%", formatChartNumber(0.25, "0%"))
+	assert.Equal(t, color.RGBA{R: 0x44, G: 0x72, B: 0xC4, A: 0xFF}, chartColor("4472C4"))
+	assert.Equal(t, uint8(0xFF), chartColor("ZZZZZZ").A)
+	assert.Len(t, smoothChartPoints([][2]float64{{0, 0}, {1, 1}, {2, 0}}), 17)
+	assert.Len(t, dashChartSegments([][2][2]float64{{{0, 0}, {14, 0}}}, 4, 3), 2)
+	axis := &chartAxis{kind: "val", font: &chartFont{size: 12}}
+	for _, value := range []float64{-8, -6, math.NaN()} {
+		axis.extend(value)
+	}
+	axis.prepareScale(300)
+	assert.Equal(t, []float64{-9, -8, -7, -6, -5, -4, -3, -2, -1, 0}, axis.ticks())
+	cr := &chartRender{f: NewFile()}
+	assert.Empty(t, cr.getColor(&decodeChartSolidFill{SchemeClr: &decodeChartColor{Val: "phClr"}}))
+	assert.Empty(t, cr.getColor(&decodeChartSolidFill{SrgbClr: &decodeChartColor{Val: "XYZ"}}))
+	assert.Equal(t, "5B9BD5", cr.getAutoColor(0))
+	assert.Equal(t, "255E91", cr.getAutoColor(6))
+	assert.Equal(t, "7CAFDD", cr.getAutoColor(12))
+	assert.Equal(t, "Title", cr.getText(&decodeChartTx{StrRef: &cStrRef{F: "\"Title\""}}))
+	assert.Equal(t, "Value", cr.getText(&decodeChartTx{V: "Value"}))
+
+	// Test get chart reference values with quoted worksheet name and the range
+	// exceeds the used range of the worksheet
+	f := NewFile()
+	_, err := f.NewSheet("Sheet,1")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet,1", "A1", &[]interface{}{1, "a"}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", 2.5))
+	values, ok := f.getChartRefValues("('Sheet,1'!$A$1:$B$1048576,Sheet1!$A$1:$A$1048576)")
+	assert.True(t, ok)
+	assert.Len(t, values, 4)
+	assert.Equal(t, "1", values[0].text)
+	assert.Equal(t, 1.0, values[0].number)
+	assert.Equal(t, "a", values[1].text)
+	assert.True(t, math.IsNaN(values[2].number))
+	assert.Equal(t, 2.5, values[3].number)
+	_, ok = f.getChartRefValues("SheetN!$A$1")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"'a,''b'!A1", "B1", ""}, splitRefs("'a,''b'!A1,B1,"))
+}
@@ -15,6 +15,7 @@ import (
 	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/hmac"
 	"crypto/md5"
 	"crypto/rand"
 	"crypto/sha1"
@@ -38,12 +39,18 @@ import (
 
 var (
 	blockKey                    = []byte{0x14, 0x6e, 0x0b, 0xe7, 0xab, 0xac, 0xd0, 0xd6} // Block keys used for encryption
+	hmacKeyBlockKey             = []byte{0x5f, 0xb2, 0xad, 0x01, 0x0c, 0xb9, 0xe1, 0xf6}
+	hmacValueBlockKey           = []byte{0xa0, 0x67, 0x7f, 0x02, 0xb2, 0x2c, 0x84, 0x33}
+	verifierHashInputBlockKey   = []byte{0xfe, 0xa7, 0xd2, 0x76, 0x3b, 0x4b, 0x9e, 0x79}
+	verifierHashValueBlockKey   = []byte{0xd7, 0xaa, 0x0f, 0x6d, 0x30, 0x61, 0x34, 0x4e}
 	oleIdentifier               = []byte{0xd0, 0xcf, 0x11, 0xe0, 0xa1, 0xb1, 0x1a, 0xe1}
 	headerCLSID                 = make([]byte, 16)
 	difSect                     = -4
 	endOfChain                  = -2
 	fatSect                     = -3
 	iterCount                   = 50000
+	agileEncryptionSpinCount    = 100000
+	maxEncryptionSpinCount      = 10000000
 	packageEncryptionChunkSize  = 4096
 	packageOffset               = 8 // First 8 bytes are the size of the stream
 	sheetProtectionSpinCount    = 1e5
@@ -159,25 +166,34 @@ func Decrypt(raw []byte, opts *Options) (packageBuf []byte, err error) {
 	return standardDecrypt(encryptionInfoBuf, encryptedPackageBuf, opts)
 }
 
-// Encrypt API encrypt data with the password.
+// Encrypt API encrypt data with the password. The ECMA-376 standard
+// encryption with 128-bit AES key will be used by default, and the ECMA-376
+// agile encryption will be used if the EncryptionMechanism option is "agile".
+// For example, save the spreadsheet with agile encryption using AES-256 and
+// SHA-512:
+//
+//	err := f.SaveAs("Book1.xlsx", excelize.Options{
+//	    Password:                  "password",
+//	    EncryptionMechanism:       "agile",
+//	    EncryptionCipherAlgorithm: "AES-256",
+//	    EncryptionHashAlgorithm:   "SHA-512",
+//	})
 func Encrypt(raw []byte, opts *Options) ([]byte, error) {
-	encryptor := encryption{
-		EncryptedVerifierHashInput: make([]byte, 16),
-		EncryptedVerifierHashValue: make([]byte, 32),
-		SaltValue:                  make([]byte, 16),
-		BlockSize:                  16,
-		KeyBits:                    128,
-		SaltSize:                   16,
+	var (
+		encryptionInfoBuffer, encryptedPackage []byte
+		err                                    error
+	)
+	switch strings.ToLower(opts.EncryptionMechanism) {
+	case "", "standard":
+		encryptionInfoBuffer, encryptedPackage, err = standardEncrypt(raw, opts)
+	case "agile":
+		encryptionInfoBuffer, encryptedPackage, err = agileEncrypt(raw, opts)
+	default:
+		err = ErrUnsupportedEncryptMechanism
 	}
-	// Key Encryption
-	encryptionInfoBuffer, err := encryptor.standardKeyEncryption(opts.Password)
 	if err != nil {
 		return nil, err
 	}
-	// Package Encryption
-	encryptedPackage := make([]byte, 8)
-	binary.LittleEndian.PutUint64(encryptedPackage, uint64(len(raw)))
-	encryptedPackage = append(encryptedPackage, encryptor.encrypt(raw)...)
 	// Create a new CFB
 	compoundFile := &cfb{
 		paths:   []string{"Root Entry/"},
@@ -232,6 +248,29 @@ func encryptionMechanism(buffer []byte) (mechanism string, err error) {
 
 // ECMA-376 Standard Encryption
 
+// standardEncrypt encrypt the package with ECMA-376 standard encryption, and
+// returns the encryption info and encrypted package stream.
+func standardEncrypt(raw []byte, opts *Options) ([]byte, []byte, error) {
+	encryptor := encryption{
+		EncryptedVerifierHashInput: make([]byte, 16),
+		EncryptedVerifierHashValue: make([]byte, 32),
+		SaltValue:                  make([]byte, 16),
+		BlockSize:                  16,
+		KeyBits:                    128,
+		SaltSize:                   16,
+	}
+	// Key Encryption
+	encryptionInfoBuffer, err := encryptor.standardKeyEncryption(opts.Password)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Package Encryption
+	encryptedPackage := make([]byte, 8)
+	binary.LittleEndian.PutUint64(encryptedPackage, uint64(len(raw)))
+	encryptedPackage = append(encryptedPackage, encryptor.encrypt(raw)...)
+	return encryptionInfoBuffer, encryptedPackage, nil
+}
+
 // standardDecrypt decrypt the CFB file format with ECMA-376 standard encryption.
 func standardDecrypt(encryptionInfoBuf, encryptedPackageBuf []byte, opts *Options) ([]byte, error) {
 	encryptionHeaderSize := binary.LittleEndian.Uint32(encryptionInfoBuf[8:12])
@@ -398,6 +437,117 @@ func (e *encryption) standardKeyEncryption(password string) ([]byte, error) {
 
 // ECMA-376 Agile Encryption
 
+// agileEncrypt encrypt the package with ECMA-376 agile encryption, and returns
+// the encryption info and encrypted package stream.
+func agileEncrypt(raw []byte, opts *Options) ([]byte, []byte, error) {
+	if countUTF16String(opts.Password) == 0 || countUTF16String(opts.Password) > MaxFieldLength {
+		return nil, nil, ErrPasswordLengthInvalid
+	}
+	keyBits, ok := map[string]int{
+		"":        256,
+		"AES-128": 128,
+		"AES-192": 192,
+		"AES-256": 256,
+	}[strings.ToUpper(opts.EncryptionCipherAlgorithm)]
+	if !ok {
+		return nil, nil, ErrUnsupportedCipherAlgorithm
+	}
+	hashAlgorithm, ok := map[string]string{
+		"":        "SHA512",
+		"SHA-1":   "SHA1",
+		"SHA-256": "SHA256",
+		"SHA-384": "SHA384",
+		"SHA-512": "SHA512",
+	}[strings.ToUpper(opts.EncryptionHashAlgorithm)]
+	if !ok {
+		return nil, nil, ErrUnsupportedHashAlgorithm
+	}
+	spinCount := opts.EncryptionSpinCount
+	if spinCount == 0 {
+		spinCount = agileEncryptionSpinCount
+	}
+	if spinCount < 0 || spinCount > maxEncryptionSpinCount {
+		return nil, nil, ErrParameterInvalid
+	}
+	hashSize := len(hashing(hashAlgorithm))
+	var randoms [5][]byte
+	for i, size := range []int{keyBits / 8, 16, 16, 16, hashSize} {
+		buf, err := randomBytes(size)
+		if err != nil {
+			return nil, nil, err
+		}
+		randoms[i] = buf
+	}
+	packageKey, keyDataSalt, passwdSalt, verifierHashInput, hmacKey := randoms[0], randoms[1], randoms[2], randoms[3], randoms[4]
+	keyData := KeyData{
+		SaltSize: 16, BlockSize: 16, KeyBits: keyBits, HashSize: hashSize,
+		CipherAlgorithm: "AES", CipherChaining: "ChainingModeCBC", HashAlgorithm: hashAlgorithm,
+		SaltValue: base64.StdEncoding.EncodeToString(keyDataSalt),
+	}
+	encryptionInfo := Encryption{KeyData: keyData}
+	// Use the package key to encrypt the package.
+	encryptedPackage, err := encryptPackage(packageKey, raw, encryptionInfo)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Generate the data integrity with HMAC key and value.
+	for _, item := range []struct {
+		blockKey, input []byte
+		value           *string
+	}{
+		{hmacKeyBlockKey, hmacKey, &encryptionInfo.DataIntegrity.EncryptedHmacKey},
+		{hmacValueBlockKey, hmacHashing(hashAlgorithm, hmacKey, encryptedPackage), &encryptionInfo.DataIntegrity.EncryptedHmacValue},
+	} {
+		iv, err := createIV(item.blockKey, encryptionInfo)
+		if err != nil {
+			return nil, nil, err
+		}
+		output, err := encrypt(packageKey, iv, item.input)
+		if err != nil {
+			return nil, nil, err
+		}
+		*item.value = base64.StdEncoding.EncodeToString(output)
+	}
+	// Convert the password into the encryption keys, and use the keys to
+	// encrypt the verifier and package key.
+	encryptedKey := EncryptedKey{SpinCount: spinCount, KeyData: keyData}
+	encryptedKey.SaltValue = base64.StdEncoding.EncodeToString(passwdSalt)
+	passwdHash, err := hashPasswd(opts.Password, hashAlgorithm, passwdSalt, spinCount)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, item := range []struct {
+		blockKey, input []byte
+		value           *string
+	}{
+		{verifierHashInputBlockKey, verifierHashInput, &encryptedKey.EncryptedVerifierHashInput},
+		{verifierHashValueBlockKey, hashing(hashAlgorithm, verifierHashInput), &encryptedKey.EncryptedVerifierHashValue},
+		{blockKey, packageKey, &encryptedKey.EncryptedKeyValue},
+	} {
+		output, err := encrypt(deriveKey(hashAlgorithm, passwdHash, item.blockKey, keyBits), passwdSalt, item.input)
+		if err != nil {
+			return nil, nil, err
+		}
+		*item.value = base64.StdEncoding.EncodeToString(output)
+	}
+	encryptionInfo.KeyEncryptors.KeyEncryptor = []KeyEncryptor{
+		{URI: NameSpaceKeyEncryptorPassword, EncryptedKey: encryptedKey},
+	}
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	if err = xml.NewEncoder(&buf).EncodeElement(encryptionInfo, xml.StartElement{
+		Name: xml.Name{Local: "encryption"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: NameSpaceEncryption}},
+	}); err != nil {
+		return nil, nil, err
+	}
+	var storage cfb
+	storage.writeUint16(0x0004)
+	storage.writeUint16(0x0004)
+	storage.writeUint32(0x40)
+	return append(storage.stream, buf.Bytes()...), encryptedPackage, err
+}
+
 // agileDecrypt decrypt the CFB file format with ECMA-376 agile encryption.
 // Support cryptographic algorithm: MD4, MD5, RIPEMD-160, SHA1, SHA256,
 // SHA384 and SHA512.
@@ -422,42 +572,63 @@ func agileDecrypt(encryptionInfoBuf, encryptedPackageBuf []byte, opts *Options)
 		return
 	}
 	packageKey, _ := decrypt(key, saltValue, encryptedKeyValue)
+	// Truncate the padding of the package key to get to length of keyBits.
+	if keyBytes := encryptionInfo.KeyData.KeyBits / 8; keyBytes > 0 && len(packageKey) > keyBytes {
+		packageKey = packageKey[:keyBytes]
+	}
 	// Use the package key to decrypt the package.
 	return decryptPackage(packageKey, encryptedPackageBuf, encryptionInfo)
 }
 
 // convertPasswdToKey convert the password into an encryption key.
 func convertPasswdToKey(passwd string, blockKey []byte, encryption Encryption) (key []byte, err error) {
-	var b bytes.Buffer
-	saltValue, err := base64.StdEncoding.DecodeString(encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey.SaltValue)
+	encryptedKey := encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey
+	saltValue, err := base64.StdEncoding.DecodeString(encryptedKey.SaltValue)
 	if err != nil {
 		return
 	}
+	if key, err = hashPasswd(passwd, encryption.KeyData.HashAlgorithm, saltValue, encryptedKey.SpinCount); err != nil {
+		return
+	}
+	return deriveKey(encryption.KeyData.HashAlgorithm, key, blockKey, encryptedKey.KeyBits), err
+}
+
+// hashPasswd generate the iterated hash of the password by given password,
+// hash algorithm, salt value and spin count.
+func hashPasswd(passwd, hashAlgorithm string, saltValue []byte, spinCount int) ([]byte, error) {
+	var b bytes.Buffer
 	b.Write(saltValue)
 	encoder := unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewEncoder()
 	passwordBuffer, err := encoder.Bytes([]byte(passwd))
 	if err != nil {
-		return
+		return nil, err
 	}
 	b.Write(passwordBuffer)
 	// Generate the initial hash.
-	key = hashing(encryption.KeyData.HashAlgorithm, b.Bytes())
+	key := hashing(hashAlgorithm, b.Bytes())
 	// Now regenerate until spin count.
-	for i := 0; i < encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey.SpinCount; i++ {
+	for i := 0; i < spinCount; i++ {
 		iterator := createUInt32LEBuffer(i, 4)
-		key = hashing(encryption.KeyData.HashAlgorithm, iterator, key)
+		key = hashing(hashAlgorithm, iterator, key)
 	}
+	return key, err
+}
+
+// deriveKey generate the encryption key by given hash algorithm, iterated
+// password hash, block key and key bits.
+func deriveKey(hashAlgorithm string, passwdHash, blockKey []byte, keyBits int) []byte {
 	// Now generate the final hash.
-	key = hashing(encryption.KeyData.HashAlgorithm, key, blockKey)
+	key := hashing(hashAlgorithm, passwdHash, blockKey)
 	// Truncate or pad as needed to get to length of keyBits.
-	keyBytes := encryption.KeyEncryptors.KeyEncryptor[0].EncryptedKey.KeyBits / 8
-	if len(key) < keyBytes {
-		tmp := make([]byte, 0x36)
-		key = append(key, tmp...)
-	} else if len(key) > keyBytes {
-		key = key[:keyBytes]
+	return padBytes(key, keyBits/8)
+}
+
+// padBytes truncate or pad the given bytes with 0x36 to the given length.
+func padBytes(buf []byte, size int) []byte {
+	if len(buf) < size {
+		return append(buf, bytes.Repeat([]byte{0x36}, size-len(buf))...)
 	}
-	return
+	return buf[:size]
 }
 
 // hashing data by specified hash algorithm.
@@ -482,6 +653,26 @@ func hashing(hashAlgorithm string, buffer ...[]byte) (key []byte) {
 	return key
 }
 
+// hmacHashing generate the keyed-hash message authentication code (HMAC) of
+// data by specified hash algorithm and key.
+func hmacHashing(hashAlgorithm string, key []byte, buffer ...[]byte) []byte {
+	hashMap := map[string]func() hash.Hash{
+		"sha1":   sha1.New,
+		"sha256": sha256.New,
+		"sha384": sha512.New384,
+		"sha512": sha512.New,
+	}
+	fn, ok := hashMap[strings.ToLower(hashAlgorithm)]
+	if !ok {
+		return nil
+	}
+	handler := hmac.New(fn, key)
+	for _, buf := range buffer {
+		_, _ = handler.Write(buf)
+	}
+	return handler.Sum(nil)
+}
+
 // createUInt32LEBuffer create buffer with little endian 32-bit unsigned
 // integer.
 func createUInt32LEBuffer(value int, bufferSize int) []byte {
@@ -507,6 +698,45 @@ func decrypt(key, iv, input []byte) (packageKey []byte, err error) {
 	return input, nil
 }
 
+// encrypt provides a function to encrypt input by AES cryptographic algorithm
+// with CBC chaining mode by given key and initialization vector, the input
+// will be padded to an integer multiple of the block size.
+func encrypt(key, iv, input []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	output := make([]byte, len(input))
+	copy(output, input)
+	if remainder := len(output) % block.BlockSize(); remainder != 0 {
+		output = append(output, make([]byte, block.BlockSize()-remainder)...)
+	}
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(output, output)
+	return output, nil
+}
+
+// encryptPackage encrypt package by given packageKey and encryption info, and
+// returns the encrypted package stream with the package size.
+func encryptPackage(packageKey, input []byte, encryption Encryption) ([]byte, error) {
+	outputChunks := make([]byte, packageOffset)
+	binary.LittleEndian.PutUint64(outputChunks, uint64(len(input)))
+	for i, start := 0, 0; start < len(input); i, start = i+1, start+packageEncryptionChunkSize {
+		end := min(start+packageEncryptionChunkSize, len(input))
+		// Create the initialization vector
+		iv, err := createIV(i, encryption)
+		if err != nil {
+			return nil, err
+		}
+		// Encrypt the chunk and add it to the array
+		outputChunk, err := encrypt(packageKey, iv, input[start:end])
+		if err != nil {
+			return nil, err
+		}
+		outputChunks = append(outputChunks, outputChunk...)
+	}
+	return outputChunks, nil
+}
+
 // decryptPackage decrypt package by given packageKey and encryption
 // info.
 func decryptPackage(packageKey, input []byte, encryption Encryption) (outputChunks []byte, err error) {
@@ -569,14 +799,7 @@ func createIV(blockKey interface{}, encryption Encryption) ([]byte, error) {
 	}
 	// Create the initialization vector by hashing the salt with the block key.
 	// Truncate or pad as needed to meet the block size.
-	iv := hashing(encryptedKey.HashAlgorithm, append(saltValue, blockKeyBuf...))
-	if len(iv) < encryptedKey.BlockSize {
-		tmp := make([]byte, 0x36)
-		iv = append(iv, tmp...)
-	} else if len(iv) > encryptedKey.BlockSize {
-		iv = iv[:encryptedKey.BlockSize]
-	}
-	return iv, nil
+	return padBytes(hashing(encryptedKey.HashAlgorithm, append(saltValue, blockKeyBuf...)), encryptedKey.BlockSize), nil
 }
 
 // randomBytes returns securely generated random bytes. It will return an
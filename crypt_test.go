@@ -200,6 +200,113 @@ func TestEncrypt(t *testing.T) {
 	compoundFile.writeDirectoryEntry([]int{1, 0, 1, 0, 1, 0, 0, 0})
 }
 
+func TestAgileEncrypt(t *testing.T) {
+	for _, opts := range []Options{
+		{Password: "password", EncryptionMechanism: "agile"},
+		{Password: "password", EncryptionMechanism: "agile", EncryptionCipherAlgorithm: "AES-128", EncryptionHashAlgorithm: "SHA-1", EncryptionSpinCount: 10},
+		{Password: "password", EncryptionMechanism: "agile", EncryptionCipherAlgorithm: "AES-192", EncryptionHashAlgorithm: "SHA-256", EncryptionSpinCount: 10},
+		{Password: "password", EncryptionMechanism: "agile", EncryptionCipherAlgorithm: "AES-256", EncryptionHashAlgorithm: "SHA-384", EncryptionSpinCount: 10},
+	} {
+		f := NewFile()
+		assert.NoError(t, f.SetCellValue("Sheet1", "A1", strings.Repeat("SECRET", 1000)))
+		path := filepath.Join("test", "TestAgileEncrypt.xlsx")
+		assert.NoError(t, f.SaveAs(path, opts))
+		assert.NoError(t, f.Close())
+		raw, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		doc, err := mscfb.New(bytes.NewReader(raw))
+		assert.NoError(t, err)
+		encryptionInfoBuf, _, err := extractPart(doc)
+		assert.NoError(t, err)
+		mechanism, err := encryptionMechanism(encryptionInfoBuf)
+		assert.NoError(t, err)
+		assert.Equal(t, "agile", mechanism)
+		encryptionInfo, err := parseEncryptionInfo(encryptionInfoBuf[8:])
+		assert.NoError(t, err)
+		assert.NotEmpty(t, encryptionInfo.DataIntegrity.EncryptedHmacKey)
+		assert.NotEmpty(t, encryptionInfo.DataIntegrity.EncryptedHmacValue)
+		// Test open spreadsheet with incorrect password
+		_, err = OpenFile(path, Options{Password: "passwd"})
+		assert.Equal(t, ErrWorkbookPassword, err)
+		// Test open spreadsheet with password
+		f, err = OpenFile(path, Options{Password: "password"})
+		assert.NoError(t, err)
+		cell, err := f.GetCellValue("Sheet1", "A1")
+		assert.NoError(t, err)
+		assert.Equal(t, strings.Repeat("SECRET", 1000), cell)
+		assert.NoError(t, f.Close())
+	}
+	// Test encrypt spreadsheet with invalid options
+	for _, opts := range []struct {
+		opts Options
+		err  error
+	}{
+		{Options{Password: "password", EncryptionMechanism: "extensible"}, ErrUnsupportedEncryptMechanism},
+		{Options{Password: strings.Repeat("*", MaxFieldLength+1), EncryptionMechanism: "agile"}, ErrPasswordLengthInvalid},
+		{Options{Password: "password", EncryptionMechanism: "agile", EncryptionCipherAlgorithm: "RC4"}, ErrUnsupportedCipherAlgorithm},
+		{Options{Password: "password", EncryptionMechanism: "agile", EncryptionHashAlgorithm: "MD5"}, ErrUnsupportedHashAlgorithm},
+		{Options{Password: "password", EncryptionMechanism: "agile", EncryptionSpinCount: -1}, ErrParameterInvalid},
+		{Options{Password: "password", EncryptionMechanism: "agile", EncryptionSpinCount: maxEncryptionSpinCount + 1}, ErrParameterInvalid},
+	} {
+		_, err := Encrypt([]byte{}, &opts.opts)
+		assert.Equal(t, opts.err, err)
+	}
+	// Test encrypt with invalid key
+	_, err := encrypt(nil, nil, nil)
+	assert.EqualError(t, err, "crypto/aes: invalid key size 0")
+	_, err = encryptPackage(nil, []byte{0}, Encryption{KeyData: KeyData{HashAlgorithm: "sha512", BlockSize: 16}})
+	assert.EqualError(t, err, "crypto/aes: invalid key size 0")
+	_, err = encryptPackage(make([]byte, 32), []byte{0}, Encryption{KeyData: KeyData{SaltValue: "=="}})
+	assert.EqualError(t, err, "illegal base64 data at input byte 0")
+}
+
+func TestAgileEncryptDataIntegrity(t *testing.T) {
+	raw := bytes.Repeat([]byte("SECRET"), 1000)
+	for _, hashAlgorithm := range []string{"SHA-1", "SHA-256", "SHA-512"} {
+		opts := &Options{Password: "password", EncryptionMechanism: "agile", EncryptionHashAlgorithm: hashAlgorithm, EncryptionSpinCount: 10}
+		buf, err := Encrypt(raw, opts)
+		assert.NoError(t, err)
+		doc, err := mscfb.New(bytes.NewReader(buf))
+		assert.NoError(t, err)
+		encryptionInfoBuf, encryptedPackageBuf, err := extractPart(doc)
+		assert.NoError(t, err)
+		encryptionInfo, err := parseEncryptionInfo(encryptionInfoBuf[8:])
+		assert.NoError(t, err)
+		// Get the package key by the password
+		key, err := convertPasswdToKey(opts.Password, blockKey, encryptionInfo)
+		assert.NoError(t, err)
+		encryptedKey := encryptionInfo.KeyEncryptors.KeyEncryptor[0].EncryptedKey
+		saltValue, err := base64.StdEncoding.DecodeString(encryptedKey.SaltValue)
+		assert.NoError(t, err)
+		encryptedKeyValue, err := base64.StdEncoding.DecodeString(encryptedKey.EncryptedKeyValue)
+		assert.NoError(t, err)
+		packageKey, err := decrypt(key, saltValue, encryptedKeyValue)
+		assert.NoError(t, err)
+		packageKey = packageKey[:encryptionInfo.KeyData.KeyBits/8]
+		// Decrypt the HMAC key and value by the package key
+		var hmacKeyValue [2][]byte
+		for i, item := range []struct {
+			blockKey []byte
+			value    string
+		}{
+			{hmacKeyBlockKey, encryptionInfo.DataIntegrity.EncryptedHmacKey},
+			{hmacValueBlockKey, encryptionInfo.DataIntegrity.EncryptedHmacValue},
+		} {
+			iv, err := createIV(item.blockKey, encryptionInfo)
+			assert.NoError(t, err)
+			encrypted, err := base64.StdEncoding.DecodeString(item.value)
+			assert.NoError(t, err)
+			decrypted, err := decrypt(packageKey, iv, encrypted)
+			assert.NoError(t, err)
+			hmacKeyValue[i] = decrypted[:encryptionInfo.KeyData.HashSize]
+		}
+		assert.Equal(t, hmacHashing(encryptionInfo.KeyData.HashAlgorithm, hmacKeyValue[0], encryptedPackageBuf), hmacKeyValue[1], hashAlgorithm)
+		// Test the HMAC value should not match the modified package
+		encryptedPackageBuf[len(encryptedPackageBuf)-1] ^= 0xFF
+		assert.NotEqual(t, hmacHashing(encryptionInfo.KeyData.HashAlgorithm, hmacKeyValue[0], encryptedPackageBuf), hmacKeyValue[1], hashAlgorithm)
+	}
+}
+
 func TestEncryptionMechanism(t *testing.T) {
 	mechanism, err := encryptionMechanism([]byte{3, 0, 3, 0})
 	assert.Equal(t, mechanism, "extensible")
@@ -210,6 +317,7 @@ func TestEncryptionMechanism(t *testing.T) {
 
 func TestHashing(t *testing.T) {
 	assert.Equal(t, hashing("unsupportedHashAlgorithm", []byte{}), []byte(nil))
+	assert.Equal(t, hmacHashing("unsupportedHashAlgorithm", []byte{}, []byte{}), []byte(nil))
 }
 
 func TestGenISOPasswdHash(t *testing.T) {
@@ -0,0 +1,313 @@
+// Copyright 2016 - 2026 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.25.0 or later.
+
+package excelize
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/nfp"
+)
+
+// csvNumberExp defined the regular expression of the numbers which will be
+// inferred from the CSV data, the numbers with leading zeros or more than 15
+// significant digits will be kept as text for preserving the identifiers such
+// as postal codes and card numbers.
+var csvNumberExp = regexp.MustCompile(`^[-+]?(0|[1-9]\d{0,14})?(\.\d{1,15})?([eE][-+]?\d{1,3})?$`)
+
+// CSVOptions directly maps the settings of importing and exporting the CSV
+// data.
+//
+// Delimiter specifies the field delimiter, the default value is comma. Use
+// '\t' for the TSV data.
+//
+// Charset specifies the character encoding label of the imported CSV data,
+// such as "GBK" or "ISO-8859-1", the data will be decoded by the charset
+// transcoder of the spreadsheet when the value is not empty and not UTF-8,
+// the transcoder could be customized by the CharsetTranscoder function.
+//
+// HeaderRow specifies if the first record is the header row, the header row
+// will be imported as text without type inference.
+//
+// InferTypes specifies if infer the numbers, boolean and date values on
+// importing the CSV data. The date values in ISO 8601 format or in the short
+// date pattern specified by the ShortDatePattern of the spreadsheet options
+// will be converted to date cells.
+//
+// LazyQuotes specifies if a quote may appear in an unquoted field and a
+// non-doubled quote may appear in a quoted field on importing.
+//
+// RawCellValue specifies if export the raw cell values instead of the
+// formatted cell values, the RawCellValue of the spreadsheet options also
+// applies.
+//
+// UseCRLF specifies if use \r\n as the line terminator on exporting.
+type CSVOptions struct {
+	Delimiter    rune
+	Charset      string
+	HeaderRow    bool
+	InferTypes   bool
+	LazyQuotes   bool
+	RawCellValue bool
+	UseCRLF      bool
+}
+
+// getCSVOptions provides a function to get the CSV options with default
+// values by given options.
+func getCSVOptions(opts ...CSVOptions) CSVOptions {
+	var options CSVOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	if options.Delimiter == 0 {
+		options.Delimiter = ','
+	}
+	return options
+}
+
+// ImportCSV provides a function to import the CSV data into the worksheet by
+// given worksheet name, reader and CSV options. The worksheet will be created
+// if not exists, and the existing cells in the worksheet will be replaced.
+// The data will be written by the stream writer without loading all records
+// into memory, and the worksheet will be left unchanged if the CSV data can't
+// be imported. For example, import the TSV data in GBK encoding with the
+// header row and infer the numbers and dates:
+//
+//	f := excelize.NewFile(excelize.Options{ShortDatePattern: "dd/mm/yyyy"})
+//	err := f.ImportCSV("Sheet1", file, excelize.CSVOptions{
+//	    Delimiter:  '\t',
+//	    Charset:    "GBK",
+//	    HeaderRow:  true,
+//	    InferTypes: true,
+//	})
+func (f *File) ImportCSV(sheet string, r io.Reader, opts ...CSVOptions) error {
+	options := getCSVOptions(opts...)
+	if options.Charset != "" && !strings.EqualFold(strings.ReplaceAll(options.Charset, "-", ""), "utf8") {
+		var err error
+		if r, err = f.CharsetReader(options.Charset, r); err != nil {
+			return err
+		}
+	}
+	br := bufio.NewReader(r)
+	if bom, err := br.Peek(3); err == nil && bytes.Equal(bom, []byte{0xEF, 0xBB, 0xBF}) {
+		_, _ = br.Discard(3)
+	}
+	idx, err := f.GetSheetIndex(sheet)
+	if err != nil {
+		return err
+	}
+	if _, err = f.NewSheet(sheet); err != nil {
+		return err
+	}
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		return err
+	}
+	cr := csv.NewReader(br)
+	cr.Comma, cr.LazyQuotes, cr.FieldsPerRecord, cr.ReuseRecord = options.Delimiter, options.LazyQuotes, -1, true
+	if err = f.writeCSVRows(sw, cr, &options); err != nil {
+		sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+		delete(f.streams, sheetXMLPath)
+		_ = sw.rawData.Close()
+		if idx == -1 {
+			_ = f.DeleteSheet(sheet)
+		}
+		return err
+	}
+	return sw.Flush()
+}
+
+// writeCSVRows provides a function to read the CSV records by given CSV reader
+// and write them into the worksheet by the stream writer.
+func (f *File) writeCSVRows(sw *StreamWriter, cr *csv.Reader, options *CSVOptions) error {
+	layouts, dateStyle := f.getCSVDateLayouts(), 0
+	for row := 1; ; row++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if row > TotalRows {
+			return ErrMaxRows
+		}
+		values := make([]interface{}, len(record))
+		for i, field := range record {
+			if field == "" {
+				continue
+			}
+			if values[i] = field; !options.InferTypes || (options.HeaderRow && row == 1) {
+				continue
+			}
+			value, isDate := inferCSVValue(field, layouts)
+			if values[i] = value; isDate {
+				if dateStyle == 0 {
+					if dateStyle, err = f.NewStyle(&Style{NumFmt: 14}); err != nil {
+						return err
+					}
+				}
+				values[i] = Cell{StyleID: dateStyle, Value: value}
+			}
+		}
+		cell, _ := CoordinatesToCellName(1, row)
+		if err = sw.SetRow(cell, values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getCSVDateLayouts provides a function to get the time layouts for inferring
+// the date values in the CSV data, the ISO 8601 date and the short date
+// pattern of the spreadsheet options are supported.
+func (f *File) getCSVDateLayouts() []string {
+	layouts := []string{"2006-01-02", "2006-01-02 15:04", "2006-01-02 15:04:05", "2006-01-02T15:04:05", time.RFC3339}
+	if layout := numFmtToTimeLayout(f.options.ShortDatePattern); layout != "" {
+		layouts = append(layouts, layout, layout+" 15:04", layout+" 15:04:05")
+	}
+	return layouts
+}
+
+// numFmtToTimeLayout provides a function to convert the date number format
+// code to the time layout for parsing the date values, returns empty string
+// for the unsupported number format code.
+func numFmtToTimeLayout(code string) string {
+	var (
+		p        = nfp.NumberFormatParser()
+		sections = p.Parse(code)
+		layout   strings.Builder
+		date     bool
+	)
+	if len(sections) == 0 {
+		return ""
+	}
+	for _, token := range sections[0].Items {
+		switch token.TType {
+		case nfp.TokenTypeLiteral:
+			layout.WriteString(token.TValue)
+		case nfp.TokenTypeDateTimes:
+			value, ok := map[string]string{
+				"YY": "06", "YYYY": "2006", "M": "1", "MM": "1", "MMM": "Jan", "MMMM": "January",
+				"D": "2", "DD": "2", "DDD": "Mon", "DDDD": "Monday",
+			}[strings.ToUpper(token.TValue)]
+			if !ok {
+				return ""
+			}
+			layout.WriteString(value)
+			date = true
+		default:
+			return ""
+		}
+	}
+	if !date {
+		return ""
+	}
+	return layout.String()
+}
+
+// inferCSVValue provides a function to infer the number, boolean and date
+// value of the CSV field by given time layouts, returns the field as is if the
+// type can't be inferred, and returns true if the value is a date without
+// time.
+func inferCSVValue(field string, layouts []string) (interface{}, bool) {
+	value := strings.TrimSpace(field)
+	if csvNumberExp.MatchString(value) && strings.ContainsAny(value, "0123456789") {
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number, false
+		}
+	}
+	switch strings.ToUpper(value) {
+	case "TRUE":
+		return true, false
+	case "FALSE":
+		return false, false
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
+		}
+	}
+	return field, false
+}
+
+// ExportCSV provides a function to export the worksheet as CSV data by given
+// worksheet name, writer and CSV options. The formatted cell values will be
+// exported by default, and the worksheet data will be read by the rows
+// iterator without loading all rows into memory. For example, export the
+// raw cell values of the worksheet as TSV data:
+//
+//	err := f.ExportCSV("Sheet1", file, excelize.CSVOptions{
+//	    Delimiter:    '\t',
+//	    RawCellValue: true,
+//	})
+func (f *File) ExportCSV(sheet string, w io.Writer, opts ...CSVOptions) error {
+	options := getCSVOptions(opts...)
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	cw.Comma, cw.UseCRLF = options.Delimiter, options.UseCRLF
+	readOpts := *f.options
+	readOpts.RawCellValue = readOpts.RawCellValue || options.RawCellValue
+	var emptyRows int
+	for rows.Next() {
+		record, err := rows.Columns(readOpts)
+		if err != nil {
+			_ = rows.Close()
+			return err
+		}
+		if len(record) == 0 {
+			emptyRows++
+			continue
+		}
+		if emptyRows > 0 {
+			if err = writeCSVEmptyRows(cw, w, emptyRows, options.UseCRLF); err != nil {
+				_ = rows.Close()
+				return err
+			}
+			emptyRows = 0
+		}
+		if err = cw.Write(record); err != nil {
+			_ = rows.Close()
+			return err
+		}
+	}
+	if err = rows.Close(); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeCSVEmptyRows provides a function to write the empty rows as the records
+// with a quoted empty field, so that the blank lines will not be skipped on
+// reading the CSV data.
+func writeCSVEmptyRows(cw *csv.Writer, w io.Writer, count int, useCRLF bool) error {
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+	record := "\"\"\n"
+	if useCRLF {
+		record = "\"\"\r\n"
+	}
+	_, err := io.WriteString(w, strings.Repeat(record, count))
+	return err
+}
@@ -0,0 +1,150 @@
+package excelize
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportCSV(t *testing.T) {
+	f := NewFile(Options{ShortDatePattern: "dd/mm/yyyy"})
+	data := "\xEF\xBB\xBFID;Name;Amount;Date;Paid\n" +
+		"00123;\"Doe; John\";1,5;31/12/2024;true\n" +
+		"2;Smith;-2.5e2;2024-01-02 10:30;FALSE\n" +
+		"1234567890123456;;;\n"
+	assert.NoError(t, f.ImportCSV("Data", strings.NewReader(data), CSVOptions{Delimiter: ';', HeaderRow: true, InferTypes: true}))
+	rows, err := f.GetRows("Data")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"ID", "Name", "Amount", "Date", "Paid"},
+		{"00123", "Doe; John", "1,5", "31/12/2024", "TRUE"},
+		{"2", "Smith", "-250", "02/01/2024 10:30", "FALSE"},
+		{"1234567890123456"},
+	}, rows)
+	for cell, expected := range map[string]CellType{
+		"A1": CellTypeInlineString, "A2": CellTypeInlineString, "A3": CellTypeUnset, "C3": CellTypeUnset,
+		"D2": CellTypeUnset, "E2": CellTypeBool, "A4": CellTypeInlineString,
+	} {
+		typ, err := f.GetCellType("Data", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, typ, cell)
+	}
+	value, err := f.GetCellValue("Data", "D2", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "45657", value)
+
+	// Test import CSV data without type inference
+	assert.NoError(t, f.ImportCSV("Sheet1", strings.NewReader("1,TRUE\n")))
+	typ, err := f.GetCellType("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, CellTypeInlineString, typ)
+
+	// Test import CSV data in the non UTF-8 encoding
+	assert.NoError(t, f.ImportCSV("Sheet1", strings.NewReader("\xC4\xE3\xBA\xC3"), CSVOptions{Charset: "GBK"}))
+	value, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "你好", value)
+	f.CharsetTranscoder(func(charset string, input io.Reader) (io.Reader, error) {
+		if charset != "ISO-8859-1" {
+			return nil, errors.New("unsupported charset")
+		}
+		b, err := io.ReadAll(input)
+		runes := make([]rune, len(b))
+		for i, c := range b {
+			runes[i] = rune(c)
+		}
+		return strings.NewReader(string(runes)), err
+	})
+	assert.NoError(t, f.ImportCSV("Sheet1", strings.NewReader("caf\xE9"), CSVOptions{Charset: "ISO-8859-1"}))
+	value, err = f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "café", value)
+	assert.EqualError(t, f.ImportCSV("Sheet1", strings.NewReader("a"), CSVOptions{Charset: "GBK"}), "unsupported charset")
+
+	// Test import CSV data with invalid sheet name
+	assert.Equal(t, ErrSheetNameInvalid, f.ImportCSV("Sheet:1", strings.NewReader("a")))
+	// Test import CSV data with invalid quotes
+	assert.EqualError(t, f.ImportCSV("Sheet1", strings.NewReader("a\"b\n")), "parse error on line 1, column 2: bare \" in non-quoted-field")
+	assert.NoError(t, f.ImportCSV("Sheet1", strings.NewReader("a\"b\n"), CSVOptions{LazyQuotes: true}))
+	assert.NoError(t, f.Close())
+
+	// Test import invalid CSV data will keep the existing cells of the worksheet
+	f = NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "keep"))
+	assert.EqualError(t, f.ImportCSV("Sheet1", strings.NewReader("x\na\"b\n")), "parse error on line 2, column 2: bare \" in non-quoted-field")
+	assert.Empty(t, f.streams)
+	assert.NoError(t, f.SetCellValue("Sheet1", "B1", "after"))
+	var buf bytes.Buffer
+	assert.NoError(t, f.Write(&buf))
+	f, err = OpenReader(&buf)
+	assert.NoError(t, err)
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"keep", "after"}}, rows)
+	// Test import invalid CSV data into a new worksheet
+	assert.Error(t, f.ImportCSV("Sheet2", strings.NewReader("a\"b\n")))
+	assert.Equal(t, []string{"Sheet1"}, f.GetSheetList())
+	assert.NoError(t, f.Close())
+}
+
+func TestExportCSV(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Amount", "Date"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"a,b", 1.5, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}))
+	style, err := f.NewStyle(&Style{NumFmt: 2})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B3", "B3", style))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 5, 30))
+
+	var buf bytes.Buffer
+	assert.NoError(t, f.ExportCSV("Sheet1", &buf))
+	assert.Equal(t, "Name,Amount,Date\n\"\"\n\"a,b\",1.50,01-02-24\n", buf.String())
+	buf.Reset()
+	assert.NoError(t, f.ExportCSV("Sheet1", &buf, CSVOptions{Delimiter: '\t', RawCellValue: true, UseCRLF: true}))
+	assert.Equal(t, "Name\tAmount\tDate\r\n\"\"\r\na,b\t1.5\t45293\r\n", buf.String())
+
+	// Test export CSV data and import it into another worksheet
+	assert.NoError(t, f.ImportCSV("Sheet2", bytes.NewReader(buf.Bytes()), CSVOptions{Delimiter: '\t', InferTypes: true}))
+	value, err := f.GetCellValue("Sheet2", "B3")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", value)
+
+	// Test export CSV data with not exist worksheet
+	assert.EqualError(t, f.ExportCSV("SheetN", &buf), "sheet SheetN does not exist")
+	// Test export CSV data with unsupported charset shared strings table
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.ExportCSV("Sheet1", &buf), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestInferCSVValue(t *testing.T) {
+	layouts := []string{"2006-01-02", numFmtToTimeLayout("mmm d, yyyy")}
+	for field, expected := range map[string]interface{}{
+		"0": 0.0, "-0.5": -0.5, ".5": 0.5, "1e3": 1000.0, "007": "007", "1.": "1.", "+": "+",
+		"NaN": "NaN", "Inf": "Inf", "true": true, "False": false,
+		"2024-02-29":  time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		"Mar 1, 2024": time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		"2024-02-30":  "2024-02-30",
+	} {
+		value, _ := inferCSVValue(field, layouts)
+		assert.Equal(t, expected, value, field)
+	}
+	// Test infer the date without time in the time zone of the parsed value
+	for field, expected := range map[string]bool{
+		"2024-01-02T00:00:00+08:00": true, "2024-01-02T08:00:00+08:00": false,
+		"2024-01-02T00:00:00.5-05:00": false,
+	} {
+		_, dateOnly := inferCSVValue(field, []string{time.RFC3339Nano})
+		assert.Equal(t, expected, dateOnly, field)
+	}
+	assert.Empty(t, numFmtToTimeLayout(""))
+	assert.Empty(t, numFmtToTimeLayout("0.00"))
+	assert.Empty(t, numFmtToTimeLayout("hh:mm"))
+	assert.Empty(t, numFmtToTimeLayout("\"text\""))
+}
@@ -54,10 +54,9 @@ func (f *File) prepareChartSheetDrawing(cs *xlsxChartsheet, drawingID int, sheet
 	}
 }
 
-// addChart provides a function to create chart as xl/charts/chart%d.xml by
-// given format sets.
-func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
-	count := f.countCharts()
+// addChart provides a function to create or replace the chart part such as
+// xl/charts/chart%d.xml by given chart part path and format sets.
+func (f *File) addChart(chartXML string, opts *Chart, comboCharts []*Chart) {
 	xlsxChartSpace := xlsxChartSpace{
 		XMLNSa:         NameSpaceDrawingML.Value,
 		Date1904:       &attrValBool{Val: boolPtr(false)},
@@ -192,8 +191,7 @@ func (f *File) addChart(opts *Chart, comboCharts []*Chart) {
 		xlsxChartSpace.Chart.PlotArea.CatAx = nil
 	}
 	chart, _ := xml.Marshal(xlsxChartSpace)
-	media := "xl/charts/chart" + strconv.Itoa(count+1) + ".xml"
-	f.saveFileList(media, chart)
+	f.saveFileList(chartXML, chart)
 }
 
 // drawBaseChart provides a function to draw the c:plotArea element for bar,
@@ -29,6 +29,12 @@ var (
 	ErrCellCharsLength = fmt.Errorf("cell value must be 0-%d characters", TotalCellChars)
 	// ErrCellStyles defined the error message on cell styles exceeds the limit.
 	ErrCellStyles = fmt.Errorf("the cell styles exceeds the %d limit", MaxCellStyles)
+	// ErrChartImageFormat defined the error message on receiving the
+	// unsupported image format for rendering the chart.
+	ErrChartImageFormat = errors.New("unsupported chart image format")
+	// ErrChartSeriesMismatch defined the error message on the series of the
+	// chart format set not match the series of the existing chart.
+	ErrChartSeriesMismatch = errors.New("the series do not match the existing chart")
 	// ErrChartTitle defined the error message on both formula and rich text for
 	// chart title.
 	ErrChartTitle = errors.New("cannot set both 'Formula' and 'Paragraph' for chart title")
@@ -127,6 +133,17 @@ var (
 	// ErrPasswordLengthInvalid defined the error message on invalid password
 	// length.
 	ErrPasswordLengthInvalid = errors.New("password length invalid")
+	// ErrPDFFontFile defined the error message on receiving the unsupported
+	// font file for exporting the workbook as PDF document.
+	ErrPDFFontFile = errors.New("unsupported PDF font file, the TrueType font file is required")
+	// ErrPivotTableCalculatedField defined the error message on receiving the
+	// pivot table calculated field without name or formula, or the name of
+	// the calculated field already exists.
+	ErrPivotTableCalculatedField = errors.New("pivot table calculated field requires a unique name and formula")
+	// ErrPivotTableFieldGroup defined the error message on receiving the
+	// invalid range or interval for grouping the pivot table field, or the
+	// number of the group items exceeds the limit.
+	ErrPivotTableFieldGroup = errors.New("pivot table field group requires a positive interval and no more than 1048576 group items")
 	// ErrPivotTableShowValuesAsBaseField defined the error message on enable
 	// this kind of "show values as" type requires a base field.
 	ErrPivotTableShowValuesAsBaseField = errors.New("this kind of show values as type requires a base field")
@@ -189,6 +206,9 @@ var (
 	// ErrUnprotectWorkbookPassword defined the error message on remove workbook
 	// protection with password verification failed.
 	ErrUnprotectWorkbookPassword = errors.New("workbook protect password not match")
+	// ErrUnsupportedCipherAlgorithm defined the error message on unsupported
+	// cipher algorithm.
+	ErrUnsupportedCipherAlgorithm = errors.New("unsupported cipher algorithm")
 	// ErrUnsupportedEncryptMechanism defined the error message on unsupported
 	// encryption mechanism.
 	ErrUnsupportedEncryptMechanism = errors.New("unsupported encryption mechanism")
@@ -219,6 +239,31 @@ func (err ErrSheetNotExist) Error() string {
 	return fmt.Sprintf("sheet %s does not exist", err.SheetName)
 }
 
+// ErrCircularReference defined an error of formulas that refer to their own
+// cells either directly or indirectly.
+type ErrCircularReference struct {
+	Cells []string
+}
+
+// Error returns the error message on detecting circular references in the
+// workbook.
+func (err ErrCircularReference) Error() string {
+	return fmt.Sprintf("circular reference detected in cells %s", strings.Join(err.Cells, ", "))
+}
+
+// ErrCalcNotConverged defined an error of the iterative calculation of the
+// circular references that did not converge within the maximum number of
+// iterations.
+type ErrCalcNotConverged struct {
+	Cells []string
+}
+
+// Error returns the error message on the iterative calculation did not
+// converge.
+func (err ErrCalcNotConverged) Error() string {
+	return fmt.Sprintf("iterative calculation did not converge in cells %s", strings.Join(err.Cells, ", "))
+}
+
 // newAddCommentError defined the error message on the comment already exist in
 // the cell.
 func newAddCommentError(cell string) error {
@@ -327,6 +372,24 @@ func newInvalidStyleID(styleID int) error {
 	return fmt.Errorf("invalid style ID %d", styleID)
 }
 
+// newInvalidThreadedCommentMentionError defined the error message on
+// receiving the mentioned person which not exists in the threaded comment text.
+func newInvalidThreadedCommentMentionError(person string) error {
+	return fmt.Errorf("mentioned person %s does not exist in the threaded comment text", person)
+}
+
+// newNoExistChartError defined the error message on receiving the non existing
+// chart.
+func newNoExistChartError(name string) error {
+	return fmt.Errorf("chart %s does not exist", name)
+}
+
+// newNoExistExternalLinkError defined the error message on receiving the non
+// existing external link index or workbook name.
+func newNoExistExternalLinkError(link string) error {
+	return fmt.Errorf("external link %s does not exist", link)
+}
+
 // newNoExistSlicerError defined the error message on receiving the non existing
 // slicer name.
 func newNoExistSlicerError(name string) error {
@@ -339,12 +402,36 @@ func newNoExistTableError(name string) error {
 	return fmt.Errorf("table %s does not exist", name)
 }
 
+// newNoExistThreadedCommentError defined the error message on receiving the
+// cell reference which not exists threaded comment.
+func newNoExistThreadedCommentError(cell string) error {
+	return fmt.Errorf("threaded comment on cell %s does not exist", cell)
+}
+
 // newNotWorksheetError defined the error message on receiving a sheet which
 // not a worksheet.
 func newNotWorksheetError(name string) error {
 	return fmt.Errorf("sheet %s is not a worksheet", name)
 }
 
+// newODSRepeatedCellsError defined the error message on the repeated cells
+// of the OpenDocument spreadsheet exceeds the limit.
+func newODSRepeatedCellsError(limit int) error {
+	return fmt.Errorf("repeated cells exceeds the %d cells limit", limit)
+}
+
+// newPDFUnsupportedCharError defined the error message on receiving the
+// character which can't be encoded by the standard PDF fonts.
+func newPDFUnsupportedCharError(char rune) error {
+	return fmt.Errorf("the character %q can't be encoded by the standard PDF fonts, the FontFile option is required", char)
+}
+
+// newPivotTableCalculatedFieldError defined the error message on the pivot
+// table calculated field used as the row, column or filter field.
+func newPivotTableCalculatedFieldError(name string) error {
+	return fmt.Errorf("calculated field %s can only be used in the pivot table data fields", name)
+}
+
 // newPivotTableColFieldsError defined the error message on same data field
 // appears both in the pivot table column fields and filter fields.
 func newPivotTableColFieldsError(data []string) error {
@@ -369,6 +456,12 @@ func newPivotTableSelectedItemError(item, field string) error {
 	return fmt.Errorf("selected item %s does not exist in pivot table field %s", item, field)
 }
 
+// newPivotTableGroupByError defined the error message on receiving the
+// unsupported date time period for grouping the pivot table field.
+func newPivotTableGroupByError(groupBy string) error {
+	return fmt.Errorf("unsupported pivot table field group by %s", groupBy)
+}
+
 // newPivotTableRangeError defined the error message on receiving the invalid
 // pivot table range.
 func newPivotTableRangeError(msg string) error {
@@ -393,6 +486,12 @@ func newStreamSetRowOrderError(name string) error {
 	return fmt.Errorf("must call the %s function before the SetRow function", name)
 }
 
+// newStreamFlushOrderError defined the error message on calling the function
+// of the stream writer after the Flush function.
+func newStreamFlushOrderError(name string) error {
+	return fmt.Errorf("must call the %s function before the Flush function", name)
+}
+
 // newUnknownFilterTokenError defined the error message on receiving a unknown
 // filter operator token.
 func newUnknownFilterTokenError(token string) error {
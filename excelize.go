@@ -23,6 +23,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"golang.org/x/net/html/charset"
 )
@@ -38,12 +39,18 @@ type File struct {
 	sharedStringsMap map[string]int
 	sharedStringTemp *os.File
 	sheetMap         map[string]string
+	signers          []*signer
 	streams          map[string]*StreamWriter
 	tempFiles        sync.Map
 	xmlAttr          sync.Map
 	calcCache        sync.Map
 	calcRawCache     sync.Map
 	formulaArgCache  sync.Map
+	lambdaCache      sync.Map
+	condFmtCache     sync.Map
+	calcGraph        atomic.Pointer[calcGraph]
+	tableRefs        atomic.Pointer[[]tableRef]
+	customFuncs      sync.Map
 	CalcChain        *xlsxCalcChain
 	CharsetReader    func(charset string, input io.Reader) (rdr io.Reader, err error)
 	Comments         map[string]*xlsxComments
@@ -111,17 +118,51 @@ type ZipWriter interface {
 //
 // CultureInfo specifies the country code for applying built-in language number
 // format code these effect by the system's local language settings.
+//
+// ExternalLinkResolver specifies the function to open the linked external
+// workbook by given target path of the external link when calculating the
+// formulas which referencing external workbooks. The cached values in the
+// external link part will be used if this function is not specified or
+// returns a nil workbook. This function may be called many times in a
+// calculation, the returned workbook will not be closed by the calculation,
+// so the caller is responsible for caching and closing the workbooks it opens.
+// The circular references across workbooks will be calculated as #REF!
+// error.
+//
+// EncryptionMechanism specifies the encryption mechanism for saving the
+// spreadsheet with password, the possible values are "standard" and "agile".
+// The ECMA-376 standard encryption with 128-bit AES key will be used if this
+// value is empty, the agile encryption uses the password key encryptor and
+// HMAC data integrity verification, which is the default mechanism of the
+// latest spreadsheet applications.
+//
+// EncryptionCipherAlgorithm specifies the cipher algorithm for the agile
+// encryption, the possible values are "AES-128", "AES-192" and "AES-256", the
+// default value is "AES-256".
+//
+// EncryptionHashAlgorithm specifies the hash algorithm for the agile
+// encryption, the possible values are "SHA-1", "SHA-256", "SHA-384" and
+// "SHA-512", the default value is "SHA-512".
+//
+// EncryptionSpinCount specifies the number of times to iterate the password
+// hash for the agile encryption, the value should be less than or equal to
+// 10000000, and the default value 100000 will be used if this value is 0.
 type Options struct {
-	MaxCalcIterations uint
-	Password          string
-	RawCellValue      bool
-	UnzipSizeLimit    int64
-	UnzipXMLSizeLimit int64
-	TmpDir            string
-	ShortDatePattern  string
-	LongDatePattern   string
-	LongTimePattern   string
-	CultureInfo       CultureName
+	MaxCalcIterations         uint
+	Password                  string
+	RawCellValue              bool
+	UnzipSizeLimit            int64
+	UnzipXMLSizeLimit         int64
+	TmpDir                    string
+	ShortDatePattern          string
+	LongDatePattern           string
+	LongTimePattern           string
+	CultureInfo               CultureName
+	ExternalLinkResolver      func(target string) (*File, error)
+	EncryptionMechanism       string
+	EncryptionCipherAlgorithm string
+	EncryptionHashAlgorithm   string
+	EncryptionSpinCount       int
 }
 
 // OpenFile take the name of a spreadsheet file and returns a populated
@@ -130,6 +171,23 @@ type Options struct {
 //
 //	f, err := excelize.OpenFile("Book1.xlsx", excelize.Options{Password: "password"})
 //
+// The legacy Excel 97-2003 workbook (BIFF8 XLS file) is also supported, the
+// workbook globals, cell values, cell formats, merged cells and formulas with
+// cached values will be converted, and the spreadsheet should be saved as the
+// Office Open XML format by the SaveAs function. For example:
+//
+//	f, err := excelize.OpenFile("Book1.xls")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.SaveAs("Book1.xlsx")
+//
+// The OpenDocument spreadsheet (ODS file) is also supported, the cell values,
+// formulas, styles, merged cells, column width, row height and visibility of
+// the tables will be converted, and the spreadsheet could be saved in both
+// Office Open XML and OpenDocument spreadsheet format by the SaveAs function.
+//
 // Close the file by Close function after opening the spreadsheet.
 func OpenFile(filename string, opts ...Options) (*File, error) {
 	file, err := os.Open(filepath.Clean(filename))
@@ -207,6 +265,12 @@ func openReaderAt(r io.ReaderAt, size int64, opts ...Options) (*File, error) {
 	}
 	if bytes.Equal(header, oleIdentifier) {
 		b, _ := io.ReadAll(io.NewSectionReader(r, 0, size))
+		if stream, ok, err := getXLSWorkbookStream(b, f.options.UnzipSizeLimit); ok {
+			if err != nil {
+				return nil, err
+			}
+			return openXLS(stream, f.options)
+		}
 		b, err := Decrypt(b, f.options)
 		if err != nil {
 			return nil, ErrWorkbookFileFormat
@@ -220,6 +284,9 @@ func openReaderAt(r io.ReaderAt, size int64, opts ...Options) (*File, error) {
 		}
 		return nil, err
 	}
+	if isODSPackage(zr) {
+		return openODS(zr, f.options)
+	}
 	file, sheetCount, err := f.ReadZipReader(zr)
 	if err != nil {
 		return nil, err
@@ -470,6 +537,7 @@ func (f *File) addRels(relPath, relType, target, targetMode string) int {
 	uniqPart := map[string]string{
 		SourceRelationshipCustomProperties: "/docProps/custom.xml",
 		SourceRelationshipSharedStrings:    "/xl/sharedStrings.xml",
+		SourceRelationshipSheetMetadata:    "/xl/metadata.xml",
 	}
 	rels, _ := f.relsReader(relPath)
 	if rels == nil {
@@ -0,0 +1,714 @@
+// Copyright 2016 - 2026 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.25.0 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"maps"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/xuri/efp"
+)
+
+// GetExternalLinks provides the method to get all external workbook links of
+// the workbook, including the target path, sheet names, defined names and
+// cached cell values of the external workbooks. For example:
+//
+//	links, err := f.GetExternalLinks()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, link := range links {
+//	    fmt.Println(link.Index, link.Target)
+//	}
+func (f *File) GetExternalLinks() ([]ExternalLink, error) {
+	var links []ExternalLink
+	wb, err := f.workbookReader()
+	if err != nil || wb.ExternalReferences == nil {
+		return links, err
+	}
+	for i := range wb.ExternalReferences.ExternalReference {
+		externalLinkXML, link, err := f.getExternalLink(i + 1)
+		if err != nil {
+			return links, err
+		}
+		externalLink := ExternalLink{Index: i + 1}
+		if link.ExternalBook != nil {
+			if rel := f.getExternalLinkRels(externalLinkXML, link.ExternalBook.RID); rel != nil {
+				externalLink.Target = rel.Target
+			}
+			link.ExternalBook.getExternalLink(&externalLink)
+		}
+		links = append(links, externalLink)
+	}
+	return links, err
+}
+
+// AddExternalLink provides the method to add an external workbook link by
+// given external link settings, and returns the one-based index of the
+// external link, which could be used in formulas to reference the external
+// workbook. The cached cell values will be used when calculating the formulas
+// referencing the external workbook. For example, add an external link to the
+// workbook Budget.xlsx, and set a formula referencing its cell:
+//
+//	idx, err := f.AddExternalLink(excelize.ExternalLink{
+//	    Target:     "Budget.xlsx",
+//	    SheetNames: []string{"Sheet1"},
+//	    Cells: []excelize.ExternalLinkCell{
+//	        {Sheet: "Sheet1", Cell: "A1", Value: "100"},
+//	    },
+//	})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = f.SetCellFormula("Sheet1", "A1", fmt.Sprintf("[%d]Sheet1!A1*2", idx))
+func (f *File) AddExternalLink(link ExternalLink) (int, error) {
+	if link.Target == "" {
+		return 0, ErrParameterRequired
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return 0, err
+	}
+	book, err := newExternalBook(&link)
+	if err != nil {
+		return 0, err
+	}
+	var externalLinkID int
+	f.Pkg.Range(func(k, v interface{}) bool {
+		if strings.HasPrefix(k.(string), "xl/externalLinks/externalLink") {
+			externalLinkID++
+		}
+		return true
+	})
+	externalLinkID++
+	externalLinkXML := "xl/externalLinks/externalLink" + strconv.Itoa(externalLinkID) + ".xml"
+	rID := f.addRels(getExternalLinkRelsPath(externalLinkXML), SourceRelationshipExternalLinkPath, link.Target, "External")
+	book.RID = "rId" + strconv.Itoa(rID)
+	output, err := xml.Marshal(&xlsxExternalLink{ExternalBook: book})
+	if err != nil {
+		return 0, err
+	}
+	f.saveFileList(externalLinkXML, output)
+	rID = f.addRels(f.getWorkbookRelsPath(), SourceRelationshipExternalLink, strings.TrimPrefix(externalLinkXML, "xl/"), "")
+	if wb.ExternalReferences == nil {
+		wb.ExternalReferences = &xlsxExternalReferences{}
+	}
+	wb.ExternalReferences.ExternalReference = append(wb.ExternalReferences.ExternalReference, xlsxExternalReference{RID: "rId" + strconv.Itoa(rID)})
+	f.clearCalcCache()
+	return len(wb.ExternalReferences.ExternalReference), f.addContentTypePart(externalLinkID, "externalLink")
+}
+
+// SetExternalLinkTarget provides the method to change the target path of the
+// external workbook link by given one-based index of the external link. For
+// example, repoint the first external link to another workbook:
+//
+//	err := f.SetExternalLinkTarget(1, "Budget2026.xlsx")
+func (f *File) SetExternalLinkTarget(index int, target string) error {
+	if target == "" {
+		return ErrParameterRequired
+	}
+	externalLinkXML, link, err := f.getExternalLink(index)
+	if err != nil {
+		return err
+	}
+	if link.ExternalBook == nil {
+		return ErrParameterInvalid
+	}
+	rel := f.getExternalLinkRels(externalLinkXML, link.ExternalBook.RID)
+	if rel == nil {
+		return newNoExistExternalLinkError(strconv.Itoa(index))
+	}
+	rel.Target, rel.TargetMode = target, "External"
+	f.clearCalcCache()
+	return err
+}
+
+// BreakExternalLinks provides the method to break all external workbook links
+// of the workbook. The formulas referencing the external workbooks will be
+// replaced with their cached values, or the values calculated with the cached
+// data of the external links if the formula cells have no cached value, and
+// the defined names referencing the external workbooks will be replaced with
+// constant values. For example:
+//
+//	err := f.BreakExternalLinks()
+func (f *File) BreakExternalLinks() error {
+	wb, err := f.workbookReader()
+	if err != nil || wb.ExternalReferences == nil {
+		return err
+	}
+	for _, sheet := range f.GetSheetList() {
+		if err = f.breakSheetExternalLinks(sheet); err != nil {
+			return err
+		}
+	}
+	if wb.DefinedNames != nil {
+		for i, dn := range wb.DefinedNames.DefinedName {
+			if !hasExternalReference(dn.Data) {
+				continue
+			}
+			sheet := f.GetSheetName(0)
+			if dn.LocalSheetID != nil {
+				sheet = f.GetSheetName(*dn.LocalSheetID)
+			}
+			arg, _ := f.evalInfixExp(f.newExternalLinkCalcContext(nil, ""), sheet, "", f.parseFormulaTokens(sheet, "", dn.Data))
+			wb.DefinedNames.DefinedName[i].Data = formulaArgToConstant(arg)
+		}
+	}
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	if err != nil {
+		return err
+	}
+	for i, ref := range wb.ExternalReferences.ExternalReference {
+		externalLinkXML, _, err := f.getExternalLink(i + 1)
+		if err != nil {
+			return err
+		}
+		externalLinkRels := getExternalLinkRelsPath(externalLinkXML)
+		f.Pkg.Delete(externalLinkXML)
+		f.Pkg.Delete(externalLinkRels)
+		f.Relationships.Delete(externalLinkRels)
+		if err = f.removeContentTypesPart(ContentTypeSpreadSheetMLExternalLink, "/"+externalLinkXML); err != nil {
+			return err
+		}
+		if rels != nil {
+			rels.mu.Lock()
+			rels.Relationships = slices.DeleteFunc(rels.Relationships, func(rel xlsxRelationship) bool {
+				return rel.ID == ref.RID
+			})
+			rels.mu.Unlock()
+		}
+	}
+	wb.ExternalReferences = nil
+	f.clearCalcCache()
+	return err
+}
+
+// breakSheetExternalLinks provides a function to replace the formulas
+// referencing the external workbooks with their values in the worksheet by
+// given worksheet name.
+func (f *File) breakSheetExternalLinks(sheet string) error {
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		if err.Error() == newNotWorksheetError(sheet).Error() {
+			return nil
+		}
+		return err
+	}
+	shared := make(map[int]bool)
+	for _, row := range ws.SheetData.Row {
+		for _, c := range row.C {
+			if c.F != nil && c.F.T == STCellFormulaTypeShared && c.F.Si != nil && hasExternalReference(c.F.Content) {
+				shared[*c.F.Si] = true
+			}
+		}
+	}
+	args := make(map[string]formulaArg)
+	for _, row := range ws.SheetData.Row {
+		for _, c := range row.C {
+			if c.F == nil || !(hasExternalReference(c.F.Content) || (c.F.T == STCellFormulaTypeShared && c.F.Si != nil && shared[*c.F.Si])) {
+				continue
+			}
+			args[c.R] = newStringFormulaArg(c.V)
+			if c.V == "" {
+				args[c.R], _ = f.calcCellValue(f.newExternalLinkCalcContext(nil, sheet+"!"+c.R), sheet, c.R)
+			}
+		}
+	}
+	sheetID := f.getSheetID(sheet)
+	for r, row := range ws.SheetData.Row {
+		for i, c := range row.C {
+			arg, ok := args[c.R]
+			if !ok {
+				continue
+			}
+			cell := &ws.SheetData.Row[r].C[i]
+			cell.F = nil
+			if c.V == "" {
+				cell.setCachedValue(arg)
+			} else if c.T == "str" {
+				cell.setInlineStr(c.V)
+			}
+			if err = f.deleteCalcChain(sheetID, c.R); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// getExternalLink provides a function to get the path and the structure after
+// deserialization of the external link part by given one-based index of the
+// external link.
+func (f *File) getExternalLink(index int) (string, *xlsxExternalLink, error) {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return "", nil, err
+	}
+	if wb.ExternalReferences == nil || index < 1 || index > len(wb.ExternalReferences.ExternalReference) {
+		return "", nil, newNoExistExternalLinkError(strconv.Itoa(index))
+	}
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	if err != nil {
+		return "", nil, err
+	}
+	var externalLinkXML string
+	if rels != nil {
+		rels.mu.Lock()
+		for _, rel := range rels.Relationships {
+			if rel.ID == wb.ExternalReferences.ExternalReference[index-1].RID {
+				externalLinkXML = f.getWorkbookRelsTargetPath(rel.Target)
+				break
+			}
+		}
+		rels.mu.Unlock()
+	}
+	if externalLinkXML == "" {
+		return "", nil, newNoExistExternalLinkError(strconv.Itoa(index))
+	}
+	link, err := f.externalLinkReader(externalLinkXML)
+	return externalLinkXML, link, err
+}
+
+// externalLinkReader provides a function to get the pointer to the structure
+// after deserialization of xl/externalLinks/externalLink%d.xml.
+func (f *File) externalLinkReader(externalLinkXML string) (*xlsxExternalLink, error) {
+	link := &xlsxExternalLink{}
+	if content, ok := f.Pkg.Load(externalLinkXML); ok && content != nil {
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+			Decode(link); err != nil && err != io.EOF {
+			return nil, err
+		}
+	}
+	return link, nil
+}
+
+// getExternalLinkRelsPath provides a function to get the relationships part
+// path of the external link by given external link part path.
+func getExternalLinkRelsPath(externalLinkXML string) string {
+	return path.Join(path.Dir(externalLinkXML), "_rels", path.Base(externalLinkXML)+".rels")
+}
+
+// getExternalLinkRels provides a function to get the relationship of the
+// external workbook path by given external link part path and relationship
+// ID.
+func (f *File) getExternalLinkRels(externalLinkXML, rID string) *xlsxRelationship {
+	rels, _ := f.relsReader(getExternalLinkRelsPath(externalLinkXML))
+	if rels == nil {
+		return nil
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for i, rel := range rels.Relationships {
+		if rel.ID == rID {
+			return &rels.Relationships[i]
+		}
+	}
+	return nil
+}
+
+// findExternalLink provides a function to find the external link by given
+// workbook identifier in the formula, which could be the one-based index of
+// the external link, the file name or the path of the external workbook.
+// Returns the target path and the external link.
+func (f *File) findExternalLink(book string) (string, *xlsxExternalLink, error) {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return "", nil, err
+	}
+	if index, err := strconv.Atoi(book); err == nil {
+		externalLinkXML, link, err := f.getExternalLink(index)
+		if err != nil || link.ExternalBook == nil {
+			return "", link, err
+		}
+		if rel := f.getExternalLinkRels(externalLinkXML, link.ExternalBook.RID); rel != nil {
+			return rel.Target, link, err
+		}
+		return "", link, err
+	}
+	if wb.ExternalReferences != nil {
+		for i := range wb.ExternalReferences.ExternalReference {
+			externalLinkXML, link, err := f.getExternalLink(i + 1)
+			if err != nil {
+				return "", nil, err
+			}
+			if link.ExternalBook == nil {
+				continue
+			}
+			rel := f.getExternalLinkRels(externalLinkXML, link.ExternalBook.RID)
+			if rel != nil && strings.EqualFold(externalLinkFileName(rel.Target), externalLinkFileName(book)) {
+				return rel.Target, link, err
+			}
+		}
+	}
+	return "", nil, newNoExistExternalLinkError(book)
+}
+
+// externalLinkFileName returns the file name of the external workbook by
+// given target path or workbook identifier in the formula.
+func externalLinkFileName(target string) string {
+	target = strings.ReplaceAll(target, "\\", "/")
+	return target[strings.LastIndex(target, "/")+1:]
+}
+
+// splitExternalReference provides a function to split the reference which
+// referencing the external workbook, such as [1]Sheet1!A1 or
+// C:\Path\[Budget.xlsx]Sheet1!A1:B2, into the workbook identifier and the
+// reference in the external workbook. The reference of the defined name in
+// the external workbook will be returned with the exclamation mark prefix.
+func splitExternalReference(reference string) (string, string, bool) {
+	start, end := strings.Index(reference, "["), strings.Index(reference, "]")
+	bang := strings.LastIndex(reference, "!")
+	if start == -1 || end < start || bang < end {
+		return "", "", false
+	}
+	return strings.TrimPrefix(reference[:start], "'") + reference[start+1:end], reference[end+1:], true
+}
+
+// hasExternalReference returns whether the formula referencing the external
+// workbook.
+func hasExternalReference(formula string) bool {
+	if !strings.Contains(formula, "[") {
+		return false
+	}
+	ps := efp.ExcelParser()
+	for _, token := range ps.Parse(formula) {
+		if token.TType == efp.TokenTypeOperand && token.TSubType == efp.TokenSubTypeRange {
+			if _, _, ok := splitExternalReference(token.TValue); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// newExternalLinkCalcContext provides a function to create the formula
+// execution context by given the parent context and entry cell. The external
+// references being calculated are shared with the parent context to detect
+// the circular references across workbooks.
+func (f *File) newExternalLinkCalcContext(ctx *calcContext, entry string) *calcContext {
+	newCtx := &calcContext{
+		entry:             entry,
+		maxCalcIterations: f.options.MaxCalcIterations,
+		iterations:        make(map[string]uint),
+		iterationsCache:   make(map[string]formulaArg),
+	}
+	if ctx != nil {
+		ctx.mu.Lock()
+		if ctx.externalReferences == nil {
+			ctx.externalReferences = &sync.Map{}
+		}
+		ctx.mu.Unlock()
+		newCtx.externalLinkResolver = ctx.externalLinkResolver
+		newCtx.externalReferences = ctx.externalReferences
+	}
+	return newCtx
+}
+
+// parseExternalReference provides a function to get the value of the
+// reference in the external workbook by given workbook identifier and
+// reference. The linked workbook opened by the external link resolver will be
+// used if it is specified, otherwise the cached values in the external link
+// part will be used.
+func (f *File) parseExternalReference(ctx *calcContext, book, reference string) (formulaArg, error) {
+	target, link, err := f.findExternalLink(book)
+	if err != nil || link == nil || link.ExternalBook == nil {
+		if err == nil {
+			err = errors.New(formulaErrorREF)
+		}
+		return newErrorFormulaArg(formulaErrorREF, err.Error()), err
+	}
+	resolver := f.options.ExternalLinkResolver
+	if ctx != nil && ctx.externalLinkResolver != nil {
+		resolver = ctx.externalLinkResolver
+	}
+	if resolver != nil {
+		ext, err := resolver(target)
+		if err != nil {
+			return newErrorFormulaArg(formulaErrorREF, err.Error()), err
+		}
+		if ext != nil {
+			if ctx == nil {
+				ctx = f.newExternalLinkCalcContext(nil, "")
+			}
+			extCtx := ext.newExternalLinkCalcContext(ctx, "")
+			key := target + "\x00" + reference
+			if _, loaded := extCtx.externalReferences.LoadOrStore(key, true); loaded {
+				return newErrorFormulaArg(formulaErrorREF, formulaErrorREF), nil
+			}
+			defer extCtx.externalReferences.Delete(key)
+			return ext.parseRangeToken(extCtx, "", efp.Token{TValue: strings.TrimPrefix(reference, "!")})
+		}
+	}
+	return link.ExternalBook.parseReference(f, ctx, book, reference)
+}
+
+// parseReference provides a function to get the value of the reference by
+// given workbook identifier and reference with the cached values of the
+// external workbook.
+func (b *xlsxExternalBook) parseReference(f *File, ctx *calcContext, book, reference string) (formulaArg, error) {
+	errRef := newErrorFormulaArg(formulaErrorREF, formulaErrorREF)
+	if name, ok := strings.CutPrefix(reference, "!"); ok {
+		if b.DefinedNames != nil {
+			for _, dn := range b.DefinedNames.DefinedName {
+				if strings.EqualFold(dn.Name, name) && dn.SheetID == nil && !strings.HasPrefix(dn.RefersTo, "=!") {
+					return b.parseReference(f, ctx, book, strings.ReplaceAll(strings.TrimPrefix(dn.RefersTo, "="), "$", ""))
+				}
+			}
+		}
+		return newErrorFormulaArg(formulaErrorNAME, formulaErrorNAME), errors.New(formulaErrorNAME)
+	}
+	idx := strings.LastIndex(reference, "!")
+	if idx == -1 {
+		return errRef, errors.New(formulaErrorREF)
+	}
+	sheetID := -1
+	if b.SheetNames != nil {
+		sheetID = slices.IndexFunc(b.SheetNames.SheetName, func(name attrValString) bool {
+			return name.Val != nil && strings.EqualFold(*name.Val, strings.Trim(reference[:idx], "'"))
+		})
+	}
+	if sheetID == -1 {
+		return errRef, errors.New(formulaErrorREF)
+	}
+	cells, maxCol, maxRow := make(map[string]*xlsxExternalCell), 1, 1
+	if b.SheetDataSet != nil {
+		for i := range b.SheetDataSet.SheetData {
+			if b.SheetDataSet.SheetData[i].SheetID != sheetID {
+				continue
+			}
+			for r := range b.SheetDataSet.SheetData[i].Row {
+				row := &b.SheetDataSet.SheetData[i].Row[r]
+				for c := range row.Cell {
+					col, rowNum, err := CellNameToCoordinates(row.Cell[c].R)
+					if err != nil {
+						continue
+					}
+					cells[row.Cell[c].R] = &row.Cell[c]
+					maxCol, maxRow = max(maxCol, col), max(maxRow, rowNum)
+				}
+			}
+		}
+	}
+	refs := strings.Split(reference[idx+1:], ":")
+	if len(refs) == 1 {
+		if _, _, err := CellNameToCoordinates(refs[0]); err != nil {
+			return errRef, err
+		}
+		return cells[refs[0]].toFormulaArg(), nil
+	}
+	if len(refs) != 2 {
+		return errRef, errors.New(formulaErrorREF)
+	}
+	var coordinates []int
+	for i, ref := range refs {
+		cr, col, row, err := parseRef(ref)
+		if err != nil {
+			return errRef, err
+		}
+		if col {
+			cr.Row = []int{1, maxRow}[i]
+		}
+		if row {
+			cr.Col = []int{1, maxCol}[i]
+		}
+		coordinates = append(coordinates, cr.Col, cr.Row)
+	}
+	_ = sortCoordinates(coordinates)
+	// Clamp the range to the cached cells, avoid building the matrix out of
+	// the cached values of the external workbook
+	coordinates[2] = max(coordinates[0], min(coordinates[2], maxCol))
+	coordinates[3] = max(coordinates[1], min(coordinates[3], maxRow))
+	var matrix [][]formulaArg
+	for row := coordinates[1]; row <= coordinates[3]; row++ {
+		var args []formulaArg
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			args = append(args, cells[cell].toFormulaArg())
+		}
+		matrix = append(matrix, args)
+	}
+	return newMatrixFormulaArg(matrix), nil
+}
+
+// toFormulaArg provides a function to convert the cached cell value of the
+// external workbook to the formula argument.
+func (c *xlsxExternalCell) toFormulaArg() formulaArg {
+	if c == nil || c.V == "" {
+		return newEmptyFormulaArg()
+	}
+	switch c.T {
+	case "b":
+		return newBoolFormulaArg(c.V == "1")
+	case "e":
+		return newErrorFormulaArg(c.V, c.V)
+	case "s", "str", "inlineStr":
+		return newStringFormulaArg(c.V)
+	default:
+		return newStringFormulaArg(c.V).ToNumber()
+	}
+}
+
+// getExternalLink provides a function to convert the external workbook
+// structure to the external link settings.
+func (b *xlsxExternalBook) getExternalLink(link *ExternalLink) {
+	if b.SheetNames != nil {
+		for _, name := range b.SheetNames.SheetName {
+			if name.Val != nil {
+				link.SheetNames = append(link.SheetNames, *name.Val)
+			}
+		}
+	}
+	sheetName := func(sheetID int) string {
+		if sheetID >= 0 && sheetID < len(link.SheetNames) {
+			return link.SheetNames[sheetID]
+		}
+		return ""
+	}
+	if b.DefinedNames != nil {
+		for _, dn := range b.DefinedNames.DefinedName {
+			definedName := ExternalLinkDefinedName{Name: dn.Name, RefersTo: dn.RefersTo}
+			if dn.SheetID != nil {
+				definedName.Scope = sheetName(*dn.SheetID)
+			}
+			link.DefinedNames = append(link.DefinedNames, definedName)
+		}
+	}
+	if b.SheetDataSet == nil {
+		return
+	}
+	for _, sheetData := range b.SheetDataSet.SheetData {
+		for _, row := range sheetData.Row {
+			for _, c := range row.Cell {
+				cell := ExternalLinkCell{Sheet: sheetName(sheetData.SheetID), Cell: c.R, Type: CellTypeNumber, Value: c.V}
+				switch c.T {
+				case "b":
+					cell.Type = CellTypeBool
+				case "e":
+					cell.Type = CellTypeError
+				case "s", "str", "inlineStr":
+					cell.Type = CellTypeInlineString
+				}
+				link.Cells = append(link.Cells, cell)
+			}
+		}
+	}
+}
+
+// newExternalBook provides a function to create the external workbook
+// structure by given external link settings.
+func newExternalBook(link *ExternalLink) (*xlsxExternalBook, error) {
+	book := &xlsxExternalBook{SheetNames: &xlsxExternalSheetNames{}}
+	sheetID := func(name string) int {
+		for i, sheetName := range book.SheetNames.SheetName {
+			if strings.EqualFold(*sheetName.Val, name) {
+				return i
+			}
+		}
+		book.SheetNames.SheetName = append(book.SheetNames.SheetName, attrValString{Val: stringPtr(name)})
+		return len(book.SheetNames.SheetName) - 1
+	}
+	for _, name := range link.SheetNames {
+		_ = sheetID(name)
+	}
+	for _, dn := range link.DefinedNames {
+		if book.DefinedNames == nil {
+			book.DefinedNames = &xlsxExternalDefinedNames{}
+		}
+		definedName := xlsxExternalDefinedName{Name: dn.Name, RefersTo: dn.RefersTo}
+		if !strings.HasPrefix(definedName.RefersTo, "=") {
+			definedName.RefersTo = "=" + definedName.RefersTo
+		}
+		if dn.Scope != "" {
+			definedName.SheetID = intPtr(sheetID(dn.Scope))
+		}
+		book.DefinedNames.DefinedName = append(book.DefinedNames.DefinedName, definedName)
+	}
+	cells := make(map[int]map[int]map[int]xlsxExternalCell)
+	for _, c := range link.Cells {
+		col, row, err := CellNameToCoordinates(c.Cell)
+		if err != nil {
+			return book, err
+		}
+		cell := xlsxExternalCell{R: c.Cell, V: c.Value}
+		switch c.Type {
+		case CellTypeBool:
+			cell.T = "b"
+		case CellTypeError:
+			cell.T = "e"
+		case CellTypeNumber, CellTypeUnset, CellTypeDate:
+			if ok, _, _ := isNumeric(c.Value); !ok && c.Value != "" {
+				cell.T = "str"
+			}
+		default:
+			cell.T = "str"
+		}
+		ID := sheetID(c.Sheet)
+		if cells[ID] == nil {
+			cells[ID] = make(map[int]map[int]xlsxExternalCell)
+		}
+		if cells[ID][row] == nil {
+			cells[ID][row] = make(map[int]xlsxExternalCell)
+		}
+		cells[ID][row][col] = cell
+	}
+	book.SheetDataSet = &xlsxExternalSheetDataSet{}
+	for ID := range book.SheetNames.SheetName {
+		sheetData := xlsxExternalSheetData{SheetID: ID}
+		for _, row := range slices.Sorted(maps.Keys(cells[ID])) {
+			externalRow := xlsxExternalRow{R: row}
+			for _, col := range slices.Sorted(maps.Keys(cells[ID][row])) {
+				externalRow.Cell = append(externalRow.Cell, cells[ID][row][col])
+			}
+			sheetData.Row = append(sheetData.Row, externalRow)
+		}
+		book.SheetDataSet.SheetData = append(book.SheetDataSet.SheetData, sheetData)
+	}
+	return book, nil
+}
+
+// formulaArgToConstant provides a function to convert the formula argument to
+// the constant formula text.
+func formulaArgToConstant(arg formulaArg) string {
+	switch arg.Type {
+	case ArgNumber:
+		if arg.Boolean {
+			return strings.ToUpper(strconv.FormatBool(arg.Number == 1))
+		}
+		return strconv.FormatFloat(arg.Number, 'f', -1, 64)
+	case ArgString:
+		return "\"" + strings.ReplaceAll(arg.String, "\"", "\"\"") + "\""
+	case ArgError:
+		return arg.String
+	case ArgMatrix:
+		var rows []string
+		for _, row := range arg.Matrix {
+			var cols []string
+			for _, col := range row {
+				cols = append(cols, formulaArgToConstant(col))
+			}
+			rows = append(rows, strings.Join(cols, ","))
+		}
+		return "{" + strings.Join(rows, ";") + "}"
+	default:
+		return "0"
+	}
+}
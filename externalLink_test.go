@@ -0,0 +1,202 @@
+// Copyright 2016 - 2026 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.25.0 or later.
+
+package excelize
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExternalLink(t *testing.T) {
+	f := NewFile()
+	links, err := f.GetExternalLinks()
+	assert.NoError(t, err)
+	assert.Empty(t, links)
+	idx, err := f.AddExternalLink(ExternalLink{
+		Target:       "Budget.xlsx",
+		SheetNames:   []string{"Sheet1"},
+		DefinedNames: []ExternalLinkDefinedName{{Name: "Total", RefersTo: "Sheet1!$A$1"}, {Name: "Local", RefersTo: "=Data!$B$1", Scope: "Data"}},
+		Cells: []ExternalLinkCell{
+			{Sheet: "Sheet1", Cell: "A2", Value: "50"},
+			{Sheet: "Sheet1", Cell: "A1", Value: "100"},
+			{Sheet: "Sheet1", Cell: "B1", Type: CellTypeBool, Value: "1"},
+			{Sheet: "Sheet1", Cell: "B2", Type: CellTypeError, Value: "#N/A"},
+			{Sheet: "Data", Cell: "B1", Value: "Excelize"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, idx)
+	for cell, formula := range map[string]string{
+		"A1":  "[1]Sheet1!A1*2",
+		"A2":  "SUM([1]Sheet1!$A$1:$A$2)",
+		"A3":  "[1]!Total+1",
+		"A4":  "'[1]Data'!B1",
+		"A5":  "SUM([1]Sheet1!A:A)",
+		"A6":  "[1]Sheet1!B1",
+		"A7":  "ISNA([1]Sheet1!B2)",
+		"A8":  "[1]Sheet1!C1&\"\"",
+		"A9":  "'C:\\Data\\[Budget.xlsx]Sheet1'!A2",
+		"A10": "SUM([Budget.xlsx]Sheet1!$A$1:$A$2)",
+		"B1":  "[2]Sheet1!A1",
+		"B2":  "[1]SheetN!A1",
+		"B3":  "[1]!Name",
+		"B4":  "[1]Sheet1!A1:B1:C1",
+	} {
+		assert.NoError(t, f.SetCellFormula("Sheet1", cell, formula))
+	}
+	for cell, expected := range map[string]string{
+		"A1": "200", "A2": "150", "A3": "101", "A4": "Excelize", "A5": "150",
+		"A6": "TRUE", "A7": "TRUE", "A8": "", "A9": "50", "A10": "150",
+	} {
+		result, err := f.CalcCellValue("Sheet1", cell)
+		assert.NoError(t, err, cell)
+		assert.Equal(t, expected, result, cell)
+	}
+	for _, cell := range []string{"B1", "B2", "B3", "B4"} {
+		_, err := f.CalcCellValue("Sheet1", cell)
+		assert.Error(t, err, cell)
+	}
+
+	file := filepath.Join("test", "TestExternalLink.xlsx")
+	assert.NoError(t, f.SaveAs(file))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(file)
+	assert.NoError(t, err)
+	links, err = f.GetExternalLinks()
+	assert.NoError(t, err)
+	assert.Equal(t, []ExternalLink{{
+		Index:        1,
+		Target:       "Budget.xlsx",
+		SheetNames:   []string{"Sheet1", "Data"},
+		DefinedNames: []ExternalLinkDefinedName{{Name: "Total", RefersTo: "=Sheet1!$A$1"}, {Name: "Local", RefersTo: "=Data!$B$1", Scope: "Data"}},
+		Cells: []ExternalLinkCell{
+			{Sheet: "Sheet1", Cell: "A1", Type: CellTypeNumber, Value: "100"},
+			{Sheet: "Sheet1", Cell: "B1", Type: CellTypeBool, Value: "1"},
+			{Sheet: "Sheet1", Cell: "A2", Type: CellTypeNumber, Value: "50"},
+			{Sheet: "Sheet1", Cell: "B2", Type: CellTypeError, Value: "#N/A"},
+			{Sheet: "Data", Cell: "B1", Type: CellTypeInlineString, Value: "Excelize"},
+		},
+	}}, links)
+
+	// Test calculate formula with external link resolver
+	ext := NewFile()
+	assert.NoError(t, ext.SetSheetRow("Sheet1", "A1", &[]int{7, 8}))
+	assert.NoError(t, ext.SetCellValue("Sheet1", "A2", 3))
+	var targets []string
+	resolver := func(target string) (*File, error) {
+		targets = append(targets, target)
+		return ext, nil
+	}
+	result, err := f.CalcCellValue("Sheet1", "A2", Options{ExternalLinkResolver: resolver})
+	assert.NoError(t, err)
+	assert.Equal(t, "10", result)
+	assert.Equal(t, []string{"Budget.xlsx"}, targets)
+	_, err = f.CalcCellValue("Sheet1", "A1", Options{ExternalLinkResolver: func(target string) (*File, error) {
+		return nil, errors.New("open failed")
+	}})
+	assert.Error(t, err)
+	result, err = f.CalcCellValue("Sheet1", "A1", Options{ExternalLinkResolver: func(target string) (*File, error) {
+		return nil, nil
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, "200", result)
+
+	// Test calculate formula with circular references across workbooks
+	book1, book2 := NewFile(), NewFile()
+	_, err = book1.AddExternalLink(ExternalLink{Target: "Book2.xlsx", SheetNames: []string{"Sheet1"}})
+	assert.NoError(t, err)
+	_, err = book2.AddExternalLink(ExternalLink{Target: "Book1.xlsx", SheetNames: []string{"Sheet1"}})
+	assert.NoError(t, err)
+	assert.NoError(t, book1.SetCellFormula("Sheet1", "A1", "[1]Sheet1!A1+1"))
+	assert.NoError(t, book2.SetCellFormula("Sheet1", "A1", "[1]Sheet1!A1+1"))
+	books := map[string]*File{"Book1.xlsx": book1, "Book2.xlsx": book2}
+	result, err = book1.CalcCellValue("Sheet1", "A1", Options{ExternalLinkResolver: func(target string) (*File, error) {
+		return books[target], nil
+	}})
+	assert.Equal(t, formulaErrorREF, result)
+	assert.EqualError(t, err, formulaErrorREF)
+	assert.NoError(t, book1.Close())
+	assert.NoError(t, book2.Close())
+
+	// Test calculate formula with the range out of the cached values
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C2", "SUM([1]Sheet1!A1:A200000)"))
+	result, err = f.CalcCellValue("Sheet1", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, "150", result)
+
+	// Test set external link target
+	assert.NoError(t, f.SetExternalLinkTarget(1, "Budget2026.xlsx"))
+	links, err = f.GetExternalLinks()
+	assert.NoError(t, err)
+	assert.Equal(t, "Budget2026.xlsx", links[0].Target)
+	assert.NoError(t, f.SetCellFormula("Sheet1", "C1", "[Budget2026.xlsx]Sheet1!A1"))
+	result, err = f.CalcCellValue("Sheet1", "C1")
+	assert.NoError(t, err)
+	assert.Equal(t, "100", result)
+	assert.Equal(t, ErrParameterRequired, f.SetExternalLinkTarget(1, ""))
+	assert.Equal(t, newNoExistExternalLinkError("2"), f.SetExternalLinkTarget(2, "Budget.xlsx"))
+
+	// Test break external links
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "[1]Sheet1!$A$1:$A$2"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Label", RefersTo: "[1]Data!$B$1", Scope: "Sheet1"}))
+	assert.NoError(t, f.BreakExternalLinks())
+	for cell, expected := range map[string]string{"A1": "200", "A2": "150", "A4": "Excelize", "C1": "100"} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Empty(t, formula, cell)
+		result, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result, cell)
+	}
+	assert.Equal(t, []DefinedName{
+		{Name: "Amount", Scope: "Workbook", RefersTo: "{100;50}"},
+		{Name: "Label", Scope: "Sheet1", RefersTo: "\"Excelize\""},
+	}, f.GetDefinedName())
+	links, err = f.GetExternalLinks()
+	assert.NoError(t, err)
+	assert.Empty(t, links)
+	_, ok := f.Pkg.Load("xl/externalLinks/externalLink1.xml")
+	assert.False(t, ok)
+	assert.NoError(t, f.BreakExternalLinks())
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestBreakExternalLinks.xlsx")))
+	assert.NoError(t, f.Close())
+}
+
+func TestAddExternalLink(t *testing.T) {
+	f := NewFile()
+	_, err := f.AddExternalLink(ExternalLink{})
+	assert.Equal(t, ErrParameterRequired, err)
+	_, err = f.AddExternalLink(ExternalLink{Target: "Budget.xlsx", Cells: []ExternalLinkCell{{Sheet: "Sheet1", Cell: "A"}}})
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test add external link with unsupported charset workbook
+	f.WorkBook = nil
+	f.Pkg.Store(defaultXMLPathWorkbook, MacintoshCyrillicCharset)
+	_, err = f.AddExternalLink(ExternalLink{Target: "Budget.xlsx"})
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	f.WorkBook = nil
+	_, err = f.GetExternalLinks()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	f.WorkBook = nil
+	assert.EqualError(t, f.BreakExternalLinks(), "XML syntax error on line 1: invalid UTF-8")
+
+	// Test get external links with unsupported charset external link part
+	f = NewFile()
+	_, err = f.AddExternalLink(ExternalLink{Target: "Budget.xlsx"})
+	assert.NoError(t, err)
+	f.Pkg.Store("xl/externalLinks/externalLink1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetExternalLinks()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.SetExternalLinkTarget(1, "Budget.xlsx"), "XML syntax error on line 1: invalid UTF-8")
+}
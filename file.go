@@ -67,13 +67,14 @@ func (f *File) Save(opts ...Options) error {
 }
 
 // SaveAs provides a function to create or update to a spreadsheet at the
-// provided path.
+// provided path. The spreadsheet will be saved in the OpenDocument
+// spreadsheet format when the file extension is ".ods".
 func (f *File) SaveAs(name string, opts ...Options) error {
 	if countUTF16String(name) > MaxFilePathLength {
 		return ErrMaxFilePathLength
 	}
 	f.Path = name
-	if _, ok := supportedContentTypes[strings.ToLower(filepath.Ext(f.Path))]; !ok {
+	if _, ok := supportedContentTypes[strings.ToLower(filepath.Ext(f.Path))]; !ok && !strings.EqualFold(filepath.Ext(f.Path), ".ods") {
 		return ErrWorkbookFileFormat
 	}
 	file, err := os.OpenFile(filepath.Clean(name), os.O_WRONLY|os.O_TRUNC|os.O_CREATE, os.ModePerm)
@@ -118,6 +119,13 @@ func (f *File) WriteTo(w io.Writer, opts ...Options) (int64, error) {
 	for i := range opts {
 		f.options = &opts[i]
 	}
+	if strings.EqualFold(filepath.Ext(f.Path), ".ods") {
+		buf, err := f.WriteODSToBuffer()
+		if err != nil {
+			return 0, err
+		}
+		return buf.WriteTo(w)
+	}
 	if len(f.Path) != 0 {
 		contentType, ok := supportedContentTypes[strings.ToLower(filepath.Ext(f.Path))]
 		if !ok {
@@ -174,6 +182,9 @@ func (f *File) writeToZip(zw ZipWriter) error {
 	f.sharedStringsWriter()
 	f.styleSheetWriter()
 	f.themeWriter()
+	if err := f.signaturesWriter(); err != nil {
+		return err
+	}
 
 	for path, stream := range f.streams {
 		fi, err := zw.Create(path)
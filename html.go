@@ -0,0 +1,425 @@
+// Copyright 2016 - 2026 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.25.0 or later.
+
+package excelize
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"image"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// HTMLOptions directly maps the settings of exporting the worksheet as HTML
+// table.
+//
+// Range specifies the range reference of the worksheet to be exported, such
+// as "A1:F20". The used range of the worksheet, including the merged cells
+// and pictures, will be exported by default.
+//
+// RawCellValue specifies if export the raw cell values instead of the
+// formatted cell values, the RawCellValue of the spreadsheet options also
+// applies.
+type HTMLOptions struct {
+	Range        string
+	RawCellValue bool
+}
+
+// htmlBorderStyles defined the CSS border width and style of the cell border
+// styles by the index of the styleBorders.
+var htmlBorderStyles = []string{
+	"none", "1px solid", "2px solid", "1px dashed", "1px dotted", "3px solid",
+	"3px double", "1px dotted", "2px dashed", "1px dashed", "2px dashed",
+	"1px dotted", "2px dotted", "2px dashed",
+}
+
+// htmlHorizontalAlignment defined the CSS text alignment of the cell
+// horizontal alignment.
+var htmlHorizontalAlignment = map[string]string{
+	"left": "left", "center": "center", "right": "right", "fill": "left",
+	"justify": "justify", "centerContinuous": "center", "distributed": "justify",
+}
+
+// htmlVerticalAlignment defined the CSS vertical alignment of the cell
+// vertical alignment.
+var htmlVerticalAlignment = map[string]string{
+	"top": "top", "center": "middle", "bottom": "bottom", "justify": "middle",
+	"distributed": "middle",
+}
+
+// htmlFontFamilyReplacer defined the replacer for removing the characters
+// which can't be used in the font family of the inline CSS.
+var htmlFontFamilyReplacer = strings.NewReplacer("'", "", "\"", "", "<", "", ">", "", "&", "", ";", "")
+
+// htmlImageTypes defined the MIME types of the pictures embedded as data URIs
+// by the image extension.
+var htmlImageTypes = map[string]string{
+	".bmp": "image/bmp", ".emf": "image/x-emf", ".emz": "image/x-emz",
+	".gif": "image/gif", ".ico": "image/x-icon", ".jpeg": "image/jpeg",
+	".png": "image/png", ".svg": "image/svg+xml", ".tiff": "image/tiff",
+	".wmf": "image/x-wmf", ".wmz": "image/x-wmz",
+}
+
+// WriteSheetHTML provides a function to render the worksheet as HTML table by
+// given worksheet name, writer and HTML options. The merged cells, column
+// widths, row heights, cell fonts, fills, borders and alignments will be
+// rendered with inline CSS, the cell values will be formatted by the number
+// format of the cells, the hidden rows and columns will be skipped, the
+// hyperlinks will be rendered as anchors and the pictures will be embedded as
+// data URIs. For example, render the range A1:F20 of the worksheet named
+// Sheet1 as HTML table:
+//
+//	var buf bytes.Buffer
+//	err := f.WriteSheetHTML("Sheet1", &buf, excelize.HTMLOptions{Range: "A1:F20"})
+func (f *File) WriteSheetHTML(sheet string, w io.Writer, opts ...HTMLOptions) error {
+	var options HTMLOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	readOpts := *f.options
+	readOpts.RawCellValue = readOpts.RawCellValue || options.RawCellValue
+	rows, err := f.GetRows(sheet, readOpts)
+	if err != nil {
+		return err
+	}
+	rs := &renderSheet{sheet: sheet, rows: rows, css: make(map[int]string)}
+	if err = f.prepareRenderSheet(sheet, rs); err != nil {
+		return err
+	}
+	area, err := rs.getArea(options.Range)
+	if err != nil || area == nil {
+		if err == nil {
+			_, err = io.WriteString(w, "<table></table>\n")
+		}
+		return err
+	}
+	var cols, visibleRows []int
+	widths := make(map[int]float64)
+	for col := area[0]; col <= area[2]; col++ {
+		name, _ := ColumnNumberToName(col)
+		visible, err := f.GetColVisible(sheet, name)
+		if err != nil {
+			return err
+		}
+		if !visible {
+			continue
+		}
+		width, err := f.GetColWidth(sheet, name)
+		if err != nil {
+			return err
+		}
+		cols, widths[col] = append(cols, col), width
+	}
+	for row := area[1]; row <= area[3]; row++ {
+		if !rs.rowAttrs[row].Hidden {
+			visibleRows = append(visibleRows, row)
+		}
+	}
+	rs.prepareMergeCells(cols, visibleRows)
+	if rs.defaultFont, err = f.getDefaultFont(); err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	_, _ = bw.WriteString("<table style=\"border-collapse:collapse;table-layout:fixed")
+	_, _ = bw.WriteString(getHTMLFontCSS(rs.defaultFont, nil, f))
+	_, _ = bw.WriteString("\">\n<colgroup>")
+	for _, col := range cols {
+		fmt.Fprintf(bw, "<col style=\"width:%gpx\">", convertColWidthToPixels(widths[col]))
+	}
+	_, _ = bw.WriteString("</colgroup>\n")
+	for _, row := range visibleRows {
+		height := rs.defaultHeight
+		if ht := rs.rowAttrs[row].Ht; ht != nil {
+			height = *ht
+		}
+		fmt.Fprintf(bw, "<tr style=\"height:%gpx\">", convertRowHeightToPixels(height))
+		for _, col := range cols {
+			if err = f.writeHTMLCell(bw, rs, col, row); err != nil {
+				return err
+			}
+		}
+		_, _ = bw.WriteString("</tr>\n")
+	}
+	_, _ = bw.WriteString("</table>\n")
+	return bw.Flush()
+}
+
+// prepareMergeCells provides a function to calculate the column span and row
+// span of the merged cells by given visible columns and rows. The merged cell
+// will be anchored on the first visible cell of the merged range, and the
+// other cells in the merged range will be skipped.
+func (rs *renderSheet) prepareMergeCells(cols, rows []int) {
+	merges := make(map[[2]int][2]int)
+	for start, end := range rs.merges {
+		var anchor [2]int
+		var mergedCols, mergedRows []int
+		for _, col := range cols {
+			if col >= start[0] && col <= end[0] {
+				mergedCols = append(mergedCols, col)
+			}
+		}
+		for _, row := range rows {
+			if row >= start[1] && row <= end[1] {
+				mergedRows = append(mergedRows, row)
+			}
+		}
+		for _, col := range mergedCols {
+			for _, row := range mergedRows {
+				rs.mergedCells[[2]int{col, row}] = true
+			}
+		}
+		colSpan, rowSpan := len(mergedCols), len(mergedRows)
+		if colSpan > 0 && rowSpan > 0 {
+			anchor = [2]int{mergedCols[0], mergedRows[0]}
+		}
+		if colSpan > 0 && rowSpan > 0 {
+			merges[anchor] = [2]int{colSpan, rowSpan}
+			delete(rs.mergedCells, anchor)
+			if anchor != start {
+				rs.moveCell(start, anchor)
+			}
+		}
+	}
+	rs.merges = merges
+}
+
+// moveCell provides a function to move the value, style, hyperlink and
+// pictures of the top-left cell of the merged range to the anchored cell.
+func (rs *renderSheet) moveCell(from, to [2]int) {
+	if from[1] <= len(rs.rows) && from[0] <= len(rs.rows[from[1]-1]) {
+		for len(rs.rows) < to[1] {
+			rs.rows = append(rs.rows, nil)
+		}
+		for len(rs.rows[to[1]-1]) < to[0] {
+			rs.rows[to[1]-1] = append(rs.rows[to[1]-1], "")
+		}
+		rs.rows[to[1]-1][to[0]-1] = rs.rows[from[1]-1][from[0]-1]
+	}
+	if link, ok := rs.getLink(from[0], from[1]); ok {
+		rs.links[to] = link
+	}
+	cell := rs.cells[from]
+	cell.S = rs.getStyleID(from[0], from[1])
+	rs.cells[to], rs.pictures[to] = cell, rs.pictures[from]
+}
+
+// getHTMLFontCSS provides a function to get the CSS declarations of the font,
+// the properties same with the given base font will be skipped.
+func getHTMLFontCSS(font, base *Font, f *File) string {
+	var css strings.Builder
+	if base == nil {
+		base = &Font{}
+	}
+	if font.Family != "" && font.Family != base.Family {
+		css.WriteString(";font-family:'" + htmlFontFamilyReplacer.Replace(font.Family) + "'")
+	}
+	if font.Size > 0 && font.Size != base.Size {
+		css.WriteString(";font-size:" + strconv.FormatFloat(font.Size, 'f', -1, 64) + "pt")
+	}
+	if font.Bold {
+		css.WriteString(";font-weight:bold")
+	}
+	if font.Italic {
+		css.WriteString(";font-style:italic")
+	}
+	var decorations []string
+	if font.Underline != "" && font.Underline != "none" {
+		decorations = append(decorations, "underline")
+	}
+	if font.Strike {
+		decorations = append(decorations, "line-through")
+	}
+	if len(decorations) > 0 {
+		css.WriteString(";text-decoration:" + strings.Join(decorations, " "))
+	}
+	if color := f.getFontColor(font); color != "" && color != f.getFontColor(base) {
+		css.WriteString(";color:#" + color)
+	}
+	return css.String()
+}
+
+// getHTMLCellCSS provides a function to get the inline CSS declarations of
+// the cell by given coordinates, the conditional formats which applied to the
+// cell will be evaluated.
+func (f *File) getHTMLCellCSS(rs *renderSheet, col, row int) (string, error) {
+	styleID, cellType := rs.getStyleID(col, row), rs.getCellType(col, row)
+	style, err := f.getRenderCondStyle(rs, col, row)
+	if err != nil {
+		return "", err
+	}
+	if style != nil {
+		return f.getHTMLStyleCSS(rs, style, cellType), err
+	}
+	key := styleID*4 + map[string]int{"n": 1, "b": 2, "e": 3}[cellType]
+	if css, ok := rs.css[key]; ok {
+		return css, nil
+	}
+	if style, err = f.GetStyle(styleID); err != nil {
+		return "", err
+	}
+	rs.css[key] = f.getHTMLStyleCSS(rs, style, cellType)
+	return rs.css[key], err
+}
+
+// getHTMLStyleCSS provides a function to get the inline CSS declarations of
+// the cell by given style and cell type.
+func (f *File) getHTMLStyleCSS(rs *renderSheet, style *Style, cellType string) string {
+	if style == nil {
+		return ""
+	}
+	var css strings.Builder
+	if style.Font != nil {
+		css.WriteString(getHTMLFontCSS(style.Font, rs.defaultFont, f))
+	}
+	if len(style.Fill.Color) > 0 && style.Fill.Color[0] != "" {
+		if style.Fill.Type == "gradient" && len(style.Fill.Color) > 1 {
+			css.WriteString(fmt.Sprintf(";background:linear-gradient(#%s,#%s)",
+				style.Fill.Color[0], style.Fill.Color[1]))
+		} else if style.Fill.Type == "gradient" || style.Fill.Pattern > 0 {
+			css.WriteString(";background-color:#" + style.Fill.Color[0])
+		}
+	}
+	for _, border := range style.Border {
+		if inStrSlice(styleBorderTypes[:4], border.Type, true) == -1 ||
+			border.Style <= 0 || border.Style >= len(htmlBorderStyles) {
+			continue
+		}
+		color := border.Color
+		if color == "" {
+			color = "000000"
+		}
+		css.WriteString(fmt.Sprintf(";border-%s:%s #%s", border.Type, htmlBorderStyles[border.Style], color))
+	}
+	horizontal, vertical, wrap := "", "bottom", false
+	switch cellType {
+	case "n":
+		horizontal = "right"
+	case "b", "e":
+		horizontal = "center"
+	}
+	if style.Alignment != nil {
+		if align, ok := htmlHorizontalAlignment[style.Alignment.Horizontal]; ok {
+			horizontal = align
+		}
+		if align, ok := htmlVerticalAlignment[style.Alignment.Vertical]; ok {
+			vertical = align
+		}
+		if style.Alignment.Indent > 0 {
+			css.WriteString(fmt.Sprintf(";padding-left:%dpx", style.Alignment.Indent*9))
+		}
+		wrap = style.Alignment.WrapText
+	}
+	if horizontal != "" {
+		css.WriteString(";text-align:" + horizontal)
+	}
+	css.WriteString(";vertical-align:" + vertical)
+	if wrap {
+		css.WriteString(";white-space:pre-wrap")
+	} else {
+		css.WriteString(";white-space:nowrap")
+	}
+	return strings.TrimPrefix(css.String(), ";")
+}
+
+// writeHTMLCell provides a function to write the HTML table cell by given
+// buffered writer, worksheet data and cell coordinates.
+func (f *File) writeHTMLCell(bw *bufio.Writer, rs *renderSheet, col, row int) error {
+	cell := [2]int{col, row}
+	if rs.mergedCells[cell] {
+		return nil
+	}
+	css, err := f.getHTMLCellCSS(rs, col, row)
+	if err != nil {
+		return err
+	}
+	_, _ = bw.WriteString("<td")
+	if span, ok := rs.merges[cell]; ok {
+		if span[0] > 1 {
+			fmt.Fprintf(bw, " colspan=\"%d\"", span[0])
+		}
+		if span[1] > 1 {
+			fmt.Fprintf(bw, " rowspan=\"%d\"", span[1])
+		}
+	}
+	_, _ = bw.WriteString(" style=\"" + css + "\">")
+	value := strings.ReplaceAll(html.EscapeString(rs.getValue(col, row)), "\n", "<br>")
+	if link, ok := rs.getLink(col, row); ok && isHTMLSafeLink(link.Location) {
+		_, _ = bw.WriteString("<a href=\"" + html.EscapeString(link.Location) + "\"")
+		if link.Tooltip != "" {
+			_, _ = bw.WriteString(" title=\"" + html.EscapeString(link.Tooltip) + "\"")
+		}
+		_, _ = bw.WriteString(">" + value + "</a>")
+	} else {
+		_, _ = bw.WriteString(value)
+	}
+	for _, pic := range rs.pictures[cell] {
+		writeHTMLPicture(bw, pic)
+	}
+	_, _ = bw.WriteString("</td>")
+	return nil
+}
+
+// isHTMLSafeLink provides a function to check if the hyperlink location could
+// be rendered as the anchor of the HTML document, only the http, https and
+// mailto links and the locations in the document are allowed.
+func isHTMLSafeLink(location string) bool {
+	if strings.HasPrefix(location, "#") {
+		return true
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "mailto":
+		return true
+	}
+	return false
+}
+
+// writeHTMLPicture provides a function to write the picture as the HTML image
+// element with data URI by given buffered writer and picture.
+func writeHTMLPicture(bw *bufio.Writer, pic Picture) {
+	ext, ok := supportedImageTypes[strings.ToLower(filepath.Ext("image"+pic.Extension))]
+	if !ok {
+		return
+	}
+	_, _ = bw.WriteString("<img src=\"data:" + htmlImageTypes[ext] + ";base64,")
+	_, _ = bw.WriteString(base64.StdEncoding.EncodeToString(pic.File) + "\"")
+	if pic.Format != nil && pic.Format.AltText != "" {
+		_, _ = bw.WriteString(" alt=\"" + html.EscapeString(pic.Format.AltText) + "\"")
+	}
+	if pic.InsertType == PictureInsertTypePlaceOverCells {
+		if cfg, _, err := image.DecodeConfig(bytes.NewReader(pic.File)); err == nil {
+			scaleX, scaleY := 1.0, 1.0
+			if pic.Format != nil {
+				if pic.Format.ScaleX > 0 {
+					scaleX = pic.Format.ScaleX
+				}
+				if pic.Format.ScaleY > 0 {
+					scaleY = pic.Format.ScaleY
+				}
+			}
+			fmt.Fprintf(bw, " width=\"%d\" height=\"%d\"",
+				int(float64(cfg.Width)*scaleX), int(float64(cfg.Height)*scaleY))
+		}
+	} else {
+		_, _ = bw.WriteString(" style=\"max-width:100%;max-height:100%\"")
+	}
+	_, _ = bw.WriteString(">")
+}
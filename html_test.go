@@ -0,0 +1,162 @@
+package excelize
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteSheetHTML(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Amount", "Hidden", "Paid"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"<Foo>\nBar", 1234.5, "x", true}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"Total"}))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A6", "Hidden row"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A7", "Sheet1"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A3", "B4"))
+	assert.NoError(t, f.SetColVisible("Sheet1", "C", false))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 6, false))
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "A", 20))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 2, 30))
+	headerStyle, err := f.NewStyle(&Style{
+		Font:      &Font{Bold: true, Italic: true, Underline: "single", Strike: true, Color: "FF0000", Family: "Arial\"", Size: 12},
+		Fill:      Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}},
+		Border:    []Border{{Type: "bottom", Style: 2, Color: "0000FF"}, {Type: "diagonalUp", Style: 1}},
+		Alignment: &Alignment{Horizontal: "center", Vertical: "center", Indent: 1},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "D1", headerStyle))
+	wrapStyle, err := f.NewStyle(&Style{
+		Alignment: &Alignment{WrapText: true},
+		Fill:      Fill{Type: "gradient", Color: []string{"FFFFFF", "E0EBF5"}, Shading: 1},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A2", "A2", wrapStyle))
+	numStyle, err := f.NewStyle(&Style{NumFmt: 4})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B2", numStyle))
+	tooltip := "Open"
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A2", "https://example.com/?a=1&b=2", "External", HyperlinkOpts{Tooltip: &tooltip}))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A7", "Sheet1!A1", "Location"))
+	assert.NoError(t, f.AddPicture("Sheet1", "D3", filepath.Join("test", "images", "excel.png"), &GraphicOptions{AltText: "Logo", ScaleX: 0.5, ScaleY: 0.5}))
+
+	var buf bytes.Buffer
+	assert.NoError(t, f.WriteSheetHTML("Sheet1", &buf))
+	output := buf.String()
+	assert.True(t, strings.HasPrefix(output, "<table style=\"border-collapse:collapse;table-layout:fixed;font-family:'Calibri';font-size:11pt"))
+	assert.Contains(t, output, "<colgroup><col style=\"width:160px\"><col style=\"width:73px\"><col style=\"width:73px\"></colgroup>")
+	assert.Contains(t, output, "<tr style=\"height:36px\">")
+	assert.Contains(t, output, "<td style=\"font-family:'Arial';font-size:12pt;font-weight:bold;font-style:italic;"+
+		"text-decoration:underline line-through;color:#FF0000;background-color:#FFFF00;border-bottom:2px solid #0000FF;"+
+		"padding-left:9px;text-align:center;vertical-align:middle;white-space:nowrap\">Name</td>")
+	assert.Contains(t, output, "<td style=\"background:linear-gradient(#FFFFFF,#E0EBF5);vertical-align:bottom;white-space:pre-wrap\">"+
+		"<a href=\"https://example.com/?a=1&amp;b=2\" title=\"Open\">&lt;Foo&gt;<br>Bar</a></td>")
+	assert.Contains(t, output, "<td style=\"text-align:right;vertical-align:bottom;white-space:nowrap\">1,234.50</td>")
+	assert.Contains(t, output, "<td style=\"text-align:center;vertical-align:bottom;white-space:nowrap\">TRUE</td>")
+	assert.Contains(t, output, "<td colspan=\"2\" rowspan=\"2\" style=\"vertical-align:bottom;white-space:nowrap\">Total</td>")
+	assert.Contains(t, output, "<img src=\"data:image/png;base64,iVBORw0KGgo")
+	assert.Contains(t, output, "alt=\"Logo\" width=\"100\" height=\"64\">")
+	assert.Contains(t, output, "<a href=\"#Sheet1!A1\">Sheet1</a>")
+	assert.NotContains(t, output, "Hidden")
+	assert.Equal(t, 6, strings.Count(output, "<tr "))
+
+	// Test render the worksheet with the range reference and raw cell values
+	buf.Reset()
+	assert.NoError(t, f.WriteSheetHTML("Sheet1", &buf, HTMLOptions{Range: "B2:A1", RawCellValue: true}))
+	output = buf.String()
+	assert.Equal(t, 2, strings.Count(output, "<tr "))
+	assert.Contains(t, output, ">1234.5</td>")
+	assert.NotContains(t, output, "Total")
+
+	// Test render the merged cell with hidden top-left cell
+	buf.Reset()
+	assert.NoError(t, f.SetRowVisible("Sheet1", 3, false))
+	assert.NoError(t, f.WriteSheetHTML("Sheet1", &buf, HTMLOptions{Range: "A3:B4"}))
+	assert.Contains(t, buf.String(), "<td colspan=\"2\" style=\"vertical-align:bottom;white-space:nowrap\">Total</td>")
+	assert.Equal(t, 1, strings.Count(buf.String(), "<td"))
+
+	// Test render the merged cell with hidden styled top-left row, the row
+	// style should not be applied to the neighbouring cells
+	f2 := NewFile()
+	assert.NoError(t, f2.SetSheetRow("Sheet1", "A1", &[]interface{}{"Merged"}))
+	assert.NoError(t, f2.SetSheetRow("Sheet1", "A2", &[]interface{}{nil, "Neighbour"}))
+	assert.NoError(t, f2.MergeCell("Sheet1", "A1", "A2"))
+	redStyle, err := f2.NewStyle(&Style{Fill: Fill{Type: "pattern", Pattern: 1, Color: []string{"FF0000"}}})
+	assert.NoError(t, err)
+	assert.NoError(t, f2.SetRowStyle("Sheet1", 1, 1, redStyle))
+	assert.NoError(t, f2.SetRowVisible("Sheet1", 1, false))
+	buf.Reset()
+	assert.NoError(t, f2.WriteSheetHTML("Sheet1", &buf))
+	assert.Contains(t, buf.String(), "<td style=\"background-color:#FF0000;vertical-align:bottom;white-space:nowrap\">Merged</td>")
+	assert.Contains(t, buf.String(), "<td style=\"vertical-align:bottom;white-space:nowrap\">Neighbour</td>")
+	assert.NoError(t, f2.Close())
+
+	// Test render the hyperlinks with unsafe location as plain text
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet3", "A1", &[]interface{}{"Script", "Mail", "Web"}))
+	assert.NoError(t, f.SetCellHyperLink("Sheet3", "A1", "javascript:alert(document.cookie)", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet3", "B1", "mailto:user@example.com", "External"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet3", "C1", "https://github.com/xuri/excelize", "External"))
+	buf.Reset()
+	assert.NoError(t, f.WriteSheetHTML("Sheet3", &buf))
+	output = buf.String()
+	assert.NotContains(t, output, "javascript:")
+	assert.Contains(t, output, "nowrap\">Script</td>")
+	assert.Contains(t, output, "<a href=\"mailto:user@example.com\">Mail</a>")
+	assert.Contains(t, output, "<a href=\"https://github.com/xuri/excelize\">Web</a>")
+	assert.False(t, isHTMLSafeLink("%zz"))
+
+	// Test render the worksheet with the hyperlink and merged cell over the
+	// whole worksheet
+	ws, err := f.workSheetReader("Sheet3")
+	assert.NoError(t, err)
+	ws.Hyperlinks.Hyperlink[2].Ref = "A2:XFD1048576"
+	ws.MergeCells = &xlsxMergeCells{Cells: []*xlsxMergeCell{{Ref: "A2:XFD1048576"}}}
+	buf.Reset()
+	assert.NoError(t, f.WriteSheetHTML("Sheet3", &buf))
+	assert.Equal(t, 2, strings.Count(buf.String(), "<tr "))
+	assert.Contains(t, buf.String(), "<td colspan=\"3\" style=\"vertical-align:bottom;white-space:nowrap\"><a href=\"https://github.com/xuri/excelize\"></a></td>")
+
+	// Test render the worksheet with conditional formats
+	_, err = f.NewSheet("Sheet4")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetCol("Sheet4", "A1", &[]interface{}{1, 2, 3}))
+	format, err := f.NewConditionalStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet4", "A1:A3", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: &format, Value: "1"},
+	}))
+	buf.Reset()
+	assert.NoError(t, f.WriteSheetHTML("Sheet4", &buf))
+	assert.Contains(t, buf.String(), "<td style=\"text-align:right;vertical-align:bottom;white-space:nowrap\">1</td>")
+	for _, value := range []string{"2", "3"} {
+		assert.Contains(t, buf.String(), "<td style=\"background-color:#FF0000;text-align:right;vertical-align:bottom;white-space:nowrap\">"+value+"</td>")
+	}
+
+	// Test render the empty worksheet
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	buf.Reset()
+	assert.NoError(t, f.WriteSheetHTML("Sheet2", &buf))
+	assert.Equal(t, "<table></table>\n", buf.String())
+
+	// Test render the worksheet with invalid range reference
+	assert.Error(t, f.WriteSheetHTML("Sheet1", &buf, HTMLOptions{Range: "A"}))
+	// Test render the worksheet on not exists worksheet
+	assert.EqualError(t, f.WriteSheetHTML("SheetN", &buf), "sheet SheetN does not exist")
+	// Test render the worksheet with invalid sheet name
+	assert.EqualError(t, f.WriteSheetHTML("Sheet:1", &buf), ErrSheetNameInvalid.Error())
+	// Test render the worksheet with writer error
+	assert.EqualError(t, f.WriteSheetHTML("Sheet1", &errWriter{err: errors.New("write error")}), "write error")
+	assert.EqualError(t, f.WriteSheetHTML("Sheet2", &errWriter{err: errors.New("write error")}), "write error")
+	// Test render the worksheet with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.WriteSheetHTML("Sheet1", &buf), "invalid style ID 0")
+	assert.NoError(t, f.Close())
+}
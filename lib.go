@@ -392,6 +392,25 @@ func (f *File) getDefinedNameRefTo(definedNameName, currentSheet string) (refTo
 	return
 }
 
+// splitRefs provides a function to split the references by the commas
+// which are not in the quoted worksheet names, such as 'Sheet,1'!A1,Sheet1!B1.
+func splitRefs(refs string) []string {
+	var (
+		parts   []string
+		start   int
+		inQuote bool
+	)
+	for i, char := range refs {
+		switch {
+		case char == '\'':
+			inQuote = !inQuote
+		case char == ',' && !inQuote:
+			parts, start = append(parts, refs[start:i]), i+1
+		}
+	}
+	return append(parts, refs[start:])
+}
+
 // flatSqref convert reference sequence to cell reference list.
 func flatSqref(sqref string) (cells map[int][][]int, err error) {
 	var coordinates []int
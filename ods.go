@@ -0,0 +1,1951 @@
+// Copyright 2016 - 2026 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.25.0 or later.
+
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/efp"
+	"github.com/xuri/nfp"
+)
+
+var (
+	// odsBorderStyles defined the border line styles of the OpenDocument
+	// spreadsheet by the border style index of the spreadsheet.
+	odsBorderStyles = map[int]string{
+		1: "0.74pt solid", 2: "1.76pt solid", 3: "0.74pt dashed", 4: "0.74pt dotted",
+		5: "2.49pt solid", 6: "2.01pt double", 7: "0.26pt solid", 8: "1.76pt dashed",
+		9: "0.74pt dashed", 10: "1.76pt dashed", 11: "0.74pt dashed", 12: "1.76pt dashed",
+		13: "1.76pt dashed",
+	}
+	// odsBorderTypes defined the border types of the spreadsheet by the border
+	// attributes of the OpenDocument spreadsheet.
+	odsBorderTypes = []struct{ space, attr, typ string }{
+		{NameSpaceODSFO, "border-left", "left"},
+		{NameSpaceODSFO, "border-right", "right"},
+		{NameSpaceODSFO, "border-top", "top"},
+		{NameSpaceODSFO, "border-bottom", "bottom"},
+		{NameSpaceODSStyle, "diagonal-tl-br", "diagonalDown"},
+		{NameSpaceODSStyle, "diagonal-bl-tr", "diagonalUp"},
+	}
+	// odsHorizontalAlignments defined the horizontal alignment types of the
+	// spreadsheet by the text alignment of the OpenDocument spreadsheet.
+	odsHorizontalAlignments = map[string]string{
+		"start": "left", "left": "left", "center": "center", "end": "right", "right": "right", "justify": "justify",
+	}
+	// odsVerticalAlignments defined the vertical alignment types of the
+	// spreadsheet by the vertical alignment of the OpenDocument spreadsheet.
+	odsVerticalAlignments = map[string]string{"top": "top", "middle": "center", "bottom": "bottom"}
+	// odsLengthUnits defined the points of the length units of the
+	// OpenDocument spreadsheet.
+	odsLengthUnits = map[string]float64{"pt": 1, "in": 72, "cm": 72 / 2.54, "mm": 72 / 25.4, "pc": 12, "px": 0.75}
+	// odsDurationExp defined the regular expression of the duration value in
+	// the OpenDocument spreadsheet.
+	odsDurationExp = regexp.MustCompile(`^(-)?P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:([\d.]+)S)?)?$`)
+	// odsReferenceExp defined the regular expression of the cell, column and
+	// row reference in the formula.
+	odsReferenceExp = regexp.MustCompile(`^(\$?[A-Za-z]{1,3}\$?\d+|\$?[A-Za-z]{1,3}|\$?\d+)$`)
+)
+
+// isODSPackage provides a function to detect if the given ZIP archive is an
+// OpenDocument spreadsheet package by the mimetype file.
+func isODSPackage(zr *zip.Reader) bool {
+	for _, file := range zr.File {
+		if file.Name == "mimetype" {
+			content, err := readFile(file)
+			return err == nil && strings.TrimSpace(string(content)) == ContentTypeODS
+		}
+	}
+	return false
+}
+
+// parseODSNode provides a function to parse the XML document of the
+// OpenDocument spreadsheet into the generic element tree, the character data
+// are stored as the children without element name.
+func (f *File) parseODSNode(r io.Reader) (*odsNode, error) {
+	var (
+		decoder = f.xmlNewDecoder(r)
+		root    = &odsNode{}
+		stack   = []*odsNode{root}
+	)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return root, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		parent := stack[len(stack)-1]
+		switch element := token.(type) {
+		case xml.StartElement:
+			node := &odsNode{Name: element.Name, Attrs: element.Copy().Attr}
+			parent.Children = append(parent.Children, node)
+			stack = append(stack, node)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			parent.Children = append(parent.Children, &odsNode{Text: string(element)})
+		}
+	}
+}
+
+// is provides a function to check if the element name matches the given
+// namespace and local name.
+func (n *odsNode) is(space, local string) bool {
+	return n != nil && n.Name.Space == space && n.Name.Local == local
+}
+
+// attr provides a function to get the attribute value by given namespace and
+// local name of the attribute.
+func (n *odsNode) attr(space, local string) string {
+	if n == nil {
+		return ""
+	}
+	for _, attr := range n.Attrs {
+		if attr.Name.Space == space && attr.Name.Local == local {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// intAttr provides a function to get the positive integer attribute value by
+// given namespace and local name of the attribute, returns 1 by default.
+func (n *odsNode) intAttr(space, local string) int {
+	if value, err := strconv.Atoi(n.attr(space, local)); err == nil && value > 1 {
+		return value
+	}
+	return 1
+}
+
+// child provides a function to get the first child element by given namespace
+// and local name.
+func (n *odsNode) child(space, local string) *odsNode {
+	if n == nil {
+		return nil
+	}
+	for _, child := range n.Children {
+		if child.is(space, local) {
+			return child
+		}
+	}
+	return nil
+}
+
+// flatten provides a function to get the elements with given local name in
+// the table namespace, the elements in the given groups will be included, and
+// the covered cells will be included for the table cells.
+func (n *odsNode) flatten(local string, groups ...string) []*odsNode {
+	var nodes []*odsNode
+	if n == nil {
+		return nodes
+	}
+	for _, child := range n.Children {
+		if child.is(NameSpaceODSTable, local) || (local == "table-cell" && child.is(NameSpaceODSTable, "covered-table-cell")) {
+			nodes = append(nodes, child)
+			continue
+		}
+		if child.Name.Space == NameSpaceODSTable && slices.Contains(groups, child.Name.Local) {
+			nodes = append(nodes, child.flatten(local, groups...)...)
+		}
+	}
+	return nodes
+}
+
+// textContent provides a function to get the text content of the paragraph
+// element, the spaces, tabs and line breaks elements will be converted.
+func (n *odsNode) textContent() string {
+	var sb strings.Builder
+	for _, child := range n.Children {
+		switch {
+		case child.Name.Local == "":
+			sb.WriteString(child.Text)
+		case child.is(NameSpaceODSText, "s"):
+			sb.WriteString(strings.Repeat(" ", min(child.intAttr(NameSpaceODSText, "c"), TotalCellChars)))
+		case child.is(NameSpaceODSText, "tab"):
+			sb.WriteString("\t")
+		case child.is(NameSpaceODSText, "line-break"):
+			sb.WriteString("\n")
+		case child.is(NameSpaceODSText, "note"), child.is(NameSpaceODSOffice, "annotation"):
+		default:
+			sb.WriteString(child.textContent())
+		}
+	}
+	return sb.String()
+}
+
+// odsReader directly maps the reader state of the OpenDocument spreadsheet.
+type odsReader struct {
+	f             *File
+	styles        map[string]*odsNode
+	fontFaces     map[string]string
+	styleIDs      map[string]int
+	repeatedCells int
+}
+
+// openODS provides a function to read the OpenDocument spreadsheet into a
+// spreadsheet, the cell values, formulas, styles, merged cells, column width,
+// row height and visibility of the tables will be converted.
+func openODS(zr *zip.Reader, opts *Options) (*File, error) {
+	x := &odsReader{
+		f:         NewFile(),
+		styles:    make(map[string]*odsNode),
+		fontFaces: make(map[string]string),
+		styleIDs:  make(map[string]int),
+	}
+	x.f.options = opts
+	var (
+		content   *odsNode
+		unzipSize int64
+	)
+	for _, name := range []string{"styles.xml", "content.xml"} {
+		idx := slices.IndexFunc(zr.File, func(file *zip.File) bool { return file.Name == name })
+		if idx == -1 {
+			continue
+		}
+		fileSize := zr.File[idx].FileInfo().Size()
+		unzipSize += fileSize
+		if err := x.f.checkFileSize(fileSize, unzipSize); err != nil {
+			return nil, err
+		}
+		node, err := x.f.readODSPart(zr.File[idx])
+		if err != nil {
+			return nil, err
+		}
+		x.addStyles(node)
+		content = node
+	}
+	spreadsheet := content.child(NameSpaceODSOffice, "document-content").
+		child(NameSpaceODSOffice, "body").child(NameSpaceODSOffice, "spreadsheet")
+	tables := spreadsheet.flatten("table")
+	if len(tables) == 0 {
+		return nil, ErrWorkbookFileFormat
+	}
+	if err := x.setDefaultFont(); err != nil {
+		return nil, err
+	}
+	for i, table := range tables {
+		name := table.attr(NameSpaceODSTable, "name")
+		if i == 0 {
+			if err := x.f.SetSheetName("Sheet1", name); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if _, err := x.f.NewSheet(name); err != nil {
+			return nil, err
+		}
+	}
+	for _, table := range tables {
+		if err := x.readTable(table); err != nil {
+			return nil, err
+		}
+	}
+	x.setDefinedNames(spreadsheet)
+	for _, table := range tables {
+		if x.styleProp("table", table.attr(NameSpaceODSTable, "style-name"),
+			"table-properties", NameSpaceODSTable, "display") == "false" {
+			if err := x.f.SetSheetVisible(table.attr(NameSpaceODSTable, "name"), false); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return x.f, nil
+}
+
+// readODSPart provides a function to read and parse the XML part of the
+// OpenDocument spreadsheet, the part will be extracted to system temporary
+// directory when the file size is over the UnzipXMLSizeLimit.
+func (f *File) readODSPart(file *zip.File) (*odsNode, error) {
+	if file.FileInfo().Size() <= f.options.UnzipXMLSizeLimit {
+		data, err := readFile(file)
+		if err != nil {
+			return nil, err
+		}
+		return f.parseODSNode(bytes.NewReader(data))
+	}
+	tempFile, err := f.unzipToTemp(file)
+	if tempFile != "" {
+		defer os.Remove(tempFile)
+	}
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := os.Open(tempFile)
+	if err != nil {
+		return nil, err
+	}
+	defer tmp.Close()
+	return f.parseODSNode(tmp)
+}
+
+// addStyles provides a function to collect the styles, number styles and font
+// face declarations in the styles.xml and content.xml.
+func (x *odsReader) addStyles(root *odsNode) {
+	for _, doc := range root.Children {
+		for _, section := range doc.Children {
+			for _, node := range section.Children {
+				switch {
+				case node.is(NameSpaceODSStyle, "font-face"):
+					x.fontFaces[node.attr(NameSpaceODSStyle, "name")] = strings.Trim(node.attr(NameSpaceODSSVG, "font-family"), "'\"")
+				case node.is(NameSpaceODSStyle, "style"):
+					x.styles[node.attr(NameSpaceODSStyle, "family")+":"+node.attr(NameSpaceODSStyle, "name")] = node
+				case node.is(NameSpaceODSStyle, "default-style"):
+					x.styles[node.attr(NameSpaceODSStyle, "family")+":"] = node
+				case node.Name.Space == NameSpaceODSNumber:
+					x.styles["data:"+node.attr(NameSpaceODSStyle, "name")] = node
+				}
+			}
+		}
+	}
+}
+
+// styleProp provides a function to get the property of the style by given
+// style family, style name, properties element name and attribute name, the
+// property will be inherited from the parent styles.
+func (x *odsReader) styleProp(family, name, props, space, attr string) string {
+	for depth := 0; name != "" && depth < 16; depth++ {
+		style, ok := x.styles[family+":"+name]
+		if !ok {
+			break
+		}
+		if value := style.child(NameSpaceODSStyle, props).attr(space, attr); value != "" {
+			return value
+		}
+		name = style.attr(NameSpaceODSStyle, "parent-style-name")
+	}
+	return ""
+}
+
+// setDefaultFont provides a function to set the default font of the
+// spreadsheet by the default cell style of the OpenDocument spreadsheet.
+func (x *odsReader) setDefaultFont() error {
+	props := x.styles["table-cell:"].child(NameSpaceODSStyle, "text-properties")
+	name := props.attr(NameSpaceODSFO, "font-family")
+	if fontName, ok := x.fontFaces[props.attr(NameSpaceODSStyle, "font-name")]; ok {
+		name = fontName
+	}
+	if name = strings.Trim(name, "'\""); name == "" {
+		return nil
+	}
+	return x.f.SetDefaultFont(name)
+}
+
+// setDefinedNames provides a function to add the named ranges and named
+// expressions of the OpenDocument spreadsheet as the defined names.
+func (x *odsReader) setDefinedNames(spreadsheet *odsNode) {
+	x.addDefinedNames(spreadsheet, "")
+	for _, table := range spreadsheet.flatten("table") {
+		x.addDefinedNames(table, table.attr(NameSpaceODSTable, "name"))
+	}
+}
+
+// addDefinedNames provides a function to add the named ranges and named
+// expressions in the given element as the defined names with given scope.
+func (x *odsReader) addDefinedNames(parent *odsNode, scope string) {
+	namedExpressions := parent.child(NameSpaceODSTable, "named-expressions")
+	if namedExpressions == nil {
+		return
+	}
+	for _, node := range namedExpressions.Children {
+		var refersTo string
+		switch {
+		case node.is(NameSpaceODSTable, "named-range"):
+			refersTo = odsToFormula("[" + node.attr(NameSpaceODSTable, "cell-range-address") + "]")
+		case node.is(NameSpaceODSTable, "named-expression"):
+			refersTo = odsToFormula(node.attr(NameSpaceODSTable, "expression"))
+		default:
+			continue
+		}
+		_ = x.f.SetDefinedName(&DefinedName{Name: node.attr(NameSpaceODSTable, "name"), RefersTo: refersTo, Scope: scope})
+	}
+}
+
+// readTable provides a function to read the table of the OpenDocument
+// spreadsheet into the worksheet.
+func (x *odsReader) readTable(table *odsNode) error {
+	sheet := table.attr(NameSpaceODSTable, "name")
+	ws, err := x.f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	var colStyles []string
+	col := 1
+	for _, column := range table.flatten("table-column", "table-column-group", "table-header-columns", "table-columns") {
+		if col > MaxColumns {
+			break
+		}
+		last := min(col+column.intAttr(NameSpaceODSTable, "number-columns-repeated")-1, MaxColumns)
+		width := x.styleProp("table-column", column.attr(NameSpaceODSTable, "style-name"),
+			"table-column-properties", NameSpaceODSStyle, "column-width")
+		if points, ok := odsLength(width); ok {
+			ws.setColWidth(col, last, min(math.Round(points*96/72/8*100)/100, MaxColumnWidth))
+		}
+		if visibility := column.attr(NameSpaceODSTable, "visibility"); visibility == "collapse" || visibility == "filter" {
+			ws.setColVisible(col, last, false)
+		}
+		for ; col <= last; col++ {
+			colStyles = append(colStyles, column.attr(NameSpaceODSTable, "default-cell-style-name"))
+		}
+	}
+	row := 1
+	for _, tr := range table.flatten("table-row", "table-row-group", "table-header-rows", "table-rows") {
+		repeated := tr.intAttr(NameSpaceODSTable, "number-rows-repeated")
+		cells := tr.flatten("table-cell")
+		if !slices.ContainsFunc(cells, func(cell *odsNode) bool { return x.hasCellContent(cell, repeated) }) && repeated > 1 {
+			row += repeated
+			continue
+		}
+		for i := 0; i < repeated && row <= TotalRows; i, row = i+1, row+1 {
+			if err = x.readRow(ws, sheet, tr, row, colStyles); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hasCellContent provides a function to check if the given cell should be
+// created in the worksheet, the cell has value, formula or style without
+// massive repeated.
+func (x *odsReader) hasCellContent(cell *odsNode, rowsRepeated int) bool {
+	if cell.attr(NameSpaceODSOffice, "value-type") != "" || cell.attr(NameSpaceODSTable, "formula") != "" ||
+		cell.child(NameSpaceODSText, "p") != nil || cell.intAttr(NameSpaceODSTable, "number-columns-spanned") > 1 ||
+		cell.intAttr(NameSpaceODSTable, "number-rows-spanned") > 1 {
+		return true
+	}
+	styleName := cell.attr(NameSpaceODSTable, "style-name")
+	return styleName != "" && styleName != "Default" && rowsRepeated*cell.intAttr(NameSpaceODSTable, "number-columns-repeated") <= 256
+}
+
+// readRow provides a function to read the table row of the OpenDocument
+// spreadsheet into the worksheet row. The cells created by the repeated rows
+// and columns, including the empty cells before them in the row, are limited
+// in the workbook, which prevents the tiny document from creating massive
+// cells.
+func (x *odsReader) readRow(ws *xlsxWorksheet, sheet string, tr *odsNode, row int, colStyles []string) error {
+	styleName := tr.attr(NameSpaceODSTable, "style-name")
+	if x.styleProp("table-row", styleName, "table-row-properties", NameSpaceODSStyle, "use-optimal-row-height") != "true" {
+		if height, ok := odsLength(x.styleProp("table-row", styleName, "table-row-properties", NameSpaceODSStyle, "row-height")); ok {
+			if err := x.f.SetRowHeight(sheet, row, min(math.Round(height*100)/100, MaxRowHeight)); err != nil {
+				return err
+			}
+		}
+	}
+	if visibility := tr.attr(NameSpaceODSTable, "visibility"); visibility == "collapse" || visibility == "filter" {
+		if err := x.f.SetRowVisible(sheet, row, false); err != nil {
+			return err
+		}
+	}
+	col, rowsRepeated := 1, tr.intAttr(NameSpaceODSTable, "number-rows-repeated")
+	for _, cell := range tr.flatten("table-cell") {
+		repeated := cell.intAttr(NameSpaceODSTable, "number-columns-repeated")
+		if cell.is(NameSpaceODSTable, "table-cell") && x.hasCellContent(cell, rowsRepeated) {
+			if rowsRepeated > 1 || repeated > 1 {
+				var cellCount int
+				if row <= len(ws.SheetData.Row) {
+					cellCount = len(ws.SheetData.Row[row-1].C)
+				}
+				if x.repeatedCells += max(min(col+repeated-1, MaxColumns)-cellCount, 0); x.repeatedCells > maxODSRepeatedCells {
+					return newODSRepeatedCellsError(maxODSRepeatedCells)
+				}
+			}
+			for i := 0; i < repeated && col+i <= MaxColumns; i++ {
+				styleName := cell.attr(NameSpaceODSTable, "style-name")
+				if styleName == "" && col+i <= len(colStyles) {
+					styleName = colStyles[col+i-1]
+				}
+				if err := x.readCell(ws, sheet, cell, col+i, row, styleName); err != nil {
+					return err
+				}
+			}
+		}
+		col += repeated
+	}
+	return nil
+}
+
+// readCell provides a function to read the table cell of the OpenDocument
+// spreadsheet into the worksheet cell.
+func (x *odsReader) readCell(ws *xlsxWorksheet, sheet string, cell *odsNode, col, row int, styleName string) error {
+	valueType := cell.attr(NameSpaceODSOffice, "value-type")
+	styleID, err := x.getStyle(styleName, valueType)
+	if err != nil {
+		return err
+	}
+	ws.prepareSheetXML(col, row)
+	c := &ws.SheetData.Row[row-1].C[col-1]
+	c.S = styleID
+	var paragraphs []string
+	for _, child := range cell.Children {
+		if child.is(NameSpaceODSText, "p") {
+			paragraphs = append(paragraphs, child.textContent())
+		}
+	}
+	text, formula := strings.Join(paragraphs, "\n"), cell.attr(NameSpaceODSTable, "formula")
+	switch valueType {
+	case "float", "percentage", "currency":
+		value, _ := strconv.ParseFloat(cell.attr(NameSpaceODSOffice, "value"), 64)
+		c.setCellFloat(value, -1, 64)
+	case "date":
+		value, _ := odsDateValue(cell.attr(NameSpaceODSOffice, "date-value"))
+		c.setCellFloat(value, -1, 64)
+	case "time":
+		c.setCellFloat(odsTimeValue(cell.attr(NameSpaceODSOffice, "time-value")), -1, 64)
+	case "boolean":
+		c.T, c.V = setCellBool(cell.attr(NameSpaceODSOffice, "boolean-value") == "true")
+	default:
+		if value := cell.attr(NameSpaceODSOffice, "string-value"); value != "" {
+			text = value
+		}
+		if valueType == "" && text == "" {
+			break
+		}
+		if formula != "" {
+			c.setCachedValue(newStringFormulaArg(text))
+			break
+		}
+		if c.T, c.V, err = x.f.setCellString(text); err != nil {
+			return err
+		}
+	}
+	if slices.ContainsFunc(cell.Attrs, func(attr xml.Attr) bool {
+		return attr.Name.Local == "value-type" && attr.Value == "error"
+	}) {
+		c.setCachedValue(newErrorFormulaArg(text, text))
+	}
+	if formula != "" {
+		c.F = &xlsxF{Content: odsToFormula(formula)}
+	}
+	if cols, rows := cell.intAttr(NameSpaceODSTable, "number-columns-spanned"), cell.intAttr(NameSpaceODSTable, "number-rows-spanned"); cols > 1 || rows > 1 {
+		topLeftCell, _ := CoordinatesToCellName(col, row)
+		bottomRightCell, err := CoordinatesToCellName(min(col+cols-1, MaxColumns), min(row+rows-1, TotalRows))
+		if err != nil {
+			return err
+		}
+		return x.f.MergeCell(sheet, topLeftCell, bottomRightCell)
+	}
+	return nil
+}
+
+// getStyle provides a function to get the style ID in the spreadsheet by
+// given cell style name and value type of the OpenDocument spreadsheet, the
+// style will be created on the first use.
+func (x *odsReader) getStyle(name, valueType string) (int, error) {
+	key := name + ":" + valueType
+	if styleID, ok := x.styleIDs[key]; ok {
+		return styleID, nil
+	}
+	var (
+		styleID int
+		err     error
+	)
+	if style := x.newStyle(name, valueType); style != nil {
+		styleID, err = x.f.NewStyle(style)
+	}
+	x.styleIDs[key] = styleID
+	return styleID, err
+}
+
+// newStyle provides a function to convert the cell style of the OpenDocument
+// spreadsheet to the style definition, returns nil for the default style.
+func (x *odsReader) newStyle(name, valueType string) *Style {
+	var (
+		style Style
+		prop  = func(props, space, attr string) string {
+			return x.styleProp("table-cell", name, props, space, attr)
+		}
+	)
+	x.setStyleNumFmt(&style, name, valueType)
+	x.setStyleFont(&style, prop)
+	if color := prop("table-cell-properties", NameSpaceODSFO, "background-color"); strings.HasPrefix(color, "#") {
+		style.Fill = Fill{Type: "pattern", Pattern: 1, Color: []string{strings.ToUpper(color[1:])}}
+	}
+	for _, border := range odsBorderTypes {
+		value := prop("table-cell-properties", border.space, border.attr)
+		if value == "" && border.space == NameSpaceODSFO {
+			value = prop("table-cell-properties", NameSpaceODSFO, "border")
+		}
+		if typ, color := odsBorderStyle(value); typ != 0 {
+			style.Border = append(style.Border, Border{Type: border.typ, Color: color, Style: typ})
+		}
+	}
+	var alignment Alignment
+	alignment.Horizontal = odsHorizontalAlignments[prop("paragraph-properties", NameSpaceODSFO, "text-align")]
+	alignment.Vertical = odsVerticalAlignments[prop("table-cell-properties", NameSpaceODSStyle, "vertical-align")]
+	alignment.WrapText = prop("table-cell-properties", NameSpaceODSFO, "wrap-option") == "wrap"
+	alignment.ShrinkToFit = prop("table-cell-properties", NameSpaceODSStyle, "shrink-to-fit") == "true"
+	if angle, err := strconv.ParseFloat(strings.TrimSuffix(prop("table-cell-properties", NameSpaceODSStyle, "rotation-angle"), "deg"), 64); err == nil {
+		if alignment.TextRotation = int(math.Mod(math.Round(angle)+360, 360)); alignment.TextRotation > 180 {
+			alignment.TextRotation = 0
+		}
+	}
+	if alignment != (Alignment{}) {
+		style.Alignment = &alignment
+	}
+	if style.NumFmt == 0 && style.CustomNumFmt == nil && style.Font == nil &&
+		style.Alignment == nil && style.Border == nil && style.Fill.Type == "" {
+		return nil
+	}
+	return &style
+}
+
+// setStyleNumFmt provides a function to set the number format of the style by
+// given cell style name and value type of the OpenDocument spreadsheet, the
+// default date and time number format will be used for the date and time
+// values without number style.
+func (x *odsReader) setStyleNumFmt(style *Style, name, valueType string) {
+	for depth := 0; name != "" && depth < 16; depth++ {
+		node, ok := x.styles["table-cell:"+name]
+		if !ok {
+			break
+		}
+		if dataStyle := node.attr(NameSpaceODSStyle, "data-style-name"); dataStyle != "" {
+			if code := x.getNumFmtCode(dataStyle); code != "" {
+				for numFmtID, builtInCode := range builtInNumFmt {
+					if builtInCode == code {
+						style.NumFmt = numFmtID
+						return
+					}
+				}
+				style.CustomNumFmt = &code
+				return
+			}
+			break
+		}
+		name = node.attr(NameSpaceODSStyle, "parent-style-name")
+	}
+	switch valueType {
+	case "date":
+		style.NumFmt = 14
+	case "time":
+		style.NumFmt = 21
+	}
+}
+
+// setStyleFont provides a function to set the font of the style by given
+// text properties getter of the cell style.
+func (x *odsReader) setStyleFont(style *Style, prop func(props, space, attr string) string) {
+	var font Font
+	get := func(space, attr string) string { return prop("text-properties", space, attr) }
+	font.Family = strings.Trim(get(NameSpaceODSFO, "font-family"), "'\"")
+	if fontName, ok := x.fontFaces[get(NameSpaceODSStyle, "font-name")]; ok {
+		font.Family = fontName
+	}
+	font.Size, _ = odsLength(get(NameSpaceODSFO, "font-size"))
+	weight := get(NameSpaceODSFO, "font-weight")
+	number, _ := strconv.Atoi(weight)
+	font.Bold = weight == "bold" || number >= 600
+	fontStyle := get(NameSpaceODSFO, "font-style")
+	font.Italic = fontStyle == "italic" || fontStyle == "oblique"
+	if color := get(NameSpaceODSFO, "color"); strings.HasPrefix(color, "#") {
+		font.Color = strings.ToUpper(color[1:])
+	}
+	if underline := get(NameSpaceODSStyle, "text-underline-style"); underline != "" && underline != "none" {
+		if font.Underline = "single"; get(NameSpaceODSStyle, "text-underline-type") == "double" {
+			font.Underline = "double"
+		}
+	}
+	if strike := get(NameSpaceODSStyle, "text-line-through-style"); strike != "" && strike != "none" {
+		font.Strike = true
+	}
+	if position := get(NameSpaceODSStyle, "text-position"); strings.HasPrefix(position, "super") {
+		font.VertAlign = "superscript"
+	} else if strings.HasPrefix(position, "sub") {
+		font.VertAlign = "subscript"
+	}
+	if font != (Font{}) {
+		style.Font = &font
+	}
+}
+
+// getNumFmtCode provides a function to convert the number style of the
+// OpenDocument spreadsheet to the number format code, returns empty string
+// for the unsupported number style.
+func (x *odsReader) getNumFmtCode(name string) string {
+	node, ok := x.styles["data:"+name]
+	if !ok || node.is(NameSpaceODSNumber, "boolean-style") {
+		return ""
+	}
+	var sb strings.Builder
+	long := func(child *odsNode, short, long string) string {
+		if child.attr(NameSpaceODSNumber, "style") == "long" {
+			return long
+		}
+		return short
+	}
+	elapsed := node.attr(NameSpaceODSNumber, "truncate-on-overflow") == "false"
+	for _, child := range node.Children {
+		if child.Name.Space != NameSpaceODSNumber {
+			continue
+		}
+		decimal, _ := strconv.Atoi(child.attr(NameSpaceODSNumber, "decimal-places"))
+		switch child.Name.Local {
+		case "number", "scientific-number":
+			sb.WriteString(odsNumberCode(child))
+			if child.Name.Local == "scientific-number" {
+				digits, _ := strconv.Atoi(child.attr(NameSpaceODSNumber, "min-exponent-digits"))
+				sb.WriteString("E+" + strings.Repeat("0", max(digits, 1)))
+			}
+		case "fraction":
+			if digits, _ := strconv.Atoi(child.attr(NameSpaceODSNumber, "min-integer-digits")); digits > 0 {
+				sb.WriteString(strings.Repeat("0", digits) + " ")
+			} else {
+				sb.WriteString("# ")
+			}
+			numerator, _ := strconv.Atoi(child.attr(NameSpaceODSNumber, "min-numerator-digits"))
+			denominator, _ := strconv.Atoi(child.attr(NameSpaceODSNumber, "min-denominator-digits"))
+			sb.WriteString(strings.Repeat("?", max(numerator, 1)) + "/")
+			if value := child.attr(NameSpaceODSNumber, "denominator-value"); value != "" {
+				sb.WriteString(value)
+				break
+			}
+			sb.WriteString(strings.Repeat("?", max(denominator, 1)))
+		case "text":
+			text := child.textContent()
+			if strings.Trim(text, " -/:.,()%") == "" {
+				sb.WriteString(text)
+				break
+			}
+			sb.WriteString("\"" + strings.ReplaceAll(text, "\"", "") + "\"")
+		case "text-content":
+			sb.WriteString("@")
+		case "currency-symbol":
+			sb.WriteString("[$" + child.textContent() + "]")
+		case "year":
+			sb.WriteString(long(child, "yy", "yyyy"))
+		case "month":
+			if child.attr(NameSpaceODSNumber, "textual") == "true" {
+				sb.WriteString(long(child, "mmm", "mmmm"))
+				break
+			}
+			sb.WriteString(long(child, "m", "mm"))
+		case "day":
+			sb.WriteString(long(child, "d", "dd"))
+		case "day-of-week":
+			sb.WriteString(long(child, "ddd", "dddd"))
+		case "hours":
+			if hours := long(child, "h", "hh"); elapsed {
+				elapsed = false
+				sb.WriteString("[" + hours + "]")
+			} else {
+				sb.WriteString(hours)
+			}
+		case "minutes":
+			sb.WriteString(long(child, "m", "mm"))
+		case "seconds":
+			sb.WriteString(long(child, "s", "ss"))
+			if decimal > 0 {
+				sb.WriteString("." + strings.Repeat("0", decimal))
+			}
+		case "am-pm":
+			sb.WriteString("AM/PM")
+		}
+	}
+	return sb.String()
+}
+
+// odsNumberCode provides a function to convert the number element of the
+// number style to the number format code.
+func odsNumberCode(node *odsNode) string {
+	decimal, _ := strconv.Atoi(node.attr(NameSpaceODSNumber, "decimal-places"))
+	digits, err := strconv.Atoi(node.attr(NameSpaceODSNumber, "min-integer-digits"))
+	if err != nil {
+		digits = 1
+	}
+	code := strings.Repeat("0", digits)
+	if node.attr(NameSpaceODSNumber, "grouping") == "true" {
+		code = strings.Repeat("#", max(4-digits, 1)) + code
+		code = code[:len(code)-3] + "," + code[len(code)-3:]
+	}
+	if code == "" {
+		code = "#"
+	}
+	if decimal > 0 {
+		code += "." + strings.Repeat("0", decimal)
+	}
+	return code
+}
+
+// odsLength provides a function to convert the length value of the
+// OpenDocument spreadsheet to points.
+func odsLength(value string) (float64, bool) {
+	for unit, points := range odsLengthUnits {
+		if number, ok := strings.CutSuffix(value, unit); ok {
+			if length, err := strconv.ParseFloat(number, 64); err == nil {
+				return length * points, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// odsBorderStyle provides a function to convert the border value of the
+// OpenDocument spreadsheet to the border style index and color.
+func odsBorderStyle(value string) (int, string) {
+	var (
+		width        float64
+		style, color string
+	)
+	for _, field := range strings.Fields(value) {
+		if points, ok := odsLength(field); ok {
+			width = points
+			continue
+		}
+		if strings.HasPrefix(field, "#") {
+			color = strings.ToUpper(field[1:])
+			continue
+		}
+		style = field
+	}
+	medium := width >= 1.5
+	switch style {
+	case "solid":
+		switch {
+		case width < 0.5:
+			return 7, color
+		case width < 1.5:
+			return 1, color
+		case width < 2.25:
+			return 2, color
+		}
+		return 5, color
+	case "double":
+		return 6, color
+	case "dotted":
+		return 4, color
+	case "dashed", "dash-dot", "dash-dot-dot":
+		if medium {
+			return 8, color
+		}
+		return 3, color
+	}
+	return 0, color
+}
+
+// odsDateValue provides a function to convert the date value of the
+// OpenDocument spreadsheet to the Excel date time serial number.
+func odsDateValue(value string) (float64, error) {
+	t, err := time.Parse("2006-01-02T15:04:05", value)
+	if err != nil {
+		if t, err = time.Parse("2006-01-02", value); err != nil {
+			return 0, err
+		}
+	}
+	return timeToExcelTime(t, false)
+}
+
+// odsTimeValue provides a function to convert the duration value of the
+// OpenDocument spreadsheet to the fraction of days.
+func odsTimeValue(value string) float64 {
+	matches := odsDurationExp.FindStringSubmatch(value)
+	if matches == nil {
+		return 0
+	}
+	var result float64
+	for i, unit := range []float64{1, 24, 24 * 60, 24 * 60 * 60} {
+		number, _ := strconv.ParseFloat(matches[i+2], 64)
+		result += number / unit
+	}
+	if matches[1] == "-" {
+		return -result
+	}
+	return result
+}
+
+// odsToFormula provides a function to convert the formula of the
+// OpenDocument spreadsheet to the formula of the spreadsheet, the references
+// in brackets, function argument separators and array constant separators
+// will be converted.
+func odsToFormula(formula string) string {
+	if expr, ok := strings.CutPrefix(formula, "msoxl:="); ok {
+		return expr
+	}
+	if _, expr, ok := strings.Cut(formula, ":="); ok && !strings.ContainsAny(formula[:strings.Index(formula, ":=")], "[\"(") {
+		formula = expr
+	}
+	formula = strings.TrimPrefix(formula, "=")
+	var sb strings.Builder
+	for i := 0; i < len(formula); i++ {
+		switch c := formula[i]; c {
+		case '"':
+			end := odsQuoteEnd(formula, i, '"')
+			sb.WriteString(formula[i:end])
+			i = end - 1
+		case '[':
+			end := i + 1
+			for end < len(formula) && formula[end] != ']' {
+				if formula[end] == '\'' {
+					end = odsQuoteEnd(formula, end, '\'') - 1
+				}
+				end++
+			}
+			sb.WriteString(odsToReference(formula[i+1 : min(end, len(formula))]))
+			i = end
+		case ';', '~':
+			sb.WriteByte(',')
+		case '|':
+			sb.WriteByte(';')
+		case '!':
+			sb.WriteByte(' ')
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// odsQuoteEnd provides a function to get the position after the end of the
+// quoted text which starts at the given position, the doubled quote
+// characters are escaped quote characters.
+func odsQuoteEnd(text string, start int, quote byte) int {
+	for i := start + 1; i < len(text); i++ {
+		if text[i] == quote {
+			if i+1 < len(text) && text[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(text)
+}
+
+// odsToReference provides a function to convert the reference in brackets of
+// the OpenDocument spreadsheet formula to the reference of the spreadsheet.
+func odsToReference(ref string) string {
+	var sheets, cells []string
+	for part := range strings.SplitSeq(ref, ":") {
+		idx, quoted := -1, false
+		for i := 0; i < len(part); i++ {
+			if part[i] == '\'' {
+				quoted = !quoted
+			}
+			if part[i] == '.' && !quoted {
+				idx = i
+			}
+		}
+		sheet := strings.TrimPrefix(part[:max(idx, 0)], "$")
+		if strings.HasPrefix(sheet, "'") && strings.HasSuffix(sheet, "'") && len(sheet) > 1 {
+			sheet = strings.ReplaceAll(sheet[1:len(sheet)-1], "''", "'")
+		}
+		sheets, cells = append(sheets, sheet), append(cells, part[idx+1:])
+	}
+	if sheets[0] == "" {
+		return strings.Join(cells, ":")
+	}
+	if len(sheets) > 1 && sheets[1] != "" && sheets[1] != sheets[0] {
+		if cells[0] == cells[1] {
+			cells = cells[:1]
+		}
+		if escapeSheetName(sheets[0]) == sheets[0] && escapeSheetName(sheets[1]) == sheets[1] {
+			return sheets[0] + ":" + sheets[1] + "!" + strings.Join(cells, ":")
+		}
+		return "'" + strings.ReplaceAll(sheets[0]+":"+sheets[1], "'", "''") + "'!" + strings.Join(cells, ":")
+	}
+	return escapeSheetName(sheets[0]) + "!" + strings.Join(cells, ":")
+}
+
+// formulaToODS provides a function to convert the formula of the spreadsheet
+// to the OpenFormula of the OpenDocument spreadsheet.
+func formulaToODS(formula string) string {
+	var (
+		ps    = efp.ExcelParser()
+		sb    strings.Builder
+		stack []string
+	)
+	sb.WriteString("of:=")
+	for _, token := range ps.Parse(formula) {
+		switch token.TType {
+		case efp.TokenTypeOperand:
+			switch token.TSubType {
+			case efp.TokenSubTypeText:
+				sb.WriteString("\"" + strings.ReplaceAll(token.TValue, "\"", "\"\"") + "\"")
+			case efp.TokenSubTypeRange:
+				sb.WriteString(referenceToODS(token.TValue))
+			default:
+				sb.WriteString(token.TValue)
+			}
+		case efp.TokenTypeFunction:
+			if token.TSubType == efp.TokenSubTypeStart {
+				stack = append(stack, token.TValue)
+				switch token.TValue {
+				case "ARRAY":
+					sb.WriteString("{")
+				case "ARRAYROW":
+				default:
+					sb.WriteString(strings.TrimPrefix(strings.TrimPrefix(token.TValue, "_xlfn."), "_xlws.") + "(")
+				}
+				break
+			}
+			if len(stack) > 0 {
+				switch stack[len(stack)-1] {
+				case "ARRAY":
+					sb.WriteString("}")
+				case "ARRAYROW":
+				default:
+					sb.WriteString(")")
+				}
+				stack = stack[:len(stack)-1]
+			}
+		case efp.TokenTypeSubexpression:
+			if token.TSubType == efp.TokenSubTypeStart {
+				stack = append(stack, "")
+				sb.WriteString("(")
+				break
+			}
+			stack = stack[:max(len(stack)-1, 0)]
+			sb.WriteString(")")
+		case efp.TokenTypeArgument:
+			if len(stack) > 0 && stack[len(stack)-1] == "ARRAY" {
+				sb.WriteString("|")
+				break
+			}
+			sb.WriteString(";")
+		case efp.TokenTypeOperatorInfix:
+			switch token.TSubType {
+			case efp.TokenSubTypeUnion:
+				sb.WriteString("~")
+			case efp.TokenSubTypeIntersection:
+				sb.WriteString("!")
+			default:
+				sb.WriteString(token.TValue)
+			}
+		case efp.TokenTypeWhitespace:
+			sb.WriteString(" ")
+		default:
+			sb.WriteString(token.TValue)
+		}
+	}
+	return sb.String()
+}
+
+// referenceToODS provides a function to convert the reference of the
+// spreadsheet formula to the reference in brackets of the OpenDocument
+// spreadsheet, the defined names will be kept.
+func referenceToODS(ref string) string {
+	var sheet string
+	cells := ref
+	if idx := strings.LastIndex(ref, "!"); idx != -1 {
+		sheet, cells = ref[:idx], ref[idx+1:]
+		if strings.HasPrefix(sheet, "'") && strings.HasSuffix(sheet, "'") && len(sheet) > 1 {
+			sheet = strings.ReplaceAll(sheet[1:len(sheet)-1], "''", "'")
+		}
+	}
+	parts := strings.Split(cells, ":")
+	if len(parts) > 2 || !slices.ContainsFunc(parts, odsReferenceExp.MatchString) ||
+		slices.ContainsFunc(parts, func(part string) bool { return !odsReferenceExp.MatchString(part) }) {
+		return ref
+	}
+	for i, part := range parts {
+		switch strings.TrimLeft(part, "$")[0] {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			parts[i] = []string{"A", "XFD"}[min(i, 1)] + part
+		default:
+			if !strings.ContainsAny(part, "0123456789") {
+				parts[i] = part + []string{"1", strconv.Itoa(TotalRows)}[min(i, 1)]
+			}
+		}
+	}
+	sheets := strings.SplitN(sheet, ":", 2)
+	if len(sheets) > 1 && len(parts) == 1 {
+		parts = append(parts, parts[0])
+	}
+	var sb strings.Builder
+	sb.WriteString("[")
+	for i, part := range parts {
+		if i > 0 {
+			sb.WriteString(":")
+		}
+		if i < len(sheets) && sheets[i] != "" {
+			sb.WriteString("$" + escapeSheetName(sheets[i]))
+		}
+		sb.WriteString("." + part)
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
+
+// odsWriter directly maps the writer state of the OpenDocument spreadsheet.
+type odsWriter struct {
+	f          *File
+	content    odsDocumentContent
+	sst        *xlsxSST
+	date1904   bool
+	cellStyles map[int][2]string
+	dataStyles map[string][2]string
+	colStyles  map[int]string
+	rowStyles  map[string]string
+}
+
+// WriteODSToBuffer provides a function to get bytes.Buffer of the spreadsheet
+// in the OpenDocument spreadsheet (ODS) format, the cell values, formulas,
+// styles, merged cells, column width, row height and visibility of the
+// worksheets will be converted. The spreadsheet will also be saved in ODS
+// format by the SaveAs function with the ".ods" file extension. For example:
+//
+//	err := f.SaveAs("Book1.ods")
+func (f *File) WriteODSToBuffer() (*bytes.Buffer, error) {
+	w := &odsWriter{
+		f: f,
+		content: odsDocumentContent{
+			XMLNSOffice: NameSpaceODSOffice, XMLNSStyle: NameSpaceODSStyle, XMLNSText: NameSpaceODSText,
+			XMLNSTable: NameSpaceODSTable, XMLNSFO: NameSpaceODSFO, XMLNSNumber: NameSpaceODSNumber,
+			XMLNSSVG: NameSpaceODSSVG, XMLNSOF: NameSpaceODSOF, Version: "1.2",
+		},
+		cellStyles: make(map[int][2]string),
+		dataStyles: make(map[string][2]string),
+		colStyles:  make(map[int]string),
+		rowStyles:  make(map[string]string),
+	}
+	var err error
+	if w.sst, err = f.sharedStringsReader(); err != nil {
+		return nil, err
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return nil, err
+	}
+	w.date1904 = wb.WorkbookPr != nil && wb.WorkbookPr.Date1904
+	for _, sheet := range wb.Sheets.Sheet {
+		if path, _ := f.getSheetXMLPath(sheet.Name); !strings.Contains(path, "worksheets/") {
+			continue
+		}
+		if err = w.writeTable(sheet.Name, sheet.State != ""); err != nil {
+			return nil, err
+		}
+	}
+	w.writeDefinedNames()
+	styles, err := w.documentStyles()
+	if err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	mimetype, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = mimetype.Write([]byte(ContentTypeODS)); err != nil {
+		return nil, err
+	}
+	for name, part := range map[string]interface{}{
+		"META-INF/manifest.xml": odsManifest{
+			XMLNSManifest: NameSpaceODSManifest, Version: "1.2",
+			FileEntries: []odsManifestFileEntry{
+				{FullPath: "/", Version: "1.2", MediaType: ContentTypeODS},
+				{FullPath: "content.xml", MediaType: "text/xml"},
+				{FullPath: "styles.xml", MediaType: "text/xml"},
+			},
+		},
+		"content.xml": w.content,
+		"styles.xml":  styles,
+	} {
+		content, _ := xml.Marshal(part)
+		fi, err := zw.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = fi.Write(append([]byte(xml.Header), content...)); err != nil {
+			return nil, err
+		}
+	}
+	return buf, zw.Close()
+}
+
+// writeDefinedNames provides a function to convert the defined names of the
+// spreadsheet to the named expressions of the OpenDocument spreadsheet, the
+// defined names with worksheet scope will be added into the tables.
+func (w *odsWriter) writeDefinedNames() {
+	if len(w.content.Tables) == 0 {
+		return
+	}
+	for _, definedName := range w.f.GetDefinedName() {
+		namedExpression := odsNamedExpression{
+			Name:            definedName.Name,
+			BaseCellAddress: "$" + escapeSheetName(w.content.Tables[0].Name) + ".$A$1",
+			Expression:      formulaToODS(strings.TrimPrefix(definedName.RefersTo, "=")),
+		}
+		if definedName.Scope == "Workbook" {
+			w.content.NamedExpressions = append(w.content.NamedExpressions, namedExpression)
+			continue
+		}
+		for i := range w.content.Tables {
+			if table := &w.content.Tables[i]; table.Name == definedName.Scope {
+				namedExpression.BaseCellAddress = "$" + escapeSheetName(table.Name) + ".$A$1"
+				table.NamedExpressions = append(table.NamedExpressions, namedExpression)
+			}
+		}
+	}
+}
+
+// documentStyles provides a function to create the styles.xml of the
+// OpenDocument spreadsheet with the default font of the spreadsheet.
+func (w *odsWriter) documentStyles() (odsDocumentStyles, error) {
+	styles := odsDocumentStyles{
+		XMLNSOffice: NameSpaceODSOffice, XMLNSStyle: NameSpaceODSStyle, XMLNSFO: NameSpaceODSFO, Version: "1.2",
+		DefaultStyle: odsStyle{Family: "table-cell"},
+		Styles:       []odsStyle{{Name: "Default", Family: "table-cell"}},
+	}
+	font, err := w.f.readDefaultFont()
+	if err != nil {
+		return styles, err
+	}
+	styles.DefaultStyle.TextProperties = &odsTextProperties{}
+	if font.Name != nil && font.Name.Val != nil {
+		styles.DefaultStyle.TextProperties.FontFamily = odsFontFamily(*font.Name.Val)
+	}
+	if font.Sz != nil && font.Sz.Val != nil {
+		styles.DefaultStyle.TextProperties.FontSize = strconv.FormatFloat(*font.Sz.Val, 'f', -1, 64) + "pt"
+	}
+	return styles, err
+}
+
+// writeTable provides a function to convert the worksheet to the table of
+// the OpenDocument spreadsheet.
+func (w *odsWriter) writeTable(sheet string, hidden bool) error {
+	ws, err := w.f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	table := odsTable{Name: sheet}
+	if hidden {
+		table.StyleName = w.addStyle(odsStyle{Name: "ta1", Family: "table", TableProperties: &odsTableProperties{Display: "false"}})
+	}
+	merged, covered, mergedRows := make(map[[2]int][2]int), make(map[[2]int]bool), make(map[int]bool)
+	maxCol := 1
+	if ws.MergeCells != nil {
+		for _, mergeCell := range ws.MergeCells.Cells {
+			if mergeCell == nil {
+				continue
+			}
+			coordinates, err := rangeRefToCoordinates(mergeCell.Ref)
+			if err != nil {
+				return err
+			}
+			_ = sortCoordinates(coordinates)
+			merged[[2]int{coordinates[0], coordinates[1]}] = [2]int{coordinates[2] - coordinates[0] + 1, coordinates[3] - coordinates[1] + 1}
+			for col := coordinates[0]; col <= coordinates[2]; col++ {
+				for row := coordinates[1]; row <= coordinates[3]; row++ {
+					covered[[2]int{col, row}] = col != coordinates[0] || row != coordinates[1]
+					mergedRows[row] = true
+				}
+			}
+			maxCol = max(maxCol, coordinates[2])
+		}
+	}
+	for _, row := range ws.SheetData.Row {
+		for _, c := range row.C {
+			col, _, err := CellNameToCoordinates(c.R)
+			if err != nil {
+				return err
+			}
+			maxCol = max(maxCol, col)
+		}
+	}
+	if ws.Cols != nil {
+		for _, col := range ws.Cols.Col {
+			if col.Max < MaxColumns {
+				maxCol = max(maxCol, col.Max)
+			}
+		}
+	}
+	table.Columns = w.writeColumns(sheet, ws, maxCol)
+	cellAt := func(col, row int) odsTableCell {
+		cell := odsTableCell{XMLName: xml.Name{Local: "table:table-cell"}}
+		if covered[[2]int{col, row}] {
+			cell.XMLName.Local = "table:covered-table-cell"
+		}
+		if spans, ok := merged[[2]int{col, row}]; ok {
+			cell.ColumnsSpanned, cell.RowsSpanned = spans[0], spans[1]
+		}
+		return cell
+	}
+	appendCell := func(cells []odsTableCell, cell odsTableCell, repeated int) []odsTableCell {
+		if last := len(cells) - 1; last >= 0 && cell.XMLName.Local == "table:table-cell" && cell.ColumnsSpanned == 0 &&
+			reflect.DeepEqual(cells[last], odsTableCell{XMLName: cell.XMLName, Repeated: cells[last].Repeated}) {
+			cells[last].Repeated = max(cells[last].Repeated, 1) + repeated
+			return cells
+		}
+		if repeated > 1 {
+			cell.Repeated = repeated
+		}
+		return append(cells, cell)
+	}
+	emptyCells := func(cells []odsTableCell, row, from, to int) []odsTableCell {
+		if !mergedRows[row] {
+			if from <= to {
+				cells = appendCell(cells, odsTableCell{XMLName: xml.Name{Local: "table:table-cell"}}, to-from+1)
+			}
+			return cells
+		}
+		for col := from; col <= to; col++ {
+			cells = appendCell(cells, cellAt(col, row), 1)
+		}
+		return cells
+	}
+	nextRow, lastEmpty := 1, false
+	emptyRows := func(to int) {
+		for nextRow < to {
+			tr, from := odsTableRow{Cells: emptyCells(nil, nextRow, 1, maxCol)}, nextRow
+			for nextRow++; !mergedRows[from] && nextRow < to && !mergedRows[nextRow]; nextRow++ {
+			}
+			repeated := nextRow - from
+			if last := len(table.Rows) - 1; last >= 0 && lastEmpty && reflect.DeepEqual(table.Rows[last].Cells, tr.Cells) {
+				table.Rows[last].Repeated = max(table.Rows[last].Repeated, 1) + repeated
+				continue
+			}
+			if repeated > 1 {
+				tr.Repeated = repeated
+			}
+			table.Rows, lastEmpty = append(table.Rows, tr), true
+		}
+	}
+	for _, row := range ws.SheetData.Row {
+		// Skip the padded empty rows, which will be merged into the repeated
+		// rows with default height and visibility
+		if len(row.C) == 0 && !row.Hidden && !row.CustomHeight {
+			continue
+		}
+		emptyRows(row.R)
+		tr := odsTableRow{StyleName: w.getRowStyle(ws, &row)}
+		if row.Hidden {
+			tr.Visibility = "collapse"
+		}
+		nextCol := 1
+		for i := range row.C {
+			col, _, _ := CellNameToCoordinates(row.C[i].R)
+			tr.Cells = emptyCells(tr.Cells, row.R, nextCol, col-1)
+			cell, err := w.writeCell(sheet, cellAt(col, row.R), &row.C[i])
+			if err != nil {
+				return err
+			}
+			tr.Cells, nextCol = append(tr.Cells, cell), col+1
+		}
+		tr.Cells = emptyCells(tr.Cells, row.R, nextCol, maxCol)
+		table.Rows, nextRow, lastEmpty = append(table.Rows, tr), row.R+1, false
+	}
+	lastRow := nextRow
+	for cell := range covered {
+		lastRow = max(lastRow, cell[1]+1)
+	}
+	emptyRows(lastRow)
+	w.content.Tables = append(w.content.Tables, table)
+	return nil
+}
+
+// writeColumns provides a function to create the table columns of the
+// OpenDocument spreadsheet by the column width and visibility of the
+// worksheet.
+func (w *odsWriter) writeColumns(sheet string, ws *xlsxWorksheet, maxCol int) []odsTableColumn {
+	var columns []odsTableColumn
+	for col := 1; col <= maxCol; col++ {
+		column := odsTableColumn{StyleName: w.getColumnStyle(w.f.getColWidth(sheet, col)), DefaultCellStyleName: "Default"}
+		if ws.Cols != nil {
+			for _, c := range ws.Cols.Col {
+				if c.Min <= col && col <= c.Max && c.Hidden {
+					column.Visibility = "collapse"
+				}
+			}
+		}
+		if last := len(columns) - 1; last >= 0 && columns[last] == (odsTableColumn{
+			StyleName: column.StyleName, Repeated: columns[last].Repeated,
+			Visibility: column.Visibility, DefaultCellStyleName: column.DefaultCellStyleName,
+		}) {
+			columns[last].Repeated = max(columns[last].Repeated, 1) + 1
+			continue
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// addStyle provides a function to add the automatic style into the content of
+// the OpenDocument spreadsheet if the style with the same name not exists,
+// returns the style name.
+func (w *odsWriter) addStyle(style odsStyle) string {
+	if !slices.ContainsFunc(w.content.AutomaticStyles.Styles, func(s odsStyle) bool { return s.Name == style.Name }) {
+		w.content.AutomaticStyles.Styles = append(w.content.AutomaticStyles.Styles, style)
+	}
+	return style.Name
+}
+
+// getColumnStyle provides a function to get the column style name by given
+// column width in pixels.
+func (w *odsWriter) getColumnStyle(width int) string {
+	if name, ok := w.colStyles[width]; ok {
+		return name
+	}
+	name := "co" + strconv.Itoa(len(w.colStyles)+1)
+	w.colStyles[width] = w.addStyle(odsStyle{
+		Name: name, Family: "table-column",
+		TableColumnProperties: &odsTableColumnProperties{ColumnWidth: strconv.FormatFloat(float64(width)/96, 'f', 4, 64) + "in"},
+	})
+	return name
+}
+
+// getRowStyle provides a function to get the row style name by given row of
+// the worksheet.
+func (w *odsWriter) getRowStyle(ws *xlsxWorksheet, row *xlsxRow) string {
+	height, optimal := defaultRowHeight, "true"
+	if ws.SheetFormatPr != nil && ws.SheetFormatPr.DefaultRowHeight > 0 {
+		height = ws.SheetFormatPr.DefaultRowHeight
+	}
+	if row.Ht != nil && row.CustomHeight {
+		height, optimal = *row.Ht, "false"
+	}
+	key := strconv.FormatFloat(height, 'f', -1, 64) + optimal
+	if name, ok := w.rowStyles[key]; ok {
+		return name
+	}
+	name := "ro" + strconv.Itoa(len(w.rowStyles)+1)
+	w.rowStyles[key] = w.addStyle(odsStyle{
+		Name: name, Family: "table-row",
+		TableRowProperties: &odsTableRowProperties{
+			RowHeight: strconv.FormatFloat(height, 'f', -1, 64) + "pt", UseOptimalRowHeight: optimal,
+		},
+	})
+	return name
+}
+
+// writeCell provides a function to convert the worksheet cell to the table
+// cell of the OpenDocument spreadsheet.
+func (w *odsWriter) writeCell(sheet string, cell odsTableCell, c *xlsxC) (odsTableCell, error) {
+	styleName, valueType, err := w.getCellStyle(c.S)
+	if err != nil {
+		return cell, err
+	}
+	cell.StyleName = styleName
+	if c.F != nil {
+		formula, err := w.f.GetCellFormula(sheet, c.R)
+		if err != nil {
+			return cell, err
+		}
+		if formula != "" {
+			cell.Formula = formulaToODS(formula)
+		}
+	}
+	raw := *c
+	text, err := raw.getValueFrom(w.f, w.sst, true)
+	if err != nil {
+		return cell, err
+	}
+	switch c.T {
+	case "b":
+		cell.ValueType, cell.BooleanValue, text = "boolean", "false", "FALSE"
+		if c.V == "1" {
+			cell.BooleanValue, text = "true", "TRUE"
+		}
+	case "", "n":
+		value, err := strconv.ParseFloat(c.V, 64)
+		if err != nil {
+			return cell, nil
+		}
+		cell.ValueType = valueType
+		switch valueType {
+		case "date":
+			cell.DateValue = timeFromExcelTime(value, w.date1904).Format("2006-01-02T15:04:05")
+		case "time":
+			cell.TimeValue = odsDuration(value)
+		default:
+			cell.Value = c.V
+		}
+		formatted := *c
+		if text, err = formatted.getValueFrom(w.f, w.sst, false); err != nil {
+			return cell, err
+		}
+	default:
+		cell.ValueType = "string"
+	}
+	for line := range strings.SplitSeq(text, "\n") {
+		cell.Paragraphs = append(cell.Paragraphs, odsText{Content: odsEncodeText(line)})
+	}
+	return cell, err
+}
+
+// getCellStyle provides a function to get the cell style name and value type
+// of the OpenDocument spreadsheet by given style ID of the spreadsheet.
+func (w *odsWriter) getCellStyle(styleID int) (string, string, error) {
+	if cellStyle, ok := w.cellStyles[styleID]; ok {
+		return cellStyle[0], cellStyle[1], nil
+	}
+	if styleID == 0 {
+		return "", "float", nil
+	}
+	style, err := w.f.GetStyle(styleID)
+	if err != nil {
+		return "", "", err
+	}
+	cellStyle := odsStyle{Name: "ce" + strconv.Itoa(styleID), Family: "table-cell", ParentStyleName: "Default"}
+	valueType := "float"
+	code := ""
+	if style.CustomNumFmt != nil {
+		code = *style.CustomNumFmt
+	} else if style.NumFmt != 0 {
+		code, _ = w.f.getBuiltInNumFmtCode(style.NumFmt)
+	}
+	if code != "" {
+		cellStyle.DataStyleName, valueType = w.getDataStyle(code)
+	}
+	cellStyle.TextProperties = odsFontProperties(style.Font)
+	cellStyle.TableCellProperties, cellStyle.ParagraphProperties = odsCellProperties(style)
+	name := ""
+	if cellStyle.DataStyleName != "" || cellStyle.TextProperties != nil ||
+		cellStyle.TableCellProperties != nil || cellStyle.ParagraphProperties != nil {
+		name = w.addStyle(cellStyle)
+	}
+	w.cellStyles[styleID] = [2]string{name, valueType}
+	return name, valueType, err
+}
+
+// getDataStyle provides a function to get the number style name and value
+// type of the OpenDocument spreadsheet by given number format code.
+func (w *odsWriter) getDataStyle(code string) (string, string) {
+	if dataStyle, ok := w.dataStyles[code]; ok {
+		return dataStyle[0], dataStyle[1]
+	}
+	name := "N" + strconv.Itoa(len(w.dataStyles)+100)
+	element, valueType := odsNumberStyle(name, code)
+	if element == nil {
+		name = ""
+	} else {
+		w.content.AutomaticStyles.NumberStyles = append(w.content.AutomaticStyles.NumberStyles, *element)
+	}
+	w.dataStyles[code] = [2]string{name, valueType}
+	return name, valueType
+}
+
+// odsNumberStyle provides a function to convert the first section of the
+// number format code to the number style of the OpenDocument spreadsheet,
+// returns nil for the general number format.
+func odsNumberStyle(name, code string) (*odsElement, string) {
+	p := nfp.NumberFormatParser()
+	sections := p.Parse(code)
+	if len(sections) == 0 {
+		return nil, "float"
+	}
+	var (
+		tokens                                  = sections[0].Items
+		date, clock, elapsed, percent, currency bool
+		text, fraction                          bool
+		children                                []odsElement
+		numbers                                 []nfp.Token
+		attr                                    = func(name, value string) xml.Attr {
+			return xml.Attr{Name: xml.Name{Local: name}, Value: value}
+		}
+		element = func(local string, attrs ...xml.Attr) odsElement {
+			return odsElement{XMLName: xml.Name{Local: "number:" + local}, Attrs: attrs}
+		}
+		long = func(value string, n int) []xml.Attr {
+			if len(value) >= n {
+				return []xml.Attr{attr("number:style", "long")}
+			}
+			return nil
+		}
+	)
+	for i, token := range tokens {
+		value := strings.ToUpper(token.TValue)
+		switch token.TType {
+		case nfp.TokenTypeGeneral:
+			return nil, "float"
+		case nfp.TokenTypeDateTimes:
+			if strings.ContainsAny(value, "YDEG") || (strings.Contains(value, "M") && !odsIsMinutes(tokens, i)) {
+				date = true
+			} else {
+				clock = true
+			}
+		case nfp.TokenTypeElapsedDateTimes:
+			clock, elapsed = true, true
+		case nfp.TokenTypePercent:
+			percent = true
+		case nfp.TokenTypeCurrencyLanguage:
+			currency = true
+		case nfp.TokenTypeTextPlaceHolder:
+			text = true
+		case nfp.TokenTypeFraction:
+			fraction = true
+		}
+	}
+	flush := func() {
+		if len(numbers) > 0 {
+			children = append(children, odsNumberElement(numbers, fraction))
+			numbers = nil
+		}
+	}
+	for i := 0; i < len(tokens); i++ {
+		token, value := tokens[i], strings.ToUpper(tokens[i].TValue)
+		switch token.TType {
+		case nfp.TokenTypeZeroPlaceHolder, nfp.TokenTypeHashPlaceHolder, nfp.TokenTypeDigitalPlaceHolder,
+			nfp.TokenTypeThousandsSeparator, nfp.TokenTypeDecimalPoint, nfp.TokenTypeExponential,
+			nfp.TokenTypeFraction, nfp.TokenTypeDenominator:
+			numbers = append(numbers, token)
+			continue
+		case nfp.TokenTypeLiteral:
+			if fraction && len(numbers) > 0 && strings.TrimSpace(token.TValue) == "" {
+				numbers = append(numbers, token)
+				continue
+			}
+		}
+		flush()
+		switch token.TType {
+		case nfp.TokenTypeLiteral:
+			if last := len(children) - 1; last >= 0 && children[last].XMLName.Local == "number:text" {
+				children[last].Text += token.TValue
+				break
+			}
+			children = append(children, odsElement{XMLName: xml.Name{Local: "number:text"}, Text: token.TValue})
+		case nfp.TokenTypePercent:
+			children = append(children, odsElement{XMLName: xml.Name{Local: "number:text"}, Text: "%"})
+		case nfp.TokenTypeTextPlaceHolder:
+			children = append(children, element("text-content"))
+		case nfp.TokenTypeCurrencyLanguage:
+			for _, part := range token.Parts {
+				if part.Token.TType == nfp.TokenSubTypeCurrencyString {
+					symbol := element("currency-symbol")
+					symbol.Text = part.Token.TValue
+					children = append(children, symbol)
+				}
+			}
+		case nfp.TokenTypeElapsedDateTimes:
+			children = append(children, element(map[byte]string{'H': "hours", 'M': "minutes", 'S': "seconds"}[value[0]], long(value, 2)...))
+		case nfp.TokenTypeDateTimes:
+			switch {
+			case value == "AM/PM" || value == "A/P":
+				children = append(children, element("am-pm"))
+			case value[0] == 'Y':
+				children = append(children, element("year", long(value, 3)...))
+			case value[0] == 'D' && len(value) >= 3:
+				children = append(children, element("day-of-week", long(value, 4)...))
+			case value[0] == 'D':
+				children = append(children, element("day", long(value, 2)...))
+			case value[0] == 'H':
+				children = append(children, element("hours", long(value, 2)...))
+			case value[0] == 'M' && odsIsMinutes(tokens, i):
+				children = append(children, element("minutes", long(value, 2)...))
+			case value[0] == 'M' && len(value) >= 3:
+				children = append(children, element("month", append(long(value, 4), attr("number:textual", "true"))...))
+			case value[0] == 'M':
+				children = append(children, element("month", long(value, 2)...))
+			case value[0] == 'S':
+				seconds := element("seconds", long(value, 2)...)
+				if i+2 < len(tokens) && tokens[i+1].TType == nfp.TokenTypeDecimalPoint && tokens[i+2].TType == nfp.TokenTypeZeroPlaceHolder {
+					seconds.Attrs = append(seconds.Attrs, attr("number:decimal-places", strconv.Itoa(len(tokens[i+2].TValue))))
+					i += 2
+				}
+				children = append(children, seconds)
+			}
+		}
+	}
+	flush()
+	styleElement, valueType := "number-style", "float"
+	switch {
+	case date:
+		styleElement, valueType = "date-style", "date"
+	case clock:
+		styleElement, valueType = "time-style", "time"
+	case text:
+		styleElement, valueType = "text-style", "string"
+	case percent:
+		styleElement, valueType = "percentage-style", "percentage"
+	case currency:
+		styleElement, valueType = "currency-style", "currency"
+	}
+	style := element(styleElement, attr("style:name", name))
+	if elapsed {
+		style.Attrs = append(style.Attrs, attr("number:truncate-on-overflow", "false"))
+	}
+	style.Children = children
+	return &style, valueType
+}
+
+// odsNumberElement provides a function to convert the number placeholder
+// tokens to the number, scientific number or fraction element of the
+// OpenDocument spreadsheet number style.
+func odsNumberElement(tokens []nfp.Token, fraction bool) odsElement {
+	var (
+		integer, decimal, exponent, numerator, denominator int
+		grouping, point, exp, slash                        bool
+		denominatorValue                                   string
+	)
+	for i, token := range tokens {
+		switch token.TType {
+		case nfp.TokenTypeThousandsSeparator:
+			grouping = !point
+		case nfp.TokenTypeDecimalPoint:
+			point = true
+		case nfp.TokenTypeExponential:
+			exp = true
+		case nfp.TokenTypeFraction:
+			slash = true
+		case nfp.TokenTypeDenominator:
+			denominatorValue = token.TValue
+		case nfp.TokenTypeLiteral:
+		default:
+			switch {
+			case exp:
+				exponent += len(token.TValue)
+			case slash:
+				denominator += len(token.TValue)
+			case fraction && i+1 < len(tokens) && tokens[i+1].TType == nfp.TokenTypeFraction:
+				numerator += len(token.TValue)
+			case point:
+				decimal += len(token.TValue)
+			case token.TType == nfp.TokenTypeZeroPlaceHolder:
+				integer += len(token.TValue)
+			}
+		}
+	}
+	attr := func(name string, value int) xml.Attr {
+		return xml.Attr{Name: xml.Name{Local: "number:" + name}, Value: strconv.Itoa(value)}
+	}
+	element := odsElement{XMLName: xml.Name{Local: "number:number"}, Attrs: []xml.Attr{attr("decimal-places", decimal)}}
+	switch {
+	case fraction:
+		element = odsElement{XMLName: xml.Name{Local: "number:fraction"}, Attrs: []xml.Attr{
+			attr("min-integer-digits", integer), attr("min-numerator-digits", max(numerator, 1)),
+		}}
+		if denominatorValue != "" {
+			element.Attrs = append(element.Attrs, xml.Attr{Name: xml.Name{Local: "number:denominator-value"}, Value: denominatorValue})
+		} else {
+			element.Attrs = append(element.Attrs, attr("min-denominator-digits", max(denominator, 1)))
+		}
+		return element
+	case exp:
+		element.XMLName.Local = "number:scientific-number"
+		element.Attrs = append(element.Attrs, attr("min-exponent-digits", exponent))
+	}
+	element.Attrs = append(element.Attrs, attr("min-integer-digits", integer))
+	if grouping {
+		element.Attrs = append(element.Attrs, xml.Attr{Name: xml.Name{Local: "number:grouping"}, Value: "true"})
+	}
+	return element
+}
+
+// odsIsMinutes provides a function to check if the month or minutes token at
+// the given position represents minutes, the token represents minutes if it
+// after the hours token or before the seconds token.
+func odsIsMinutes(tokens []nfp.Token, i int) bool {
+	for idx := i - 1; idx >= 0; idx-- {
+		if tokens[idx].TType == nfp.TokenTypeElapsedDateTimes {
+			return true
+		}
+		if tokens[idx].TType == nfp.TokenTypeDateTimes {
+			if strings.ContainsAny(strings.ToUpper(tokens[idx].TValue), "H") {
+				return true
+			}
+			break
+		}
+	}
+	for idx := i + 1; idx < len(tokens); idx++ {
+		if tokens[idx].TType == nfp.TokenTypeDateTimes || tokens[idx].TType == nfp.TokenTypeElapsedDateTimes {
+			return strings.ContainsAny(strings.ToUpper(tokens[idx].TValue), "S")
+		}
+	}
+	return false
+}
+
+// odsFontProperties provides a function to convert the font of the style to
+// the text properties of the OpenDocument spreadsheet.
+func odsFontProperties(font *Font) *odsTextProperties {
+	if font == nil {
+		return nil
+	}
+	props := odsTextProperties{FontFamily: odsFontFamily(font.Family), Color: odsColor(font.Color)}
+	if font.Size > 0 {
+		props.FontSize = strconv.FormatFloat(font.Size, 'f', -1, 64) + "pt"
+	}
+	if font.Bold {
+		props.FontWeight = "bold"
+	}
+	if font.Italic {
+		props.FontStyle = "italic"
+	}
+	if font.Underline == "single" || font.Underline == "double" {
+		props.UnderlineStyle, props.UnderlineWidth, props.UnderlineColor = "solid", "auto", "font-color"
+		if font.Underline == "double" {
+			props.UnderlineType = "double"
+		}
+	}
+	if font.Strike {
+		props.LineThroughStyle = "solid"
+	}
+	switch font.VertAlign {
+	case "superscript":
+		props.TextPosition = "super 58%"
+	case "subscript":
+		props.TextPosition = "sub 58%"
+	}
+	if props == (odsTextProperties{}) {
+		return nil
+	}
+	return &props
+}
+
+// odsCellProperties provides a function to convert the fill, border and
+// alignment of the style to the cell properties and paragraph properties of
+// the OpenDocument spreadsheet.
+func odsCellProperties(style *Style) (*odsTableCellProperties, *odsParagraphProperties) {
+	var (
+		cellProps odsTableCellProperties
+		paraProps odsParagraphProperties
+	)
+	if len(style.Fill.Color) > 0 && (style.Fill.Type == "gradient" || style.Fill.Pattern > 0) {
+		cellProps.BackgroundColor = odsColor(style.Fill.Color[0])
+	}
+	for _, border := range style.Border {
+		value, ok := odsBorderStyles[border.Style]
+		if !ok {
+			continue
+		}
+		if value += " " + odsColor(border.Color); odsColor(border.Color) == "" {
+			value += "#000000"
+		}
+		switch border.Type {
+		case "left":
+			cellProps.BorderLeft = value
+		case "right":
+			cellProps.BorderRight = value
+		case "top":
+			cellProps.BorderTop = value
+		case "bottom":
+			cellProps.BorderBottom = value
+		case "diagonalDown":
+			cellProps.DiagonalTLBR = value
+		case "diagonalUp":
+			cellProps.DiagonalBLTR = value
+		}
+	}
+	if alignment := style.Alignment; alignment != nil {
+		cellProps.TextAlignSource = "fix"
+		for typ, horizontal := range odsHorizontalAlignments {
+			if horizontal == alignment.Horizontal && typ != "left" && typ != "right" {
+				paraProps.TextAlign = typ
+			}
+		}
+		for typ, vertical := range odsVerticalAlignments {
+			if vertical == alignment.Vertical {
+				cellProps.VerticalAlign = typ
+			}
+		}
+		if alignment.WrapText {
+			cellProps.WrapOption = "wrap"
+		}
+		if alignment.TextRotation > 0 && alignment.TextRotation <= 180 {
+			cellProps.RotationAngle = strconv.Itoa(alignment.TextRotation)
+		}
+		if alignment.ShrinkToFit {
+			cellProps.ShrinkToFit = "true"
+		}
+	}
+	var (
+		cell *odsTableCellProperties
+		para *odsParagraphProperties
+	)
+	if cellProps != (odsTableCellProperties{}) {
+		cell = &cellProps
+	}
+	if paraProps != (odsParagraphProperties{}) {
+		para = &paraProps
+	}
+	return cell, para
+}
+
+// odsColor provides a function to convert the RGB or ARGB color to the color
+// of the OpenDocument spreadsheet.
+func odsColor(color string) string {
+	if color = strings.TrimPrefix(color, "#"); len(color) == 8 {
+		color = color[2:]
+	}
+	if len(color) != 6 {
+		return ""
+	}
+	return "#" + strings.ToLower(color)
+}
+
+// odsFontFamily provides a function to quote the font family name which
+// contains spaces for the OpenDocument spreadsheet.
+func odsFontFamily(name string) string {
+	if strings.Contains(name, " ") {
+		return "'" + name + "'"
+	}
+	return name
+}
+
+// odsDuration provides a function to convert the fraction of days to the
+// duration value of the OpenDocument spreadsheet.
+func odsDuration(value float64) string {
+	var sign string
+	if value < 0 {
+		sign, value = "-", -value
+	}
+	seconds := math.Round(value*86400*1000) / 1000
+	hours := math.Floor(seconds / 3600)
+	minutes := math.Floor((seconds - hours*3600) / 60)
+	seconds -= hours*3600 + minutes*60
+	second := strconv.FormatFloat(seconds, 'f', -1, 64)
+	if seconds < 10 {
+		second = "0" + second
+	}
+	return fmt.Sprintf("%sPT%02.fH%02.fM%sS", sign, hours, minutes, second)
+}
+
+// odsEncodeText provides a function to encode the text for the paragraph of
+// the OpenDocument spreadsheet, the consecutive, leading and trailing spaces
+// and tabs will be converted to elements.
+func odsEncodeText(text string) string {
+	var (
+		sb     strings.Builder
+		spaces int
+	)
+	flush := func(leading bool) {
+		if spaces == 0 {
+			return
+		}
+		if !leading {
+			sb.WriteString(" ")
+			spaces--
+		}
+		if spaces == 1 {
+			sb.WriteString("<text:s/>")
+		} else if spaces > 1 {
+			sb.WriteString(`<text:s text:c="` + strconv.Itoa(spaces) + `"/>`)
+		}
+		spaces = 0
+	}
+	for i, r := range text {
+		if r == ' ' {
+			spaces++
+			continue
+		}
+		flush(sb.Len() == 0 && i == spaces)
+		if r == '\t' {
+			sb.WriteString("<text:tab/>")
+			continue
+		}
+		_ = xml.EscapeText(&sb, []byte(string(r)))
+	}
+	flush(true)
+	return sb.String()
+}
@@ -0,0 +1,394 @@
+package excelize
+
+import (
+	"archive/zip"
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveAsODS(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]any{"Name", "Value", "Date", "Flag"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]any{"  leading\tand  spaces ", 1.5, 45000.5, true}))
+	assert.NoError(t, f.SetCellFormula("Sheet1", "B3", "SUM(B2,Sheet2!A1:A2)*2"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A4", "line 1\nline 2"))
+	assert.NoError(t, f.MergeCell("Sheet1", "A6", "B7"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A6", "merged"))
+	assert.NoError(t, f.SetColWidth("Sheet1", "A", "A", 20))
+	assert.NoError(t, f.SetColVisible("Sheet1", "E", false))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 2, 30))
+	style, err := f.NewStyle(&Style{
+		Font:      &Font{Bold: true, Italic: true, Underline: "double", Color: "FF0000", Size: 14, Family: "Arial Black"},
+		Fill:      Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}},
+		Border:    []Border{{Type: "left", Color: "0000FF", Style: 2}, {Type: "bottom", Style: 6}},
+		Alignment: &Alignment{Horizontal: "center", Vertical: "center", WrapText: true, TextRotation: 45},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "A1", style))
+	dateStyle, err := f.NewStyle(&Style{CustomNumFmt: func() *string { s := "yyyy-mm-dd hh:mm"; return &s }()})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C2", "C2", dateStyle))
+	numStyle, err := f.NewStyle(&Style{NumFmt: 4})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "B2", "B2", numStyle))
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetCol("Sheet2", "A1", &[]any{1, 2}))
+	_, err = f.NewSheet("Hidden Sheet")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetVisible("Hidden Sheet", false))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "Amount", RefersTo: "Sheet1!$B$2"}))
+
+	path := filepath.Join("test", "TestSaveAsODS.ods")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1", "Sheet2", "Hidden Sheet"}, f.GetSheetList())
+	for cell, expected := range map[string]string{
+		"A1": "Name", "A2": "  leading\tand  spaces ", "B2": "1.50", "C2": "2023-03-15 12:00",
+		"D2": "TRUE", "A4": "line 1\nline 2", "A6": "merged",
+	} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	formula, err := f.GetCellFormula("Sheet1", "B3")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(B2,Sheet2!A1:A2)*2", formula)
+	value, err := f.CalcCellValue("Sheet1", "B3")
+	assert.NoError(t, err)
+	assert.Equal(t, "9", value)
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "A6", mergeCells[0].GetStartAxis())
+	assert.Equal(t, "B7", mergeCells[0].GetEndAxis())
+	width, err := f.GetColWidth("Sheet1", "A")
+	assert.NoError(t, err)
+	assert.InDelta(t, 20, width, 0.2)
+	visible, err := f.GetColVisible("Sheet1", "E")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	height, err := f.GetRowHeight("Sheet1", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, height)
+	visible, err = f.GetSheetVisible("Hidden Sheet")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	styleID, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	s, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, &Font{Bold: true, Italic: true, Underline: "double", Color: "FF0000", Size: 14, Family: "Arial Black"}, s.Font)
+	assert.Equal(t, []string{"FFFF00"}, s.Fill.Color)
+	assert.Equal(t, []Border{{Type: "left", Color: "0000FF", Style: 2}, {Type: "bottom", Color: "000000", Style: 6}}, s.Border)
+	assert.Equal(t, &Alignment{Horizontal: "center", Vertical: "center", WrapText: true, TextRotation: 45}, s.Alignment)
+	styleID, err = f.GetCellStyle("Sheet1", "B2")
+	assert.NoError(t, err)
+	s, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, s.NumFmt)
+	definedNames := f.GetDefinedName()
+	assert.Len(t, definedNames, 1)
+	assert.Equal(t, "Sheet1!$B$2", definedNames[0].RefersTo)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestSaveAsODS.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test write the spreadsheet in ODS format with unsupported style ID
+	f = NewFile()
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).SheetData.Row = []xlsxRow{{R: 1, C: []xlsxC{{R: "A1", S: 10, V: "1"}}}}
+	_, err = f.WriteODSToBuffer()
+	assert.Equal(t, newInvalidStyleID(10), err)
+	// Test write the spreadsheet in ODS format with unsupported charset
+	f = NewFile()
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	f.SharedStrings = nil
+	_, err = f.WriteODSToBuffer()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+}
+
+func TestSaveAsODSSparseSheet(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "first"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A200000", "last"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "Z2", "wide"))
+	for row := 10; row <= 12; row++ {
+		assert.NoError(t, f.SetRowVisible("Sheet1", row, false))
+	}
+	buf, err := f.WriteODSToBuffer()
+	require.NoError(t, err)
+	assert.NoError(t, f.Close())
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	for _, file := range zr.File {
+		if file.Name == "content.xml" {
+			assert.Less(t, file.UncompressedSize64, uint64(4096))
+		}
+	}
+
+	f, err = OpenReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	for cell, expected := range map[string]string{"A1": "first", "A200000": "last", "Z2": "wide", "A199999": ""} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	for row, expected := range map[int]bool{9: true, 10: false, 12: false, 13: true} {
+		visible, err := f.GetRowVisible("Sheet1", row)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, visible, row)
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestOpenODS(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0" xmlns:number="urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0" xmlns:svg="urn:oasis:names:tc:opendocument:xmlns:svg-compatible:1.0" xmlns:calcext="urn:org:documentfoundation:names:experimental:calc:xmlns:calcext:1.0">
+<office:font-face-decls><style:font-face style:name="Liberation Sans" svg:font-family="'Liberation Sans'"/></office:font-face-decls>
+<office:automatic-styles>
+<style:style style:name="co1" style:family="table-column"><style:table-column-properties style:column-width="1in"/></style:style>
+<style:style style:name="ro1" style:family="table-row"><style:table-row-properties style:row-height="0.5in" style:use-optimal-row-height="false"/></style:style>
+<number:number-style style:name="N1"><number:number number:decimal-places="2" number:min-integer-digits="1" number:grouping="true"/></number:number-style>
+<number:percentage-style style:name="N2"><number:number number:decimal-places="1" number:min-integer-digits="1"/><number:text>%</number:text></number:percentage-style>
+<number:date-style style:name="N3"><number:day number:style="long"/><number:text>.</number:text><number:month number:style="long"/><number:text>.</number:text><number:year number:style="long"/></number:date-style>
+<number:time-style style:name="N4" number:truncate-on-overflow="false"><number:hours/><number:text>:</number:text><number:minutes number:style="long"/></number:time-style>
+<number:currency-style style:name="N5"><number:currency-symbol>€</number:currency-symbol><number:text> </number:text><number:number number:decimal-places="2" number:min-integer-digits="1"/></number:currency-style>
+<number:number-style style:name="N6"><number:fraction number:min-integer-digits="0" number:min-numerator-digits="1" number:min-denominator-digits="2"/></number:number-style>
+<style:style style:name="ce1" style:family="table-cell" style:data-style-name="N1"/>
+<style:style style:name="ce2" style:family="table-cell" style:data-style-name="N2"/>
+<style:style style:name="ce3" style:family="table-cell" style:data-style-name="N3"/>
+<style:style style:name="ce4" style:family="table-cell" style:data-style-name="N4"/>
+<style:style style:name="ce5" style:family="table-cell" style:data-style-name="N5"/>
+<style:style style:name="ce6" style:family="table-cell" style:data-style-name="N6"/>
+<style:style style:name="ce7" style:family="table-cell" style:parent-style-name="Heading"><style:table-cell-properties fo:border="0.06pt solid #ff0000" style:vertical-align="top"/><style:paragraph-properties fo:text-align="end"/><style:text-properties fo:font-weight="700" style:font-name="Liberation Sans" style:text-line-through-style="solid" style:text-position="super 58%"/></style:style>
+<style:style style:name="ta1" style:family="table"><style:table-properties table:display="false"/></style:style>
+</office:automatic-styles>
+<office:body><office:spreadsheet>
+<table:table table:name="Data">
+<table:table-column table:style-name="co1" table:number-columns-repeated="2"/>
+<table:table-column table:number-columns-repeated="16382" table:visibility="collapse"/>
+<table:table-row table:style-name="ro1">
+<table:table-cell table:style-name="ce1" office:value-type="float" office:value="1234.5"><text:p>1,234.50</text:p></table:table-cell>
+<table:table-cell table:style-name="ce2" office:value-type="percentage" office:value="0.25"><text:p>25.0%</text:p></table:table-cell>
+<table:table-cell table:style-name="ce3" office:value-type="date" office:date-value="2024-02-29"><text:p>29.02.2024</text:p></table:table-cell>
+<table:table-cell table:style-name="ce4" office:value-type="time" office:time-value="PT26H30M00S"><text:p>26:30</text:p></table:table-cell>
+<table:table-cell table:style-name="ce5" office:value-type="currency" office:value="3"><text:p>€ 3.00</text:p></table:table-cell>
+<table:table-cell table:style-name="ce6" office:value-type="float" office:value="0.5"><text:p>1/2</text:p></table:table-cell>
+</table:table-row>
+<table:table-row table:number-rows-repeated="2"><table:table-cell table:number-columns-repeated="1024"/></table:table-row>
+<table:table-row>
+<table:table-cell table:style-name="ce7" office:value-type="string" table:number-columns-spanned="2" table:number-rows-spanned="1"><text:p>a<text:s text:c="2"/>b<text:tab/><text:span>c</text:span></text:p><text:p>d</text:p></table:table-cell>
+<table:covered-table-cell/>
+<table:table-cell table:formula="of:=SUM([.A1];[.B1:.B1])*[$'Other ''s'.A1]" office:value-type="float" office:value="1235.75"><text:p>1235.75</text:p></table:table-cell>
+<table:table-cell table:formula="of:=IF([.A1]&gt;0;&quot;a;b&quot;;{1;2|3;4})" office:value-type="string" office:string-value="a;b"><text:p>a;b</text:p></table:table-cell>
+<table:table-cell table:formula="of:=1/0" office:value-type="float" office:value="0" calcext:value-type="error"><text:p>#DIV/0!</text:p></table:table-cell>
+<table:table-cell office:value-type="boolean" office:boolean-value="true"><text:p>TRUE</text:p></table:table-cell>
+</table:table-row>
+</table:table>
+<table:table table:name="Other 's" table:style-name="ta1"><table:table-row table:visibility="collapse"><table:table-cell office:value-type="float" office:value="1"/></table:table-row></table:table>
+<table:named-expressions><table:named-range table:name="Range1" table:base-cell-address="$Data.$A$1" table:cell-range-address="$Data.$A$1:.$B$1"/></table:named-expressions>
+</office:spreadsheet></office:body>
+</office:document-content>`
+	styles := `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-styles xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0" xmlns:svg="urn:oasis:names:tc:opendocument:xmlns:svg-compatible:1.0">
+<office:font-face-decls><style:font-face style:name="Arial1" svg:font-family="Arial"/></office:font-face-decls>
+<office:styles>
+<style:default-style style:family="table-cell"><style:text-properties style:font-name="Arial1" fo:font-size="10pt"/></style:default-style>
+<style:style style:name="Default" style:family="table-cell"/>
+<style:style style:name="Heading" style:family="table-cell" style:parent-style-name="Default"><style:text-properties fo:font-size="16pt" fo:color="#0000ff" style:text-underline-style="solid"/></style:style>
+</office:styles>
+</office:document-styles>`
+	f, err := OpenReader(bytes.NewReader(odsTestPackage(t, map[string]string{"content.xml": content, "styles.xml": styles})))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Data", "Other 's"}, f.GetSheetList())
+	defaultFont, err := f.GetDefaultFont()
+	assert.NoError(t, err)
+	assert.Equal(t, "Arial", defaultFont)
+	rows, err := f.GetRows("Data")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"1,234.50", "25.0%", "29.02.2024", "26:30", "€ 3.00", "0 1/2 "},
+		nil, nil,
+		{"a  b\tc\nd", "", "1235.75", "a;b", "#DIV/0!", "TRUE"},
+	}, rows)
+	for cell, expected := range map[string]string{
+		"C4": "SUM(A1,B1:B1)*'Other ''s'!A1", "D4": "IF(A1>0,\"a;b\",{1,2;3,4})", "E4": "1/0",
+	} {
+		formula, err := f.GetCellFormula("Data", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	mergeCells, err := f.GetMergeCells("Data")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "B4", mergeCells[0].GetEndAxis())
+	width, err := f.GetColWidth("Data", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 12.0, width)
+	visible, err := f.GetColVisible("Data", "C")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	height, err := f.GetRowHeight("Data", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 36.0, height)
+	visible, err = f.GetSheetVisible("Other 's")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	visible, err = f.GetRowVisible("Other 's", 1)
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	styleID, err := f.GetCellStyle("Data", "A1")
+	assert.NoError(t, err)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, style.NumFmt)
+	styleID, err = f.GetCellStyle("Data", "A4")
+	assert.NoError(t, err)
+	style, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, &Font{Bold: true, Underline: "single", Strike: true, Color: "0000FF", Size: 16, Family: "Liberation Sans", VertAlign: "superscript"}, style.Font)
+	assert.Len(t, style.Border, 4)
+	assert.Equal(t, Border{Type: "left", Color: "FF0000", Style: 7}, style.Border[0])
+	assert.Equal(t, &Alignment{Horizontal: "right", Vertical: "top"}, style.Alignment)
+	definedNames := f.GetDefinedName()
+	assert.Len(t, definedNames, 1)
+	assert.Equal(t, "Data!$A$1:$B$1", definedNames[0].RefersTo)
+	assert.NoError(t, f.Close())
+
+	// Test open the OpenDocument spreadsheet with the parts over the unzip XML
+	// size limit
+	f, err = OpenReader(bytes.NewReader(odsTestPackage(t, map[string]string{"content.xml": content, "styles.xml": styles})), Options{UnzipXMLSizeLimit: 128})
+	require.NoError(t, err)
+	rows, err = f.GetRows("Data")
+	assert.NoError(t, err)
+	assert.Equal(t, "a  b\tc\nd", rows[3][0])
+	assert.NoError(t, f.Close())
+	// Test open the OpenDocument spreadsheet with the parts over the unzip
+	// size limit
+	_, err = OpenReader(bytes.NewReader(odsTestPackage(t, map[string]string{"content.xml": content, "styles.xml": styles})), Options{UnzipSizeLimit: 1024, UnzipXMLSizeLimit: 128})
+	assert.EqualError(t, err, newUnzipSizeLimitError(1024).Error())
+}
+
+func TestOpenODSSparseTable(t *testing.T) {
+	f, err := OpenReader(bytes.NewReader(odsTestPackage(t, map[string]string{"content.xml": `<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0"><office:body><office:spreadsheet><table:table table:name="Sheet1"><table:table-row><table:table-cell table:number-columns-repeated="15999"/><table:table-cell office:value-type="float" office:value="1"/></table:table-row><table:table-row table:number-rows-repeated="2000"><table:table-cell/></table:table-row><table:table-row><table:table-cell office:value-type="float" office:value="2"/></table:table-row></table:table></office:spreadsheet></office:body></office:document-content>`})))
+	require.NoError(t, err)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2002, len(ws.SheetData.Row))
+	for _, row := range ws.SheetData.Row[1:2001] {
+		assert.Zero(t, cap(row.C))
+	}
+	cell, err := CoordinatesToCellName(16000, 1)
+	assert.NoError(t, err)
+	for cell, expected := range map[string]string{cell: "1", "A2002": "2"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	assert.NoError(t, f.Close())
+}
+
+// odsTestPackage build the OpenDocument spreadsheet package by given parts
+// in the tests.
+func odsTestPackage(t *testing.T, parts map[string]string) []byte {
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	fi, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	assert.NoError(t, err)
+	_, err = fi.Write([]byte(ContentTypeODS))
+	assert.NoError(t, err)
+	for name, content := range parts {
+		fi, err := zw.Create(name)
+		assert.NoError(t, err)
+		_, err = fi.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestOpenODSError(t *testing.T) {
+	// Test open the OpenDocument spreadsheet without tables
+	_, err := OpenReader(bytes.NewReader(odsTestPackage(t, map[string]string{"content.xml": "<a/>"})))
+	assert.Equal(t, ErrWorkbookFileFormat, err)
+	// Test open the OpenDocument spreadsheet with invalid XML
+	_, err = OpenReader(bytes.NewReader(odsTestPackage(t, map[string]string{"content.xml": "<a>"})))
+	assert.EqualError(t, err, "XML syntax error on line 1: unexpected EOF")
+	// Test open the OpenDocument spreadsheet with invalid sheet name
+	_, err = OpenReader(bytes.NewReader(odsTestPackage(t, map[string]string{"content.xml": `<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0"><office:body><office:spreadsheet><table:table table:name="a:b"/></office:spreadsheet></office:body></office:document-content>`})))
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test open the OpenDocument spreadsheet with the spaces count over the
+	// limit of the cell characters
+	f, err := OpenReader(bytes.NewReader(odsTestPackage(t, map[string]string{"content.xml": `<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0"><office:body><office:spreadsheet><table:table table:name="Sheet1"><table:table-row><table:table-cell office:value-type="string"><text:p>a<text:s text:c="9999999999"/></text:p></table:table-cell><table:table-cell office:value-type="string"><text:p>b<text:s text:c="-1"/></text:p></table:table-cell></table:table-row></table:table></office:spreadsheet></office:body></office:document-content>`})))
+	require.NoError(t, err)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows[0][0], TotalCellChars)
+	assert.Equal(t, "b ", rows[0][1])
+	assert.NoError(t, f.Close())
+	// Test open the OpenDocument spreadsheet with the repeated cells over the
+	// limit
+	_, err = OpenReader(bytes.NewReader(odsTestPackage(t, map[string]string{"content.xml": `<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0"><office:body><office:spreadsheet><table:table table:name="Sheet1"><table:table-row table:number-rows-repeated="1048576"><table:table-cell table:number-columns-repeated="16384" office:value-type="float" office:value="1"><text:p>1</text:p></table:table-cell></table:table-row></table:table></office:spreadsheet></office:body></office:document-content>`})))
+	assert.Equal(t, newODSRepeatedCellsError(maxODSRepeatedCells), err)
+	// Test open the OpenDocument spreadsheet with the empty cells before the
+	// cell in the repeated rows over the limit
+	_, err = OpenReader(bytes.NewReader(odsTestPackage(t, map[string]string{"content.xml": `<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0"><office:body><office:spreadsheet><table:table table:name="Sheet1"><table:table-row table:number-rows-repeated="2000"><table:table-cell table:number-columns-repeated="15999"/><table:table-cell office:value-type="float" office:value="1"/></table:table-row></table:table></office:spreadsheet></office:body></office:document-content>`})))
+	assert.Equal(t, newODSRepeatedCellsError(maxODSRepeatedCells), err)
+}
+
+func TestODSFormula(t *testing.T) {
+	for formula, expected := range map[string]string{
+		"SUM(A1:B2,Sheet1!C3)":        "of:=SUM([.A1:.B2];[$Sheet1.C3])",
+		"'My Sheet'!$A$1+1":           "of:=[$'My Sheet'.$A$1]+1",
+		"SUM(A:A)":                    "of:=SUM([.A1:.A1048576])",
+		"SUM(1:2)":                    "of:=SUM([.A1:.XFD2])",
+		"SUM({1,2;3,4})":              "of:=SUM({1;2|3;4})",
+		"\"a\"\"b\"&Name":             "of:=\"a\"\"b\"&Name",
+		"_xlfn.CONCAT(A1 B1,(A1,B1))": "of:=CONCAT([.A1]![.B1];([.A1]~[.B1]))",
+		"SUM(Sheet1:Sheet3!A1)":       "of:=SUM([$Sheet1.A1:$Sheet3.A1])",
+	} {
+		assert.Equal(t, expected, formulaToODS(formula), formula)
+	}
+	for formula, expected := range map[string]string{
+		"of:=SUM([.A1:.B2];[$Sheet1.C3])":  "SUM(A1:B2,Sheet1!C3)",
+		"of:=[$'My Sheet'.$A$1]+1":         "'My Sheet'!$A$1+1",
+		"of:=SUM({1;2|3;4})":               "SUM({1,2;3,4})",
+		"of:=\"a;[b]\"&[.A1]":              "\"a;[b]\"&A1",
+		"of:=SUM([$Sheet1.A1:$Sheet3.A1])": "SUM(Sheet1:Sheet3!A1)",
+		"of:=[.A1]![.B1]~[.C1]":            "A1 B1,C1",
+		"msoxl:=SUM(A1,B1)":                "SUM(A1,B1)",
+		"=1+1":                             "1+1",
+	} {
+		assert.Equal(t, expected, odsToFormula(formula), formula)
+	}
+}
+
+func TestODSNumberStyle(t *testing.T) {
+	for code, expected := range map[string]string{
+		"#,##0.00":      "number-style",
+		"0.00E+00":      "number-style",
+		"0.0%":          "percentage-style",
+		"yyyy-mm-dd":    "date-style",
+		"[h]:mm:ss":     "time-style",
+		"@":             "text-style",
+		"[$€-407]#,##0": "currency-style",
+	} {
+		element, _ := odsNumberStyle("N1", code)
+		require.NotNil(t, element, code)
+		assert.Equal(t, "number:"+expected, element.XMLName.Local, code)
+	}
+	element, valueType := odsNumberStyle("N1", "General")
+	assert.Nil(t, element)
+	assert.Equal(t, "float", valueType)
+	assert.Equal(t, "PT26H30M00S", odsDuration(26.5/24))
+	assert.Equal(t, "-PT01H00M00S", odsDuration(-1.0/24))
+	assert.Equal(t, "<text:s/>a <text:s/>b<text:tab/>&amp;<text:s/>", odsEncodeText(" a  b\t& "))
+}
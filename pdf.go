@@ -0,0 +1,1714 @@
+// Copyright 2016 - 2026 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.25.0 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"cmp"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"  // register the GIF decoder for embedding pictures
+	_ "image/jpeg" // register the JPEG decoder for embedding pictures
+	_ "image/png"  // register the PNG decoder for embedding pictures
+	"io"
+	"math"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// PDFOptions directly maps the settings of exporting the workbook as PDF
+// document.
+//
+// Sheets specifies the names of the worksheets to be exported, all visible
+// worksheets will be exported in the order of the workbook by default.
+//
+// FontFile specifies the content of the TrueType font file, which will be
+// embedded in the PDF document as the CIDFontType2 font with the Identity-H
+// encoding for rendering the text which can't be encoded in the
+// WinAnsiEncoding of the standard PDF fonts, such as Chinese, Japanese,
+// Korean, Cyrillic and Greek characters. The whole font file will be embedded
+// without subsetting, and the OpenType font with CFF outlines and the font
+// collection file are not supported.
+type PDFOptions struct {
+	Sheets   []string
+	FontFile []byte
+}
+
+const (
+	// pdfPointsPerInch defined the number of PDF points per inch.
+	pdfPointsPerInch = 72.0
+	// pdfPointsPerMM defined the number of PDF points per millimeter.
+	pdfPointsPerMM = pdfPointsPerInch / 25.4
+	// pdfPointsPerPixel defined the number of PDF points per screen pixel.
+	pdfPointsPerPixel = 0.75
+	// pdfCellPadding defined the horizontal padding of the cell text in
+	// points.
+	pdfCellPadding = 2.0
+)
+
+// pdfPaperSizes defined the width and height in points of the paper sizes by
+// the paper size index of the page layout.
+var pdfPaperSizes = map[int][2]float64{
+	1: {8.5 * pdfPointsPerInch, 11 * pdfPointsPerInch}, 2: {8.5 * pdfPointsPerInch, 11 * pdfPointsPerInch},
+	3: {11 * pdfPointsPerInch, 17 * pdfPointsPerInch}, 4: {17 * pdfPointsPerInch, 11 * pdfPointsPerInch},
+	5: {8.5 * pdfPointsPerInch, 14 * pdfPointsPerInch}, 6: {5.5 * pdfPointsPerInch, 8.5 * pdfPointsPerInch},
+	7: {7.25 * pdfPointsPerInch, 10.5 * pdfPointsPerInch}, 8: {297 * pdfPointsPerMM, 420 * pdfPointsPerMM},
+	9: {210 * pdfPointsPerMM, 297 * pdfPointsPerMM}, 10: {210 * pdfPointsPerMM, 297 * pdfPointsPerMM},
+	11: {148 * pdfPointsPerMM, 210 * pdfPointsPerMM}, 12: {250 * pdfPointsPerMM, 353 * pdfPointsPerMM},
+	13: {176 * pdfPointsPerMM, 250 * pdfPointsPerMM}, 14: {8.5 * pdfPointsPerInch, 13 * pdfPointsPerInch},
+	15: {215 * pdfPointsPerMM, 275 * pdfPointsPerMM}, 16: {10 * pdfPointsPerInch, 14 * pdfPointsPerInch},
+	17: {11 * pdfPointsPerInch, 17 * pdfPointsPerInch}, 18: {8.5 * pdfPointsPerInch, 11 * pdfPointsPerInch},
+	19: {3.875 * pdfPointsPerInch, 8.875 * pdfPointsPerInch}, 20: {4.125 * pdfPointsPerInch, 9.5 * pdfPointsPerInch},
+	21: {4.5 * pdfPointsPerInch, 10.375 * pdfPointsPerInch}, 22: {4.75 * pdfPointsPerInch, 11 * pdfPointsPerInch},
+	23: {5 * pdfPointsPerInch, 11.5 * pdfPointsPerInch}, 24: {17 * pdfPointsPerInch, 22 * pdfPointsPerInch},
+	25: {22 * pdfPointsPerInch, 34 * pdfPointsPerInch}, 26: {34 * pdfPointsPerInch, 44 * pdfPointsPerInch},
+	27: {110 * pdfPointsPerMM, 220 * pdfPointsPerMM}, 28: {162 * pdfPointsPerMM, 229 * pdfPointsPerMM},
+	29: {324 * pdfPointsPerMM, 458 * pdfPointsPerMM}, 30: {229 * pdfPointsPerMM, 324 * pdfPointsPerMM},
+	31: {114 * pdfPointsPerMM, 162 * pdfPointsPerMM}, 32: {114 * pdfPointsPerMM, 229 * pdfPointsPerMM},
+	33: {250 * pdfPointsPerMM, 353 * pdfPointsPerMM}, 34: {176 * pdfPointsPerMM, 250 * pdfPointsPerMM},
+	35: {176 * pdfPointsPerMM, 125 * pdfPointsPerMM}, 36: {110 * pdfPointsPerMM, 230 * pdfPointsPerMM},
+	37: {3.875 * pdfPointsPerInch, 7.5 * pdfPointsPerInch}, 38: {3.625 * pdfPointsPerInch, 6.5 * pdfPointsPerInch},
+	39: {14.875 * pdfPointsPerInch, 11 * pdfPointsPerInch}, 40: {8.5 * pdfPointsPerInch, 12 * pdfPointsPerInch},
+}
+
+// pdfBorderStyles defined the line width and dash pattern in points of the
+// cell border styles by the index of the styleBorders.
+var pdfBorderStyles = []struct {
+	width float64
+	dash  string
+}{
+	{}, {0.5, ""}, {1, ""}, {0.5, "3 2"}, {0.5, "1 1"}, {1.5, ""}, {1.5, ""},
+	{0.25, ""}, {1, "3 2"}, {0.5, "3 1 1 1"}, {1, "3 1 1 1"}, {0.5, "3 1 1 1 1 1"},
+	{1, "3 1 1 1 1 1"}, {1, "3 1 1 1"},
+}
+
+// pdfHelveticaWidths defined the glyph widths of the printable ASCII
+// characters in the Helvetica font, in thousandths of the font size.
+var pdfHelveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// pdfHelveticaBoldWidths defined the glyph widths of the printable ASCII
+// characters in the Helvetica-Bold font, in thousandths of the font size.
+var pdfHelveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+// pdfWinAnsiEncoding defined the WinAnsiEncoding character codes of the
+// characters which are not in the Latin-1 code page.
+var pdfWinAnsiEncoding = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91,
+	'’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98,
+	'™': 0x99, 'š': 0x9A, '›': 0x9B, 'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// pdfDocument directly maps the objects of the PDF document. The first three
+// objects are reserved for the catalog, the page tree and the shared
+// resources dictionary.
+type pdfDocument struct {
+	objects     [][]byte
+	pages       []int
+	fonts       map[string]string
+	fontIDs     []int
+	images      []int
+	unicodeFont *pdfUnicodeFont
+	err         error
+}
+
+// pdfFont directly maps the standard Type 1 font used for rendering the text,
+// and the embedded TrueType font used for rendering the text which can't be
+// encoded in WinAnsiEncoding.
+type pdfFont struct {
+	name    string
+	widths  *[95]int
+	unicode *pdfUnicodeFont
+}
+
+// pdfUnicodeFont directly maps the TrueType font embedded in the PDF document,
+// the glyph widths are in thousandths of the font size, and the characters
+// of the glyphs are used for creating the ToUnicode character map.
+type pdfUnicodeFont struct {
+	data   []byte
+	font   *sfnt.Font
+	buf    sfnt.Buffer
+	index  int
+	widths map[sfnt.GlyphIndex]int
+	chars  map[sfnt.GlyphIndex]rune
+}
+
+// pdfCanvas directly maps the content stream of the PDF page, the
+// coordinates of the canvas are in points from the top-left corner of the
+// page.
+type pdfCanvas struct {
+	doc    *pdfDocument
+	buf    bytes.Buffer
+	height float64
+}
+
+// pdfChartCanvas directly maps the chart rendered on the PDF page, the
+// position is the top-left corner of the chart in points, and the scale
+// converts the pixels of the chart to points.
+type pdfChartCanvas struct {
+	c           *pdfCanvas
+	x, y, scale float64
+}
+
+// pdfDrawing directly maps the floating object anchored to the cell of the
+// worksheet, such as pictures. The offsets and size are in points without
+// print scaling, the object will be rendered by the render function on the
+// page which contains the anchor cell.
+type pdfDrawing struct {
+	col, row      int
+	offsetX       float64
+	offsetY       float64
+	width, height float64
+	fitCell       bool
+	render        func(c *pdfCanvas, x, y, width, height float64) error
+}
+
+// pdfPage directly maps the columns and rows of the worksheet printed on the
+// PDF page, the print title columns and rows are not included.
+type pdfPage struct {
+	cols, rows []int
+}
+
+// pdfCell directly maps the rectangle of the cell or merged cell on the PDF
+// page.
+type pdfCell struct {
+	col, row   int
+	x, y, w, h float64
+}
+
+// pdfSheet directly maps the worksheet data and page setup used for
+// rendering the worksheet as PDF pages.
+type pdfSheet struct {
+	*renderSheet
+	layout        PageLayoutOptions
+	margins       PageLayoutMarginsOptions
+	headerFooter  *HeaderFooterOptions
+	printOptions  xlsxPrintOptions
+	fitToPage     bool
+	rowBreaks     []int
+	colBreaks     []int
+	printAreas    [][]int
+	titleRows     []int
+	titleCols     []int
+	colWidths     map[int]float64
+	hiddenCols    map[int]bool
+	styles        map[int]*Style
+	drawings      []pdfDrawing
+	pageWidth     float64
+	pageHeight    float64
+	scale         float64
+	blackAndWhite bool
+}
+
+// WritePDF provides a function to export the worksheets as PDF document by
+// given writer and PDF options, without any office suite. The page size,
+// orientation, scaling, fit to page, margins, centering, headers and footers,
+// print areas, print titles, manual page breaks, page order and grid lines
+// printing settings of the worksheets will be applied. The cells will be
+// rendered with the fonts, fills, borders and alignments of the cell styles,
+// the cell values will be formatted by the number format of the cells, the
+// pictures in the worksheets will be embedded, and the charts in the
+// worksheets will be rendered as vector graphics. The text will be rendered with
+// the standard PDF fonts in WinAnsiEncoding, and the text which can't be
+// encoded will be rendered with the TrueType font specified by the FontFile
+// option, an error will be returned if the font file is not specified. For
+// example, export the worksheets named Sheet1 and Sheet2 as PDF document:
+//
+//	file, err := os.Create("Book1.pdf")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer file.Close()
+//	if err := f.WritePDF(file, excelize.PDFOptions{
+//	    Sheets: []string{"Sheet1", "Sheet2"},
+//	}); err != nil {
+//	    fmt.Println(err)
+//	}
+func (f *File) WritePDF(w io.Writer, opts ...PDFOptions) error {
+	var options PDFOptions
+	for _, opt := range opts {
+		options = opt
+	}
+	sheets := options.Sheets
+	if len(sheets) == 0 {
+		for _, sheet := range f.GetSheetList() {
+			path, _ := f.getSheetXMLPath(sheet)
+			if visible, _ := f.GetSheetVisible(sheet); visible && strings.HasPrefix(path, "xl/worksheets") {
+				sheets = append(sheets, sheet)
+			}
+		}
+	}
+	doc := &pdfDocument{objects: make([][]byte, 3), fonts: make(map[string]string)}
+	if len(options.FontFile) > 0 {
+		var err error
+		if doc.unicodeFont, err = newPDFUnicodeFont(options.FontFile); err != nil {
+			return err
+		}
+	}
+	for _, sheet := range sheets {
+		if err := f.writePDFSheet(doc, sheet); err != nil {
+			return err
+		}
+	}
+	if doc.err != nil {
+		return doc.err
+	}
+	if len(doc.pages) == 0 {
+		size := pdfPaperSizes[1]
+		doc.addPage(size[0], size[1], nil)
+	}
+	info := "/Producer (Excelize)"
+	if props, err := f.GetDocProps(); err == nil {
+		for key, value := range map[string]string{
+			"Title": props.Title, "Subject": props.Subject, "Author": props.Creator,
+		} {
+			if value != "" {
+				info += " /" + key + " " + pdfTextString(value)
+			}
+		}
+	}
+	return doc.write(w, info)
+}
+
+// writePDFSheet provides a function to render the worksheet as PDF pages by
+// given PDF document and worksheet name.
+func (f *File) writePDFSheet(doc *pdfDocument, sheet string) error {
+	rows, err := f.GetRows(sheet)
+	if err != nil {
+		return err
+	}
+	ps := &pdfSheet{
+		renderSheet: &renderSheet{sheet: sheet, rows: rows}, colWidths: make(map[int]float64),
+		hiddenCols: make(map[int]bool), styles: make(map[int]*Style),
+	}
+	if err = f.prepareRenderSheet(sheet, ps.renderSheet); err != nil {
+		return err
+	}
+	if err = f.preparePDFSheet(ps); err != nil {
+		return err
+	}
+	if len(ps.printAreas) == 0 {
+		return err
+	}
+	if ps.defaultFont, err = f.getDefaultFont(); err != nil {
+		return err
+	}
+	if err = f.preparePDFDrawings(ps); err != nil {
+		return err
+	}
+	ps.prepareScale()
+	var pages []pdfPage
+	for _, area := range ps.printAreas {
+		pages = append(pages, ps.paginate(area)...)
+	}
+	for idx, page := range pages {
+		if err = f.renderPDFPage(doc, ps, page, idx, len(pages)); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// preparePDFSheet provides a function to read the page setup, print areas,
+// print titles and column widths of the worksheet.
+func (f *File) preparePDFSheet(ps *pdfSheet) error {
+	var err error
+	if ps.layout, err = f.GetPageLayout(ps.sheet); err != nil {
+		return err
+	}
+	if ps.margins, err = f.GetPageMargins(ps.sheet); err != nil {
+		return err
+	}
+	if ps.headerFooter, err = f.GetHeaderFooter(ps.sheet); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(ps.sheet)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	if ws.PrintOptions != nil {
+		ps.printOptions = *ws.PrintOptions
+	}
+	if ws.SheetPr != nil && ws.SheetPr.PageSetUpPr != nil {
+		ps.fitToPage = ws.SheetPr.PageSetUpPr.FitToPage
+	}
+	if ws.RowBreaks != nil {
+		for _, brk := range ws.RowBreaks.Brk {
+			ps.rowBreaks = append(ps.rowBreaks, brk.ID)
+		}
+	}
+	if ws.ColBreaks != nil {
+		for _, brk := range ws.ColBreaks.Brk {
+			ps.colBreaks = append(ps.colBreaks, brk.ID)
+		}
+	}
+	ws.mu.Unlock()
+	for _, dn := range f.GetDefinedName() {
+		if !strings.EqualFold(dn.Scope, ps.sheet) {
+			continue
+		}
+		switch dn.Name {
+		case builtInDefinedNames[0]:
+			for _, ref := range parsePDFPrintRanges(dn.RefersTo) {
+				if !strings.Contains(ref, ":") {
+					ref += ":" + ref
+				}
+				coordinates, err := rangeRefToCoordinates(ref)
+				if err != nil {
+					return err
+				}
+				_ = sortCoordinates(coordinates)
+				ps.printAreas = append(ps.printAreas, coordinates)
+			}
+		case builtInDefinedNames[1]:
+			for _, ref := range parsePDFPrintRanges(dn.RefersTo) {
+				ps.parsePrintTitles(ref)
+			}
+		}
+	}
+	if len(ps.printAreas) == 0 {
+		area, _ := ps.getArea("")
+		charts, err := f.getSheetCharts(ps.sheet)
+		if err != nil {
+			return err
+		}
+		for _, chart := range charts {
+			if area == nil {
+				area = []int{chart.col, chart.row, chart.endCol, chart.endRow}
+				continue
+			}
+			area[0], area[1] = min(area[0], chart.col), min(area[1], chart.row)
+			area[2], area[3] = max(area[2], chart.endCol), max(area[3], chart.endRow)
+		}
+		if area == nil {
+			return err
+		}
+		ps.printAreas = append(ps.printAreas, area)
+	}
+	maxCol := 0
+	for _, area := range ps.printAreas {
+		maxCol = max(maxCol, area[2])
+	}
+	for _, col := range ps.titleCols {
+		maxCol = max(maxCol, col)
+	}
+	for col := 1; col <= maxCol; col++ {
+		name, _ := ColumnNumberToName(col)
+		visible, err := f.GetColVisible(ps.sheet, name)
+		if err != nil {
+			return err
+		}
+		width, err := f.GetColWidth(ps.sheet, name)
+		if err != nil {
+			return err
+		}
+		ps.hiddenCols[col] = !visible
+		ps.colWidths[col] = convertColWidthToPixels(width) * pdfPointsPerPixel
+	}
+	size, ok := pdfPaperSizes[*ps.layout.Size]
+	if !ok {
+		size = pdfPaperSizes[1]
+	}
+	ps.pageWidth, ps.pageHeight = size[0], size[1]
+	if *ps.layout.Orientation == "landscape" {
+		ps.pageWidth, ps.pageHeight = size[1], size[0]
+	}
+	ps.blackAndWhite = ps.layout.BlackAndWhite != nil && *ps.layout.BlackAndWhite
+	return err
+}
+
+// parsePDFPrintRanges provides a function to parse the references of the
+// print area or print titles defined name into range references without
+// worksheet name and absolute reference symbols.
+func parsePDFPrintRanges(refersTo string) []string {
+	var refs []string
+	for _, ref := range splitRefs(refersTo) {
+		if idx := strings.LastIndex(ref, "!"); idx != -1 {
+			ref = ref[idx+1:]
+		}
+		if ref = strings.ReplaceAll(strings.TrimSpace(ref), "$", ""); ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// parsePrintTitles provides a function to parse the rows or columns to be
+// repeated on each printed page by given range reference, such as "1:2" or
+// "A:B".
+func (ps *pdfSheet) parsePrintTitles(ref string) {
+	parts := strings.Split(ref, ":")
+	if len(parts) != 2 {
+		return
+	}
+	if start, err := strconv.Atoi(parts[0]); err == nil {
+		if end, err := strconv.Atoi(parts[1]); err == nil {
+			for row := min(start, end); row <= max(start, end); row++ {
+				ps.titleRows = append(ps.titleRows, row)
+			}
+		}
+		return
+	}
+	start, err := ColumnNameToNumber(parts[0])
+	if err != nil {
+		return
+	}
+	if end, err := ColumnNameToNumber(parts[1]); err == nil {
+		for col := min(start, end); col <= max(start, end); col++ {
+			ps.titleCols = append(ps.titleCols, col)
+		}
+	}
+}
+
+// preparePDFDrawings provides a function to prepare the floating objects of
+// the worksheet, such as pictures and charts, to be rendered on the PDF pages.
+func (f *File) preparePDFDrawings(ps *pdfSheet) error {
+	cells := make([][2]int, 0, len(ps.pictures))
+	for cell := range ps.pictures {
+		cells = append(cells, cell)
+	}
+	slices.SortFunc(cells, func(a, b [2]int) int {
+		return cmp.Or(a[1]-b[1], a[0]-b[0])
+	})
+	for _, cell := range cells {
+		for _, pic := range ps.pictures[cell] {
+			cfg, _, err := image.DecodeConfig(bytes.NewReader(pic.File))
+			if err != nil {
+				continue
+			}
+			drawing := pdfDrawing{
+				col: cell[0], row: cell[1],
+				width:   float64(cfg.Width) * pdfPointsPerPixel,
+				height:  float64(cfg.Height) * pdfPointsPerPixel,
+				fitCell: pic.InsertType != PictureInsertTypePlaceOverCells,
+			}
+			if pic.Format != nil {
+				drawing.offsetX = float64(pic.Format.OffsetX) * pdfPointsPerPixel
+				drawing.offsetY = float64(pic.Format.OffsetY) * pdfPointsPerPixel
+				if pic.Format.ScaleX > 0 {
+					drawing.width *= pic.Format.ScaleX
+				}
+				if pic.Format.ScaleY > 0 {
+					drawing.height *= pic.Format.ScaleY
+				}
+			}
+			data := pic.File
+			drawing.render = func(c *pdfCanvas, x, y, width, height float64) error {
+				name, err := c.doc.addImage(data)
+				if err == nil {
+					c.image(name, x, y, width, height)
+				}
+				return err
+			}
+			ps.drawings = append(ps.drawings, drawing)
+		}
+	}
+	charts, err := f.getSheetCharts(ps.sheet)
+	if err != nil {
+		return err
+	}
+	for _, chart := range charts {
+		ps.drawings = append(ps.drawings, pdfDrawing{
+			col: chart.col, row: chart.row,
+			offsetX: chart.offsetX * pdfPointsPerPixel, offsetY: chart.offsetY * pdfPointsPerPixel,
+			width: chart.width * pdfPointsPerPixel, height: chart.height * pdfPointsPerPixel,
+			render: func(c *pdfCanvas, x, y, width, height float64) error {
+				return f.renderChart(&pdfChartCanvas{c: c, x: x, y: y, scale: width / chart.width}, chart)
+			},
+		})
+	}
+	return nil
+}
+
+// rowHeight provides a function to get the height in points of the row by
+// given row number, returns 0 if the row is hidden.
+func (ps *pdfSheet) rowHeight(row int) float64 {
+	attrs := ps.rowAttrs[row]
+	if attrs.Hidden {
+		return 0
+	}
+	if attrs.Ht != nil {
+		return *attrs.Ht
+	}
+	return ps.defaultHeight
+}
+
+// colWidth provides a function to get the width in points of the column by
+// given column number, returns 0 if the column is hidden.
+func (ps *pdfSheet) colWidth(col int) float64 {
+	if ps.hiddenCols[col] {
+		return 0
+	}
+	return ps.colWidths[col]
+}
+
+// printableSize provides a function to get the width and height in points of
+// the printable area of the page.
+func (ps *pdfSheet) printableSize() (float64, float64) {
+	return ps.pageWidth - (*ps.margins.Left+*ps.margins.Right)*pdfPointsPerInch,
+		ps.pageHeight - (*ps.margins.Top+*ps.margins.Bottom)*pdfPointsPerInch
+}
+
+// prepareScale provides a function to calculate the print scaling of the
+// worksheet by the fit to page settings or the adjust to percentage.
+func (ps *pdfSheet) prepareScale() {
+	ps.scale = float64(*ps.layout.AdjustTo) / 100
+	if !ps.fitToPage {
+		return
+	}
+	fitWidth, fitHeight := 1, 1
+	if ps.layout.FitToWidth != nil {
+		fitWidth = *ps.layout.FitToWidth
+	}
+	if ps.layout.FitToHeight != nil {
+		fitHeight = *ps.layout.FitToHeight
+	}
+	var totalWidth, totalHeight float64
+	for _, area := range ps.printAreas {
+		var width, height float64
+		for col := area[0]; col <= area[2]; col++ {
+			width += ps.colWidth(col)
+		}
+		for row := area[1]; row <= area[3]; row++ {
+			height += ps.rowHeight(row)
+		}
+		totalWidth, totalHeight = max(totalWidth, width), max(totalHeight, height)
+	}
+	availWidth, availHeight := ps.printableSize()
+	ps.scale = 1
+	if fitWidth > 0 && totalWidth > 0 {
+		ps.scale = min(ps.scale, availWidth*float64(fitWidth)/totalWidth)
+	}
+	if fitHeight > 0 && totalHeight > 0 {
+		ps.scale = min(ps.scale, availHeight*float64(fitHeight)/totalHeight)
+	}
+	ps.scale = max(ps.scale, 0.1)
+}
+
+// paginate provides a function to split the print area into pages by the
+// printable size of the page, the print titles, the manual page breaks and the
+// page order.
+func (ps *pdfSheet) paginate(area []int) []pdfPage {
+	availWidth, availHeight := ps.printableSize()
+	colGroups := paginatePDFItems(area[0], area[2], ps.colWidth, ps.colBreaks, ps.titleCols, availWidth/ps.scale)
+	rowGroups := paginatePDFItems(area[1], area[3], ps.rowHeight, ps.rowBreaks, ps.titleRows, availHeight/ps.scale)
+	var pages []pdfPage
+	if ps.layout.PageOrder != nil && *ps.layout.PageOrder == "overThenDown" {
+		for _, rows := range rowGroups {
+			for _, cols := range colGroups {
+				pages = append(pages, pdfPage{cols: cols, rows: rows})
+			}
+		}
+		return pages
+	}
+	for _, cols := range colGroups {
+		for _, rows := range rowGroups {
+			pages = append(pages, pdfPage{cols: cols, rows: rows})
+		}
+	}
+	return pages
+}
+
+// paginatePDFItems provides a function to split the columns or rows into
+// groups by given start and end number, size function, manual page breaks,
+// print titles and available size of each page. The hidden columns or rows
+// will be skipped, and the size of the print titles before the first item of
+// each group will be reserved.
+func paginatePDFItems(start, end int, size func(int) float64, breaks, titles []int, avail float64) [][]int {
+	var (
+		groups         [][]int
+		group          []int
+		used, reserved float64
+	)
+	for item := start; item <= end; item++ {
+		itemSize := size(item)
+		if itemSize == 0 {
+			continue
+		}
+		if len(group) > 0 {
+			newPage := used+itemSize > avail-reserved
+			for _, brk := range breaks {
+				if brk >= group[len(group)-1] && brk < item {
+					newPage = true
+				}
+			}
+			if newPage {
+				groups, group, used = append(groups, group), nil, 0
+			}
+		}
+		if len(group) == 0 {
+			reserved = 0
+			for _, title := range titles {
+				if title < item {
+					reserved += size(title)
+				}
+			}
+		}
+		group, used = append(group, item), used+itemSize
+	}
+	if len(group) > 0 {
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// getPDFStyle provides a function to get the cell style by given style ID
+// with cache.
+func (f *File) getPDFStyle(ps *pdfSheet, styleID int) (*Style, error) {
+	if style, ok := ps.styles[styleID]; ok {
+		return style, nil
+	}
+	style, err := f.GetStyle(styleID)
+	if err != nil {
+		return nil, err
+	}
+	if style.Font == nil {
+		style.Font = ps.defaultFont
+	}
+	ps.styles[styleID] = style
+	return style, err
+}
+
+// getPDFCellStyle provides a function to get the style of the cell by given
+// coordinates, the conditional formats which applied to the cell will be
+// evaluated.
+func (f *File) getPDFCellStyle(ps *pdfSheet, col, row int) (*Style, error) {
+	style, err := f.getRenderCondStyle(ps.renderSheet, col, row)
+	if err != nil {
+		return nil, err
+	}
+	if style == nil {
+		return f.getPDFStyle(ps, ps.getStyleID(col, row))
+	}
+	if style.Font == nil {
+		style.Font = ps.defaultFont
+	}
+	return style, err
+}
+
+// renderPDFPage provides a function to render the page of the worksheet by
+// given PDF document, worksheet, page, page index and number of pages.
+func (f *File) renderPDFPage(doc *pdfDocument, ps *pdfSheet, page pdfPage, idx, total int) error {
+	var cols, rows []int
+	for _, col := range ps.titleCols {
+		if col < page.cols[0] && ps.colWidth(col) > 0 {
+			cols = append(cols, col)
+		}
+	}
+	for _, row := range ps.titleRows {
+		if row < page.rows[0] && ps.rowHeight(row) > 0 {
+			rows = append(rows, row)
+		}
+	}
+	cols, rows = append(cols, page.cols...), append(rows, page.rows...)
+	colX, rowY := make(map[int]float64), make(map[int]float64)
+	availWidth, availHeight := ps.printableSize()
+	x, y := *ps.margins.Left*pdfPointsPerInch, *ps.margins.Top*pdfPointsPerInch
+	var width, height float64
+	for _, col := range cols {
+		width += ps.colWidth(col) * ps.scale
+	}
+	for _, row := range rows {
+		height += ps.rowHeight(row) * ps.scale
+	}
+	if ps.margins.Horizontally != nil && *ps.margins.Horizontally {
+		x += max(availWidth-width, 0) / 2
+	}
+	if ps.margins.Vertically != nil && *ps.margins.Vertically {
+		y += max(availHeight-height, 0) / 2
+	}
+	for _, col := range cols {
+		colX[col], x = x, x+ps.colWidth(col)*ps.scale
+	}
+	for _, row := range rows {
+		rowY[row], y = y, y+ps.rowHeight(row)*ps.scale
+	}
+	cells := ps.getPageCells(cols, rows, colX, rowY)
+	c := &pdfCanvas{doc: doc, height: ps.pageHeight}
+	for _, fn := range []func(*pdfCanvas, *pdfSheet, []pdfCell) error{
+		f.renderPDFFills, f.renderPDFBorders, f.renderPDFTexts,
+	} {
+		if err := fn(c, ps, cells); err != nil {
+			return err
+		}
+	}
+	for _, drawing := range ps.drawings {
+		if err := ps.renderDrawing(c, drawing, page, colX, rowY); err != nil {
+			return err
+		}
+	}
+	ps.renderHeaderFooter(c, f, idx, total)
+	doc.addPage(ps.pageWidth, ps.pageHeight, c.buf.Bytes())
+	return nil
+}
+
+// getPageCells provides a function to get the rectangles of the cells and
+// merged cells on the page by given columns, rows and the positions of them.
+func (ps *pdfSheet) getPageCells(cols, rows []int, colX, rowY map[int]float64) []pdfCell {
+	var cells []pdfCell
+	covered := make(map[[2]int]bool)
+	for start, end := range ps.merges {
+		cell := pdfCell{col: start[0], row: start[1], x: -1, y: -1}
+		var right, bottom float64
+		for _, col := range cols {
+			if col >= start[0] && col <= end[0] {
+				if cell.x == -1 {
+					cell.x = colX[col]
+				}
+				right = colX[col] + ps.colWidth(col)*ps.scale
+			}
+		}
+		for _, row := range rows {
+			if row >= start[1] && row <= end[1] {
+				if cell.y == -1 {
+					cell.y = rowY[row]
+				}
+				bottom = rowY[row] + ps.rowHeight(row)*ps.scale
+			}
+		}
+		if cell.x == -1 || cell.y == -1 {
+			continue
+		}
+		cell.w, cell.h = right-cell.x, bottom-cell.y
+		for _, col := range cols {
+			if col < start[0] || col > end[0] {
+				continue
+			}
+			for _, row := range rows {
+				if row >= start[1] && row <= end[1] {
+					covered[[2]int{col, row}] = true
+				}
+			}
+		}
+		cells = append(cells, cell)
+	}
+	for _, row := range rows {
+		for _, col := range cols {
+			if !covered[[2]int{col, row}] {
+				cells = append(cells, pdfCell{
+					col: col, row: row, x: colX[col], y: rowY[row],
+					w: ps.colWidth(col) * ps.scale, h: ps.rowHeight(row) * ps.scale,
+				})
+			}
+		}
+	}
+	return cells
+}
+
+// renderPDFFills provides a function to render the fills and the grid lines
+// of the cells on the page.
+func (f *File) renderPDFFills(c *pdfCanvas, ps *pdfSheet, cells []pdfCell) error {
+	for _, cell := range cells {
+		style, err := f.getPDFCellStyle(ps, cell.col, cell.row)
+		if err != nil {
+			return err
+		}
+		if ps.blackAndWhite || len(style.Fill.Color) == 0 || style.Fill.Color[0] == "" ||
+			(style.Fill.Type == "pattern" && style.Fill.Pattern == 0) {
+			continue
+		}
+		c.rect(cell.x, cell.y, cell.w, cell.h, style.Fill.Color[0])
+	}
+	if ps.printOptions.GridLines {
+		for _, cell := range cells {
+			c.strokeRect(cell.x, cell.y, cell.w, cell.h, 0.25, "808080")
+		}
+	}
+	return nil
+}
+
+// renderPDFBorders provides a function to render the borders of the cells on
+// the page.
+func (f *File) renderPDFBorders(c *pdfCanvas, ps *pdfSheet, cells []pdfCell) error {
+	for _, cell := range cells {
+		style, err := f.getPDFCellStyle(ps, cell.col, cell.row)
+		if err != nil {
+			return err
+		}
+		for _, border := range style.Border {
+			if border.Style <= 0 || border.Style >= len(pdfBorderStyles) {
+				continue
+			}
+			color := border.Color
+			if color == "" || ps.blackAndWhite {
+				color = "000000"
+			}
+			x1, y1, x2, y2 := cell.x, cell.y, cell.x+cell.w, cell.y+cell.h
+			switch border.Type {
+			case "left":
+				x2 = x1
+			case "right":
+				x1 = x2
+			case "top":
+				y2 = y1
+			case "bottom":
+				y1 = y2
+			case "diagonalUp":
+				y1, y2 = y2, y1
+			}
+			c.line(x1, y1, x2, y2, pdfBorderStyles[border.Style].width, color, pdfBorderStyles[border.Style].dash)
+		}
+	}
+	return nil
+}
+
+// renderPDFTexts provides a function to render the values of the cells on
+// the page.
+func (f *File) renderPDFTexts(c *pdfCanvas, ps *pdfSheet, cells []pdfCell) error {
+	empty := make(map[[2]int]pdfCell)
+	for _, cell := range cells {
+		if _, ok := ps.merges[[2]int{cell.col, cell.row}]; !ok && ps.getValue(cell.col, cell.row) == "" {
+			empty[[2]int{cell.col, cell.row}] = cell
+		}
+	}
+	for _, cell := range cells {
+		value := ps.getValue(cell.col, cell.row)
+		if value == "" || cell.w <= 0 || cell.h <= 0 {
+			continue
+		}
+		style, err := f.getPDFCellStyle(ps, cell.col, cell.row)
+		if err != nil {
+			return err
+		}
+		font, size := c.doc.getFont(style.Font), style.Font.Size*ps.scale
+		if size <= 0 {
+			size = 11 * ps.scale
+		}
+		color := f.getFontColor(style.Font)
+		if color == "" || ps.blackAndWhite {
+			color = "000000"
+		}
+		horizontal, vertical, wrap, indent := "", "bottom", false, 0.0
+		switch ps.getCellType(cell.col, cell.row) {
+		case "n":
+			horizontal = "right"
+		case "b", "e":
+			horizontal = "center"
+		}
+		if style.Alignment != nil {
+			if style.Alignment.Horizontal != "" && style.Alignment.Horizontal != "general" {
+				horizontal = style.Alignment.Horizontal
+			}
+			if style.Alignment.Vertical != "" {
+				vertical = style.Alignment.Vertical
+			}
+			wrap, indent = style.Alignment.WrapText, float64(style.Alignment.Indent)*9*pdfPointsPerPixel*ps.scale
+		}
+		padding := pdfCellPadding * ps.scale
+		lines := strings.Split(value, "\n")
+		if wrap {
+			lines = font.wrap(value, size, cell.w-2*padding-indent)
+		}
+		clipW := cell.w
+		if !wrap && (horizontal == "" || horizontal == "left") {
+			textWidth := font.measure(lines[0], size) + 2*padding + indent
+			for col := cell.col + 1; clipW < textWidth; col++ {
+				next, ok := empty[[2]int{col, cell.row}]
+				if !ok {
+					break
+				}
+				clipW = next.x + next.w - cell.x
+			}
+		}
+		lineHeight := size * 1.2
+		top := cell.y + cell.h - padding/2 - float64(len(lines))*lineHeight
+		switch vertical {
+		case "top":
+			top = cell.y + padding/2
+		case "center", "justify", "distributed":
+			top = cell.y + (cell.h-float64(len(lines))*lineHeight)/2
+		}
+		c.clip(cell.x, cell.y, clipW, cell.h)
+		for i, line := range lines {
+			lineWidth := font.measure(line, size)
+			x := cell.x + padding + indent
+			switch horizontal {
+			case "right":
+				x = cell.x + cell.w - padding - lineWidth - indent
+			case "center", "centerContinuous":
+				x = cell.x + (cell.w-lineWidth)/2
+			}
+			baseline := top + float64(i)*lineHeight + size
+			c.text(x, baseline, font, size, color, line)
+			if style.Font.Underline != "" && style.Font.Underline != "none" {
+				c.line(x, baseline+size*0.12, x+lineWidth, baseline+size*0.12, size*0.06, color, "")
+			}
+			if style.Font.Strike {
+				c.line(x, baseline-size*0.3, x+lineWidth, baseline-size*0.3, size*0.06, color, "")
+			}
+		}
+		c.restore()
+	}
+	return nil
+}
+
+// renderDrawing provides a function to render the floating object on the page
+// if the anchor cell of the object is on the page.
+func (ps *pdfSheet) renderDrawing(c *pdfCanvas, drawing pdfDrawing, page pdfPage, colX, rowY map[int]float64) error {
+	if !slices.Contains(page.cols, drawing.col) || !slices.Contains(page.rows, drawing.row) {
+		return nil
+	}
+	x := colX[drawing.col] + drawing.offsetX*ps.scale
+	y := rowY[drawing.row] + drawing.offsetY*ps.scale
+	width, height := drawing.width*ps.scale, drawing.height*ps.scale
+	if drawing.fitCell {
+		cellWidth, cellHeight := ps.colWidth(drawing.col)*ps.scale, ps.rowHeight(drawing.row)*ps.scale
+		ratio := min(cellWidth/drawing.width, cellHeight/drawing.height)
+		width, height = drawing.width*ratio, drawing.height*ratio
+		x, y = colX[drawing.col]+(cellWidth-width)/2, rowY[drawing.row]+(cellHeight-height)/2
+	}
+	availWidth, availHeight := ps.printableSize()
+	c.clip(*ps.margins.Left*pdfPointsPerInch, *ps.margins.Top*pdfPointsPerInch, availWidth, availHeight)
+	defer c.restore()
+	return drawing.render(c, x, y, width, height)
+}
+
+// renderHeaderFooter provides a function to render the header and footer of
+// the page by given page index and number of pages.
+func (ps *pdfSheet) renderHeaderFooter(c *pdfCanvas, f *File, idx, total int) {
+	if ps.headerFooter == nil {
+		return
+	}
+	header, footer := ps.headerFooter.OddHeader, ps.headerFooter.OddFooter
+	pageNum := int(*ps.layout.FirstPageNumber) + idx
+	if ps.headerFooter.DifferentOddEven && pageNum%2 == 0 {
+		header, footer = ps.headerFooter.EvenHeader, ps.headerFooter.EvenFooter
+	}
+	if ps.headerFooter.DifferentFirst && idx == 0 {
+		header, footer = ps.headerFooter.FirstHeader, ps.headerFooter.FirstFooter
+	}
+	left := *ps.margins.Left * pdfPointsPerInch
+	right := ps.pageWidth - *ps.margins.Right*pdfPointsPerInch
+	for i, value := range []string{header, footer} {
+		for section, part := range parsePDFHeaderFooter(value, f, ps.sheet, pageNum, total) {
+			if part.text == "" {
+				continue
+			}
+			font := c.doc.getFont(&Font{Family: part.family, Bold: part.bold, Italic: part.italic})
+			width := font.measure(part.text, part.size)
+			baseline := *ps.margins.Header*pdfPointsPerInch + part.size
+			if i == 1 {
+				baseline = ps.pageHeight - *ps.margins.Footer*pdfPointsPerInch - part.size*0.2
+			}
+			x := left
+			switch section {
+			case 1:
+				x = (left + right - width) / 2
+			case 2:
+				x = right - width
+			}
+			color := part.color
+			if ps.blackAndWhite {
+				color = "000000"
+			}
+			c.text(x, baseline, font, part.size, color, part.text)
+		}
+	}
+}
+
+// pdfHeaderFooterSection directly maps the left, center or right section of
+// the header or footer.
+type pdfHeaderFooterSection struct {
+	text, family, color string
+	bold, italic        bool
+	size                float64
+}
+
+// parsePDFHeaderFooter provides a function to parse the header or footer
+// format codes into the left, center and right sections by given format
+// codes, workbook, worksheet name, page number and number of pages.
+func parsePDFHeaderFooter(value string, f *File, sheet string, pageNum, total int) [3]pdfHeaderFooterSection {
+	var sections [3]pdfHeaderFooterSection
+	for i := range sections {
+		sections[i].size, sections[i].color = 11, "000000"
+	}
+	cur, runes, now := 1, []rune(value), time.Now()
+	var text [3]strings.Builder
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '&' || i+1 >= len(runes) {
+			text[cur].WriteRune(runes[i])
+			continue
+		}
+		i++
+		switch code := runes[i]; {
+		case code == 'L', code == 'C', code == 'R':
+			cur = strings.IndexRune("LCR", code)
+		case code == 'P':
+			text[cur].WriteString(strconv.Itoa(pageNum))
+		case code == 'N':
+			text[cur].WriteString(strconv.Itoa(total))
+		case code == 'D':
+			text[cur].WriteString(now.Format("1/2/2006"))
+		case code == 'T':
+			text[cur].WriteString(now.Format("3:04 PM"))
+		case code == 'A':
+			text[cur].WriteString(sheet)
+		case code == 'F':
+			if f.Path != "" {
+				text[cur].WriteString(filepath.Base(f.Path))
+			}
+		case code == 'Z':
+			if f.Path != "" {
+				text[cur].WriteString(filepath.Dir(f.Path) + string(filepath.Separator))
+			}
+		case code == '&':
+			text[cur].WriteRune('&')
+		case code == 'B':
+			sections[cur].bold = !sections[cur].bold
+		case code == 'I':
+			sections[cur].italic = !sections[cur].italic
+		case code == 'K':
+			if i+6 < len(runes) {
+				if clr := string(runes[i+1 : i+7]); len(strings.Trim(clr, "0123456789ABCDEFabcdef")) == 0 {
+					sections[cur].color = strings.ToUpper(clr)
+				}
+				i += 6
+			}
+		case code == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			font := strings.Split(string(runes[i+1:min(end, len(runes))]), ",")
+			if font[0] != "-" {
+				sections[cur].family = font[0]
+			}
+			if len(font) > 1 {
+				sections[cur].bold = strings.Contains(font[1], "Bold")
+				sections[cur].italic = strings.Contains(font[1], "Italic")
+			}
+			i = end
+		case code >= '0' && code <= '9':
+			end := i
+			for end < len(runes) && runes[end] >= '0' && runes[end] <= '9' {
+				end++
+			}
+			size, _ := strconv.Atoi(string(runes[i:end]))
+			sections[cur].size, i = float64(size), end-1
+		}
+	}
+	for i := range sections {
+		sections[i].text = text[i].String()
+	}
+	return sections
+}
+
+// getPDFFont provides a function to get the standard PDF font by given font
+// settings. The monospaced and serif font families will be mapped to the
+// Courier and Times fonts, and the other font families will be mapped to the
+// Helvetica font.
+func getPDFFont(font *Font) *pdfFont {
+	family, widths := "Helvetica", &pdfHelveticaWidths
+	bold, italic := "Bold", "Oblique"
+	switch name := strings.ToLower(font.Family); {
+	case strings.Contains(name, "courier"), strings.Contains(name, "mono"), strings.Contains(name, "consolas"):
+		family, widths = "Courier", nil
+	case strings.Contains(name, "times"), strings.Contains(name, "serif") && !strings.Contains(name, "sans"),
+		strings.Contains(name, "georgia"), strings.Contains(name, "cambria"):
+		family, italic = "Times", "Italic"
+	}
+	if font.Bold && widths != nil {
+		widths = &pdfHelveticaBoldWidths
+	}
+	var style string
+	if font.Bold {
+		style = bold
+	}
+	if font.Italic {
+		style += italic
+	}
+	switch {
+	case style != "":
+		family += "-" + style
+	case family == "Times":
+		family += "-Roman"
+	}
+	return &pdfFont{name: family, widths: widths}
+}
+
+// measure provides a function to get the width in points of the text by
+// given font size. The glyph widths of the Helvetica font are used as the
+// approximation of the Times font.
+func (pf *pdfFont) measure(text string, size float64) float64 {
+	if _, ok := pdfUnsupportedChar(text); ok && pf.unicode != nil {
+		return pf.unicode.measure(text, size)
+	}
+	var width int
+	for _, char := range pdfEncodeText(text) {
+		switch {
+		case pf.widths == nil:
+			width += 600
+		case char >= 32 && char <= 126:
+			width += pf.widths[char-32]
+		case char > 126:
+			width += 556
+		}
+	}
+	return float64(width) * size / 1000
+}
+
+// wrap provides a function to wrap the text into lines by given font size and
+// the maximum width of each line.
+func (pf *pdfFont) wrap(text string, size, width float64) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		var line string
+		for _, word := range strings.Split(paragraph, " ") {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if pf.measure(candidate, size) <= width || line == "" && pf.measure(word, size) <= width {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			line = ""
+			for _, char := range word {
+				if line != "" && pf.measure(line+string(char), size) > width {
+					lines, line = append(lines, line), ""
+				}
+				line += string(char)
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// pdfEncodeChar provides a function to encode the character in
+// WinAnsiEncoding, returns false if the character not in the encoding.
+func pdfEncodeChar(char rune) (byte, bool) {
+	switch {
+	case char == '\t':
+		return ' ', true
+	case char < 128, char >= 0xA0 && char <= 0xFF:
+		return byte(char), true
+	}
+	b, ok := pdfWinAnsiEncoding[char]
+	return b, ok
+}
+
+// pdfUnsupportedChar provides a function to get the first character of the
+// text which can't be encoded in WinAnsiEncoding.
+func pdfUnsupportedChar(text string) (rune, bool) {
+	for _, char := range text {
+		if _, ok := pdfEncodeChar(char); !ok {
+			return char, true
+		}
+	}
+	return 0, false
+}
+
+// pdfEncodeText provides a function to encode the text in WinAnsiEncoding,
+// the characters not in the encoding will be replaced by the question mark.
+func pdfEncodeText(text string) []byte {
+	buf := make([]byte, 0, len(text))
+	for _, char := range text {
+		if char < 32 && char != '\t' {
+			continue
+		}
+		b, ok := pdfEncodeChar(char)
+		if !ok {
+			b = '?'
+		}
+		buf = append(buf, b)
+	}
+	return buf
+}
+
+// pdfTextString provides a function to encode the text as the PDF text
+// string, the text which can't be encoded in WinAnsiEncoding will be encoded
+// as the hexadecimal string in UTF-16BE with the byte order mark.
+func pdfTextString(text string) string {
+	if _, ok := pdfUnsupportedChar(text); !ok {
+		return "(" + pdfEscapeText(text) + ")"
+	}
+	var buf strings.Builder
+	buf.WriteString("<FEFF")
+	for _, code := range utf16.Encode([]rune(text)) {
+		fmt.Fprintf(&buf, "%04X", code)
+	}
+	buf.WriteByte('>')
+	return buf.String()
+}
+
+// pdfEscapeText provides a function to encode the text as the content of the
+// PDF literal string.
+func pdfEscapeText(text string) string {
+	var buf strings.Builder
+	for _, b := range pdfEncodeText(text) {
+		if b == '\\' || b == '(' || b == ')' {
+			buf.WriteByte('\\')
+		}
+		buf.WriteByte(b)
+	}
+	return buf.String()
+}
+
+// pdfNumber provides a function to format the number as the PDF number
+// operand.
+func pdfNumber(num float64) string {
+	return strconv.FormatFloat(math.Round(num*100)/100, 'f', -1, 64)
+}
+
+// pdfColor provides a function to convert the RGB hex color to the PDF color
+// operands, returns black for the invalid color.
+func pdfColor(hex string) string {
+	rgb, err := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	if err != nil || len(strings.TrimPrefix(hex, "#")) != 6 {
+		return "0 0 0"
+	}
+	return fmt.Sprintf("%s %s %s", pdfNumber(float64(rgb>>16&0xFF)/255),
+		pdfNumber(float64(rgb>>8&0xFF)/255), pdfNumber(float64(rgb&0xFF)/255))
+}
+
+// rect provides a function to fill the rectangle by given position, size
+// and RGB color.
+func (c *pdfCanvas) rect(x, y, w, h float64, color string) {
+	fmt.Fprintf(&c.buf, "%s rg %s %s %s %s re f\n", pdfColor(color),
+		pdfNumber(x), pdfNumber(c.height-y-h), pdfNumber(w), pdfNumber(h))
+}
+
+// strokeRect provides a function to stroke the rectangle by given position,
+// size, line width and RGB color.
+func (c *pdfCanvas) strokeRect(x, y, w, h, width float64, color string) {
+	fmt.Fprintf(&c.buf, "[] 0 d %s w %s RG %s %s %s %s re S\n", pdfNumber(width), pdfColor(color),
+		pdfNumber(x), pdfNumber(c.height-y-h), pdfNumber(w), pdfNumber(h))
+}
+
+// line provides a function to stroke the line by given start and end
+// position, line width, RGB color and dash pattern.
+func (c *pdfCanvas) line(x1, y1, x2, y2, width float64, color, dash string) {
+	fmt.Fprintf(&c.buf, "[%s] 0 d %s w %s RG %s %s m %s %s l S\n", dash, pdfNumber(width), pdfColor(color),
+		pdfNumber(x1), pdfNumber(c.height-y1), pdfNumber(x2), pdfNumber(c.height-y2))
+}
+
+// text provides a function to show the text by given position of the
+// baseline, font, font size and RGB color.
+func (c *pdfCanvas) text(x, y float64, font *pdfFont, size float64, color, text string) {
+	name, str := c.doc.textOperands(font, text)
+	fmt.Fprintf(&c.buf, "BT /%s %s Tf %s rg %s %s Td %s Tj ET\n", name, pdfNumber(size),
+		pdfColor(color), pdfNumber(x), pdfNumber(c.height-y), str)
+}
+
+// image provides a function to draw the image XObject by given resource
+// name, position and size.
+func (c *pdfCanvas) image(name string, x, y, w, h float64) {
+	fmt.Fprintf(&c.buf, "q %s 0 0 %s %s %s cm /%s Do Q\n", pdfNumber(w), pdfNumber(h),
+		pdfNumber(x), pdfNumber(c.height-y-h), name)
+}
+
+// clip provides a function to save the graphics state and intersect the
+// clipping path with the rectangle by given position and size.
+func (c *pdfCanvas) clip(x, y, w, h float64) {
+	fmt.Fprintf(&c.buf, "q %s %s %s %s re W n\n", pdfNumber(x), pdfNumber(c.height-y-h), pdfNumber(w), pdfNumber(h))
+}
+
+// restore provides a function to restore the graphics state saved by the
+// clip function.
+func (c *pdfCanvas) restore() {
+	c.buf.WriteString("Q\n")
+}
+
+// point provides a function to convert the position in pixels on the chart
+// to the position in points on the PDF page.
+func (pc *pdfChartCanvas) point(p [2]float64) string {
+	return pdfNumber(pc.x+p[0]*pc.scale) + " " + pdfNumber(pc.c.height-pc.y-p[1]*pc.scale)
+}
+
+// fill provides a function to fill the polygon of the chart on the PDF page.
+func (pc *pdfChartCanvas) fill(points [][2]float64, color string) {
+	if len(points) < 3 {
+		return
+	}
+	fmt.Fprintf(&pc.c.buf, "%s rg %s m", pdfColor(color), pc.point(points[0]))
+	for _, p := range points[1:] {
+		fmt.Fprintf(&pc.c.buf, " %s l", pc.point(p))
+	}
+	pc.c.buf.WriteString(" h f\n")
+}
+
+// stroke provides a function to stroke the polyline of the chart on the PDF
+// page.
+func (pc *pdfChartCanvas) stroke(points [][2]float64, width float64, color string, dash bool) {
+	if len(points) < 2 {
+		return
+	}
+	pattern := ""
+	if dash {
+		pattern = pdfNumber(width*4*pc.scale) + " " + pdfNumber(width*3*pc.scale)
+	}
+	fmt.Fprintf(&pc.c.buf, "[%s] 0 d 1 j %s w %s RG %s m", pattern, pdfNumber(width*pc.scale), pdfColor(color), pc.point(points[0]))
+	for _, p := range points[1:] {
+		fmt.Fprintf(&pc.c.buf, " %s l", pc.point(p))
+	}
+	pc.c.buf.WriteString(" S\n")
+}
+
+// text provides a function to show the text of the chart on the PDF page.
+func (pc *pdfChartCanvas) text(x, y float64, text string, font *chartFont, anchor string, rotate bool) {
+	pdfFont := pc.c.doc.getFont(&Font{Bold: font.bold})
+	size := font.size * pc.scale
+	offset := map[string]float64{"middle": 0.5, "end": 1}[anchor] * pdfFont.measure(text, size)
+	matrix := fmt.Sprintf("1 0 0 1 %s", pc.point([2]float64{x - offset/pc.scale, y}))
+	if rotate {
+		matrix = fmt.Sprintf("0 1 -1 0 %s", pc.point([2]float64{x, y + offset/pc.scale}))
+	}
+	name, str := pc.c.doc.textOperands(pdfFont, text)
+	fmt.Fprintf(&pc.c.buf, "BT /%s %s Tf %s rg %s Tm %s Tj ET\n", name, pdfNumber(size),
+		pdfColor(font.color), matrix, str)
+}
+
+// measure provides a function to get the width in pixels of the text of the
+// chart.
+func (pc *pdfChartCanvas) measure(text string, font *chartFont) float64 {
+	return pc.c.doc.getFont(&Font{Bold: font.bold}).measure(text, font.size)
+}
+
+// addObject provides a function to add the object into the PDF document,
+// returns the object number.
+func (doc *pdfDocument) addObject(data []byte) int {
+	doc.objects = append(doc.objects, data)
+	return len(doc.objects)
+}
+
+// addStream provides a function to add the stream object compressed by the
+// Flate filter into the PDF document by given stream dictionary entries and
+// stream data, returns the object number.
+func (doc *pdfDocument) addStream(dict string, data []byte) int {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	_, _ = zw.Write(data)
+	_ = zw.Close()
+	return doc.addRawStream(dict+" /Filter /FlateDecode", buf.Bytes())
+}
+
+// addRawStream provides a function to add the stream object into the PDF
+// document by given stream dictionary entries and encoded stream data,
+// returns the object number.
+func (doc *pdfDocument) addRawStream(dict string, data []byte) int {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<< %s /Length %d >>\nstream\n", dict, len(data))
+	buf.Write(data)
+	buf.WriteString("\nendstream")
+	return doc.addObject(buf.Bytes())
+}
+
+// addPage provides a function to add the page into the PDF document by given
+// page width, height and content stream.
+func (doc *pdfDocument) addPage(width, height float64, content []byte) {
+	contentID := doc.addStream("", content)
+	doc.pages = append(doc.pages, doc.addObject([]byte(fmt.Sprintf(
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources 3 0 R /Contents %d 0 R >>",
+		pdfNumber(width), pdfNumber(height), contentID))))
+}
+
+// font provides a function to get the resource name of the standard font by
+// given font name, the font object will be created if not exists.
+func (doc *pdfDocument) font(name string) string {
+	if resource, ok := doc.fonts[name]; ok {
+		return resource
+	}
+	doc.fontIDs = append(doc.fontIDs, doc.addObject([]byte(
+		"<< /Type /Font /Subtype /Type1 /BaseFont /"+name+" /Encoding /WinAnsiEncoding >>")))
+	doc.fonts[name] = "F" + strconv.Itoa(len(doc.fontIDs))
+	return doc.fonts[name]
+}
+
+// getFont provides a function to get the PDF font by given font settings,
+// the embedded TrueType font of the document will be used for rendering the
+// text which can't be encoded in WinAnsiEncoding.
+func (doc *pdfDocument) getFont(font *Font) *pdfFont {
+	pf := getPDFFont(font)
+	pf.unicode = doc.unicodeFont
+	return pf
+}
+
+// textOperands provides a function to get the resource name of the font and
+// the string operand for showing the text by given font and text. The error
+// will be recorded if the text can't be encoded without the embedded TrueType
+// font.
+func (doc *pdfDocument) textOperands(font *pdfFont, text string) (string, string) {
+	if char, ok := pdfUnsupportedChar(text); ok {
+		if font.unicode != nil {
+			return font.unicode.resource(doc), font.unicode.encode(text)
+		}
+		if doc.err == nil {
+			doc.err = newPDFUnsupportedCharError(char)
+		}
+	}
+	return doc.font(font.name), "(" + pdfEscapeText(text) + ")"
+}
+
+// addImage provides a function to add the image XObject into the PDF
+// document by given image data, returns the resource name of the image. The
+// JPEG images will be embedded directly, and the other images will be decoded
+// and embedded as the RGB image with the alpha channel soft mask.
+func (doc *pdfDocument) addImage(data []byte) (string, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	var id int
+	if format == "jpeg" {
+		colorSpace := "/DeviceRGB"
+		switch cfg.ColorModel {
+		case color.GrayModel:
+			colorSpace = "/DeviceGray"
+		case color.CMYKModel:
+			colorSpace = "/DeviceCMYK /Decode [1 0 1 0 1 0 1 0]"
+		}
+		id = doc.addRawStream(fmt.Sprintf(
+			"/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace %s /BitsPerComponent 8 /Filter /DCTDecode",
+			cfg.Width, cfg.Height, colorSpace), data)
+	} else {
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return "", err
+		}
+		bounds := img.Bounds()
+		rgb := make([]byte, 0, bounds.Dx()*bounds.Dy()*3)
+		alpha := make([]byte, 0, bounds.Dx()*bounds.Dy())
+		var transparent bool
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+				rgb, alpha = append(rgb, c.R, c.G, c.B), append(alpha, c.A)
+				transparent = transparent || c.A != 0xFF
+			}
+		}
+		dict := fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /BitsPerComponent 8",
+			bounds.Dx(), bounds.Dy())
+		if transparent {
+			dict += fmt.Sprintf(" /SMask %d 0 R", doc.addStream(dict+" /ColorSpace /DeviceGray", alpha))
+		}
+		id = doc.addStream(dict+" /ColorSpace /DeviceRGB", rgb)
+	}
+	doc.images = append(doc.images, id)
+	return "Im" + strconv.Itoa(len(doc.images)), err
+}
+
+// write provides a function to write the PDF document by given writer and
+// document information dictionary entries.
+func (doc *pdfDocument) write(w io.Writer, info string) error {
+	kids := make([]string, len(doc.pages))
+	for i, id := range doc.pages {
+		kids[i] = strconv.Itoa(id) + " 0 R"
+	}
+	doc.objects[0] = []byte("<< /Type /Catalog /Pages 2 0 R >>")
+	doc.objects[1] = []byte(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids)))
+	if uf := doc.unicodeFont; uf != nil && uf.index > 0 {
+		doc.fontIDs[uf.index-1] = uf.addObjects(doc)
+	}
+	var resources bytes.Buffer
+	resources.WriteString("<< /ProcSet [/PDF /Text /ImageB /ImageC] /Font <<")
+	for i, id := range doc.fontIDs {
+		fmt.Fprintf(&resources, " /F%d %d 0 R", i+1, id)
+	}
+	resources.WriteString(" >> /XObject <<")
+	for i, id := range doc.images {
+		fmt.Fprintf(&resources, " /Im%d %d 0 R", i+1, id)
+	}
+	resources.WriteString(" >> >>")
+	doc.objects[2] = resources.Bytes()
+	infoID := doc.addObject([]byte("<< " + info + " >>"))
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+	offsets := make([]int, len(doc.objects))
+	for i, obj := range doc.objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n", i+1)
+		buf.Write(obj)
+		buf.WriteString("\nendobj\n")
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(doc.objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(doc.objects)+1, infoID, xref)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// newPDFUnicodeFont provides a function to parse the TrueType font file by
+// given font file content.
+func newPDFUnicodeFont(data []byte) (*pdfUnicodeFont, error) {
+	if bytes.HasPrefix(data, []byte("OTTO")) {
+		return nil, ErrPDFFontFile
+	}
+	parsed, err := sfnt.Parse(data)
+	if err != nil {
+		return nil, ErrPDFFontFile
+	}
+	return &pdfUnicodeFont{
+		data: data, font: parsed,
+		widths: make(map[sfnt.GlyphIndex]int), chars: make(map[sfnt.GlyphIndex]rune),
+	}, nil
+}
+
+// scale provides a function to convert the value in font units to
+// thousandths of the font size.
+func (uf *pdfUnicodeFont) scale(value fixed.Int26_6) int {
+	return int(math.Round(float64(value) * 1000 / float64(uf.font.UnitsPerEm())))
+}
+
+// glyph provides a function to get the glyph index and the glyph width of
+// the character, the missing glyph will be used if the font doesn't contain
+// the character.
+func (uf *pdfUnicodeFont) glyph(char rune) (sfnt.GlyphIndex, int) {
+	if char == '\t' {
+		char = ' '
+	}
+	idx, err := uf.font.GlyphIndex(&uf.buf, char)
+	if err != nil {
+		idx = 0
+	}
+	if width, ok := uf.widths[idx]; ok {
+		return idx, width
+	}
+	advance, _ := uf.font.GlyphAdvance(&uf.buf, idx, fixed.Int26_6(uf.font.UnitsPerEm()), font.HintingNone)
+	uf.widths[idx] = uf.scale(advance)
+	return idx, uf.widths[idx]
+}
+
+// measure provides a function to get the width in points of the text by
+// given font size.
+func (uf *pdfUnicodeFont) measure(text string, size float64) float64 {
+	var width int
+	for _, char := range text {
+		if char < 32 && char != '\t' {
+			continue
+		}
+		_, w := uf.glyph(char)
+		width += w
+	}
+	return float64(width) * size / 1000
+}
+
+// encode provides a function to encode the text as the hexadecimal string of
+// the glyph indexes in the Identity-H encoding.
+func (uf *pdfUnicodeFont) encode(text string) string {
+	var buf strings.Builder
+	buf.WriteByte('<')
+	for _, char := range text {
+		if char < 32 && char != '\t' {
+			continue
+		}
+		idx, _ := uf.glyph(char)
+		if _, ok := uf.chars[idx]; !ok && idx != 0 {
+			uf.chars[idx] = char
+		}
+		fmt.Fprintf(&buf, "%04X", uint16(idx))
+	}
+	buf.WriteByte('>')
+	return buf.String()
+}
+
+// resource provides a function to get the resource name of the font, the
+// font object number will be reserved in the PDF document if not exists and
+// the font objects will be created on writing the document.
+func (uf *pdfUnicodeFont) resource(doc *pdfDocument) string {
+	if uf.index == 0 {
+		doc.fontIDs = append(doc.fontIDs, 0)
+		uf.index = len(doc.fontIDs)
+	}
+	return "F" + strconv.Itoa(uf.index)
+}
+
+// addObjects provides a function to add the font file, font descriptor,
+// descendant CIDFontType2 font, ToUnicode character map and Type0 font
+// objects into the PDF document, returns the object number of the Type0
+// font.
+func (uf *pdfUnicodeFont) addObjects(doc *pdfDocument) int {
+	ppem := fixed.Int26_6(uf.font.UnitsPerEm())
+	metrics, _ := uf.font.Metrics(&uf.buf, ppem, font.HintingNone)
+	bounds, _ := uf.font.Bounds(&uf.buf, ppem, font.HintingNone)
+	name, _ := uf.font.Name(&uf.buf, sfnt.NameIDPostScript)
+	name = strings.Map(func(r rune) rune {
+		if r <= 32 || r >= 127 || strings.ContainsRune("()<>[]{}/%#", r) {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" {
+		name = "Unicode"
+	}
+	fileID := doc.addStream(fmt.Sprintf("/Length1 %d", len(uf.data)), uf.data)
+	descriptorID := doc.addObject([]byte(fmt.Sprintf(
+		"<< /Type /FontDescriptor /FontName /%s /Flags 4 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		name, uf.scale(bounds.Min.X), -uf.scale(bounds.Max.Y), uf.scale(bounds.Max.X), -uf.scale(bounds.Min.Y),
+		uf.scale(metrics.Ascent), -uf.scale(metrics.Descent), uf.scale(metrics.CapHeight), fileID)))
+	glyphs := make([]sfnt.GlyphIndex, 0, len(uf.chars))
+	for idx := range uf.chars {
+		glyphs = append(glyphs, idx)
+	}
+	slices.Sort(glyphs)
+	var widths, cmap strings.Builder
+	for i, idx := range glyphs {
+		fmt.Fprintf(&widths, " %d [%d]", idx, uf.widths[idx])
+		if i%100 == 0 {
+			fmt.Fprintf(&cmap, "%d beginbfchar\n", min(len(glyphs)-i, 100))
+		}
+		fmt.Fprintf(&cmap, "<%04X> <", uint16(idx))
+		for _, code := range utf16.Encode([]rune{uf.chars[idx]}) {
+			fmt.Fprintf(&cmap, "%04X", code)
+		}
+		cmap.WriteString(">\n")
+		if i%100 == 99 || i == len(glyphs)-1 {
+			cmap.WriteString("endbfchar\n")
+		}
+	}
+	cidFontID := doc.addObject([]byte(fmt.Sprintf(
+		"<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /DW 1000 /W [%s ] /CIDToGIDMap /Identity >>",
+		name, descriptorID, widths.String())))
+	toUnicodeID := doc.addStream("", []byte("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n"+
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n"+
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n"+
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n"+cmap.String()+
+		"endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend"))
+	return doc.addObject([]byte(fmt.Sprintf(
+		"<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		name, cidFontID, toUnicodeID)))
+}
@@ -0,0 +1,308 @@
+package excelize
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"image"
+	"image/jpeg"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// pdfContents returns the decompressed content streams of the pages in the
+// PDF document.
+func pdfContents(t *testing.T, data []byte) []string {
+	var contents []string
+	for _, match := range regexp.MustCompile(`<< ([^\n]*)/Length (\d+) >>\nstream\n`).FindAllSubmatchIndex(data, -1) {
+		if strings.Contains(string(data[match[2]:match[3]]), "/Image") {
+			continue
+		}
+		length, err := strconv.Atoi(string(data[match[4]:match[5]]))
+		assert.NoError(t, err)
+		zr, err := zlib.NewReader(bytes.NewReader(data[match[1] : match[1]+length]))
+		assert.NoError(t, err)
+		content, err := io.ReadAll(zr)
+		assert.NoError(t, err)
+		contents = append(contents, string(content))
+	}
+	return contents
+}
+
+func TestWritePDF(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 120; row++ {
+		cell, err := CoordinatesToCellName(1, row)
+		assert.NoError(t, err)
+		assert.NoError(t, f.SetSheetRow("Sheet1", cell, &[]interface{}{"Item (" + strconv.Itoa(row) + ") with a long name", row, float64(row) * 1.5, row%2 == 0}))
+	}
+	assert.NoError(t, f.SetCellValue("Sheet1", "E2", "Wrap the text into multiple lines"))
+	assert.NoError(t, f.MergeCell("Sheet1", "B3", "C4"))
+	headerStyle, err := f.NewStyle(&Style{
+		Font:      &Font{Bold: true, Italic: true, Underline: "single", Strike: true, Color: "FF0000"},
+		Fill:      Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}},
+		Border:    []Border{{Type: "left", Style: 1}, {Type: "right", Style: 3}, {Type: "top", Style: 4}, {Type: "bottom", Style: 2, Color: "0000FF"}, {Type: "diagonalUp", Style: 1}, {Type: "diagonalDown", Style: 1}},
+		Alignment: &Alignment{Horizontal: "center", Vertical: "top"},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "D1", headerStyle))
+	numStyle, err := f.NewStyle(&Style{NumFmt: 4, Alignment: &Alignment{Vertical: "center", Indent: 1}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "C2", "C120", numStyle))
+	wrapStyle, err := f.NewStyle(&Style{Alignment: &Alignment{WrapText: true, Horizontal: "right"}, Font: &Font{Family: "Courier New"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellStyle("Sheet1", "E2", "E2", wrapStyle))
+	assert.NoError(t, f.SetRowHeight("Sheet1", 2, 60))
+	assert.NoError(t, f.SetRowVisible("Sheet1", 5, false))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "_xlnm.Print_Titles", RefersTo: "Sheet1!$1:$1", Scope: "Sheet1"}))
+	assert.NoError(t, f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{
+		OddHeader: "&L&BReport&C&A&RPage &P of &N", OddFooter: "&C&8Confidential",
+	}))
+	assert.NoError(t, f.InsertPageBreak("Sheet1", "A50"))
+	assert.NoError(t, f.AddPicture("Sheet1", "F3", filepath.Join("test", "images", "excel.png"), &GraphicOptions{OffsetX: 10, ScaleX: 0.5, ScaleY: 0.5}))
+	assert.NoError(t, f.AddPicture("Sheet1", "F20", filepath.Join("test", "images", "excel.jpg"), nil))
+	assert.NoError(t, f.AddPicture("Sheet1", "F80", filepath.Join("test", "images", "excel.emf"), nil))
+	assert.NoError(t, f.SetDocProps(&DocProperties{Title: "Sales (2024)", Creator: "Excelize"}))
+	_, err = f.NewSheet("Empty")
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, f.WritePDF(&buf))
+	data := buf.String()
+	assert.True(t, strings.HasPrefix(data, "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(data, "%%EOF\n"))
+	assert.Contains(t, data, "/Type /Pages /Kids [")
+	assert.Contains(t, data, "/Count 4 >>")
+	assert.Contains(t, data, "/MediaBox [0 0 612 792]")
+	assert.Contains(t, data, "/Title (Sales \\(2024\\))")
+	assert.Contains(t, data, "/Author (Excelize)")
+	assert.Contains(t, data, "/BaseFont /Helvetica-BoldOblique")
+	assert.Contains(t, data, "/BaseFont /Courier ")
+	assert.Contains(t, data, "/Filter /DCTDecode")
+	assert.Contains(t, data, "/SMask ")
+	contents := pdfContents(t, buf.Bytes())
+	assert.Len(t, contents, 4)
+	for i, content := range contents {
+		assert.Contains(t, content, "(Page "+strconv.Itoa(i+1)+" of 4) Tj")
+		assert.Contains(t, content, "/F1 8 Tf 0 0 0 rg")
+		assert.Contains(t, content, "(Confidential) Tj")
+		// Test the print title row is repeated on each page
+		assert.Contains(t, content, "(Item \\(1\\) with a long name) Tj")
+	}
+	assert.Contains(t, contents[0], "1 1 0 rg 50.4 723 54.75 15 re f")
+	assert.Contains(t, contents[0], "[] 0 d 1 w 0 0 1 RG 50.4 723 m 105.15 723 l S")
+	assert.Contains(t, contents[0], "[3 2] 0 d 0.5 w 0 0 0 RG 105.15 738 m 105.15 723 l S")
+	assert.Contains(t, contents[0], "(Wrap) Tj")
+	assert.Contains(t, contents[0], "(e lines) Tj")
+	assert.Contains(t, contents[0], "(3.00) Tj")
+	assert.Contains(t, contents[0], "(TRUE) Tj")
+	assert.Contains(t, contents[0], "/Im1 Do")
+	assert.Contains(t, contents[0], "/Im2 Do")
+	assert.NotContains(t, contents[0], "/Im3 Do")
+	assert.NotContains(t, contents[0], "(Item \\(5\\) with a long name) Tj")
+	assert.NotContains(t, contents[0], "(Item \\(50\\) with a long name) Tj")
+	assert.Contains(t, contents[1], "(Item \\(49\\) with a long name) Tj")
+	assert.Contains(t, contents[2], "(Item \\(50\\) with a long name) Tj")
+
+	// Test export the worksheet with print area, fit to page, page setup and
+	// grid lines printing settings
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "_xlnm.Print_Area", RefersTo: "Sheet1!$A$1:$D$100,Sheet1!$A$110", Scope: "Sheet1"}))
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{FitToPage: boolPtr(true)}))
+	assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{
+		Size: intPtr(9), Orientation: stringPtr("landscape"), FitToHeight: intPtr(1), FitToWidth: intPtr(1),
+		BlackAndWhite: boolPtr(true), PageOrder: stringPtr("overThenDown"),
+	}))
+	assert.NoError(t, f.SetPageMargins("Sheet1", &PageLayoutMarginsOptions{Horizontally: boolPtr(true), Vertically: boolPtr(true)}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	ws.(*xlsxWorksheet).PrintOptions.GridLines = true
+	ws.(*xlsxWorksheet).RowBreaks = nil
+	buf.Reset()
+	assert.NoError(t, f.WritePDF(&buf, PDFOptions{Sheets: []string{"Sheet1"}}))
+	data = buf.String()
+	assert.Contains(t, data, "/MediaBox [0 0 841.89 595.28]")
+	assert.Contains(t, data, "/Count 2 >>")
+	contents = pdfContents(t, buf.Bytes())
+	assert.Len(t, contents, 2)
+	assert.Contains(t, contents[0], "re S")
+	assert.NotContains(t, contents[0], "1 1 0 rg")
+	assert.NotContains(t, contents[0], "1 0 0 rg")
+	assert.NotContains(t, contents[0], "(Item \\(101\\) with a long name) Tj")
+	assert.Contains(t, contents[1], "(Item \\(110\\) with a long name) Tj")
+	assert.NotContains(t, contents[1], "(3.00) Tj")
+
+	// Test export the worksheet with the first page and even page headers
+	assert.NoError(t, f.SetSheetProps("Sheet1", &SheetPropsOptions{FitToPage: boolPtr(false)}))
+	assert.NoError(t, f.SetPageLayout("Sheet1", &PageLayoutOptions{Size: intPtr(0), Orientation: stringPtr("portrait"), AdjustTo: uintPtr(50)}))
+	assert.NoError(t, f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{
+		DifferentFirst: true, DifferentOddEven: true, OddHeader: "Odd", EvenHeader: "Even", FirstHeader: "First",
+	}))
+	assert.NoError(t, f.DeleteDefinedName(&DefinedName{Name: "_xlnm.Print_Titles", Scope: "Sheet1"}))
+	assert.NoError(t, f.DeleteDefinedName(&DefinedName{Name: "_xlnm.Print_Area", Scope: "Sheet1"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "_xlnm.Print_Titles", RefersTo: "Sheet1!$A:$A", Scope: "Sheet1"}))
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "_xlnm.Print_Area", RefersTo: "Sheet1!$A$1:$B$150", Scope: "Sheet1"}))
+	buf.Reset()
+	assert.NoError(t, f.WritePDF(&buf, PDFOptions{Sheets: []string{"Sheet1"}}))
+	contents = pdfContents(t, buf.Bytes())
+	assert.Len(t, contents, 2)
+	assert.Contains(t, contents[0], "(First) Tj")
+	assert.Contains(t, contents[1], "(Even) Tj")
+	assert.Contains(t, contents[0], "/F1 5.5 Tf")
+
+	// Test export the worksheet with conditional formats
+	_, err = f.NewSheet("Format")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetCol("Format", "A1", &[]interface{}{1, 2}))
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "0000FF"}, Fill: Fill{Type: "pattern", Color: []string{"00FF00"}, Pattern: 1}})
+	assert.NoError(t, err)
+	buf.Reset()
+	assert.NoError(t, f.WritePDF(&buf, PDFOptions{Sheets: []string{"Format"}}))
+	assert.NotContains(t, pdfContents(t, buf.Bytes())[0], "0 1 0 rg")
+	assert.NoError(t, f.SetConditionalFormat("Format", "A1:A2", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: &format, Value: "1"},
+	}))
+	buf.Reset()
+	assert.NoError(t, f.WritePDF(&buf, PDFOptions{Sheets: []string{"Format"}}))
+	contents = pdfContents(t, buf.Bytes())
+	assert.Equal(t, 1, strings.Count(contents[0], "0 1 0 rg"))
+	assert.Contains(t, contents[0], "0 0 1 rg")
+
+	// Test export the workbook without printable worksheets
+	buf.Reset()
+	assert.NoError(t, f.WritePDF(&buf, PDFOptions{Sheets: []string{"Empty"}}))
+	assert.Contains(t, buf.String(), "/Count 1 >>")
+	assert.Equal(t, []string{""}, pdfContents(t, buf.Bytes()))
+
+	// Test export the worksheet with invalid print area
+	assert.NoError(t, f.SetDefinedName(&DefinedName{Name: "_xlnm.Print_Area", RefersTo: "Empty!$A$1:$B", Scope: "Empty"}))
+	assert.Equal(t, newCellNameToCoordinatesError("B", newInvalidCellNameError("B")),
+		f.WritePDF(&buf, PDFOptions{Sheets: []string{"Empty"}}))
+	// Test export the worksheet on not exists worksheet
+	assert.EqualError(t, f.WritePDF(&buf, PDFOptions{Sheets: []string{"SheetN"}}), "sheet SheetN does not exist")
+	// Test export the worksheet with writer error
+	assert.EqualError(t, f.WritePDF(&errWriter{err: errors.New("write error")}, PDFOptions{Sheets: []string{"Sheet1"}}), "write error")
+	// Test export the worksheet with unsupported charset style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.WritePDF(&buf, PDFOptions{Sheets: []string{"Sheet1"}}), "invalid style ID 0")
+	assert.NoError(t, f.Close())
+}
+
+func TestWritePDFUnicodeFont(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Привет, мир"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "Hello"))
+	assert.NoError(t, f.SetHeaderFooter("Sheet1", &HeaderFooterOptions{OddHeader: "&CΑλφα"}))
+	assert.NoError(t, f.SetDocProps(&DocProperties{Title: "Отчёт"}))
+	// Test export the text which can't be encoded without the font file
+	var buf bytes.Buffer
+	assert.Equal(t, newPDFUnsupportedCharError('П'), f.WritePDF(&buf))
+	// Test export the text with the embedded TrueType font
+	buf.Reset()
+	assert.NoError(t, f.WritePDF(&buf, PDFOptions{FontFile: goregular.TTF}))
+	data := buf.String()
+	assert.Contains(t, data, "/Title <FEFF041E0442044704510442>")
+	assert.Contains(t, data, "/Subtype /Type0 /BaseFont /GoRegular /Encoding /Identity-H")
+	assert.Contains(t, data, "/Subtype /CIDFontType2 /BaseFont /GoRegular")
+	assert.Contains(t, data, "/FontFile2 ")
+	assert.Contains(t, data, "/Length1 "+strconv.Itoa(len(goregular.TTF)))
+	contents := pdfContents(t, buf.Bytes())
+	assert.Contains(t, contents[0], "/F1 11 Tf 0 0 0 rg 52.4 726.2 Td <01CE01EF01E701E101E401F1000F000301EB01E701EF> Tj")
+	assert.Contains(t, contents[0], "/F2 11 Tf 0 0 0 rg 52.4 711.2 Td (Hello) Tj")
+	assert.Contains(t, strings.Join(contents, ""), "> <041F>\n")
+	assert.Contains(t, strings.Join(contents, ""), "> <0391>\n")
+	// Test export with the unsupported font file
+	for _, fontFile := range [][]byte{[]byte("font"), []byte("OTTO")} {
+		assert.Equal(t, ErrPDFFontFile, f.WritePDF(&buf, PDFOptions{FontFile: fontFile}))
+	}
+	assert.NoError(t, f.Close())
+}
+
+func TestParsePDFHeaderFooter(t *testing.T) {
+	f := NewFile()
+	f.Path = filepath.Join("path", "Book1.xlsx")
+	sections := parsePDFHeaderFooter("Center &&&L&\"Arial,Bold Italic\"&14&KFF0000&F&R&\"-,Regular\"&Z&B&I&U&X&KZZZZZZ", f, "Sheet1", 2, 5)
+	assert.Equal(t, pdfHeaderFooterSection{text: "Center &", size: 11, color: "000000"}, sections[1])
+	assert.Equal(t, pdfHeaderFooterSection{text: "Book1.xlsx", family: "Arial", color: "FF0000", bold: true, italic: true, size: 14}, sections[0])
+	assert.Equal(t, pdfHeaderFooterSection{text: filepath.Join("path", "") + string(filepath.Separator), color: "000000", bold: true, italic: true, size: 11}, sections[2])
+	sections = parsePDFHeaderFooter("&D &T&", f, "Sheet1", 1, 1)
+	assert.Regexp(t, `^\d+/\d+/\d{4} \d+:\d{2} (AM|PM)&$`, sections[1].text)
+}
+
+func TestPDFHelpers(t *testing.T) {
+	assert.Equal(t, []string{"A1:B2", "A1", "C:D", "1:2"}, parsePDFPrintRanges("'Sheet,1'!$A$1:$B$2,Sheet1!$A$1, Sheet1!$C:$D,$1:$2,"))
+	ps := &pdfSheet{}
+	for _, ref := range []string{"A", "1:A", "A:1", "C:B", "3:2"} {
+		ps.parsePrintTitles(ref)
+	}
+	assert.Equal(t, []int{2, 3}, ps.titleRows)
+	assert.Equal(t, []int{2, 3}, ps.titleCols)
+	assert.Equal(t, [][]int{{1}, {2, 4}, {5}}, paginatePDFItems(1, 5, func(i int) float64 {
+		return map[int]float64{1: 10, 2: 10, 4: 10, 5: 30}[i]
+	}, []int{1}, nil, 25))
+	assert.Equal(t, [][]int{{1, 2}, {4}, {5}}, paginatePDFItems(1, 5, func(i int) float64 {
+		return map[int]float64{1: 10, 2: 10, 4: 10, 5: 10}[i]
+	}, nil, []int{1}, 25))
+
+	for font, expected := range map[Font]string{
+		{Family: "Calibri"}: "Helvetica", {Family: "Arial", Bold: true, Italic: true}: "Helvetica-BoldOblique",
+		{Family: "Times New Roman"}: "Times-Roman", {Family: "Cambria", Italic: true}: "Times-Italic",
+		{Family: "Consolas", Bold: true}: "Courier-Bold",
+	} {
+		assert.Equal(t, expected, getPDFFont(&font).name)
+	}
+	font := getPDFFont(&Font{})
+	assert.Equal(t, 11.12, font.measure("00", 10))
+	assert.Equal(t, 5.56, font.measure("é\x01", 10))
+	assert.Equal(t, 12.0, getPDFFont(&Font{Family: "Courier"}).measure("ab", 10))
+	assert.Equal(t, []string{"abc", "de", "fghi", "jk", "", "l"}, font.wrap("abc de fghijk\n\nl", 10, 17))
+	assert.Equal(t, []byte{'a', ' ', 0x80, 0xE9, '?'}, pdfEncodeText("a\t€é中\n"))
+	assert.Equal(t, "\\(a\\\\b\\)", pdfEscapeText("(a\\b)"))
+	assert.Equal(t, "(a\\(b)", pdfTextString("a(b"))
+	assert.Equal(t, "<FEFF4E2DD83DDE00>", pdfTextString("中😀"))
+	assert.Equal(t, "0 0 0", pdfColor("XYZ"))
+	assert.Equal(t, "0 0 0", pdfColor("FFF"))
+	assert.Equal(t, "1 0.5 0", pdfColor("#FF8000"))
+
+	f := NewFile()
+	margins, err := f.GetPageMargins("Sheet1")
+	assert.NoError(t, err)
+	ps = &pdfSheet{
+		renderSheet: &renderSheet{defaultHeight: 15}, margins: margins, scale: 1,
+		colWidths: map[int]float64{1: 60}, pageHeight: 792, pageWidth: 612,
+	}
+	var rect []float64
+	drawing := pdfDrawing{col: 1, row: 1, width: 120, height: 20, fitCell: true, render: func(c *pdfCanvas, x, y, width, height float64) error {
+		rect = []float64{x, y, width, height}
+		return nil
+	}}
+	c := &pdfCanvas{height: ps.pageHeight}
+	assert.NoError(t, ps.renderDrawing(c, drawing, pdfPage{cols: []int{1}, rows: []int{1}}, map[int]float64{1: 50}, map[int]float64{1: 60}))
+	assert.Equal(t, []float64{50, 62.5, 60, 10}, rect)
+	rect = nil
+	assert.NoError(t, ps.renderDrawing(c, drawing, pdfPage{cols: []int{2}, rows: []int{1}}, nil, nil))
+	assert.Nil(t, rect)
+
+	doc := &pdfDocument{objects: make([][]byte, 3), fonts: make(map[string]string)}
+	_, err = doc.addImage([]byte("unsupported"))
+	assert.Equal(t, image.ErrFormat, err)
+	var gray bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&gray, image.NewGray(image.Rect(0, 0, 2, 2)), nil))
+	name, err := doc.addImage(gray.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, "Im1", name)
+	assert.Contains(t, string(doc.objects[len(doc.objects)-1]), "/ColorSpace /DeviceGray /BitsPerComponent 8 /Filter /DCTDecode")
+	// Test add image with corrupted image data
+	data, err := os.ReadFile(filepath.Join("test", "images", "excel.png"))
+	assert.NoError(t, err)
+	_, err = doc.addImage(data[:len(data)/2])
+	assert.Error(t, err)
+}
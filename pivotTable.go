@@ -16,11 +16,16 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"math"
+	"path"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/xuri/efp"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
@@ -32,6 +37,19 @@ import (
 //	PivotStyleLight1 - PivotStyleLight28
 //	PivotStyleMedium1 - PivotStyleMedium28
 //	PivotStyleDark1 - PivotStyleDark28
+//
+// RefreshData specifies if aggregate the data source and write the rendered
+// pivot table, including the headers, subtotals and grand totals into the
+// cells of the pivot table range when adding the pivot table, the pivot cache
+// records will be saved in the workbook. Use the RefreshPivotTable function to
+// recalculate the pivot table after the data source changed.
+//
+// CalculatedFields specifies the calculated fields of the pivot table, the
+// formula of the calculated field could reference the other fields in the
+// data range by the field name, the field name contains spaces should be
+// enclosed in single quotes, for example: 'Unit Price'*Quantity. The
+// calculated fields can only be used in the data fields, and the values of
+// the referenced fields are summarized by sum before calculation.
 type PivotTableOptions struct {
 	items               map[string][]*xlsxItem
 	sharedItems         map[string]xlsxSharedItems
@@ -64,6 +82,8 @@ type PivotTableOptions struct {
 	FieldPrintTitles    bool
 	ItemPrintTitles     bool
 	PivotTableStyleName string
+	RefreshData         bool
+	CalculatedFields    []PivotTableCalculatedField
 }
 
 // PivotTableShowValuesAsType is the type of calculation for showing values in a
@@ -97,6 +117,24 @@ type PivotTableShowValuesAs struct {
 	BaseItem  string
 }
 
+// PivotTableFieldGroup directly maps the grouping settings of the pivot table
+// field.
+type PivotTableFieldGroup struct {
+	GroupBy   []string
+	Start     float64
+	End       float64
+	Interval  float64
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// PivotTableCalculatedField directly maps the calculated field settings of
+// the pivot table.
+type PivotTableCalculatedField struct {
+	Name    string
+	Formula string
+}
+
 // PivotTableField directly maps the field settings of the pivot table.
 //
 // Name specifies the name of the data field. Maximum 255 characters
@@ -163,6 +201,28 @@ type PivotTableShowValuesAs struct {
 //	PivotTableShowValuesAsPercentOf
 //	PivotTableShowValuesAsDifferenceFrom
 //	PivotTableShowValuesAsPercentDifferenceFrom
+//
+// Group specifies the grouping settings of the row, column or filter field.
+// The GroupBy of Group specifies the date time periods for grouping the date
+// values, the possible values are:
+//
+//	Years
+//	Quarters
+//	Months
+//	Days
+//	Hours
+//	Minutes
+//	Seconds
+//
+// The additional fields will be created for the coarser periods when more than
+// one period specified, and the finest period applies to the field itself. The
+// dates before StartDate or after EndDate will be grouped into the separate
+// items, and the start and end date will be calculated from the field values
+// if not specified. Set the Interval of Group without GroupBy to group the
+// numeric values into the ranges from Start to End, the range will be
+// calculated from the field values if the Start is not less than the End. The
+// SelectedItems of the grouped field specifies the names of the group items,
+// such as "Jan" or "0-9".
 type PivotTableField struct {
 	Compact         bool
 	Data            string
@@ -175,9 +235,16 @@ type PivotTableField struct {
 	NumFmt          int
 	SelectedItems   []string
 	ShowValuesAs    PivotTableShowValuesAs
+	Group           PivotTableFieldGroup
 }
 
 var (
+	// pivotTableGroupByLevels defined the date time periods for grouping the
+	// pivot table field from the coarser to the finer.
+	pivotTableGroupByLevels = []string{"years", "quarters", "months", "days", "hours", "minutes", "seconds"}
+	// pivotTableDateLayout defined the layout of the start and end date for
+	// grouping the pivot table field.
+	pivotTableDateLayout      = "2006-01-02T15:04:05"
 	pivotTableShowValuesAsMap = map[PivotTableShowValuesAsType]string{
 		PivotTableShowValuesAsPercentOfGrandTotal:        "percentOfTotal",
 		PivotTableShowValuesAsPercentOfColumnTotal:       "percentOfCol",
@@ -323,7 +390,13 @@ func (f *File) AddPivotTable(opts *PivotTableOptions) error {
 	if err = f.addContentTypePart(pivotTableID, "pivotTable"); err != nil {
 		return err
 	}
-	return f.addContentTypePart(pivotCacheID, "pivotCache")
+	if err = f.addContentTypePart(pivotCacheID, "pivotCache"); err != nil {
+		return err
+	}
+	if opts.RefreshData {
+		return f.refreshPivotTable(opts)
+	}
+	return err
 }
 
 // parseFormatPivotTableSet provides a function to validate pivot table
@@ -358,6 +431,25 @@ func (f *File) parseFormatPivotTableSet(opts *PivotTableOptions) (*xlsxWorksheet
 	if opts.CompactData && opts.ClassicLayout {
 		return nil, "", ErrPivotTableClassicLayout
 	}
+	if _, orderErr := f.getTableFieldsOrder(opts); orderErr == ErrPivotTableCalculatedField {
+		return dataSheet, pivotTableSheetPath, orderErr
+	}
+	for _, fields := range [][]PivotTableField{opts.Rows, opts.Columns, opts.Filter} {
+		for _, field := range fields {
+			for _, calculatedField := range opts.CalculatedFields {
+				if strings.EqualFold(field.Data, calculatedField.Name) {
+					return dataSheet, pivotTableSheetPath, newPivotTableCalculatedFieldError(calculatedField.Name)
+				}
+			}
+			if _, err = getPivotTableGroupBy(field.Group); err != nil {
+				return dataSheet, pivotTableSheetPath, err
+			}
+			if group := field.Group; group.Interval < 0 || !isFiniteNumber(group.Interval) ||
+				!isFiniteNumber(group.Start) || !isFiniteNumber(group.End) {
+				return dataSheet, pivotTableSheetPath, ErrPivotTableFieldGroup
+			}
+		}
+	}
 	var colDataFields, rowDataFields []string
 	for _, f := range opts.Filter {
 		if inPivotTableField(opts.Columns, f.Data) != -1 {
@@ -407,8 +499,51 @@ func (f *File) adjustRange(rangeStr string) (string, []int, error) {
 }
 
 // getTableFieldsOrder provides a function to get order list of pivot table
-// fields.
+// fields, including the fields in the data range, the fields for the coarser
+// grouping periods and the calculated fields.
 func (f *File) getTableFieldsOrder(opts *PivotTableOptions) ([]string, error) {
+	order, _, err := f.getPivotTableFieldsOrder(opts)
+	return order, err
+}
+
+// getPivotTableFieldsOrder provides a function to get order list of pivot
+// table fields and the names of the fields for the coarser grouping periods
+// of each grouped field.
+func (f *File) getPivotTableFieldsOrder(opts *PivotTableOptions) ([]string, map[string][]string, error) {
+	order, err := f.getPivotTableSourceFields(opts)
+	if err != nil {
+		return order, nil, err
+	}
+	groups := map[string][]string{}
+	for _, fields := range [][]PivotTableField{opts.Rows, opts.Columns, opts.Filter} {
+		for _, field := range fields {
+			levels, err := getPivotTableGroupBy(field.Group)
+			if err != nil || len(levels) < 2 || len(groups[field.Data]) > 0 || inStrSlice(order, field.Data, true) == -1 {
+				continue
+			}
+			for _, level := range levels[:len(levels)-1] {
+				name, caption := "", cases.Title(language.English).String(level)
+				for i := 1; name == "" || inStrSlice(order, name, true) != -1; i++ {
+					if name = caption; i > 1 {
+						name += strconv.Itoa(i)
+					}
+				}
+				order, groups[field.Data] = append(order, name), append(groups[field.Data], name)
+			}
+		}
+	}
+	for _, field := range opts.CalculatedFields {
+		if field.Name == "" || field.Formula == "" || inStrSlice(order, field.Name, true) != -1 {
+			return order, groups, ErrPivotTableCalculatedField
+		}
+		order = append(order, field.Name)
+	}
+	return order, groups, nil
+}
+
+// getPivotTableSourceFields provides a function to get order list of the
+// fields in the data range of the pivot table.
+func (f *File) getPivotTableSourceFields(opts *PivotTableOptions) ([]string, error) {
 	var order []string
 	if err := f.getPivotTableDataRange(opts); err != nil {
 		return order, err
@@ -431,6 +566,228 @@ func (f *File) getTableFieldsOrder(opts *PivotTableOptions) ([]string, error) {
 	return order, nil
 }
 
+// getPivotTableGroupBy provides a function to get the date time periods for
+// grouping the pivot table field from the coarser to the finer by given field
+// group settings.
+func getPivotTableGroupBy(group PivotTableFieldGroup) ([]string, error) {
+	var levels []string
+	for _, level := range pivotTableGroupByLevels {
+		for _, groupBy := range group.GroupBy {
+			if strings.EqualFold(groupBy, level) {
+				levels = append(levels, level)
+				break
+			}
+		}
+	}
+	for _, groupBy := range group.GroupBy {
+		if inStrSlice(pivotTableGroupByLevels, groupBy, false) == -1 {
+			return levels, newPivotTableGroupByError(groupBy)
+		}
+	}
+	return levels, nil
+}
+
+// pivotTableGroup directly maps the range grouping properties of the pivot
+// table field and the names of the group items.
+type pivotTableGroup struct {
+	rangePr          *xlsxRangePr
+	date1904         bool
+	start, end       time.Time
+	startNum, endNum float64
+	interval         float64
+	items            []string
+}
+
+// newPivotTableRangePr provides a function to create the range grouping
+// properties by given field group settings, the date time period and the
+// values of the field.
+func newPivotTableRangePr(group PivotTableFieldGroup, groupBy string, values []pivotTableValue, date1904 bool) *xlsxRangePr {
+	rangePr := &xlsxRangePr{GroupBy: groupBy}
+	if groupBy == "" {
+		rangePr.GroupInterval = group.Interval
+		if group.Start < group.End {
+			rangePr.AutoStart, rangePr.AutoEnd = boolPtr(false), boolPtr(false)
+			rangePr.StartNum, rangePr.EndNum = group.Start, group.End
+		}
+	} else {
+		if !group.StartDate.IsZero() {
+			rangePr.AutoStart, rangePr.StartDate = boolPtr(false), group.StartDate.Format(pivotTableDateLayout)
+		}
+		if !group.EndDate.IsZero() {
+			rangePr.AutoEnd, rangePr.EndDate = boolPtr(false), group.EndDate.Format(pivotTableDateLayout)
+		}
+	}
+	rangePr.setBounds(values, date1904)
+	return rangePr
+}
+
+// setBounds provides a function to update the automatic start and end of the
+// range grouping properties by given the values of the field.
+func (rp *xlsxRangePr) setBounds(values []pivotTableValue, date1904 bool) {
+	var minVal, maxVal float64
+	found := false
+	for _, val := range values {
+		if val.typ != "n" {
+			continue
+		}
+		if !found || val.num < minVal {
+			minVal = val.num
+		}
+		if !found || val.num > maxVal {
+			maxVal = val.num
+		}
+		found = true
+	}
+	if !found {
+		return
+	}
+	autoStart, autoEnd := rp.AutoStart == nil || *rp.AutoStart, rp.AutoEnd == nil || *rp.AutoEnd
+	if rp.GroupBy == "" {
+		if autoStart {
+			rp.StartNum = minVal
+		}
+		if autoEnd {
+			rp.EndNum = maxVal
+		}
+		return
+	}
+	if autoStart {
+		rp.StartDate = timeFromExcelTime(math.Floor(minVal), date1904).Format(pivotTableDateLayout)
+	}
+	if autoEnd {
+		rp.EndDate = timeFromExcelTime(math.Floor(maxVal)+1, date1904).Format(pivotTableDateLayout)
+	}
+}
+
+// isFiniteNumber returns whether the given number is neither NaN nor
+// infinity.
+func isFiniteNumber(num float64) bool {
+	return !math.IsNaN(num) && !math.IsInf(num, 0)
+}
+
+// newPivotTableGroup provides a function to create the pivot table group by
+// given range grouping properties. The default interval 1 will be used if
+// the interval is not specified, and returns an error if the interval is
+// invalid or the number of the group items exceeds the limit.
+func newPivotTableGroup(rangePr *xlsxRangePr, date1904 bool) (*pivotTableGroup, error) {
+	g := &pivotTableGroup{rangePr: rangePr, date1904: date1904, interval: rangePr.GroupInterval}
+	if rangePr.GroupBy == "" {
+		if g.interval == 0 {
+			g.interval = 1
+		}
+		g.startNum, g.endNum = rangePr.StartNum, math.Max(rangePr.StartNum, rangePr.EndNum)
+		count := math.Floor((g.endNum - g.startNum) / g.interval)
+		if g.interval < 0 || !isFiniteNumber(g.interval) || !isFiniteNumber(g.startNum) ||
+			!isFiniteNumber(count) || count >= TotalRows {
+			return g, ErrPivotTableFieldGroup
+		}
+		g.items = append(g.items, "<"+pivotTableNumber(g.startNum))
+		integer := g.startNum == math.Trunc(g.startNum) && g.interval == math.Trunc(g.interval)
+		for i := 0; i <= int(count); i++ {
+			lo := g.startNum + float64(i)*g.interval
+			hi := lo + g.interval
+			if integer {
+				hi--
+			}
+			g.items = append(g.items, pivotTableNumber(lo)+"-"+pivotTableNumber(hi))
+		}
+		g.items = append(g.items, ">"+pivotTableNumber(g.endNum))
+		return g, nil
+	}
+	g.start, _ = time.Parse(pivotTableDateLayout, rangePr.StartDate)
+	g.end, _ = time.Parse(pivotTableDateLayout, rangePr.EndDate)
+	if g.end.Before(g.start) {
+		g.end = g.start
+	}
+	g.startNum, _ = timeToExcelTime(g.start, date1904)
+	g.endNum, _ = timeToExcelTime(g.end, date1904)
+	g.items = append(g.items, "<"+g.start.Format("1/2/2006"))
+	switch rangePr.GroupBy {
+	case "years":
+		for year := g.start.Year(); year <= g.end.Year(); year++ {
+			g.items = append(g.items, strconv.Itoa(year))
+		}
+	case "quarters":
+		for quarter := 1; quarter <= 4; quarter++ {
+			g.items = append(g.items, "Qtr"+strconv.Itoa(quarter))
+		}
+	case "months":
+		for month := time.January; month <= time.December; month++ {
+			g.items = append(g.items, month.String()[:3])
+		}
+	case "days":
+		for day := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC); day.Year() == 2000; day = day.AddDate(0, 0, 1) {
+			g.items = append(g.items, day.Format("2-Jan"))
+		}
+	case "hours":
+		for hour := 0; hour < 24; hour++ {
+			g.items = append(g.items, time.Date(2000, 1, 1, hour, 0, 0, 0, time.UTC).Format("3 PM"))
+		}
+	default:
+		for i := 0; i < 60; i++ {
+			g.items = append(g.items, fmt.Sprintf(":%02d", i))
+		}
+	}
+	g.items = append(g.items, ">"+g.end.Format("1/2/2006"))
+	return g, nil
+}
+
+// groupItems provides a function to get the group items of the pivot cache
+// field.
+func (g *pivotTableGroup) groupItems() *xlsxGroupItems {
+	groupItems := &xlsxGroupItems{Count: len(g.items)}
+	for _, item := range g.items {
+		groupItems.Items = append(groupItems.Items, xlsxSharedItem{XMLName: xml.Name{Local: "s"}, V: item})
+	}
+	return groupItems
+}
+
+// index provides a function to get the index of the group item which the
+// given value belongs to.
+func (g *pivotTableGroup) index(val pivotTableValue) int {
+	last := len(g.items) - 1
+	if val.typ != "n" {
+		return 0
+	}
+	if g.rangePr.GroupBy == "" {
+		if val.num < g.startNum {
+			return 0
+		}
+		if val.num > g.endNum {
+			return last
+		}
+		return min(1+int(math.Floor((val.num-g.startNum)/g.interval)), last-1)
+	}
+	if math.Floor(val.num) < math.Floor(g.startNum) {
+		return 0
+	}
+	if math.Floor(val.num) > math.Floor(g.endNum) {
+		return last
+	}
+	t := timeFromExcelTime(val.num, g.date1904)
+	switch g.rangePr.GroupBy {
+	case "years":
+		return min(1+t.Year()-g.start.Year(), last-1)
+	case "quarters":
+		return 1 + (int(t.Month())-1)/3
+	case "months":
+		return int(t.Month())
+	case "days":
+		return time.Date(2000, t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).YearDay()
+	case "hours":
+		return 1 + t.Hour()
+	case "minutes":
+		return 1 + t.Minute()
+	}
+	return 1 + t.Second()
+}
+
+// pivotTableNumber provides a function to format the number of the group
+// item names.
+func pivotTableNumber(num float64) string {
+	return strconv.FormatFloat(num, 'f', -1, 64)
+}
+
 // MarshalXML encodes a shared item element without default namespace.
 func (si xlsxSharedItem) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	start.Name = xml.Name{Local: si.XMLName.Local}
@@ -649,6 +1006,9 @@ func (f *File) addPivotSharedItems(opts *PivotTableOptions, coordinates []int, f
 		}
 	}
 	for i, field := range fields {
+		if isPivotTableFieldGroup(field) {
+			continue
+		}
 		if len(field.SelectedItems) > 0 || showValuesAsBaseFieldRequired {
 			if opts.items == nil {
 				opts.items = make(map[string][]*xlsxItem)
@@ -671,7 +1031,7 @@ func (f *File) addPivotCache(opts *PivotTableOptions) error {
 	if err != nil {
 		return newPivotTableDataRangeError(err.Error())
 	}
-	order, err := f.getTableFieldsOrder(opts)
+	order, groups, err := f.getPivotTableFieldsOrder(opts)
 	if err != nil {
 		return newPivotTableDataRangeError(err.Error())
 	}
@@ -704,7 +1064,23 @@ func (f *File) addPivotCache(opts *PivotTableOptions) error {
 	if err = f.addPivotSharedItems(opts, coordinates, "rows"); err != nil {
 		return err
 	}
-	for _, name := range order {
+	calculatedFields := map[string]string{}
+	for _, field := range opts.CalculatedFields {
+		calculatedFields[field.Name] = strings.TrimPrefix(field.Formula, "=")
+	}
+	for i, name := range order {
+		if formula, ok := calculatedFields[name]; ok {
+			pc.CacheFields.CacheField = append(pc.CacheFields.CacheField, &xlsxCacheField{
+				Name: name, Formula: formula, DatabaseField: boolPtr(false),
+			})
+			continue
+		}
+		if i > coordinates[2]-coordinates[0] {
+			pc.CacheFields.CacheField = append(pc.CacheFields.CacheField, &xlsxCacheField{
+				Name: name, DatabaseField: boolPtr(false),
+			})
+			continue
+		}
 		si, ok := opts.sharedItems[name]
 		if !ok {
 			si = xlsxSharedItems{ContainsBlank: true, Items: []xlsxSharedItem{{XMLName: xml.Name{Local: "m"}}}}
@@ -714,12 +1090,84 @@ func (f *File) addPivotCache(opts *PivotTableOptions) error {
 			SharedItems: &si,
 		})
 	}
+	if err = f.addPivotCacheFieldGroups(&pc, opts, order, groups, dataSheet, coordinates); err != nil {
+		return err
+	}
 	pc.CacheFields.Count = len(pc.CacheFields.CacheField)
 	pivotCache, err := xml.Marshal(pc)
 	f.saveFileList(opts.pivotCacheXML, pivotCache)
 	return err
 }
 
+// addPivotCacheFieldGroups provides a function to set the field groups of the
+// grouped pivot table fields in the pivot cache, and prepare the items of the
+// grouped fields by given pivot table options.
+func (f *File) addPivotCacheFieldGroups(pc *xlsxPivotCacheDefinition, opts *PivotTableOptions, order []string, groups map[string][]string, dataSheet string, coordinates []int) error {
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	date1904 := wb != nil && wb.WorkbookPr != nil && wb.WorkbookPr.Date1904
+	for _, fields := range [][]PivotTableField{opts.Rows, opts.Columns, opts.Filter} {
+		for _, field := range fields {
+			base := inStrSlice(order, field.Data, true)
+			if !isPivotTableFieldGroup(field) || base == -1 {
+				continue
+			}
+			col := coordinates[0] + base
+			values, err := f.getPivotTableSourceValues(dataSheet, []int{col, coordinates[1], col, coordinates[3]})
+			if err != nil {
+				return err
+			}
+			column := make([]pivotTableValue, len(values))
+			for i := range values {
+				column[i] = values[i][0]
+			}
+			levels, _ := getPivotTableGroupBy(field.Group)
+			if len(levels) == 0 {
+				levels = []string{""}
+			}
+			var par *int
+			for i, name := range append(append([]string{}, groups[field.Data]...), field.Data) {
+				idx := inStrSlice(order, name, true)
+				group, err := newPivotTableGroup(newPivotTableRangePr(field.Group, levels[i], column, date1904), date1904)
+				if err != nil {
+					return err
+				}
+				pc.CacheFields.CacheField[idx].FieldGroup = &xlsxFieldGroup{
+					Par: par, Base: intPtr(base), RangePr: group.rangePr, GroupItems: group.groupItems(),
+				}
+				par = intPtr(idx)
+				var selectedItems []string
+				if name == field.Data {
+					selectedItems = field.SelectedItems
+				}
+				for _, item := range selectedItems {
+					if inStrSlice(group.items, item, true) == -1 {
+						return newPivotTableSelectedItemError(item, field.Data)
+					}
+				}
+				if opts.items == nil {
+					opts.items = make(map[string][]*xlsxItem)
+				}
+				opts.items[name] = nil
+				for x, item := range group.items {
+					opts.items[name] = append(opts.items[name], &xlsxItem{
+						H: len(selectedItems) > 0 && inStrSlice(selectedItems, item, true) == -1, X: intPtr(x),
+					})
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isPivotTableFieldGroup returns whether the pivot table field is grouped by
+// the date time periods or numeric ranges.
+func isPivotTableFieldGroup(field PivotTableField) bool {
+	return len(field.Group.GroupBy) > 0 || field.Group.Interval > 0
+}
+
 // addPivotTable provides a function to create a pivot table by given pivot
 // table ID and properties.
 func (f *File) addPivotTable(cacheID, pivotTableID int, opts *PivotTableOptions) error {
@@ -767,7 +1215,7 @@ func (f *File) addPivotTable(cacheID, pivotTableID int, opts *PivotTableOptions)
 			Count: 1,
 			I: []*xlsxI{
 				{
-					[]*xlsxX{{}},
+					X: []*xlsxX{{}},
 				},
 			},
 		},
@@ -816,7 +1264,7 @@ func (f *File) addPivotTable(cacheID, pivotTableID int, opts *PivotTableOptions)
 // given pivot table options.
 func (f *File) addPivotRowFields(pt *xlsxPivotTableDefinition, opts *PivotTableOptions) error {
 	// row fields
-	rowFieldsIndex, err := f.getPivotFieldsIndex(opts.Rows, opts)
+	rowFieldsIndex, _, err := f.getPivotAxisFieldsIndex(opts.Rows, opts)
 	if err != nil {
 		return err
 	}
@@ -840,11 +1288,10 @@ func (f *File) addPivotRowFields(pt *xlsxPivotTableDefinition, opts *PivotTableO
 // given pivot table options.
 func (f *File) addPivotPageFields(pt *xlsxPivotTableDefinition, opts *PivotTableOptions) error {
 	// page fields
-	pageFieldsIndex, err := f.getPivotFieldsIndex(opts.Filter, opts)
+	pageFieldsIndex, pageFieldsName, err := f.getPivotAxisFieldsIndex(opts.Filter, opts)
 	if err != nil {
 		return err
 	}
-	pageFieldsName := f.getPivotTableFieldsName(opts.Filter)
 	for idx, pageField := range pageFieldsIndex {
 		if pt.PageFields == nil {
 			pt.PageFields = &xlsxPageFields{}
@@ -995,7 +1442,7 @@ func (f *File) addPivotColFields(pt *xlsxPivotTableDefinition, opts *PivotTableO
 	pt.ColFields = &xlsxColFields{}
 
 	// col fields
-	colFieldsIndex, err := f.getPivotFieldsIndex(opts.Columns, opts)
+	colFieldsIndex, _, err := f.getPivotAxisFieldsIndex(opts.Columns, opts)
 	if err != nil {
 		return err
 	}
@@ -1028,14 +1475,24 @@ func (fld *xlsxPivotField) setClassicLayout(classicLayout bool) {
 // addPivotFields create pivot fields based on the column order of the first
 // row in the data region by given pivot table definition and option.
 func (f *File) addPivotFields(pt *xlsxPivotTableDefinition, opts *PivotTableOptions) error {
-	order, err := f.getTableFieldsOrder(opts)
+	order, groups, err := f.getPivotTableFieldsOrder(opts)
 	if err != nil {
 		return err
 	}
+	groupBase := map[string]string{}
+	for base, names := range groups {
+		for _, name := range names {
+			groupBase[name] = base
+		}
+	}
 	x := 0
 	for _, name := range order {
-		if inPivotTableField(opts.Rows, name) != -1 {
-			rowOptions, ok := f.getPivotTableFieldOptions(name, opts.Rows)
+		field := name
+		if base, ok := groupBase[name]; ok {
+			field = base
+		}
+		if inPivotTableField(opts.Rows, field) != -1 {
+			rowOptions, ok := f.getPivotTableFieldOptions(field, opts.Rows)
 			items := opts.items[name]
 			if ok && rowOptions.DefaultSubtotal {
 				items = append(items, &xlsxItem{T: "default"})
@@ -1062,7 +1519,7 @@ func (f *File) addPivotFields(pt *xlsxPivotTableDefinition, opts *PivotTableOpti
 			pt.PivotFields.PivotField = append(pt.PivotFields.PivotField, fld)
 			continue
 		}
-		if inPivotTableField(opts.Filter, name) != -1 {
+		if inPivotTableField(opts.Filter, field) != -1 {
 			items := append(opts.items[name], &xlsxItem{T: "default"})
 			fld := &xlsxPivotField{
 				Axis:                         "axisPage",
@@ -1078,8 +1535,8 @@ func (f *File) addPivotFields(pt *xlsxPivotTableDefinition, opts *PivotTableOpti
 			pt.PivotFields.PivotField = append(pt.PivotFields.PivotField, fld)
 			continue
 		}
-		if inPivotTableField(opts.Columns, name) != -1 {
-			columnOptions, ok := f.getPivotTableFieldOptions(name, opts.Columns)
+		if inPivotTableField(opts.Columns, field) != -1 {
+			columnOptions, ok := f.getPivotTableFieldOptions(field, opts.Columns)
 			items := opts.items[name]
 			if ok && columnOptions.DefaultSubtotal {
 				items = append(items, &xlsxItem{T: "default"})
@@ -1163,6 +1620,35 @@ func (f *File) getPivotFieldsIndex(fields []PivotTableField, opts *PivotTableOpt
 	return pivotFieldsIndex, nil
 }
 
+// getPivotAxisFieldsIndex provides a function to get the index of the row,
+// column or page fields by given pivot table fields, the fields for the
+// coarser grouping periods will be placed before the grouped field. This
+// function also returns the custom names of the fields.
+func (f *File) getPivotAxisFieldsIndex(fields []PivotTableField, opts *PivotTableOptions) ([]int, []string, error) {
+	var (
+		pivotFieldsIndex []int
+		pivotFieldsName  []string
+	)
+	orders, groups, err := f.getPivotTableFieldsOrder(opts)
+	if err != nil {
+		return pivotFieldsIndex, pivotFieldsName, err
+	}
+	fieldsName := f.getPivotTableFieldsName(fields)
+	for i, field := range fields {
+		pos := inStrSlice(orders, field.Data, true)
+		if pos == -1 {
+			continue
+		}
+		for _, name := range groups[field.Data] {
+			pivotFieldsIndex = append(pivotFieldsIndex, inStrSlice(orders, name, true))
+			pivotFieldsName = append(pivotFieldsName, "")
+		}
+		pivotFieldsIndex = append(pivotFieldsIndex, pos)
+		pivotFieldsName = append(pivotFieldsName, fieldsName[i])
+	}
+	return pivotFieldsIndex, pivotFieldsName, nil
+}
+
 // getPivotTableFieldsSubtotal prepare fields subtotal by given pivot table fields.
 func (f *File) getPivotTableFieldsSubtotal(fields []PivotTableField) []string {
 	field := make([]string, len(fields))
@@ -1426,15 +1912,27 @@ func (f *File) pivotCacheReader(path string) (*xlsxPivotCacheDefinition, error)
 // settings by given pivot table fields.
 func (f *File) extractPivotTableFields(pt *xlsxPivotTableDefinition, pc *xlsxPivotCacheDefinition, opts *PivotTableOptions) {
 	order := pc.getPivotCacheFieldsName()
+	if pc.CacheFields != nil {
+		for _, cacheField := range pc.CacheFields.CacheField {
+			if cacheField != nil && cacheField.Formula != "" {
+				opts.CalculatedFields = append(opts.CalculatedFields, PivotTableCalculatedField{
+					Name: cacheField.Name, Formula: cacheField.Formula,
+				})
+			}
+		}
+	}
 	for fieldIdx, field := range pt.PivotFields.PivotField {
+		if pc.isPivotCacheGroupField(fieldIdx) {
+			continue
+		}
 		if field.Axis == "axisRow" {
-			opts.Rows = append(opts.Rows, pc.extractPivotTableField(order[fieldIdx], field))
+			opts.Rows = append(opts.Rows, pc.extractPivotTableField(fieldIdx, order[fieldIdx], field))
 		}
 		if field.Axis == "axisCol" {
-			opts.Columns = append(opts.Columns, pc.extractPivotTableField(order[fieldIdx], field))
+			opts.Columns = append(opts.Columns, pc.extractPivotTableField(fieldIdx, order[fieldIdx], field))
 		}
 		if field.Axis == "axisPage" {
-			opts.Filter = append(opts.Filter, pc.extractPivotTableField(order[fieldIdx], field))
+			opts.Filter = append(opts.Filter, pc.extractPivotTableField(fieldIdx, order[fieldIdx], field))
 		}
 	}
 	if pt.DataFields != nil {
@@ -1493,7 +1991,7 @@ func (f *File) extractPivotTableShowValuesAs(pc *xlsxPivotCacheDefinition, df *x
 
 // extractPivotTableField provides a function to extract pivot table field
 // settings by given pivot table fields.
-func (pc *xlsxPivotCacheDefinition) extractPivotTableField(data string, fld *xlsxPivotField) PivotTableField {
+func (pc *xlsxPivotCacheDefinition) extractPivotTableField(fieldIdx int, data string, fld *xlsxPivotField) PivotTableField {
 	pivotTableField := PivotTableField{
 		Data:           data,
 		ShowAll:        fld.ShowAll,
@@ -1515,9 +2013,75 @@ func (pc *xlsxPivotCacheDefinition) extractPivotTableField(data string, fld *xls
 	}
 	setPtrFieldsVal([]string{"Compact", "Outline", "DefaultSubtotal"},
 		reflect.ValueOf(*fld), reflect.ValueOf(&pivotTableField).Elem())
+	pc.extractPivotTableFieldGroup(fieldIdx, fld, &pivotTableField)
 	return pivotTableField
 }
 
+// extractPivotTableFieldGroup provides a function to extract the grouping
+// settings and the selected group items of the grouped pivot table field by
+// given pivot cache field index.
+func (pc *xlsxPivotCacheDefinition) extractPivotTableFieldGroup(fieldIdx int, fld *xlsxPivotField, field *PivotTableField) {
+	if pc.CacheFields == nil || fieldIdx >= len(pc.CacheFields.CacheField) {
+		return
+	}
+	cacheField := pc.CacheFields.CacheField[fieldIdx]
+	if cacheField == nil || cacheField.FieldGroup == nil || cacheField.FieldGroup.RangePr == nil {
+		return
+	}
+	rangePr := cacheField.FieldGroup.RangePr
+	if rangePr.GroupBy == "" || rangePr.GroupBy == "range" {
+		field.Group.Interval = rangePr.GroupInterval
+		if rangePr.AutoStart != nil && !*rangePr.AutoStart && rangePr.AutoEnd != nil && !*rangePr.AutoEnd {
+			field.Group.Start, field.Group.End = rangePr.StartNum, rangePr.EndNum
+		}
+	} else {
+		if rangePr.AutoStart != nil && !*rangePr.AutoStart {
+			field.Group.StartDate, _ = time.Parse(pivotTableDateLayout, rangePr.StartDate)
+		}
+		if rangePr.AutoEnd != nil && !*rangePr.AutoEnd {
+			field.Group.EndDate, _ = time.Parse(pivotTableDateLayout, rangePr.EndDate)
+		}
+		visited := map[int]bool{}
+		for group := cacheField.FieldGroup; group != nil && group.RangePr != nil; {
+			field.Group.GroupBy = append([]string{cases.Title(language.English).String(group.RangePr.GroupBy)}, field.Group.GroupBy...)
+			if group.Par == nil || visited[*group.Par] || *group.Par >= len(pc.CacheFields.CacheField) ||
+				pc.CacheFields.CacheField[*group.Par] == nil {
+				break
+			}
+			visited[*group.Par] = true
+			group = pc.CacheFields.CacheField[*group.Par].FieldGroup
+		}
+	}
+	field.SelectedItems = nil
+	if fld.Items == nil || cacheField.FieldGroup.GroupItems == nil {
+		return
+	}
+	var selectedItems []string
+	hidden := false
+	for _, item := range fld.Items.Item {
+		if item.X == nil || *item.X >= len(cacheField.FieldGroup.GroupItems.Items) {
+			continue
+		}
+		if hidden = hidden || item.H; !item.H {
+			selectedItems = append(selectedItems, cacheField.FieldGroup.GroupItems.Items[*item.X].V)
+		}
+	}
+	if hidden {
+		field.SelectedItems = selectedItems
+	}
+}
+
+// isPivotCacheGroupField returns whether the pivot cache field is the field
+// for the coarser grouping period of other field by given field index.
+func (pc *xlsxPivotCacheDefinition) isPivotCacheGroupField(fieldIdx int) bool {
+	if pc.CacheFields == nil || fieldIdx >= len(pc.CacheFields.CacheField) {
+		return false
+	}
+	cacheField := pc.CacheFields.CacheField[fieldIdx]
+	return cacheField != nil && cacheField.DatabaseField != nil && !*cacheField.DatabaseField &&
+		cacheField.FieldGroup != nil && cacheField.FieldGroup.Base != nil && *cacheField.FieldGroup.Base != fieldIdx
+}
+
 // genPivotCacheDefinitionID generates a unique pivot table cache definition ID.
 func (f *File) genPivotCacheDefinitionID() int {
 	var (
@@ -1630,3 +2194,1185 @@ func (f *File) getPivotTables() (map[string][]PivotTableOptions, error) {
 	}
 	return pivotTables, nil
 }
+
+// pivotTableValue directly maps the value of a cell in the data source of the
+// pivot table, the typ is the shared item type of the value: n for number, s
+// for string, b for boolean, e for error and m for blank.
+type pivotTableValue struct {
+	typ   string
+	v     string
+	num   float64
+	label string
+}
+
+// pivotTableLine directly maps a row or column in the rendered pivot table,
+// the t is the item type: empty for the data item, default for the subtotal
+// and grand for the grand total. The keys are the item indexes of the axis
+// fields, and the data is the index of the data field.
+type pivotTableLine struct {
+	t    string
+	keys []int
+	data int
+}
+
+// pivotTableDataField directly maps the data field settings for calculating
+// the values of the pivot table.
+type pivotTableDataField struct {
+	fld      int
+	name     string
+	subtotal string
+	numFmt   int
+	showAs   PivotTableShowValuesAsType
+	baseItem string
+}
+
+// pivotTableRefresh directly maps the context for refreshing the pivot cache
+// records and rendering the pivot table.
+type pivotTableRefresh struct {
+	file               *File
+	order              []string
+	sources            int
+	shared             [][]pivotTableValue
+	cacheRecords       [][]int
+	formulas           map[int]string
+	items              [][]pivotTableValue
+	records            [][]int
+	hidden             []map[int]bool
+	rows, cols, pages  []int
+	data               []pivotTableDataField
+	buckets            map[string][]int
+	rowLines, colLines []pivotTableLine
+}
+
+// pivotTableValueRank defined the sort order of the shared item types in the
+// pivot table.
+var pivotTableValueRank = map[string]int{"n": 0, "s": 1, "b": 2, "e": 3, "m": 4}
+
+// pivotTableSubtotalCaption defined the default caption of the data fields by
+// the subtotal function.
+var pivotTableSubtotalCaption = map[string]string{
+	"average": "Average", "count": "Count", "countNums": "Count", "max": "Max",
+	"min": "Min", "product": "Product", "stdDev": "StdDev", "stdDevp": "StdDevp",
+	"sum": "Sum", "var": "Var", "varp": "Varp",
+}
+
+// key returns the unique key of the pivot table value for grouping items.
+func (pv pivotTableValue) key() string {
+	if pv.typ == "n" {
+		return "n" + strconv.FormatFloat(pv.num, 'g', -1, 64)
+	}
+	return pv.typ + pv.v
+}
+
+// less reports whether the pivot table value should sort before the given
+// value, the numbers sort before the text, boolean, errors and blanks.
+func (pv pivotTableValue) less(val pivotTableValue) bool {
+	if pv.typ != val.typ {
+		return pivotTableValueRank[pv.typ] < pivotTableValueRank[val.typ]
+	}
+	switch pv.typ {
+	case "n":
+		return pv.num < val.num
+	case "s":
+		if a, b := strings.ToLower(pv.v), strings.ToLower(val.v); a != b {
+			return a < b
+		}
+	}
+	return pv.v < val.v
+}
+
+// match reports whether the pivot table value matches the given item value
+// of the pivot table field settings.
+func (pv pivotTableValue) match(val string) bool {
+	switch pv.typ {
+	case "m":
+		return val == ""
+	case "b":
+		return strings.EqualFold(pv.v, val)
+	}
+	return pv.v == val || pv.label == val
+}
+
+// sharedItemKey returns the unique key of the pivot cache shared item, which
+// is the same as the key of the pivot table value.
+func sharedItemKey(item xlsxSharedItem) string {
+	switch item.XMLName.Local {
+	case "n":
+		if num, err := strconv.ParseFloat(item.V, 64); err == nil {
+			return pivotTableValue{typ: "n", num: num}.key()
+		}
+	case "b":
+		return "b" + strconv.FormatBool(strings.EqualFold(item.V, "true") || item.V == "1")
+	}
+	return item.XMLName.Local + item.V
+}
+
+// pivotTableKey returns the key of the pivot table aggregation bucket by
+// given item indexes.
+func pivotTableKey(keys []int) string {
+	var b strings.Builder
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.Itoa(key))
+	}
+	return b.String()
+}
+
+// commonPrefixLen returns the length of the common prefix of two item index
+// lists.
+func commonPrefixLen(a, b []int) int {
+	var n int
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
+
+// RefreshPivotTable provides a function to recalculate the pivot table by
+// given worksheet name and pivot table name. The data source of the pivot
+// table will be aggregated according to the row, column, filter and data
+// fields, the pivot cache records will be updated, and the rendered grid
+// including the headers, subtotals and grand totals will be written into the
+// cells of the pivot table range in tabular form. The filter fields will be
+// written above the pivot table range if there are enough rows. For example,
+// refresh the pivot table named PivotTable1 in Sheet1 after the data source
+// changed:
+//
+//	err := f.RefreshPivotTable("Sheet1", "PivotTable1")
+//
+// Note that the values of the data fields with the show values as calculation
+// types except PivotTableShowValuesAsPercentOfGrandTotal,
+// PivotTableShowValuesAsPercentOfColumnTotal,
+// PivotTableShowValuesAsPercentOfRowTotal and PivotTableShowValuesAsIndex are
+// written as the values without calculation.
+func (f *File) RefreshPivotTable(sheet, name string) error {
+	pivotTables, err := f.GetPivotTables(sheet)
+	if err != nil {
+		return err
+	}
+	for _, pivotTable := range pivotTables {
+		if pivotTable.Name == name {
+			return f.refreshPivotTable(&pivotTable)
+		}
+	}
+	return newNoExistTableError(name)
+}
+
+// refreshPivotTable provides a function to update the pivot cache records and
+// render the pivot table cells by given pivot table options.
+func (f *File) refreshPivotTable(opts *PivotTableOptions) error {
+	pt, err := f.pivotTableReader(opts.pivotTableXML)
+	if err != nil {
+		return err
+	}
+	pc, err := f.pivotCacheReader(opts.pivotCacheXML)
+	if err != nil {
+		return err
+	}
+	order, err := f.getPivotTableSourceFields(opts)
+	if err != nil {
+		return err
+	}
+	wb, err := f.workbookReader()
+	if err != nil {
+		return err
+	}
+	date1904 := wb != nil && wb.WorkbookPr != nil && wb.WorkbookPr.Date1904
+	dataSheet, coordinates, err := f.adjustRange(opts.pivotDataRange)
+	if err != nil {
+		return newPivotTableDataRangeError(err.Error())
+	}
+	sheet, location, err := f.adjustRange(opts.PivotTableRange)
+	if err != nil {
+		return newPivotTableRangeError(err.Error())
+	}
+	values, err := f.getPivotTableSourceValues(dataSheet, coordinates)
+	if err != nil {
+		return err
+	}
+	r := &pivotTableRefresh{file: f, order: order, sources: len(order)}
+	if names := pc.getPivotCacheFieldsName(); len(names) > len(order) {
+		r.order = append(r.order, names[len(order):]...)
+	}
+	r.setFields(f, pt, pc)
+	hidden := r.getHiddenItems(pt, pc)
+	if err = r.buildCache(pc, values, date1904); err != nil {
+		return err
+	}
+	r.setHiddenItems(hidden)
+	r.buildLines(pt)
+	r.setPivotFields(pt)
+	if err = f.setPivotCacheRecords(pc, opts.pivotCacheXML, r.cacheRecords); err != nil {
+		return err
+	}
+	if err = f.clearPivotTableCells(sheet, pt, location); err != nil {
+		return err
+	}
+	if err = f.writePivotTable(sheet, pt, r, location); err != nil {
+		return err
+	}
+	pc.SaveData, pc.RecordCount = true, len(r.cacheRecords)
+	pivotCache, err := xml.Marshal(pc)
+	if err != nil {
+		return err
+	}
+	f.saveFileList(opts.pivotCacheXML, pivotCache)
+	pivotTable, err := xml.Marshal(pt)
+	f.saveFileList(opts.pivotTableXML, pivotTable)
+	return err
+}
+
+// getPivotTableSourceValues provides a function to get the values of the
+// data source of the pivot table by given worksheet name and coordinates of
+// the data range, the header row is not included.
+func (f *File) getPivotTableSourceValues(sheet string, coordinates []int) ([][]pivotTableValue, error) {
+	var values [][]pivotTableValue
+	for row := coordinates[1] + 1; row <= coordinates[3]; row++ {
+		record := make([]pivotTableValue, coordinates[2]-coordinates[0]+1)
+		for col := coordinates[0]; col <= coordinates[2]; col++ {
+			cell, _ := CoordinatesToCellName(col, row)
+			value, err := f.getPivotTableSourceValue(sheet, cell)
+			if err != nil {
+				return values, err
+			}
+			record[col-coordinates[0]] = value
+		}
+		values = append(values, record)
+	}
+	return values, nil
+}
+
+// getPivotTableSourceValue provides a function to get the value of the cell
+// in the data source of the pivot table by given worksheet name and cell
+// reference.
+func (f *File) getPivotTableSourceValue(sheet, cell string) (pivotTableValue, error) {
+	raw, err := f.CalcCellValue(sheet, cell, Options{RawCellValue: true})
+	if err != nil {
+		if inStrSlice(formulaErrors, err.Error(), true) != -1 {
+			return pivotTableValue{typ: "e", v: err.Error(), label: err.Error()}, nil
+		}
+		return pivotTableValue{}, err
+	}
+	if raw == "" {
+		return pivotTableValue{typ: "m", label: "(blank)"}, nil
+	}
+	cellType, err := f.GetCellType(sheet, cell)
+	if err != nil {
+		return pivotTableValue{}, err
+	}
+	switch cellType {
+	case CellTypeBool:
+		v := strconv.FormatBool(strings.EqualFold(raw, "TRUE") || raw == "1")
+		return pivotTableValue{typ: "b", v: v, label: strings.ToUpper(v)}, nil
+	case CellTypeError:
+		return pivotTableValue{typ: "e", v: raw, label: raw}, nil
+	case CellTypeInlineString, CellTypeSharedString:
+		return pivotTableValue{typ: "s", v: raw, label: raw}, nil
+	}
+	if num, err := strconv.ParseFloat(raw, 64); err == nil {
+		label, _ := f.CalcCellValue(sheet, cell)
+		return pivotTableValue{typ: "n", v: raw, num: num, label: label}, nil
+	}
+	return pivotTableValue{typ: "s", v: raw, label: raw}, nil
+}
+
+// setFields provides a function to set the axis fields and data fields for
+// refreshing the pivot table by given pivot table and pivot cache
+// definition.
+func (r *pivotTableRefresh) setFields(f *File, pt *xlsxPivotTableDefinition, pc *xlsxPivotCacheDefinition) {
+	axisFields := func(fields []*xlsxField) []int {
+		var indexes []int
+		for _, field := range fields {
+			if field.X >= 0 && field.X < len(r.order) {
+				indexes = append(indexes, field.X)
+			}
+		}
+		return indexes
+	}
+	if pt.RowFields != nil {
+		r.rows = axisFields(pt.RowFields.Field)
+	}
+	if pt.ColFields != nil {
+		r.cols = axisFields(pt.ColFields.Field)
+	}
+	if pt.PageFields != nil {
+		for _, field := range pt.PageFields.PageField {
+			if field.Fld >= 0 && field.Fld < len(r.order) {
+				r.pages = append(r.pages, field.Fld)
+			}
+		}
+	}
+	if pt.DataFields == nil {
+		return
+	}
+	for _, df := range pt.DataFields.DataField {
+		if df.Fld < 0 || df.Fld >= len(r.order) {
+			continue
+		}
+		field := PivotTableField{Subtotal: df.Subtotal}
+		if df.ShowDataAs != "" || df.ExtLst != nil {
+			f.extractPivotTableShowValuesAs(pc, df, &field)
+		}
+		dataField := pivotTableDataField{
+			fld:      df.Fld,
+			name:     df.Name,
+			subtotal: f.getPivotTableFieldsSubtotal([]PivotTableField{field})[0],
+			numFmt:   df.NumFmtID,
+			showAs:   field.ShowValuesAs.Type,
+			baseItem: field.ShowValuesAs.BaseItem,
+		}
+		if dataField.name == "" {
+			dataField.name = pivotTableSubtotalCaption[dataField.subtotal] + " of " + r.order[df.Fld]
+		}
+		r.data = append(r.data, dataField)
+	}
+}
+
+// getHiddenItems provides a function to get the keys of the hidden items of
+// the pivot table fields by given pivot table and pivot cache definition
+// before the pivot cache has been rebuilt.
+func (r *pivotTableRefresh) getHiddenItems(pt *xlsxPivotTableDefinition, pc *xlsxPivotCacheDefinition) []map[string]bool {
+	hidden := make([]map[string]bool, len(r.order))
+	if pt.PivotFields == nil || pc.CacheFields == nil {
+		return hidden
+	}
+	for idx, fld := range pt.PivotFields.PivotField {
+		if idx >= len(r.order) || idx >= len(pc.CacheFields.CacheField) || fld.Items == nil {
+			continue
+		}
+		cacheField := pc.CacheFields.CacheField[idx]
+		if cacheField == nil {
+			continue
+		}
+		var items []xlsxSharedItem
+		if cacheField.SharedItems != nil {
+			items = cacheField.SharedItems.Items
+		}
+		if cacheField.FieldGroup != nil && cacheField.FieldGroup.GroupItems != nil {
+			items = cacheField.FieldGroup.GroupItems.Items
+		}
+		for _, item := range fld.Items.Item {
+			if item.H && item.X != nil && *item.X < len(items) {
+				if hidden[idx] == nil {
+					hidden[idx] = map[string]bool{}
+				}
+				hidden[idx][sharedItemKey(items[*item.X])] = true
+			}
+		}
+	}
+	return hidden
+}
+
+// setHiddenItems provides a function to convert the keys of the hidden items
+// to the indexes of the rebuilt shared items.
+func (r *pivotTableRefresh) setHiddenItems(hidden []map[string]bool) {
+	r.hidden = make([]map[int]bool, len(r.order))
+	for idx, keys := range hidden {
+		r.hidden[idx] = map[int]bool{}
+		for i, item := range r.items[idx] {
+			if keys[item.key()] {
+				r.hidden[idx][i] = true
+			}
+		}
+	}
+}
+
+// buildCache provides a function to rebuild the sorted shared items of the
+// pivot cache fields and the pivot cache records by given pivot cache
+// definition and values of the data source.
+func (r *pivotTableRefresh) buildCache(pc *xlsxPivotCacheDefinition, values [][]pivotTableValue, date1904 bool) error {
+	r.items, r.shared = make([][]pivotTableValue, len(r.order)), make([][]pivotTableValue, r.sources)
+	indexes := make([]map[string]int, r.sources)
+	for col := range r.shared {
+		indexes[col] = map[string]int{}
+		for _, record := range values {
+			if _, ok := indexes[col][record[col].key()]; !ok {
+				indexes[col][record[col].key()] = len(r.shared[col])
+				r.shared[col] = append(r.shared[col], record[col])
+			}
+		}
+		sort.SliceStable(r.shared[col], func(i, j int) bool { return r.shared[col][i].less(r.shared[col][j]) })
+		for i, item := range r.shared[col] {
+			indexes[col][item.key()] = i
+		}
+	}
+	r.cacheRecords, r.records = make([][]int, len(values)), make([][]int, len(values))
+	for row, record := range values {
+		r.cacheRecords[row], r.records[row] = make([]int, r.sources), make([]int, len(r.order))
+		for col, value := range record {
+			r.cacheRecords[row][col] = indexes[col][value.key()]
+		}
+		copy(r.records[row], r.cacheRecords[row])
+	}
+	cacheFields := &xlsxCacheFields{Count: len(r.order)}
+	r.formulas = map[int]string{}
+	for col, name := range r.order {
+		cacheField := &xlsxCacheField{Name: name}
+		if col >= r.sources {
+			cacheField.DatabaseField = boolPtr(false)
+		}
+		if pc.CacheFields != nil && col < len(pc.CacheFields.CacheField) &&
+			pc.CacheFields.CacheField[col] != nil && pc.CacheFields.CacheField[col].Name == name {
+			cacheField = pc.CacheFields.CacheField[col]
+		}
+		if col < r.sources {
+			r.items[col] = r.shared[col]
+			cacheField.SharedItems = newPivotTableSharedItems(r.shared[col])
+		}
+		if cacheField.Formula != "" {
+			r.formulas[col] = cacheField.Formula
+		}
+		cacheFields.CacheField = append(cacheFields.CacheField, cacheField)
+		if err := r.buildGroup(col, cacheField.FieldGroup, values, date1904); err != nil {
+			return err
+		}
+	}
+	pc.CacheFields = cacheFields
+	return nil
+}
+
+// buildGroup provides a function to update the range grouping properties and
+// the group items of the grouped pivot table field, and map the records to
+// the group items.
+func (r *pivotTableRefresh) buildGroup(col int, fieldGroup *xlsxFieldGroup, values [][]pivotTableValue, date1904 bool) error {
+	if fieldGroup == nil || fieldGroup.RangePr == nil {
+		return nil
+	}
+	base := col
+	if fieldGroup.Base != nil {
+		base = *fieldGroup.Base
+	}
+	if base < 0 || base >= r.sources {
+		return nil
+	}
+	column := make([]pivotTableValue, len(values))
+	for row := range values {
+		column[row] = values[row][base]
+	}
+	fieldGroup.RangePr.setBounds(column, date1904)
+	group, err := newPivotTableGroup(fieldGroup.RangePr, date1904)
+	if err != nil {
+		return err
+	}
+	fieldGroup.GroupItems, r.items[col] = group.groupItems(), nil
+	for _, item := range group.items {
+		r.items[col] = append(r.items[col], pivotTableValue{typ: "s", v: item, label: item})
+	}
+	for row := range values {
+		r.records[row][col] = group.index(column[row])
+	}
+	return nil
+}
+
+// newPivotTableSharedItems provides a function to create the shared items of
+// the pivot cache field by given sorted pivot table values.
+func newPivotTableSharedItems(values []pivotTableValue) *xlsxSharedItems {
+	si := &xlsxSharedItems{Count: len(values)}
+	types, integer := map[string]bool{}, true
+	for _, value := range values {
+		types[value.typ] = true
+		si.Items = append(si.Items, xlsxSharedItem{XMLName: xml.Name{Local: value.typ}, V: value.v})
+		if value.typ != "n" {
+			continue
+		}
+		if !si.ContainsNumber || value.num < si.MinValue {
+			si.MinValue = value.num
+		}
+		if !si.ContainsNumber || value.num > si.MaxValue {
+			si.MaxValue = value.num
+		}
+		si.ContainsNumber, integer = true, integer && value.num == math.Trunc(value.num)
+	}
+	si.ContainsBlank, si.ContainsInteger = types["m"], si.ContainsNumber && integer
+	if types["m"] {
+		si.ContainsMixedTypes = len(types) > 2
+	} else {
+		si.ContainsMixedTypes = len(types) > 1
+	}
+	if !types["s"] {
+		si.ContainsString = boolPtr(false)
+		if !types["b"] && !types["e"] && !types["m"] {
+			si.ContainsSemiMixedTypes = boolPtr(false)
+		}
+	}
+	return si
+}
+
+// visible reports whether the pivot cache record is not filtered by the
+// hidden items of the axis fields.
+func (r *pivotTableRefresh) visible(record []int) bool {
+	for _, fields := range [][]int{r.rows, r.cols, r.pages} {
+		for _, fld := range fields {
+			if r.hidden[fld][record[fld]] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// buildLines provides a function to aggregate the visible records into the
+// buckets and build the rows and columns of the rendered pivot table by given
+// pivot table definition.
+func (r *pivotTableRefresh) buildLines(pt *xlsxPivotTableDefinition) {
+	var (
+		rowTuples, colTuples [][]int
+		rowSeen, colSeen     = map[string]bool{}, map[string]bool{}
+	)
+	tuple := func(record, fields []int) []int {
+		keys := make([]int, len(fields))
+		for i, fld := range fields {
+			keys[i] = record[fld]
+		}
+		return keys
+	}
+	r.buckets = map[string][]int{}
+	for idx, record := range r.records {
+		if !r.visible(record) {
+			continue
+		}
+		rowKeys, colKeys := tuple(record, r.rows), tuple(record, r.cols)
+		if key := pivotTableKey(rowKeys); !rowSeen[key] && len(rowKeys) > 0 {
+			rowSeen[key], rowTuples = true, append(rowTuples, rowKeys)
+		}
+		if key := pivotTableKey(colKeys); !colSeen[key] && len(colKeys) > 0 {
+			colSeen[key], colTuples = true, append(colTuples, colKeys)
+		}
+		for lr := 0; lr <= len(rowKeys); lr++ {
+			for lc := 0; lc <= len(colKeys); lc++ {
+				key := pivotTableKey(rowKeys[:lr]) + "|" + pivotTableKey(colKeys[:lc])
+				r.buckets[key] = append(r.buckets[key], idx)
+			}
+		}
+	}
+	r.rowLines = r.newPivotTableLines(pt, rowTuples, r.rows, len(r.rows) > 0 && (pt.ColGrandTotals == nil || *pt.ColGrandTotals))
+	r.colLines = r.newPivotTableLines(pt, colTuples, r.cols, len(r.cols) > 0 && (pt.RowGrandTotals == nil || *pt.RowGrandTotals))
+	if len(r.data) < 2 {
+		return
+	}
+	var lines []pivotTableLine
+	for _, line := range r.colLines {
+		for d := range r.data {
+			line.data = d
+			lines = append(lines, line)
+		}
+	}
+	r.colLines = lines
+}
+
+// newPivotTableLines provides a function to create the lines of the rendered
+// pivot table axis with subtotals and grand total by given pivot table
+// definition, sorted item index tuples and axis fields.
+func (r *pivotTableRefresh) newPivotTableLines(pt *xlsxPivotTableDefinition, tuples [][]int, fields []int, grand bool) []pivotTableLine {
+	if len(fields) == 0 {
+		return []pivotTableLine{{}}
+	}
+	sort.Slice(tuples, func(i, j int) bool {
+		a, b := tuples[i], tuples[j]
+		n := commonPrefixLen(a, b)
+		return n < len(a) && a[n] < b[n]
+	})
+	subtotals := make([]bool, len(fields))
+	for l, fld := range fields {
+		if fld < len(pt.PivotFields.PivotField) {
+			defaultSubtotal := pt.PivotFields.PivotField[fld].DefaultSubtotal
+			subtotals[l] = defaultSubtotal == nil || *defaultSubtotal
+		}
+	}
+	var (
+		lines []pivotTableLine
+		prev  []int
+	)
+	closeLevels := func(from int) {
+		for l := len(prev) - 2; l >= from; l-- {
+			if subtotals[l] {
+				lines = append(lines, pivotTableLine{t: "default", keys: prev[:l+1]})
+			}
+		}
+	}
+	for _, keys := range tuples {
+		if prev != nil {
+			closeLevels(commonPrefixLen(prev, keys))
+		}
+		lines, prev = append(lines, pivotTableLine{keys: keys}), keys
+	}
+	closeLevels(0)
+	if grand {
+		lines = append(lines, pivotTableLine{t: "grand"})
+	}
+	return lines
+}
+
+// setPivotFields provides a function to update the items of the axis fields,
+// the row and column items of the pivot table definition.
+func (r *pivotTableRefresh) setPivotFields(pt *xlsxPivotTableDefinition) {
+	for _, fields := range [][]int{r.rows, r.cols, r.pages} {
+		for _, fld := range fields {
+			if fld >= len(pt.PivotFields.PivotField) {
+				continue
+			}
+			pivotField := pt.PivotFields.PivotField[fld]
+			var items []*xlsxItem
+			for i := range r.items[fld] {
+				items = append(items, &xlsxItem{X: intPtr(i), H: r.hidden[fld][i]})
+			}
+			if pivotField.Axis == "axisPage" || pivotField.DefaultSubtotal == nil || *pivotField.DefaultSubtotal {
+				items = append(items, &xlsxItem{T: "default"})
+			}
+			pivotField.Items = &xlsxItems{Count: len(items), Item: items}
+		}
+	}
+	if pt.DataFields != nil {
+		for d, df := range pt.DataFields.DataField {
+			if d >= len(r.data) || df.BaseField == nil || df.BaseItem == nil || *df.BaseField >= len(r.items) {
+				continue
+			}
+			for i, item := range r.items[*df.BaseField] {
+				if item.match(r.data[d].baseItem) {
+					df.BaseItem = intPtr(i)
+					break
+				}
+			}
+		}
+	}
+	rowItems, colItems := r.newPivotTableItems(r.rowLines, false), r.newPivotTableItems(r.colLines, len(r.data) > 1)
+	pt.RowItems = &xlsxRowItems{Count: len(rowItems), I: rowItems}
+	pt.ColItems = &xlsxColItems{Count: len(colItems), I: colItems}
+	if len(r.data) > 1 && pt.ColFields == nil {
+		pt.ColFields = &xlsxColFields{Count: 1, Field: []*xlsxField{{X: -2}}}
+	}
+}
+
+// newPivotTableItems provides a function to create the row or column items
+// of the pivot table definition by given lines of the rendered pivot table
+// axis.
+func (r *pivotTableRefresh) newPivotTableItems(lines []pivotTableLine, dataAxis bool) []*xlsxI {
+	var (
+		items    []*xlsxI
+		prev     []int
+		prevItem bool
+	)
+	for _, line := range lines {
+		x := append([]int{}, line.keys...)
+		switch {
+		case line.t == "grand" && dataAxis:
+			x = []int{line.data}
+		case line.t == "grand":
+			x = []int{0}
+		case line.t == "" && dataAxis:
+			x = append(x, line.data)
+		}
+		item := &xlsxI{T: line.t, I: line.data}
+		if line.t == "" && prevItem {
+			item.R = commonPrefixLen(prev, x)
+		}
+		for _, v := range x[item.R:] {
+			item.X = append(item.X, &xlsxX{V: v})
+		}
+		prev, prevItem = x, line.t == ""
+		items = append(items, item)
+	}
+	return items
+}
+
+// setPivotCacheRecords provides a function to save the pivot cache records
+// part by given pivot cache definition, the path of the pivot cache
+// definition and the records.
+func (f *File) setPivotCacheRecords(pc *xlsxPivotCacheDefinition, pivotCacheXML string, records [][]int) error {
+	var (
+		recordsXML string
+		relsPath   = "xl/pivotCache/_rels/" + filepath.Base(pivotCacheXML) + ".rels"
+	)
+	if pc.RID != "" {
+		rels, err := f.relsReader(relsPath)
+		if err != nil {
+			return err
+		}
+		if rels != nil {
+			for _, rel := range rels.Relationships {
+				if rel.ID == pc.RID && rel.Type == SourceRelationshipPivotCacheRecords {
+					recordsXML = path.Join("xl/pivotCache", rel.Target)
+					if strings.HasPrefix(rel.Target, "/") {
+						recordsXML = strings.TrimPrefix(rel.Target, "/")
+					}
+				}
+			}
+		}
+	}
+	if recordsXML == "" {
+		idx := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(pivotCacheXML), "pivotCacheDefinition"), ".xml")
+		recordsXML = "xl/pivotCache/pivotCacheRecords" + idx + ".xml"
+		pc.RID = "rId" + strconv.Itoa(f.addRels(relsPath, SourceRelationshipPivotCacheRecords, filepath.Base(recordsXML), ""))
+		index, _ := strconv.Atoi(idx)
+		if err := f.addContentTypePart(index, "pivotCacheRecords"); err != nil {
+			return err
+		}
+	}
+	pivotCacheRecords := xlsxPivotCacheRecords{Count: len(records)}
+	for _, record := range records {
+		r := &xlsxPivotCacheRecord{}
+		for _, v := range record {
+			r.X = append(r.X, &xlsxX{V: v})
+		}
+		pivotCacheRecords.R = append(pivotCacheRecords.R, r)
+	}
+	content, err := xml.Marshal(pivotCacheRecords)
+	f.saveFileList(recordsXML, content)
+	return err
+}
+
+// clearPivotTableCells provides a function to clear the values of the cells
+// in the previous location and filter area of the pivot table by given
+// worksheet name, pivot table definition and coordinates of the pivot table
+// range, the cell styles will be kept.
+func (f *File) clearPivotTableCells(sheet string, pt *xlsxPivotTableDefinition, location []int) error {
+	areas := [][]int{location}
+	if pt.Location != nil {
+		if _, coordinates, err := f.adjustRange(sheet + "!" + pt.Location.Ref); err == nil {
+			areas = append(areas, coordinates)
+			if pageRows := pt.Location.RowPageCount; pageRows > 0 && coordinates[1]-pageRows-1 > 0 {
+				areas = append(areas, []int{coordinates[0], coordinates[1] - pageRows - 1, coordinates[0] + 1, coordinates[1] - 2})
+			}
+		}
+	}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	for r := range ws.SheetData.Row {
+		for c := range ws.SheetData.Row[r].C {
+			cell := &ws.SheetData.Row[r].C[c]
+			col, row, err := CellNameToCoordinates(cell.R)
+			if err != nil {
+				continue
+			}
+			for _, area := range areas {
+				if col >= area[0] && col <= area[2] && row >= area[1] && row <= area[3] {
+					cell.T, cell.V, cell.F, cell.IS = "", "", nil, nil
+				}
+			}
+		}
+	}
+	f.clearCalcCache()
+	return nil
+}
+
+// fieldName returns the display name of the pivot table field by given field
+// index.
+func (r *pivotTableRefresh) fieldName(pt *xlsxPivotTableDefinition, fld int) string {
+	if fld < len(pt.PivotFields.PivotField) && pt.PivotFields.PivotField[fld].Name != "" {
+		return pt.PivotFields.PivotField[fld].Name
+	}
+	return r.order[fld]
+}
+
+// aggregate provides a function to calculate the value of the data field by
+// given records index and data field settings, returns the error value if the
+// value could not be calculated, and returns false if there are no records.
+func (r *pivotTableRefresh) aggregate(records []int, df pivotTableDataField) (float64, string, bool) {
+	if len(records) == 0 {
+		return 0, "", false
+	}
+	var (
+		nums  []float64
+		count int
+	)
+	if formula, ok := r.formulas[df.fld]; ok {
+		return r.calculate(records, formula)
+	}
+	if df.fld >= r.sources {
+		return 0, "", false
+	}
+	for _, idx := range records {
+		value := r.shared[df.fld][r.cacheRecords[idx][df.fld]]
+		switch value.typ {
+		case "m":
+			continue
+		case "e":
+			if df.subtotal != "count" {
+				return 0, value.v, true
+			}
+		case "n":
+			nums = append(nums, value.num)
+		}
+		count++
+	}
+	var sum, product = 0.0, 1.0
+	for _, num := range nums {
+		sum, product = sum+num, product*num
+	}
+	n := float64(len(nums))
+	variance := func(sample bool) (float64, string, bool) {
+		if (sample && n < 2) || n < 1 {
+			return 0, formulaErrorDIV, true
+		}
+		var squares float64
+		for _, num := range nums {
+			squares += (num - sum/n) * (num - sum/n)
+		}
+		if sample {
+			return squares / (n - 1), "", true
+		}
+		return squares / n, "", true
+	}
+	switch df.subtotal {
+	case "count":
+		return float64(count), "", true
+	case "countNums":
+		return n, "", true
+	case "average":
+		if n == 0 {
+			return 0, formulaErrorDIV, true
+		}
+		return sum / n, "", true
+	case "max", "min":
+		if n == 0 {
+			return 0, "", true
+		}
+		result := nums[0]
+		for _, num := range nums {
+			if (df.subtotal == "max" && num > result) || (df.subtotal == "min" && num < result) {
+				result = num
+			}
+		}
+		return result, "", true
+	case "product":
+		if n == 0 {
+			return 0, "", true
+		}
+		return product, "", true
+	case "stdDev", "stdDevp":
+		v, e, ok := variance(df.subtotal == "stdDev")
+		return math.Sqrt(v), e, ok
+	case "var", "varp":
+		return variance(df.subtotal == "var")
+	}
+	return sum, "", true
+}
+
+// calculate provides a function to calculate the value of the calculated
+// field by given records and formula, the fields referenced in the formula
+// will be replaced with the sum of the field values.
+func (r *pivotTableRefresh) calculate(records []int, formula string) (float64, string, bool) {
+	if len(records) == 0 {
+		return 0, "", false
+	}
+	ps := efp.ExcelParser()
+	tokens := ps.Parse(formula)
+	for i, token := range tokens {
+		if token.TType != efp.TokenTypeOperand || token.TSubType != efp.TokenSubTypeRange {
+			continue
+		}
+		fld := inStrSlice(r.order[:r.sources], strings.Trim(token.TValue, "'"), false)
+		if fld == -1 {
+			return 0, formulaErrorNAME, true
+		}
+		num, e, _ := r.aggregate(records, pivotTableDataField{fld: fld, subtotal: "sum"})
+		if e != "" {
+			return 0, e, true
+		}
+		tokens[i] = efp.Token{TValue: strconv.FormatFloat(num, 'f', -1, 64), TType: efp.TokenTypeOperand, TSubType: efp.TokenSubTypeNumber}
+	}
+	result, err := r.file.evalInfixExp(&calcContext{
+		iterations:      make(map[string]uint),
+		iterationsCache: make(map[string]formulaArg),
+	}, "", "", tokens)
+	if err != nil {
+		if inStrSlice(formulaErrors, err.Error(), true) != -1 {
+			return 0, err.Error(), true
+		}
+		return 0, formulaErrorVALUE, true
+	}
+	if result.Type == ArgError {
+		return 0, result.Error, true
+	}
+	if num := result.ToNumber(); num.Type == ArgNumber {
+		return num.Number, "", true
+	}
+	return 0, formulaErrorVALUE, true
+}
+
+// value provides a function to calculate the value of the data field at the
+// intersection of the given row and column of the rendered pivot table.
+func (r *pivotTableRefresh) value(row, col pivotTableLine, d int) (float64, string, bool) {
+	key := func(line pivotTableLine) string {
+		if line.t == "grand" {
+			return ""
+		}
+		return pivotTableKey(line.keys)
+	}
+	df := r.data[d]
+	v, e, ok := r.aggregate(r.buckets[key(row)+"|"+key(col)], df)
+	if !ok || e != "" {
+		return v, e, ok
+	}
+	grand, total := pivotTableLine{t: "grand"}, func(row, col pivotTableLine) (float64, string) {
+		v, e, _ := r.aggregate(r.buckets[key(row)+"|"+key(col)], df)
+		return v, e
+	}
+	var base float64
+	switch df.showAs {
+	case PivotTableShowValuesAsPercentOfGrandTotal:
+		base, e = total(grand, grand)
+	case PivotTableShowValuesAsPercentOfColumnTotal:
+		base, e = total(grand, col)
+	case PivotTableShowValuesAsPercentOfRowTotal:
+		base, e = total(row, grand)
+	case PivotTableShowValuesAsIndex:
+		rowTotal, e1 := total(row, grand)
+		colTotal, e2 := total(grand, col)
+		grandTotal, e3 := total(grand, grand)
+		if e = e1 + e2 + e3; e == "" && rowTotal*colTotal != 0 {
+			return v * grandTotal / (rowTotal * colTotal), "", true
+		}
+		base = 0
+	default:
+		return v, "", true
+	}
+	if e != "" {
+		return 0, e, true
+	}
+	if base == 0 {
+		return 0, formulaErrorDIV, true
+	}
+	return v / base, "", true
+}
+
+// writePivotTable provides a function to write the rendered pivot table into
+// the worksheet cells and update the location of the pivot table by given
+// worksheet name, pivot table definition, refreshing context and coordinates
+// of the pivot table range.
+func (f *File) writePivotTable(sheet string, pt *xlsxPivotTableDefinition, r *pivotTableRefresh, location []int) error {
+	x0, y0 := location[0], location[1]
+	labelCols, headerRows := max(len(r.rows), 1), len(r.cols)
+	if len(r.data) > 1 {
+		headerRows++
+	}
+	firstDataRow := headerRows + 1
+	setCell := func(col, row int, value interface{}) error {
+		cell, err := CoordinatesToCellName(col, row)
+		if err != nil {
+			return err
+		}
+		return f.SetCellValue(sheet, cell, value)
+	}
+	setLabel := func(col, row int, value pivotTableValue) error {
+		if value.typ == "n" && value.label == value.v {
+			return setCell(col, row, value.num)
+		}
+		return setCell(col, row, value.label)
+	}
+	dataName := func(d int) string {
+		if d < len(r.data) {
+			return r.data[d].name
+		}
+		return ""
+	}
+	// headers
+	if headerRows == 0 {
+		firstDataRow = 1
+		for j, fld := range r.rows {
+			if err := setCell(x0+j, y0, r.fieldName(pt, fld)); err != nil {
+				return err
+			}
+		}
+		for c, line := range r.colLines {
+			if err := setCell(x0+labelCols+c, y0, dataName(line.data)); err != nil {
+				return err
+			}
+		}
+	} else {
+		caption := pt.DataCaption
+		if caption == "" {
+			caption = "Values"
+		}
+		if len(r.data) == 1 {
+			if err := setCell(x0, y0, dataName(0)); err != nil {
+				return err
+			}
+		}
+		for j := 0; j < headerRows; j++ {
+			name := caption
+			if j < len(r.cols) {
+				name = r.fieldName(pt, r.cols[j])
+			}
+			if err := setCell(x0+labelCols+j, y0, name); err != nil {
+				return err
+			}
+		}
+		for j, fld := range r.rows {
+			if err := setCell(x0+j, y0+headerRows, r.fieldName(pt, fld)); err != nil {
+				return err
+			}
+		}
+		if err := r.writeColumnLabels(x0+labelCols, y0+1, setCell, setLabel, dataName); err != nil {
+			return err
+		}
+	}
+	// rows
+	for i, line := range r.rowLines {
+		y := y0 + firstDataRow + i
+		switch line.t {
+		case "grand":
+			if err := setCell(x0, y, "Grand Total"); err != nil {
+				return err
+			}
+		case "default":
+			l := len(line.keys) - 1
+			if err := setCell(x0+l, y, r.items[r.rows[l]][line.keys[l]].label+" Total"); err != nil {
+				return err
+			}
+		default:
+			var prev []int
+			if i > 0 && r.rowLines[i-1].t == "" {
+				prev = r.rowLines[i-1].keys
+			}
+			for j := commonPrefixLen(prev, line.keys); j < len(line.keys); j++ {
+				if err := setLabel(x0+j, y, r.items[r.rows[j]][line.keys[j]]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	// values
+	if err := r.writeValues(f, sheet, x0+labelCols, y0+firstDataRow); err != nil {
+		return err
+	}
+	// filters
+	pt.Location = &xlsxLocation{FirstHeaderRow: 1, FirstDataRow: firstDataRow, FirstDataCol: labelCols}
+	if pageRows := len(r.pages); pageRows > 0 && y0-pageRows-1 > 0 {
+		for p, fld := range r.pages {
+			selected, visible := "(Multiple Items)", 0
+			for i, item := range r.items[fld] {
+				if !r.hidden[fld][i] {
+					visible++
+					if visible == 1 {
+						selected = item.label
+					}
+				}
+			}
+			if visible == len(r.items[fld]) {
+				selected = "(All)"
+			} else if visible != 1 {
+				selected = "(Multiple Items)"
+			}
+			if err := setCell(x0, y0-pageRows-1+p, r.fieldName(pt, fld)); err != nil {
+				return err
+			}
+			if err := setCell(x0+1, y0-pageRows-1+p, selected); err != nil {
+				return err
+			}
+		}
+		pt.Location.RowPageCount, pt.Location.ColPageCount = pageRows, 1
+	}
+	topLeftCell, _ := CoordinatesToCellName(x0, y0)
+	bottomRightCell, err := CoordinatesToCellName(x0+labelCols+len(r.colLines)-1, y0+firstDataRow+len(r.rowLines)-1)
+	pt.Location.Ref = topLeftCell + ":" + bottomRightCell
+	return err
+}
+
+// writeColumnLabels provides a function to write the item labels of the
+// column fields into the header rows of the pivot table by given top left
+// coordinates of the column labels area.
+func (r *pivotTableRefresh) writeColumnLabels(x, y int, setCell func(int, int, interface{}) error,
+	setLabel func(int, int, pivotTableValue) error, dataName func(int) string,
+) error {
+	total := func(line pivotTableLine, label string) string {
+		if len(r.data) > 1 {
+			return label + " " + dataName(line.data)
+		}
+		return label + " Total"
+	}
+	var prev []int
+	for c, line := range r.colLines {
+		switch line.t {
+		case "grand":
+			label := "Grand Total"
+			if len(r.data) > 1 {
+				label = "Total " + dataName(line.data)
+			}
+			if err := setCell(x+c, y, label); err != nil {
+				return err
+			}
+			prev = nil
+			continue
+		case "default":
+			l := len(line.keys) - 1
+			if err := setCell(x+c, y+l, total(line, r.items[r.cols[l]][line.keys[l]].label)); err != nil {
+				return err
+			}
+			prev = nil
+			continue
+		}
+		keys := line.keys
+		if len(r.data) > 1 {
+			keys = append(append([]int{}, keys...), line.data)
+		}
+		for j := commonPrefixLen(prev, keys); j < len(keys); j++ {
+			var err error
+			if j < len(r.cols) {
+				err = setLabel(x+c, y+j, r.items[r.cols[j]][keys[j]])
+			} else {
+				err = setCell(x+c, y+j, dataName(line.data))
+			}
+			if err != nil {
+				return err
+			}
+		}
+		prev = keys
+	}
+	return nil
+}
+
+// writeValues provides a function to write the values of the data fields into
+// the data area of the pivot table by given worksheet name and top left
+// coordinates of the data area.
+func (r *pivotTableRefresh) writeValues(f *File, sheet string, x, y int) error {
+	styles := make([]int, len(r.data))
+	for d, df := range r.data {
+		numFmt := df.numFmt
+		if numFmt == 0 && (df.showAs == PivotTableShowValuesAsPercentOfGrandTotal ||
+			df.showAs == PivotTableShowValuesAsPercentOfColumnTotal ||
+			df.showAs == PivotTableShowValuesAsPercentOfRowTotal) {
+			numFmt = 10
+		}
+		if numFmt != 0 {
+			styleID, err := f.NewStyle(&Style{NumFmt: numFmt})
+			if err != nil {
+				return err
+			}
+			styles[d] = styleID
+		}
+	}
+	if len(r.data) == 0 {
+		return nil
+	}
+	for i, row := range r.rowLines {
+		for c, col := range r.colLines {
+			cell, err := CoordinatesToCellName(x+c, y+i)
+			if err != nil {
+				return err
+			}
+			v, e, ok := r.value(row, col, col.data)
+			if !ok {
+				continue
+			}
+			if e != "" {
+				err = f.SetCellStr(sheet, cell, e)
+			} else {
+				err = f.SetCellFloat(sheet, cell, v, -1, 64)
+			}
+			if err == nil && styles[col.data] != 0 {
+				err = f.SetCellStyle(sheet, cell, cell, styles[col.data])
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
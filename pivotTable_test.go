@@ -1,11 +1,14 @@
 package excelize
 
 import (
+	"bytes"
 	"fmt"
+	"math"
 	"math/rand"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -742,3 +745,333 @@ func TestDeleteWorkbookPivotCache(t *testing.T) {
 	f.Pkg.Store("xl/_rels/workbook.xml.rels", MacintoshCyrillicCharset)
 	assert.EqualError(t, f.deleteWorkbookPivotCache(PivotTableOptions{pivotCacheXML: "pivotCache/pivotCacheDefinition1.xml"}), "XML syntax error on line 1: invalid UTF-8")
 }
+
+func TestRefreshPivotTable(t *testing.T) {
+	f := NewFile()
+	month := []string{"Jan", "Feb", "Mar"}
+	year := []int{2017, 2018}
+	types := []string{"Meat", "Dairy"}
+	region := []string{"West", "East"}
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Month", "Year", "Type", "Revenue", "Region"}))
+	for row := 2; row < 14; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row), &[]interface{}{
+			month[(row-2)%len(month)], year[(row-2)%len(year)], types[(row-2)/6], row * 10, region[row%2],
+		}))
+	}
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!A1:E13",
+		PivotTableRange: "Sheet1!G5:M30",
+		Rows:            []PivotTableField{{Data: "Type", DefaultSubtotal: true}, {Data: "Month"}},
+		Columns:         []PivotTableField{{Data: "Year"}},
+		Filter:          []PivotTableField{{Data: "Region", SelectedItems: []string{"West"}}},
+		Data:            []PivotTableField{{Data: "Revenue", Subtotal: "Sum"}, {Data: "Revenue", Subtotal: "Count", Name: "Count"}},
+		RowGrandTotals:  true,
+		ColGrandTotals:  true,
+		RefreshData:     true,
+	}))
+	expected := [][]string{
+		{"Region", "West"},
+		nil,
+		{"", "", "Year", "Values"},
+		{"", "", "2017", "", "Total Sum of Revenue", "Total Count"},
+		{"Type", "Month", "Sum of Revenue", "Count"},
+		{"Dairy", "Feb", "120", "1", "120", "1"},
+		{"", "Jan", "80", "1", "80", "1"},
+		{"", "Mar", "100", "1", "100", "1"},
+		{"Dairy Total", "", "300", "3", "300", "3"},
+		{"Meat", "Feb", "60", "1", "60", "1"},
+		{"", "Jan", "20", "1", "20", "1"},
+		{"", "Mar", "40", "1", "40", "1"},
+		{"Meat Total", "", "120", "3", "120", "3"},
+		{"Grand Total", "", "420", "6", "420", "6"},
+	}
+	checkPivotTableCells := func(t *testing.T, f *File, expected [][]string) {
+		for r, row := range expected {
+			for c, value := range row {
+				cell, err := CoordinatesToCellName(7+c, 3+r)
+				assert.NoError(t, err)
+				val, err := f.GetCellValue("Sheet1", cell)
+				assert.NoError(t, err)
+				assert.Equal(t, value, val, cell)
+			}
+		}
+	}
+	checkPivotTableCells(t, f, expected)
+	pivotTables, err := f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pivotTables, 1)
+	assert.Equal(t, "Sheet1!G5:L16", pivotTables[0].PivotTableRange)
+	assert.Equal(t, []string{"West"}, pivotTables[0].Filter[0].SelectedItems)
+	pivotCacheRecords, ok := f.Pkg.Load("xl/pivotCache/pivotCacheRecords1.xml")
+	assert.True(t, ok)
+	assert.Contains(t, string(pivotCacheRecords.([]byte)), `<pivotCacheRecords xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" count="12">`)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestRefreshPivotTable.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test refresh pivot table after the data source changed
+	f, err = OpenFile(filepath.Join("test", "TestRefreshPivotTable.xlsx"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "D2", 1000))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A14", &[]interface{}{"Apr", 2019, "Meat", 5, "East"}))
+	assert.NoError(t, f.RefreshPivotTable("Sheet1", "PivotTable1"))
+	expected[10], expected[12], expected[13] = []string{"", "Jan", "1000", "1", "1000", "1"},
+		[]string{"Meat Total", "", "1100", "3", "1100", "3"}, []string{"Grand Total", "", "1400", "6", "1400", "6"}
+	checkPivotTableCells(t, f, expected)
+	pivotTables, err = f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Sheet1!A1:E13", pivotTables[0].DataRange)
+	// Test refresh pivot table with not exist pivot table
+	assert.Equal(t, newNoExistTableError("PivotTable2"), f.RefreshPivotTable("Sheet1", "PivotTable2"))
+	// Test refresh pivot table with not exist worksheet
+	assert.EqualError(t, f.RefreshPivotTable("SheetN", "PivotTable1"), "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+
+	// Test refresh pivot table with show values as, blank and error values
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Type", "Value"}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"B", 1}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A3", &[]interface{}{"A", 3}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A4", &[]interface{}{nil, 4}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A5", &[]interface{}{true, nil}))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A6", &[]interface{}{"C", "x"}))
+	assert.NoError(t, f.AddPivotTable(&PivotTableOptions{
+		DataRange:       "Sheet1!A1:B6",
+		PivotTableRange: "Sheet1!D1:F10",
+		Rows:            []PivotTableField{{Data: "Type"}},
+		Data: []PivotTableField{
+			{Data: "Value", Subtotal: "Sum", ShowValuesAs: PivotTableShowValuesAs{Type: PivotTableShowValuesAsPercentOfGrandTotal}},
+			{Data: "Value", Subtotal: "Average", Name: "Average"},
+		},
+		ColGrandTotals: true,
+		RefreshData:    true,
+	}))
+	rows, err := f.GetRows("Sheet1", Options{RawCellValue: true})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"Type", "Value", "", "", "Values"},
+		{"B", "1", "", "Type", "Sum of Value", "Average"},
+		{"A", "3", "", "A", "0.375", "3"},
+		{"", "4", "", "B", "0.125", "1"},
+		{"1", "", "", "C", "0", "#DIV/0!"},
+		{"C", "x", "", "TRUE", "0", "#DIV/0!"},
+		{"", "", "", "(blank)", "0.5", "4"},
+		{"", "", "", "Grand Total", "1", "2.6666666666666665"},
+	}, rows)
+	styleID, err := f.GetCellStyle("Sheet1", "E3")
+	assert.NoError(t, err)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, style.NumFmt)
+	assert.NoError(t, f.Close())
+}
+
+func TestPivotTableAggregate(t *testing.T) {
+	r := &pivotTableRefresh{
+		sources:      1,
+		shared:       [][]pivotTableValue{{{typ: "n", num: 2}, {typ: "n", num: 4}, {typ: "s", v: "a"}, {typ: "m"}}},
+		cacheRecords: [][]int{{0}, {1}, {2}, {3}},
+	}
+	for subtotal, expected := range map[string]float64{
+		"average": 3, "count": 3, "countNums": 2, "max": 4, "min": 2, "product": 8,
+		"stdDev": math.Sqrt(2), "stdDevp": 1, "sum": 6, "var": 2, "varp": 1,
+	} {
+		v, e, ok := r.aggregate([]int{0, 1, 2, 3}, pivotTableDataField{subtotal: subtotal})
+		assert.True(t, ok)
+		assert.Empty(t, e)
+		assert.InDelta(t, expected, v, 1e-9, subtotal)
+	}
+	for _, subtotal := range []string{"max", "min", "product"} {
+		v, e, ok := r.aggregate([]int{2}, pivotTableDataField{subtotal: subtotal})
+		assert.True(t, ok)
+		assert.Empty(t, e)
+		assert.Zero(t, v)
+	}
+	for _, subtotal := range []string{"average", "stdDevp", "varp"} {
+		_, e, _ := r.aggregate([]int{2}, pivotTableDataField{subtotal: subtotal})
+		assert.Equal(t, formulaErrorDIV, e, subtotal)
+	}
+	for _, subtotal := range []string{"stdDev", "var"} {
+		_, e, _ := r.aggregate([]int{0}, pivotTableDataField{subtotal: subtotal})
+		assert.Equal(t, formulaErrorDIV, e, subtotal)
+	}
+	_, _, ok := r.aggregate(nil, pivotTableDataField{})
+	assert.False(t, ok)
+	// Test aggregate with error values
+	r.shared[0] = append(r.shared[0], pivotTableValue{typ: "e", v: formulaErrorNA})
+	r.cacheRecords = append(r.cacheRecords, []int{4})
+	_, e, _ := r.aggregate([]int{0, 4}, pivotTableDataField{subtotal: "sum"})
+	assert.Equal(t, formulaErrorNA, e)
+	v, e, _ := r.aggregate([]int{0, 4}, pivotTableDataField{subtotal: "count"})
+	assert.Empty(t, e)
+	assert.Equal(t, 2.0, v)
+}
+
+func TestPivotTableFieldGroup(t *testing.T) {
+	f := NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Date", "Qty", "Unit Price", "Region"}))
+	for i := 0; i < 8; i++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", i+2), &[]interface{}{
+			time.Date(2023+i/4, time.Month(1+i*2%12), 5, 0, 0, 0, 0, time.UTC), i + 1, 10 * (i%3 + 1), []string{"East", "West"}[i%2],
+		}))
+	}
+	opts := &PivotTableOptions{
+		DataRange:       "Sheet1!A1:D9",
+		PivotTableRange: "Sheet1!G2:M30",
+		Rows: []PivotTableField{
+			{Data: "Date", DefaultSubtotal: true, Group: PivotTableFieldGroup{GroupBy: []string{"Quarters", "Years"}}},
+		},
+		Columns:          []PivotTableField{{Data: "Qty", Group: PivotTableFieldGroup{Interval: 4}}},
+		Data:             []PivotTableField{{Data: "Revenue"}},
+		CalculatedFields: []PivotTableCalculatedField{{Name: "Revenue", Formula: "='Unit Price'*Qty"}},
+		RowGrandTotals:   true,
+		ColGrandTotals:   true,
+		RefreshData:      true,
+	}
+	assert.NoError(t, f.AddPivotTable(opts))
+	for r, row := range [][]string{
+		{"Sum of Revenue", "", "Qty"},
+		{"Years", "Date", "1-4", "5-8", "Grand Total"},
+		{"2023", "Qtr1", "90", "", "90"},
+		{"", "Qtr2", "90", "", "90"},
+		{"", "Qtr3", "40", "", "40"},
+		{"2023 Total", "", "700", "", "700"},
+		{"2024", "Qtr1", "", "450", "450"},
+		{"", "Qtr3", "", "100", "100"},
+		{"", "Qtr4", "", "180", "180"},
+		{"2024 Total", "", "", "2080", "2080"},
+		{"Grand Total", "", "700", "2080", "5400"},
+	} {
+		for c, value := range row {
+			cell, err := CoordinatesToCellName(7+c, 2+r)
+			assert.NoError(t, err)
+			val, err := f.GetCellValue("Sheet1", cell)
+			assert.NoError(t, err)
+			assert.Equal(t, value, val, cell)
+		}
+	}
+	pivotTables, err := f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, pivotTables, 1)
+	assert.Len(t, pivotTables[0].Rows, 1)
+	assert.Equal(t, []string{"Years", "Quarters"}, pivotTables[0].Rows[0].Group.GroupBy)
+	assert.Equal(t, 4.0, pivotTables[0].Columns[0].Group.Interval)
+	assert.Equal(t, []PivotTableCalculatedField{{Name: "Revenue", Formula: "'Unit Price'*Qty"}}, pivotTables[0].CalculatedFields)
+	assert.Equal(t, "Revenue", pivotTables[0].Data[0].Data)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestPivotTableFieldGroup.xlsx")))
+	assert.NoError(t, f.Close())
+
+	// Test add pivot table with the specified group range and selected items
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]string{"Date", "Qty"}))
+	for i := 0; i < 6; i++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", i+2), &[]interface{}{
+			time.Date(2024, time.Month(1+i), 1, 0, 0, 0, 0, time.UTC), i*10 + 5,
+		}))
+	}
+	startDate, endDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	opts = &PivotTableOptions{
+		DataRange:       "Sheet1!A1:B7",
+		PivotTableRange: "Sheet1!D2:H30",
+		Rows: []PivotTableField{{Data: "Date", Group: PivotTableFieldGroup{
+			GroupBy: []string{"months"}, StartDate: startDate, EndDate: endDate,
+		}, SelectedItems: []string{"Jan", "Feb"}}},
+		Filter:         []PivotTableField{{Data: "Qty", Group: PivotTableFieldGroup{Start: 0, End: 49, Interval: 25}}},
+		Data:           []PivotTableField{{Data: "Qty", Subtotal: "Sum"}},
+		RowGrandTotals: true,
+		ColGrandTotals: true,
+	}
+	assert.NoError(t, f.AddPivotTable(opts))
+	pivotTables, err = f.GetPivotTables("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, PivotTableFieldGroup{GroupBy: []string{"Months"}, StartDate: startDate, EndDate: endDate}, pivotTables[0].Rows[0].Group)
+	assert.Equal(t, []string{"Jan", "Feb"}, pivotTables[0].Rows[0].SelectedItems)
+	assert.Equal(t, PivotTableFieldGroup{Start: 0, End: 49, Interval: 25}, pivotTables[0].Filter[0].Group)
+	assert.NoError(t, f.RefreshPivotTable("Sheet1", pivotTables[0].Name))
+	for cell, expected := range map[string]string{"D2": "Date", "E2": "Sum of Qty", "D3": "Jan", "E3": "5", "D4": "Feb", "E4": "15", "D5": "Grand Total", "E5": "20"} {
+		val, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, val, cell)
+	}
+	// Test add pivot table with invalid group settings and calculated fields
+	for _, c := range []struct {
+		opts PivotTableOptions
+		err  error
+	}{
+		{opts: PivotTableOptions{Rows: []PivotTableField{{Data: "Date", Group: PivotTableFieldGroup{GroupBy: []string{"Weeks"}}}}}, err: newPivotTableGroupByError("Weeks")},
+		{opts: PivotTableOptions{Rows: []PivotTableField{{Data: "Date", Group: PivotTableFieldGroup{GroupBy: []string{"Months"}}, SelectedItems: []string{"Month"}}}}, err: newPivotTableSelectedItemError("Month", "Date")},
+		{opts: PivotTableOptions{CalculatedFields: []PivotTableCalculatedField{{Name: "Qty", Formula: "Qty*2"}}}, err: ErrPivotTableCalculatedField},
+		{opts: PivotTableOptions{CalculatedFields: []PivotTableCalculatedField{{Name: "Total"}}}, err: ErrPivotTableCalculatedField},
+		{opts: PivotTableOptions{Rows: []PivotTableField{{Data: "Total"}}, CalculatedFields: []PivotTableCalculatedField{{Name: "Total", Formula: "Qty*2"}}}, err: newPivotTableCalculatedFieldError("Total")},
+		{opts: PivotTableOptions{Rows: []PivotTableField{{Data: "Qty", Group: PivotTableFieldGroup{Interval: -1}}}}, err: ErrPivotTableFieldGroup},
+		{opts: PivotTableOptions{Rows: []PivotTableField{{Data: "Qty", Group: PivotTableFieldGroup{Interval: math.NaN()}}}}, err: ErrPivotTableFieldGroup},
+		{opts: PivotTableOptions{Rows: []PivotTableField{{Data: "Qty", Group: PivotTableFieldGroup{End: math.Inf(1), Interval: 1}}}}, err: ErrPivotTableFieldGroup},
+		{opts: PivotTableOptions{Rows: []PivotTableField{{Data: "Qty", Group: PivotTableFieldGroup{Interval: 1e-7}}}}, err: ErrPivotTableFieldGroup},
+	} {
+		c.opts.DataRange, c.opts.PivotTableRange = "Sheet1!A1:B7", "Sheet1!J2:M30"
+		c.opts.Data = []PivotTableField{{Data: "Qty"}}
+		assert.Equal(t, c.err, f.AddPivotTable(&c.opts))
+	}
+	// Test refresh pivot table with too many group items in the pivot cache
+	pc, ok := f.Pkg.Load(pivotTables[0].pivotCacheXML)
+	assert.True(t, ok)
+	f.Pkg.Store(pivotTables[0].pivotCacheXML, bytes.ReplaceAll(pc.([]byte), []byte(`groupInterval="25"`), []byte(`groupInterval="1e-7"`)))
+	assert.Equal(t, ErrPivotTableFieldGroup, f.RefreshPivotTable("Sheet1", pivotTables[0].Name))
+	assert.NoError(t, f.Close())
+}
+
+func TestPivotTableCalculate(t *testing.T) {
+	r := &pivotTableRefresh{
+		file:         NewFile(),
+		order:        []string{"Qty", "Unit Price"},
+		sources:      2,
+		shared:       [][]pivotTableValue{{{typ: "n", num: 2}, {typ: "e", v: formulaErrorNA}}, {{typ: "n", num: 5}}},
+		cacheRecords: [][]int{{0, 0}, {0, 0}, {1, 0}},
+	}
+	v, e, ok := r.calculate([]int{0, 1}, "'Unit Price'*Qty")
+	assert.True(t, ok)
+	assert.Empty(t, e)
+	assert.Equal(t, 40.0, v)
+	_, _, ok = r.calculate(nil, "Qty")
+	assert.False(t, ok)
+	for formula, expected := range map[string]string{
+		"Price*2": formulaErrorNAME, "Qty/0": formulaErrorDIV, "\"a\"&Qty": formulaErrorVALUE,
+	} {
+		_, e, _ = r.calculate([]int{0}, formula)
+		assert.Equal(t, expected, e, formula)
+	}
+	_, e, _ = r.calculate([]int{2}, "Qty")
+	assert.Equal(t, formulaErrorNA, e)
+}
+
+func TestPivotTableGroup(t *testing.T) {
+	for groupBy, expected := range map[string][]string{
+		"quarters": {"Qtr1", "Qtr4"}, "days": {"1-Jan", "31-Dec"}, "hours": {"12 AM", "11 PM"}, "minutes": {":00", ":59"}, "seconds": {":00", ":59"},
+	} {
+		group, err := newPivotTableGroup(&xlsxRangePr{GroupBy: groupBy, StartDate: "2024-01-01T00:00:00", EndDate: "2024-03-01T00:00:00"}, false)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, []string{group.items[1], group.items[len(group.items)-2]}, groupBy)
+	}
+	group, err := newPivotTableGroup(&xlsxRangePr{GroupBy: "days", StartDate: "2024-01-01T00:00:00", EndDate: "2024-03-01T00:00:00"}, false)
+	assert.NoError(t, err)
+	for num, expected := range map[float64]int{45292: 1, 45351: 60, 45352: 61, 45291: 0, 45353: 367} {
+		assert.Equal(t, expected, group.index(pivotTableValue{typ: "n", num: num}), num)
+	}
+	assert.Equal(t, 0, group.index(pivotTableValue{typ: "s", v: "a"}))
+	group, err = newPivotTableGroup(&xlsxRangePr{StartNum: 0.5, EndNum: 2, GroupInterval: 0.5}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"<0.5", "0.5-1", "1-1.5", "1.5-2", "2-2.5", ">2"}, group.items)
+	for num, expected := range map[float64]int{0: 0, 0.5: 1, 1.9: 3, 2: 4, 3: 5} {
+		assert.Equal(t, expected, group.index(pivotTableValue{typ: "n", num: num}), num)
+	}
+	// Test create the pivot table group with invalid interval or range
+	for _, rangePr := range []*xlsxRangePr{
+		{StartNum: 0, EndNum: 10, GroupInterval: -1},
+		{StartNum: 0, EndNum: 10, GroupInterval: math.Inf(1)},
+		{StartNum: 0, EndNum: math.Inf(1), GroupInterval: 1},
+		{StartNum: math.NaN(), EndNum: 10, GroupInterval: 1},
+		{StartNum: 0, EndNum: TotalRows, GroupInterval: 1},
+	} {
+		_, err = newPivotTableGroup(rangePr, false)
+		assert.Equal(t, ErrPivotTableFieldGroup, err)
+	}
+}
@@ -0,0 +1,279 @@
+// Copyright 2016 - 2026 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.25.0 or later.
+
+package excelize
+
+// renderSheet directly maps the worksheet data used for rendering the
+// worksheet as HTML table or PDF pages.
+type renderSheet struct {
+	sheet         string
+	rows          [][]string
+	cells         map[[2]int]xlsxC
+	rowAttrs      map[int]xlsxRow
+	links         map[[2]int]xlsxHyperlink
+	linkRanges    []renderLink
+	pictures      map[[2]int][]Picture
+	merges        map[[2]int][2]int
+	mergedCells   map[[2]int]bool
+	colStyles     []xlsxCol
+	condFmtRanges [][]int
+	condStyles    map[[2]int]*Style
+	defaultHeight float64
+	css           map[int]string
+	defaultFont   *Font
+}
+
+// renderLink directly maps the hyperlink which applies to a range of cells
+// of the worksheet for rendering.
+type renderLink struct {
+	coordinates []int
+	link        xlsxHyperlink
+}
+
+// prepareRenderSheet provides a function to read the cells, row attributes,
+// hyperlinks, merged cells, conditional formatting ranges and pictures of the
+// worksheet for rendering.
+func (f *File) prepareRenderSheet(sheet string, rs *renderSheet) error {
+	mergeCells, err := f.GetMergeCells(sheet, true)
+	if err != nil {
+		return err
+	}
+	picCells, err := f.GetPictureCells(sheet)
+	if err != nil {
+		return err
+	}
+	rs.pictures = make(map[[2]int][]Picture)
+	for _, cell := range picCells {
+		col, row, _ := CellNameToCoordinates(cell)
+		if rs.pictures[[2]int{col, row}], err = f.GetPictures(sheet, cell); err != nil {
+			return err
+		}
+	}
+	f.mu.Lock()
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		f.mu.Unlock()
+		return err
+	}
+	f.mu.Unlock()
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	rs.cells, rs.rowAttrs = make(map[[2]int]xlsxC), make(map[int]xlsxRow)
+	for _, r := range ws.SheetData.Row {
+		rs.rowAttrs[r.R] = xlsxRow{S: r.S, CustomFormat: r.CustomFormat, Ht: r.Ht, Hidden: r.Hidden}
+		for _, c := range r.C {
+			if col, row, err := CellNameToCoordinates(c.R); err == nil {
+				rs.cells[[2]int{col, row}] = xlsxC{S: c.S, T: c.T, V: c.V}
+			}
+		}
+	}
+	if rs.defaultHeight = defaultRowHeight; ws.SheetFormatPr != nil && ws.SheetFormatPr.CustomHeight {
+		rs.defaultHeight = ws.SheetFormatPr.DefaultRowHeight
+	}
+	if ws.Cols != nil {
+		rs.colStyles = ws.Cols.Col
+	}
+	rs.condStyles = make(map[[2]int]*Style)
+	for _, cf := range ws.ConditionalFormatting {
+		rs.condFmtRanges = append(rs.condFmtRanges, parseCondFmtRanges(cf.SQRef)...)
+	}
+	// The merged ranges are clamped to the used range of the worksheet, avoid
+	// iterating over the cells out of the worksheet data
+	used := rs.getUsedRange()
+	for cell := range rs.cells {
+		used = expandRenderArea(used, cell[0], cell[1])
+	}
+	rs.merges, rs.mergedCells = make(map[[2]int][2]int), make(map[[2]int]bool)
+	for _, mergeCell := range mergeCells {
+		coordinates, err := rangeRefToCoordinates(mergeCell[0])
+		if err != nil {
+			return err
+		}
+		_ = sortCoordinates(coordinates)
+		end := [2]int{coordinates[0], coordinates[1]}
+		if used != nil {
+			end[0], end[1] = max(end[0], min(coordinates[2], used[2])), max(end[1], min(coordinates[3], used[3]))
+		}
+		rs.merges[[2]int{coordinates[0], coordinates[1]}] = end
+	}
+	rs.links = make(map[[2]int]xlsxHyperlink)
+	if ws.Hyperlinks != nil {
+		for _, link := range ws.Hyperlinks.Hyperlink {
+			coordinates, err := rangeRefToCoordinates(link.Ref)
+			if err != nil {
+				if col, row, err := CellNameToCoordinates(link.Ref); err == nil {
+					coordinates = []int{col, row, col, row}
+				} else {
+					continue
+				}
+			}
+			_ = sortCoordinates(coordinates)
+			if link.RID != "" {
+				link.Location = f.getSheetRelationshipsTargetByID(sheet, link.RID)
+			} else if link.Location != "" {
+				link.Location = "#" + link.Location
+			}
+			if coordinates[0] == coordinates[2] && coordinates[1] == coordinates[3] {
+				if _, ok := rs.links[[2]int{coordinates[0], coordinates[1]}]; !ok {
+					rs.links[[2]int{coordinates[0], coordinates[1]}] = link
+				}
+				continue
+			}
+			rs.linkRanges = append(rs.linkRanges, renderLink{coordinates: coordinates, link: link})
+		}
+	}
+	return err
+}
+
+// getArea provides a function to get the coordinates of the worksheet area to
+// be rendered by given range reference, returns the used range of the
+// worksheet if the range reference is empty, and returns nil if the worksheet
+// is empty.
+func (rs *renderSheet) getArea(ref string) ([]int, error) {
+	if ref != "" {
+		coordinates, err := rangeRefToCoordinates(ref)
+		if err != nil {
+			return nil, err
+		}
+		return coordinates, sortCoordinates(coordinates)
+	}
+	area := rs.getUsedRange()
+	for start, end := range rs.merges {
+		area = expandRenderArea(area, start[0], start[1])
+		area = expandRenderArea(area, end[0], end[1])
+	}
+	if area != nil {
+		area[0], area[1] = 1, 1
+	}
+	return area, nil
+}
+
+// getUsedRange provides a function to get the coordinates of the range which
+// contains the cell values and pictures of the worksheet, returns nil if the
+// worksheet is empty.
+func (rs *renderSheet) getUsedRange() []int {
+	var area []int
+	for r, row := range rs.rows {
+		for c, value := range row {
+			if value != "" {
+				area = expandRenderArea(area, c+1, r+1)
+			}
+		}
+	}
+	for cell := range rs.pictures {
+		area = expandRenderArea(area, cell[0], cell[1])
+	}
+	return area
+}
+
+// expandRenderArea provides a function to expand the area coordinates to
+// include the given cell coordinates.
+func expandRenderArea(area []int, col, row int) []int {
+	if area == nil {
+		return []int{col, row, col, row}
+	}
+	area[0], area[1] = min(area[0], col), min(area[1], row)
+	area[2], area[3] = max(area[2], col), max(area[3], row)
+	return area
+}
+
+// getLink provides a function to get the hyperlink of the cell by given
+// coordinates.
+func (rs *renderSheet) getLink(col, row int) (xlsxHyperlink, bool) {
+	if link, ok := rs.links[[2]int{col, row}]; ok {
+		return link, ok
+	}
+	for _, r := range rs.linkRanges {
+		if r.coordinates[0] <= col && col <= r.coordinates[2] && r.coordinates[1] <= row && row <= r.coordinates[3] {
+			return r.link, true
+		}
+	}
+	return xlsxHyperlink{}, false
+}
+
+// getRenderCondStyle provides a function to get the effective style of the
+// cell with the conditional formats applied by given coordinates, returns nil
+// if the cell is not within the ranges of the conditional formats. The data
+// bars and icon sets will not be rendered.
+func (f *File) getRenderCondStyle(rs *renderSheet, col, row int) (*Style, error) {
+	cell := [2]int{col, row}
+	if !inCondFmtRanges(cell[:], rs.condFmtRanges) {
+		return nil, nil
+	}
+	if style, ok := rs.condStyles[cell]; ok {
+		return style, nil
+	}
+	ref, _ := CoordinatesToCellName(col, row)
+	effective, err := f.getCellEffectiveStyle(rs.sheet, ref, rs.getStyleID(col, row))
+	if err != nil {
+		return nil, err
+	}
+	rs.condStyles[cell] = effective.Style
+	return effective.Style, err
+}
+
+// getStyleID provides a function to get the style ID of the cell by given
+// coordinates, the row and column styles will be applied for the cell without
+// style.
+func (rs *renderSheet) getStyleID(col, row int) int {
+	if c, ok := rs.cells[[2]int{col, row}]; ok && c.S != 0 {
+		return c.S
+	}
+	if r := rs.rowAttrs[row]; r.CustomFormat && r.S != 0 {
+		return r.S
+	}
+	for _, c := range rs.colStyles {
+		if c.Min <= col && col <= c.Max && c.Style != 0 {
+			return c.Style
+		}
+	}
+	return 0
+}
+
+// getValue provides a function to get the formatted value of the cell by
+// given coordinates.
+func (rs *renderSheet) getValue(col, row int) string {
+	if row <= len(rs.rows) && col <= len(rs.rows[row-1]) {
+		return rs.rows[row-1][col-1]
+	}
+	return ""
+}
+
+// getCellType provides a function to get the type of the cell by given
+// coordinates, the number cells are returned as "n".
+func (rs *renderSheet) getCellType(col, row int) string {
+	c := rs.cells[[2]int{col, row}]
+	if (c.T == "" || c.T == "n") && c.V != "" {
+		return "n"
+	}
+	return c.T
+}
+
+// getDefaultFont provides a function to get the font of the default cell
+// style in the workbook.
+func (f *File) getDefaultFont() (*Font, error) {
+	style, err := f.GetStyle(0)
+	if err != nil || style.Font == nil {
+		return &Font{}, err
+	}
+	return style.Font, err
+}
+
+// getFontColor provides a function to get the RGB color of the font
+// color, returns empty string if the color is not specified.
+func (f *File) getFontColor(font *Font) string {
+	if font.Color == "" && font.ColorTheme == nil && font.ColorIndexed == 0 {
+		return ""
+	}
+	return f.getThemeColor(&xlsxColor{
+		RGB: font.Color, Indexed: font.ColorIndexed, Theme: font.ColorTheme, Tint: font.ColorTint,
+	})
+}
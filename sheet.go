@@ -26,6 +26,7 @@ import (
 	"strings"
 
 	"github.com/tiendc/go-deepcopy"
+	"github.com/xuri/efp"
 )
 
 // IgnoredErrorsType is the type of ignored errors.
@@ -159,23 +160,7 @@ func (f *File) workSheetWriter() {
 	f.Sheet.Range(func(p, ws interface{}) bool {
 		if ws != nil {
 			sheet := ws.(*xlsxWorksheet)
-			if sheet.MergeCells != nil && len(sheet.MergeCells.Cells) > 0 {
-				_ = sheet.mergeOverlapCells()
-			}
-			if sheet.Cols != nil && len(sheet.Cols.Col) > 0 {
-				f.mergeExpandedCols(sheet)
-			}
-			sheet.SheetData.Row = trimRow(&sheet.SheetData)
-			if sheet.SheetPr != nil || sheet.Drawing != nil || sheet.Hyperlinks != nil || sheet.Picture != nil || sheet.TableParts != nil {
-				f.addNameSpaces(p.(string), SourceRelationship)
-			}
-			if sheet.DecodeAlternateContent != nil {
-				sheet.AlternateContent = &xlsxAlternateContent{
-					Content: sheet.DecodeAlternateContent.Content,
-					XMLNSMC: SourceRelationshipCompatibility.Value,
-				}
-			}
-			sheet.DecodeAlternateContent = nil
+			f.prepareWorkSheet(p.(string), sheet)
 			// reusing buffer
 			_ = encoder.Encode(sheet)
 			f.saveFileList(p.(string), replaceRelationshipsBytes(f.replaceNameSpaceBytes(p.(string), buffer.Bytes())))
@@ -190,6 +175,28 @@ func (f *File) workSheetWriter() {
 	})
 }
 
+// prepareWorkSheet provides a function to prepare the worksheet before
+// serialization by given worksheet XML path.
+func (f *File) prepareWorkSheet(path string, sheet *xlsxWorksheet) {
+	if sheet.MergeCells != nil && len(sheet.MergeCells.Cells) > 0 {
+		_ = sheet.mergeOverlapCells()
+	}
+	if sheet.Cols != nil && len(sheet.Cols.Col) > 0 {
+		f.mergeExpandedCols(sheet)
+	}
+	sheet.SheetData.Row = trimRow(&sheet.SheetData)
+	if sheet.SheetPr != nil || sheet.Drawing != nil || sheet.Hyperlinks != nil || sheet.Picture != nil || sheet.TableParts != nil {
+		f.addNameSpaces(path, SourceRelationship)
+	}
+	if sheet.DecodeAlternateContent != nil {
+		sheet.AlternateContent = &xlsxAlternateContent{
+			Content: sheet.DecodeAlternateContent.Content,
+			XMLNSMC: SourceRelationshipCompatibility.Value,
+		}
+	}
+	sheet.DecodeAlternateContent = nil
+}
+
 // trimRow provides a function to trim empty rows.
 func trimRow(sheetData *xlsxSheetData) []xlsxRow {
 	var (
@@ -787,6 +794,578 @@ func (f *File) copySheet(from, to int) error {
 	return err
 }
 
+// copySheetContext defined the context for copying a worksheet across
+// workbooks, which caches the mapping of the cell style index, differential
+// formatting index and table name between the source and target workbook.
+type copySheetContext struct {
+	src                *File
+	srcSheet, dstSheet string
+	srcMetadata        *xlsxMetadata
+	styles, dxfs       map[int]int
+	tables             map[string]string
+}
+
+// CopySheetFrom provides a function to copy a worksheet from another workbook
+// by given source workbook, source worksheet name and the new worksheet name
+// in the workbook. The cell values, formulas, styles, shared strings, merged
+// cells, data validations, conditional formats, hyperlinks, tables, comments,
+// threaded comments, background picture, pictures and charts will be copied,
+// and the styles will be remapped to the style sheet of the workbook. The
+// table will be renamed with a numeric suffix if the table name already
+// exists in the workbook, and the references to the source worksheet or the
+// renamed tables in formulas will be updated. Note that references to other
+// worksheets or defined names of the source workbook will be kept as-is, and
+// shapes, form controls, slicers, timelines and pictures inside cells are
+// not supported yet. The new worksheet will be removed if the worksheet
+// can't be copied. For example, copy the worksheet named Sheet1 in the
+// workbook Book1.xlsx to the new worksheet named Sheet2 in the workbook:
+//
+//	src, err := excelize.OpenFile("Book1.xlsx")
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	defer func() {
+//	    if err := src.Close(); err != nil {
+//	        fmt.Println(err)
+//	    }
+//	}()
+//	err = f.CopySheetFrom(src, "Sheet1", "Sheet2")
+func (f *File) CopySheetFrom(src *File, srcSheet, dstSheet string) error {
+	if src == nil {
+		return ErrParameterRequired
+	}
+	if err := checkSheetName(dstSheet); err != nil {
+		return err
+	}
+	idx, err := f.GetSheetIndex(dstSheet)
+	if err != nil {
+		return err
+	}
+	if idx != -1 {
+		return ErrExistsSheet
+	}
+	// Read the shared strings of the source workbook and the style sheet of
+	// the target workbook before adding any items into the target workbook
+	if err = src.sharedStringsLoader(); err != nil {
+		return err
+	}
+	if _, err = src.sharedStringsReader(); err != nil {
+		return err
+	}
+	if _, err = f.stylesReader(); err != nil {
+		return err
+	}
+	srcWs, err := src.workSheetReader(srcSheet)
+	if err != nil {
+		return err
+	}
+	ws := &xlsxWorksheet{}
+	if err = deepcopy.Copy(ws, srcWs); err != nil {
+		return err
+	}
+	ctx := &copySheetContext{
+		src: src, srcSheet: srcSheet, dstSheet: dstSheet,
+		styles: map[int]int{0: 0}, dxfs: map[int]int{}, tables: map[string]string{},
+	}
+	if ctx.srcMetadata, err = src.metadataReader(); err != nil {
+		return err
+	}
+	tables, err := ctx.prepareTables(f, srcWs)
+	if err != nil {
+		return err
+	}
+	if _, err = f.NewSheet(dstSheet); err != nil {
+		return err
+	}
+	if err = ctx.prepareWorksheet(f, ws); err != nil {
+		_ = f.DeleteSheet(dstSheet)
+		return err
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(dstSheet)
+	srcSheetXMLPath, _ := src.getSheetXMLPath(srcSheet)
+	if attrs, ok := src.xmlAttr.Load(srcSheetXMLPath); ok && attrs != nil {
+		f.xmlAttr.Store(sheetXMLPath, append([]xml.Attr{}, attrs.([]xml.Attr)...))
+	}
+	f.Sheet.Store(sheetXMLPath, ws)
+	f.clearCalcCache()
+	for _, fn := range []func(*File, *xlsxWorksheet, *xlsxWorksheet) error{
+		ctx.copyHyperlinks, ctx.copyBackground, ctx.copyComments,
+		ctx.copyPictures, ctx.copyCharts,
+	} {
+		if err = fn(f, srcWs, ws); err != nil {
+			_ = f.DeleteSheet(dstSheet)
+			return err
+		}
+	}
+	if err = ctx.copyTables(f, tables); err != nil {
+		_ = f.DeleteSheet(dstSheet)
+	}
+	return err
+}
+
+// prepareTables provides a function to read the tables of the source
+// worksheet, and generate the new table names for the tables which name
+// already exists in the target workbook.
+func (ctx *copySheetContext) prepareTables(f *File, srcWs *xlsxWorksheet) ([]*xlsxTable, error) {
+	var tables []*xlsxTable
+	if srcWs.TableParts == nil {
+		return tables, nil
+	}
+	exists, err := f.getTables()
+	if err != nil {
+		return tables, err
+	}
+	var names []string
+	for _, tbls := range exists {
+		for _, tbl := range tbls {
+			names = append(names, tbl.Name)
+		}
+	}
+	for _, tbl := range srcWs.TableParts.TableParts {
+		if tbl == nil {
+			continue
+		}
+		target := ctx.src.getSheetRelationshipsTargetByID(ctx.srcSheet, tbl.RID)
+		content, ok := ctx.src.Pkg.Load(strings.ReplaceAll(target, "..", "xl"))
+		if !ok {
+			continue
+		}
+		t := new(xlsxTable)
+		if err = ctx.src.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+			Decode(t); err != nil && err != io.EOF {
+			return tables, err
+		}
+		name := t.Name
+		for i := 1; inStrSlice(names, name, false) != -1; i++ {
+			name = t.Name + "_" + strconv.Itoa(i)
+		}
+		if name != t.Name {
+			ctx.tables[strings.ToLower(t.Name)] = name
+		}
+		t.Name, t.DisplayName = name, name
+		names = append(names, name)
+		tables = append(tables, t)
+	}
+	return tables, nil
+}
+
+// prepareWorksheet provides a function to remap the styles, shared strings
+// and formulas of the copied worksheet, and remove the elements which refer
+// to the parts of the source workbook.
+func (ctx *copySheetContext) prepareWorksheet(f *File, ws *xlsxWorksheet) error {
+	var err error
+	if ws.SheetViews != nil && len(ws.SheetViews.SheetView) > 0 {
+		ws.SheetViews.SheetView[0].TabSelected = false
+	}
+	if ws.PageSetUp != nil {
+		ws.PageSetUp.RID = ""
+	}
+	ws.Drawing, ws.LegacyDrawing, ws.LegacyDrawingHF, ws.DrawingHF = nil, nil, nil, nil
+	ws.Picture, ws.OleObjects, ws.Controls, ws.TableParts = nil, nil, nil, nil
+	if ws.Cols != nil {
+		for i := range ws.Cols.Col {
+			if ws.Cols.Col[i].Style, err = ctx.style(f, ws.Cols.Col[i].Style); err != nil {
+				return err
+			}
+		}
+	}
+	for i := range ws.SheetData.Row {
+		row := &ws.SheetData.Row[i]
+		if row.S, err = ctx.style(f, row.S); err != nil {
+			return err
+		}
+		for j := range row.C {
+			if err = ctx.prepareCell(f, &row.C[j]); err != nil {
+				return err
+			}
+		}
+	}
+	for _, cf := range ws.ConditionalFormatting {
+		for _, rule := range cf.CfRule {
+			if rule.DxfID != nil {
+				dxfID, err := ctx.dxf(f, *rule.DxfID)
+				if err != nil {
+					return err
+				}
+				rule.DxfID = intPtr(dxfID)
+			}
+			for k := range rule.Formula {
+				rule.Formula[k] = ctx.formula(rule.Formula[k])
+			}
+		}
+	}
+	if ws.DataValidations != nil {
+		for _, dv := range ws.DataValidations.DataValidation {
+			if dv.Formula1.isFormula() {
+				dv.Formula1.Content = formulaEscaper.Replace(ctx.formula(formulaUnescaper.Replace(dv.Formula1.Content)))
+			}
+			if dv.Formula2.isFormula() {
+				dv.Formula2.Content = formulaEscaper.Replace(ctx.formula(formulaUnescaper.Replace(dv.Formula2.Content)))
+			}
+		}
+	}
+	return ctx.prepareExtLst(f, ws)
+}
+
+// prepareCell provides a function to remap the style, shared string, cell
+// metadata and formula of the copied cell. Only the dynamic array cell
+// metadata will be kept, other kinds of the cell metadata which refer to the
+// parts of the source workbook will be removed.
+func (ctx *copySheetContext) prepareCell(f *File, c *xlsxC) error {
+	var err error
+	if c.S, err = ctx.style(f, c.S); err != nil {
+		return err
+	}
+	if c.T == "s" {
+		if c.V, err = ctx.sharedString(f, c.V); err != nil {
+			return err
+		}
+	}
+	if c.Cm != nil {
+		if !ctx.srcMetadata.isDynamicArray(c.Cm) {
+			c.Cm = nil
+		} else {
+			cm, err := f.setDynamicArrayMetadata()
+			if err != nil {
+				return err
+			}
+			c.Cm = &cm
+		}
+	}
+	c.Vm = nil
+	if c.F != nil && c.F.Content != "" {
+		c.F.Content = ctx.formula(c.F.Content)
+	}
+	return err
+}
+
+// prepareExtLst provides a function to remove the slicers, timelines and web
+// extensions in the extension list of the copied worksheet, which refer to
+// the parts of the source workbook.
+func (ctx *copySheetContext) prepareExtLst(f *File, ws *xlsxWorksheet) error {
+	if ws.ExtLst == nil {
+		return nil
+	}
+	decodeExtLst := new(decodeExtLst)
+	if err := f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return err
+	}
+	var exts []*xlsxExt
+	for _, ext := range decodeExtLst.Ext {
+		if inStrSlice([]string{ExtURISlicerListX14, ExtURISlicerListX15, ExtURITimelineRefs, ExtURIWebExtensions}, ext.URI, true) == -1 {
+			exts = append(exts, ext)
+		}
+	}
+	if len(exts) == 0 {
+		ws.ExtLst = nil
+		return nil
+	}
+	decodeExtLst.Ext = exts
+	extLstBytes, err := xml.Marshal(decodeExtLst)
+	ws.ExtLst = &xlsxExtLst{Ext: strings.TrimSuffix(strings.TrimPrefix(string(extLstBytes), "<extLst>"), "</extLst>")}
+	return err
+}
+
+// style provides a function to get the cell style index in the target
+// workbook by given cell style index in the source workbook.
+func (ctx *copySheetContext) style(f *File, idx int) (int, error) {
+	if styleID, ok := ctx.styles[idx]; ok {
+		return styleID, nil
+	}
+	style, err := ctx.src.GetStyle(idx)
+	if err != nil {
+		return 0, err
+	}
+	styleID, err := f.NewStyle(style)
+	if err != nil {
+		return 0, err
+	}
+	ctx.styles[idx] = styleID
+	return styleID, nil
+}
+
+// dxf provides a function to get the differential formatting index in the
+// target workbook by given differential formatting index in the source
+// workbook.
+func (ctx *copySheetContext) dxf(f *File, idx int) (int, error) {
+	if dxfID, ok := ctx.dxfs[idx]; ok {
+		return dxfID, nil
+	}
+	style, err := ctx.src.GetConditionalStyle(idx)
+	if err != nil {
+		return 0, err
+	}
+	dxfID, err := f.NewConditionalStyle(style)
+	if err != nil {
+		return 0, err
+	}
+	ctx.dxfs[idx] = dxfID
+	return dxfID, nil
+}
+
+// sharedString provides a function to add the shared string item of the
+// source workbook into the shared string table of the target workbook, and
+// returns the index of the shared string item in the target workbook.
+func (ctx *copySheetContext) sharedString(f *File, val string) (string, error) {
+	idx, err := strconv.Atoi(val)
+	if err != nil {
+		return val, nil
+	}
+	if err = ctx.src.sharedStringsLoader(); err != nil {
+		return val, err
+	}
+	srcSST, err := ctx.src.sharedStringsReader()
+	if err != nil {
+		return val, err
+	}
+	if idx < 0 || idx >= len(srcSST.SI) {
+		return val, nil
+	}
+	si := srcSST.SI[idx]
+	if len(si.R) == 0 {
+		idx, err = f.setSharedString(si.String())
+		return strconv.Itoa(idx), err
+	}
+	if err = f.sharedStringsLoader(); err != nil {
+		return val, err
+	}
+	sst, err := f.sharedStringsReader()
+	if err != nil {
+		return val, err
+	}
+	var item xlsxSI
+	if err = deepcopy.Copy(&item, si); err != nil {
+		return val, err
+	}
+	for idx, strItem := range sst.SI {
+		if reflect.DeepEqual(strItem, item) {
+			return strconv.Itoa(idx), err
+		}
+	}
+	sst.SI = append(sst.SI, item)
+	sst.Count++
+	sst.UniqueCount++
+	return strconv.Itoa(len(sst.SI) - 1), err
+}
+
+// formula provides a function to update the references to the source
+// worksheet and renamed tables in the formula of the copied worksheet.
+func (ctx *copySheetContext) formula(formula string) string {
+	if ctx.srcSheet == ctx.dstSheet && len(ctx.tables) == 0 {
+		return formula
+	}
+	var (
+		val string
+		ps  = efp.ExcelParser()
+	)
+	for _, token := range mergeStructuredRefTokens(ps.Parse(formula)) {
+		if token.TType == efp.TokenTypeUnknown {
+			return formula
+		}
+		if token.TType == efp.TokenTypeOperand && token.TSubType == efp.TokenSubTypeRange {
+			val += ctx.formulaOperand(token.TValue)
+			continue
+		}
+		if paren := transformParenthesesToken(token); paren != "" {
+			val += paren
+			continue
+		}
+		if token.TType == efp.TokenTypeOperand && token.TSubType == efp.TokenSubTypeText {
+			val += string(efp.QuoteDouble) + strings.ReplaceAll(token.TValue, "\"", "\"\"") + string(efp.QuoteDouble)
+			continue
+		}
+		val += token.TValue
+	}
+	return val
+}
+
+// formulaOperand provides a function to update the reference to the source
+// worksheet or renamed table in the range operand of the formula.
+func (ctx *copySheetContext) formulaOperand(operand string) string {
+	if isExternalSheetReference(operand) {
+		return operand
+	}
+	if idx := strings.LastIndex(operand, "!"); idx != -1 {
+		if sheet := operand[:idx]; !strings.ContainsAny(sheet, "[]") {
+			if sheet != ctx.srcSheet {
+				return escapeSheetName(sheet) + operand[idx:]
+			}
+			return escapeSheetName(ctx.dstSheet) + operand[idx:]
+		}
+	}
+	if idx := strings.Index(operand, "["); idx > 0 {
+		if name, ok := ctx.tables[strings.ToLower(operand[:idx])]; ok {
+			return name + operand[idx:]
+		}
+	}
+	return operand
+}
+
+// copyHyperlinks provides a function to create the relationships for the
+// external hyperlinks of the copied worksheet.
+func (ctx *copySheetContext) copyHyperlinks(f *File, _, ws *xlsxWorksheet) error {
+	if ws.Hyperlinks == nil {
+		return nil
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(ctx.dstSheet)
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+	for i := range ws.Hyperlinks.Hyperlink {
+		link := &ws.Hyperlinks.Hyperlink[i]
+		if link.RID == "" {
+			continue
+		}
+		target := ctx.src.getSheetRelationshipsTargetByID(ctx.srcSheet, link.RID)
+		rID := f.addRels(sheetRels, SourceRelationshipHyperLink, target, "External")
+		link.RID = "rId" + strconv.Itoa(rID)
+		f.addSheetNameSpace(ctx.dstSheet, SourceRelationship)
+	}
+	return nil
+}
+
+// copyBackground provides a function to copy the background picture of the
+// source worksheet.
+func (ctx *copySheetContext) copyBackground(f *File, srcWs, _ *xlsxWorksheet) error {
+	if srcWs.Picture == nil {
+		return nil
+	}
+	target := ctx.src.getSheetRelationshipsTargetByID(ctx.srcSheet, srcWs.Picture.RID)
+	file, ok := ctx.src.Pkg.Load(strings.Replace(target, "..", "xl", 1))
+	if !ok {
+		return nil
+	}
+	return f.setSheetBackground(ctx.dstSheet, path.Ext(target), file.([]byte))
+}
+
+// copyComments provides a function to copy the comments and threaded
+// comments of the source worksheet.
+func (ctx *copySheetContext) copyComments(f *File, _, _ *xlsxWorksheet) error {
+	threadedComments, err := ctx.src.GetThreadedComments(ctx.srcSheet)
+	if err != nil {
+		return err
+	}
+	for _, tc := range threadedComments {
+		if err = f.AddThreadedComment(ctx.dstSheet, tc); err != nil {
+			return err
+		}
+		if tc.Done {
+			if err = f.ResolveThreadedComment(ctx.dstSheet, tc.Cell, true); err != nil {
+				return err
+			}
+		}
+	}
+	comments, err := ctx.src.GetComments(ctx.srcSheet)
+	if err != nil {
+		return err
+	}
+	for _, comment := range comments {
+		if strings.HasPrefix(comment.Author, "tc=") {
+			continue
+		}
+		if err = f.AddComment(ctx.dstSheet, comment); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// copyPictures provides a function to copy the pictures placed over cells in
+// the source worksheet.
+func (ctx *copySheetContext) copyPictures(f *File, _, _ *xlsxWorksheet) error {
+	cells, err := ctx.src.GetPictureCells(ctx.srcSheet)
+	if err != nil {
+		return err
+	}
+	for _, cell := range cells {
+		pics, err := ctx.src.GetPictures(ctx.srcSheet, cell)
+		if err != nil {
+			return err
+		}
+		for _, pic := range pics {
+			if pic.InsertType != PictureInsertTypePlaceOverCells {
+				continue
+			}
+			if err = f.AddPictureFromBytes(ctx.dstSheet, cell, &pic); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// copyCharts provides a function to copy the charts in the source worksheet,
+// and update the references to the source worksheet in the chart series.
+func (ctx *copySheetContext) copyCharts(f *File, _, _ *xlsxWorksheet) error {
+	charts, err := ctx.src.GetCharts(ctx.srcSheet)
+	if err != nil {
+		return err
+	}
+	for _, chart := range charts {
+		for _, c := range append([]*Chart{&chart}, chart.Combo...) {
+			for i := range c.Series {
+				series := &c.Series[i]
+				series.Name = adjustRangeSheetName(series.Name, ctx.srcSheet, ctx.dstSheet)
+				series.Categories = adjustRangeSheetName(series.Categories, ctx.srcSheet, ctx.dstSheet)
+				series.Values = adjustRangeSheetName(series.Values, ctx.srcSheet, ctx.dstSheet)
+				series.Sizes = adjustRangeSheetName(series.Sizes, ctx.srcSheet, ctx.dstSheet)
+			}
+		}
+		if err = f.AddChart(ctx.dstSheet, chart.Cell, &chart, chart.Combo...); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// copyTables provides a function to add the tables of the source worksheet
+// into the copied worksheet.
+func (ctx *copySheetContext) copyTables(f *File, tables []*xlsxTable) error {
+	sheetXMLPath, _ := f.getSheetXMLPath(ctx.dstSheet)
+	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetXMLPath, "xl/worksheets/") + ".rels"
+	for _, t := range tables {
+		var err error
+		tableID := f.countTables() + 1
+		sheetRelationshipsTableXML := "../tables/table" + strconv.Itoa(tableID) + ".xml"
+		t.ID = tableID
+		for _, dxfID := range []*int{
+			&t.HeaderRowDxfID, &t.DataDxfID, &t.TotalsRowDxfID,
+			&t.HeaderRowBorderDxfID, &t.TableBorderDxfID, &t.TotalsRowBorderDxfID,
+		} {
+			if *dxfID != 0 {
+				if *dxfID, err = ctx.dxf(f, *dxfID); err != nil {
+					return err
+				}
+			}
+		}
+		if t.TableColumns != nil {
+			for _, col := range t.TableColumns.TableColumn {
+				for _, dxfID := range []*int{&col.HeaderRowDxfID, &col.DataDxfID, &col.TotalsRowDxfID} {
+					if *dxfID != 0 {
+						if *dxfID, err = ctx.dxf(f, *dxfID); err != nil {
+							return err
+						}
+					}
+				}
+			}
+		}
+		table, err := xml.Marshal(t)
+		if err != nil {
+			return err
+		}
+		f.saveFileList(strings.ReplaceAll(sheetRelationshipsTableXML, "..", "xl"), table)
+		rID := f.addRels(sheetRels, SourceRelationshipTable, sheetRelationshipsTableXML, "")
+		if err = f.addSheetTable(ctx.dstSheet, rID); err != nil {
+			return err
+		}
+		f.addSheetNameSpace(ctx.dstSheet, SourceRelationship)
+		if err = f.addContentTypePart(tableID, "table"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // getSheetState returns sheet visible enumeration by given hidden status.
 func getSheetState(visible bool, veryHidden []bool) string {
 	state := "hidden"
@@ -2078,7 +2657,9 @@ func (f *File) relsReader(path string) (*xlsxRelationships, error) {
 
 // fillSheetData ensures there are enough rows, and columns in the chosen
 // row to accept data. Missing rows are backfilled and given their row number
-// Uses the last populated row as a hint for the size of the next row to add
+// Uses the last populated row as a hint for the size of the chosen row, the
+// backfilled rows are added without cells, so a sparse worksheet doesn't
+// allocate the cells of each skipped row by the width of the last row.
 func (ws *xlsxWorksheet) prepareSheetXML(col, row int) {
 	rowCount := len(ws.SheetData.Row)
 	sizeHint := 0
@@ -2093,9 +2674,10 @@ func (ws *xlsxWorksheet) prepareSheetXML(col, row int) {
 	}
 	if rowCount < row {
 		// append missing rows
-		for rowIdx := rowCount; rowIdx < row; rowIdx++ {
-			ws.SheetData.Row = append(ws.SheetData.Row, xlsxRow{R: rowIdx + 1, CustomHeight: customHeight, Ht: ht, C: make([]xlsxC, 0, sizeHint)})
+		for rowIdx := rowCount; rowIdx < row-1; rowIdx++ {
+			ws.SheetData.Row = append(ws.SheetData.Row, xlsxRow{R: rowIdx + 1, CustomHeight: customHeight, Ht: ht})
 		}
+		ws.SheetData.Row = append(ws.SheetData.Row, xlsxRow{R: row, CustomHeight: customHeight, Ht: ht, C: make([]xlsxC, 0, sizeHint)})
 	}
 	rowData := &ws.SheetData.Row[row-1]
 	fillColumns(rowData, col, row)
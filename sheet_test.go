@@ -910,3 +910,221 @@ func TestAddIgnoredErrors(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddIgnoredErrors.xlsx")))
 	assert.NoError(t, f.Close())
 }
+
+func TestCopySheetFrom(t *testing.T) {
+	src := NewFile()
+	assert.NoError(t, src.SetSheetName("Sheet1", "Data"))
+	styleID, err := src.NewStyle(&Style{
+		Font:   &Font{Bold: true, Color: "FF0000"},
+		Fill:   Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}},
+		NumFmt: 4,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, src.SetSheetRow("Data", "A1", &[]interface{}{"Name", "Qty"}))
+	assert.NoError(t, src.SetSheetRow("Data", "A2", &[]interface{}{"Apple", 10}))
+	assert.NoError(t, src.SetSheetRow("Data", "A3", &[]interface{}{"Orange", 20}))
+	assert.NoError(t, src.SetCellStyle("Data", "B2", "B3", styleID))
+	assert.NoError(t, src.SetCellFormula("Data", "C2", "Data!B2*2"))
+	assert.NoError(t, src.SetCellFormula("Data", "C3", "SUM(Table1[Qty])&\"Data!A1\""))
+	assert.NoError(t, src.AddTable("Data", &Table{Range: "A1:B3", Name: "Table1"}))
+	runs := []RichTextRun{{Text: "bold", Font: &Font{Bold: true}}, {Text: " text"}}
+	assert.NoError(t, src.SetCellRichText("Data", "D1", runs))
+	assert.NoError(t, src.MergeCell("Data", "D5", "E6"))
+	dv := NewDataValidation(true)
+	dv.Sqref = "E1"
+	dv.SetSqrefDropList("Data!$A$2:$A$3")
+	assert.NoError(t, src.AddDataValidation("Data", dv))
+	format, err := src.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	assert.NoError(t, src.SetConditionalFormat("Data", "B2:B3", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: &format, Value: "15"},
+	}))
+	assert.NoError(t, src.SetCellHyperLink("Data", "F1", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, src.AddComment("Data", Comment{Cell: "A1", Author: "Excelize", Text: "Note"}))
+	assert.NoError(t, src.AddThreadedComment("Data", ThreadedComment{
+		Cell: "A2", Author: "Excelize", Text: "Thread",
+		Replies: []ThreadedComment{{Author: "Alice", Text: "Reply"}},
+	}))
+	assert.NoError(t, src.AddPicture("Data", "H1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, src.AddChart("Data", "H20", &Chart{
+		Type:   Col,
+		Series: []ChartSeries{{Name: "Data!$B$1", Categories: "Data!$A$2:$A$3", Values: "Data!$B$2:$B$3"}},
+	}))
+
+	f := NewFile()
+	_, err = f.NewStyle(&Style{Font: &Font{Italic: true}})
+	assert.NoError(t, err)
+	_, err = f.NewConditionalStyle(&Style{Font: &Font{Strike: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Existing"))
+	assert.NoError(t, f.SetCellValue("Sheet1", "A2", "Value"))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "A1:A2", Name: "Table1"}))
+	assert.NoError(t, f.CopySheetFrom(src, "Data", "Copy"))
+
+	check := func(f *File) {
+		rows, err := f.GetRows("Copy")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Name", "Qty", "", "bold text"}, rows[0][:4])
+		assert.Equal(t, []string{"Apple", "10.00"}, rows[1][:2])
+		// Check remapped cell style
+		idx, err := f.GetCellStyle("Copy", "B2")
+		assert.NoError(t, err)
+		style, err := f.GetStyle(idx)
+		assert.NoError(t, err)
+		assert.True(t, style.Font.Bold)
+		assert.Equal(t, "FF0000", style.Font.Color)
+		assert.Equal(t, []string{"FFFF00"}, style.Fill.Color)
+		assert.Equal(t, 4, style.NumFmt)
+		// Check updated formulas
+		formula, err := f.GetCellFormula("Copy", "C2")
+		assert.NoError(t, err)
+		assert.Equal(t, "Copy!B2*2", formula)
+		formula, err = f.GetCellFormula("Copy", "C3")
+		assert.NoError(t, err)
+		assert.Equal(t, "SUM(Table1_1[Qty])&\"Data!A1\"", formula)
+		// Check rich text
+		richText, err := f.GetCellRichText("Copy", "D1")
+		assert.NoError(t, err)
+		assert.Len(t, richText, 2)
+		assert.True(t, richText[0].Font.Bold)
+		// Check merged cells, data validations and conditional formats
+		mergeCells, err := f.GetMergeCells("Copy")
+		assert.NoError(t, err)
+		assert.Len(t, mergeCells, 1)
+		assert.Equal(t, "D5", mergeCells[0].GetStartAxis())
+		assert.Equal(t, "E6", mergeCells[0].GetEndAxis())
+		dvs, err := f.GetDataValidations("Copy")
+		assert.NoError(t, err)
+		assert.Len(t, dvs, 1)
+		assert.Equal(t, "Copy!$A$2:$A$3", dvs[0].Formula1)
+		cfs, err := f.GetConditionalFormats("Copy")
+		assert.NoError(t, err)
+		assert.Len(t, cfs["B2:B3"], 1)
+		cfStyle, err := f.GetConditionalStyle(*cfs["B2:B3"][0].Format)
+		assert.NoError(t, err)
+		assert.Equal(t, "9A0511", cfStyle.Font.Color)
+		// Check hyperlinks, tables, comments, pictures and charts
+		link, target, err := f.GetCellHyperLink("Copy", "F1")
+		assert.NoError(t, err)
+		assert.True(t, link)
+		assert.Equal(t, "https://github.com/xuri/excelize", target)
+		tables, err := f.GetTables("Copy")
+		assert.NoError(t, err)
+		assert.Len(t, tables, 1)
+		assert.Equal(t, "Table1_1", tables[0].Name)
+		assert.Equal(t, "A1:B3", tables[0].Range)
+		comments, err := f.GetComments("Copy")
+		assert.NoError(t, err)
+		assert.Len(t, comments, 2)
+		threadedComments, err := f.GetThreadedComments("Copy")
+		assert.NoError(t, err)
+		assert.Len(t, threadedComments, 1)
+		assert.Equal(t, "Thread", threadedComments[0].Text)
+		assert.Len(t, threadedComments[0].Replies, 1)
+		pics, err := f.GetPictures("Copy", "H1")
+		assert.NoError(t, err)
+		assert.Len(t, pics, 1)
+		charts, err := f.GetCharts("Copy")
+		assert.NoError(t, err)
+		assert.Len(t, charts, 1)
+		assert.Equal(t, "Copy!$B$2:$B$3", charts[0].Series[0].Values)
+	}
+	check(f)
+	// Check the source worksheet was not changed
+	formula, err := src.GetCellFormula("Data", "C2")
+	assert.NoError(t, err)
+	assert.Equal(t, "Data!B2*2", formula)
+	tables, err := src.GetTables("Data")
+	assert.NoError(t, err)
+	assert.Equal(t, "Table1", tables[0].Name)
+
+	path := filepath.Join("test", "TestCopySheetFrom.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	check(f)
+	assert.NoError(t, f.Close())
+
+	f = NewFile()
+	// Test copy worksheet with nil source workbook
+	assert.Equal(t, ErrParameterRequired, f.CopySheetFrom(nil, "Data", "Copy"))
+	// Test copy worksheet with exists target worksheet name
+	assert.Equal(t, ErrExistsSheet, f.CopySheetFrom(src, "Data", "Sheet1"))
+	// Test copy worksheet with invalid target worksheet name
+	assert.Equal(t, ErrSheetNameBlank, f.CopySheetFrom(src, "Data", ""))
+	// Test copy worksheet which not exists in the source workbook
+	assert.EqualError(t, f.CopySheetFrom(src, "SheetN", "Copy"), "sheet SheetN does not exist")
+	// Test copy worksheet with unsupported charset shared strings, the styles
+	// should not be added to the target workbook
+	styles, err := f.stylesReader()
+	assert.NoError(t, err)
+	count := len(styles.CellXfs.Xf)
+	assert.NoError(t, src.Close())
+	src = NewFile()
+	assert.NoError(t, src.SetSheetRow("Sheet1", "A1", &[]interface{}{1, "Text"}))
+	styleID, err = src.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, src.SetCellStyle("Sheet1", "A1", "A1", styleID))
+	src.SharedStrings = nil
+	src.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.CopySheetFrom(src, "Sheet1", "Copy"), "XML syntax error on line 1: invalid UTF-8")
+	assert.Equal(t, []string{"Sheet1"}, f.GetSheetList())
+	assert.Len(t, styles.CellXfs.Xf, count)
+	assert.NoError(t, src.Close())
+	// Test copy worksheet with unsupported charset source workbook metadata
+	src = NewFile()
+	src.Pkg.Store(defaultXMLMetadata, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.CopySheetFrom(src, "Sheet1", "Copy"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, src.Close())
+	// Test copy worksheet with unsupported charset source comments, the target
+	// worksheet should be removed
+	src = NewFile()
+	assert.NoError(t, src.AddComment("Sheet1", Comment{Cell: "A1", Author: "Excelize", Text: "Comment"}))
+	src.Comments = make(map[string]*xlsxComments)
+	src.Pkg.Store("xl/comments1.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.CopySheetFrom(src, "Sheet1", "Copy"), "XML syntax error on line 1: invalid UTF-8")
+	assert.Equal(t, []string{"Sheet1"}, f.GetSheetList())
+	assert.NoError(t, src.Close())
+	src = NewFile()
+	assert.NoError(t, f.CopySheetFrom(src, "Sheet1", "Copy"))
+	assert.Equal(t, []string{"Sheet1", "Copy"}, f.GetSheetList())
+	assert.NoError(t, f.DeleteSheet("Copy"))
+	// Test copy worksheet with unsupported charset target style sheet
+	f.Styles = nil
+	f.Pkg.Store(defaultXMLPathStyles, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.CopySheetFrom(src, "Sheet1", "Copy"), "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+	assert.NoError(t, src.Close())
+}
+
+func TestCopySheetFromCellMetadata(t *testing.T) {
+	src := NewFile()
+	formulaType, ref := STCellFormulaTypeArray, "A1"
+	assert.NoError(t, src.SetCellFormula("Sheet1", "A1", "_xlfn.SEQUENCE(2)", FormulaOpts{Type: &formulaType, Ref: &ref, DynamicArray: true}))
+	assert.NoError(t, src.SetCellValue("Sheet1", "B1", "Rich"))
+	// Add the cell metadata which is not the dynamic array properties
+	metadata, err := src.metadataReader()
+	assert.NoError(t, err)
+	metadata.MetadataTypes.MetadataType = append(metadata.MetadataTypes.MetadataType, xlsxMetadataType{Name: "XLRICHVALUE"})
+	metadata.CellMetadata.Bk = append(metadata.CellMetadata.Bk, xlsxMetadataBlock{
+		Rc: []xlsxMetadataRecord{{T: len(metadata.MetadataTypes.MetadataType), V: 0}},
+	})
+	output, err := xml.Marshal(metadata)
+	assert.NoError(t, err)
+	src.Pkg.Store(defaultXMLMetadata, output)
+	ws, err := src.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	ws.SheetData.Row[0].C[1].Cm = uintPtr(uint(len(metadata.CellMetadata.Bk)))
+
+	f := NewFile()
+	assert.NoError(t, f.CopySheetFrom(src, "Sheet1", "Copy"))
+	ws, err = f.workSheetReader("Copy")
+	assert.NoError(t, err)
+	metadata, err = f.metadataReader()
+	assert.NoError(t, err)
+	assert.True(t, metadata.isDynamicArray(ws.SheetData.Row[0].C[0].Cm))
+	assert.Nil(t, ws.SheetData.Row[0].C[1].Cm)
+	assert.NoError(t, f.Close())
+	assert.NoError(t, src.Close())
+}
@@ -0,0 +1,799 @@
+// Copyright 2016 - 2026 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.25.0 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"math/big"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// signer directly maps the settings of a pending digital signature, which will
+// be generated when saving the workbook.
+type signer struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+	opts SignatureOptions
+	path string
+}
+
+// c14nFrame directly maps the namespace context of an element during the XML
+// canonicalization.
+type c14nFrame struct {
+	scope, rendered map[string]string
+}
+
+var (
+	// signatureCanonicalization defined the URI of the inclusive XML
+	// canonicalization algorithm.
+	signatureCanonicalization = "http://www.w3.org/TR/2001/REC-xml-c14n-20010315"
+	// signatureRelationshipTransform defined the URI of the OPC relationship
+	// transform algorithm.
+	signatureRelationshipTransform = "http://schemas.openxmlformats.org/package/2006/RelationshipTransform"
+	// signatureDigestMethods defined the supported digest algorithms.
+	signatureDigestMethods = map[string]crypto.Hash{
+		"http://www.w3.org/2000/09/xmldsig#sha1":        crypto.SHA1,
+		"http://www.w3.org/2001/04/xmlenc#sha256":       crypto.SHA256,
+		"http://www.w3.org/2001/04/xmldsig-more#sha384": crypto.SHA384,
+		"http://www.w3.org/2001/04/xmlenc#sha512":       crypto.SHA512,
+	}
+	// signatureMethods defined the supported signature algorithms.
+	signatureMethods = map[string]crypto.Hash{
+		"http://www.w3.org/2000/09/xmldsig#rsa-sha1":          crypto.SHA1,
+		"http://www.w3.org/2001/04/xmldsig-more#rsa-sha256":   crypto.SHA256,
+		"http://www.w3.org/2001/04/xmldsig-more#rsa-sha384":   crypto.SHA384,
+		"http://www.w3.org/2001/04/xmldsig-more#rsa-sha512":   crypto.SHA512,
+		"http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha1":   crypto.SHA1,
+		"http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha256": crypto.SHA256,
+		"http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha384": crypto.SHA384,
+		"http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha512": crypto.SHA512,
+	}
+	// signatureExcludedRelationships defined the relationship types which
+	// will not be signed in the package.
+	signatureExcludedRelationships = map[string]bool{
+		"http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties":      true,
+		"http://schemas.openxmlformats.org/package/2006/relationships/metadata/thumbnail":            true,
+		SourceRelationshipCustomProperties:                                                           true,
+		SourceRelationshipDigitalSignature:                                                           true,
+		SourceRelationshipDigitalSignatureOrigin:                                                     true,
+		SourceRelationshipExtendProperties:                                                           true,
+		"http://schemas.openxmlformats.org/package/2006/relationships/digital-signature/certificate": true,
+	}
+)
+
+// Sign provides a method to digitally sign the workbook by given X.509
+// certificate, private key and signature options. The signature will be
+// generated when saving the workbook, which contains the digests of the
+// package parts and relationships, and the XAdES-BES signed properties. The
+// RSA and ECDSA private keys are supported, and the private key must match
+// the public key of the certificate. Note that any change of the workbook
+// after saving will invalidate the signature. For example, sign a workbook
+// with certificate and private key:
+//
+//	err := f.Sign(cert, key, &excelize.SignatureOptions{
+//	    Comments: "Approved",
+//	})
+func (f *File) Sign(cert *x509.Certificate, key crypto.Signer, opts *SignatureOptions) error {
+	if cert == nil || key == nil {
+		return ErrParameterRequired
+	}
+	if _, err := signatureMethod(key); err != nil {
+		return err
+	}
+	if pub, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool }); !ok || !pub.Equal(cert.PublicKey) {
+		return ErrParameterInvalid
+	}
+	s := &signer{cert: cert, key: key}
+	if opts != nil {
+		s.opts = *opts
+	}
+	rels, err := f.relsReader(defaultXMLPathRels)
+	if err != nil {
+		return err
+	}
+	origin := "_xmlsignatures/origin.sigs"
+	if target := signatureRelTarget(rels, "", SourceRelationshipDigitalSignatureOrigin); target != "" {
+		origin = target
+	} else {
+		f.addRels(defaultXMLPathRels, SourceRelationshipDigitalSignatureOrigin, origin, "")
+	}
+	if _, ok := f.Pkg.Load(origin); !ok {
+		f.Pkg.Store(origin, []byte{})
+		if err = f.setContentTypes("/"+origin, ContentTypeDigitalSignatureOrigin); err != nil {
+			return err
+		}
+	}
+	for idx := 1; s.path == ""; idx++ {
+		name := path.Join(path.Dir(origin), "sig"+strconv.Itoa(idx)+".xml")
+		if _, ok := f.Pkg.Load(name); ok {
+			continue
+		}
+		s.path = name
+		for _, signer := range f.signers {
+			if signer.path == name {
+				s.path = ""
+			}
+		}
+	}
+	f.addRels(signatureRelsPath(origin), SourceRelationshipDigitalSignature, path.Base(s.path), "")
+	if err = f.setContentTypes("/"+s.path, ContentTypeDigitalSignatureXML); err != nil {
+		return err
+	}
+	f.signers = append(f.signers, s)
+	return err
+}
+
+// VerifySignatures provides a method to get the digital signatures of the
+// workbook and verify them. For each signature, it reports the signer, the
+// comments, the signing time, the signing certificate and whether the
+// signature value and the digests of all signed package parts are valid. Note
+// that this function only verifies the integrity of the signed content, and
+// doesn't validate the trust chain of the certificates. For example, verify
+// signatures of the workbook which opened by OpenFile:
+//
+//	signatures, err := f.VerifySignatures()
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	for _, sig := range signatures {
+//	    fmt.Println(sig.Signer, sig.SigningTime, sig.Valid)
+//	}
+func (f *File) VerifySignatures() ([]Signature, error) {
+	var signatures []Signature
+	rels, err := f.relsReader(defaultXMLPathRels)
+	if err != nil {
+		return signatures, err
+	}
+	origin := signatureRelTarget(rels, "", SourceRelationshipDigitalSignatureOrigin)
+	if origin == "" {
+		return signatures, err
+	}
+	if rels, err = f.relsReader(signatureRelsPath(origin)); err != nil || rels == nil {
+		return signatures, err
+	}
+	for _, rel := range rels.Relationships {
+		if rel.Type != SourceRelationshipDigitalSignature {
+			continue
+		}
+		name := signatureRelTarget(&xlsxRelationships{Relationships: []xlsxRelationship{rel}}, path.Dir(origin), rel.Type)
+		content := f.readXML(name)
+		if len(content) == 0 {
+			continue
+		}
+		sig := new(xlsxSignature)
+		if err = f.xmlNewDecoder(bytes.NewReader(content)).Decode(sig); err != nil && err != io.EOF {
+			return signatures, err
+		}
+		signatures = append(signatures, f.verifySignature(content, sig))
+	}
+	return signatures, nil
+}
+
+// signatureRelsPath returns the relationships part path of the given part.
+func signatureRelsPath(name string) string {
+	return path.Join(path.Dir(name), "_rels", path.Base(name)+".rels")
+}
+
+// signatureRelTarget returns the package part path of the first relationship
+// with the given type, the target of the relationship will be resolved
+// relative to the given base directory.
+func signatureRelTarget(rels *xlsxRelationships, base, relType string) string {
+	if rels == nil {
+		return ""
+	}
+	for _, rel := range rels.Relationships {
+		if rel.Type != relType {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/")
+		}
+		return path.Join(base, rel.Target)
+	}
+	return ""
+}
+
+// signatureMethod returns the URI of the signature algorithm by given
+// private key.
+func signatureMethod(key crypto.Signer) (string, error) {
+	switch key.Public().(type) {
+	case *rsa.PublicKey:
+		return "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256", nil
+	case *ecdsa.PublicKey:
+		return "http://www.w3.org/2001/04/xmldsig-more#ecdsa-sha256", nil
+	}
+	return "", ErrParameterInvalid
+}
+
+// signaturesWriter provides a function to generate the digital signature
+// parts of the workbook, this function should be called after all other
+// package parts were serialized.
+func (f *File) signaturesWriter() error {
+	for _, s := range f.signers {
+		content, err := f.signPackage(s)
+		if err != nil {
+			return err
+		}
+		f.saveFileList(s.path, content)
+	}
+	return nil
+}
+
+// signPackage provides a function to generate the XML digital signature
+// for the package by given signer.
+func (f *File) signPackage(s *signer) ([]byte, error) {
+	method, err := signatureMethod(s.key)
+	if err != nil {
+		return nil, err
+	}
+	signingTime := s.opts.SigningTime
+	if signingTime.IsZero() {
+		signingTime = time.Now()
+	}
+	timeValue := signingTime.UTC().Format("2006-01-02T15:04:05Z")
+	digestMethod := xlsxSignatureAlgorithm{Algorithm: "http://www.w3.org/2001/04/xmlenc#sha256"}
+	manifest, err := f.signatureManifest(digestMethod)
+	if err != nil {
+		return nil, err
+	}
+	certDigest := sha256.Sum256(s.cert.Raw)
+	qualifyingProperties := &xlsxXAdESQualifyingProperties{
+		Target:           "#idPackageSignature",
+		SignedProperties: xlsxXAdESSignedProperties{ID: "idSignedProperties"},
+	}
+	props := &qualifyingProperties.SignedProperties.SignedSignatureProperties
+	props.SigningTime = timeValue
+	cert := xlsxXAdESCert{}
+	cert.CertDigest.DigestMethod = digestMethod
+	cert.CertDigest.DigestValue = base64.StdEncoding.EncodeToString(certDigest[:])
+	cert.IssuerSerial.X509IssuerName = s.cert.Issuer.String()
+	cert.IssuerSerial.X509SerialNumber = s.cert.SerialNumber.String()
+	props.SigningCertificate.Cert = []xlsxXAdESCert{cert}
+	sig := xlsxSignature{
+		ID:      "idPackageSignature",
+		KeyInfo: &xlsxKeyInfo{},
+		Object: []xlsxSignatureObject{
+			{
+				ID:       "idPackageObject",
+				Manifest: manifest,
+				SignatureProperties: &xlsxSignatureProperties{
+					SignatureProperty: []xlsxSignatureProperty{{
+						ID:     "idSignatureTime",
+						Target: "#idPackageSignature",
+						SignatureTime: &xlsxSignatureTime{
+							Format: "YYYY-MM-DDThh:mm:ssTZD",
+							Value:  timeValue,
+						},
+					}},
+				},
+			},
+			{
+				ID: "idOfficeObject",
+				SignatureProperties: &xlsxSignatureProperties{
+					SignatureProperty: []xlsxSignatureProperty{{
+						ID:     "idOfficeV1Details",
+						Target: "#idPackageSignature",
+						SignatureInfoV1: &xlsxSignatureInfoV1{
+							SignatureComments:        s.opts.Comments,
+							WindowsVersion:           "10.0",
+							OfficeVersion:            "16.0",
+							ApplicationVersion:       "16.0",
+							Monitors:                 1,
+							HorizontalResolution:     1920,
+							VerticalResolution:       1080,
+							ColorDepth:               32,
+							SignatureProviderID:      "{00000000-0000-0000-0000-000000000000}",
+							SignatureProviderDetails: 9,
+							SignatureType:            1,
+							ManifestHashAlgorithm:    digestMethod.Algorithm,
+						},
+					}},
+				},
+			},
+			{QualifyingProperties: qualifyingProperties},
+		},
+	}
+	sig.KeyInfo.X509Data.X509Certificate = []string{base64.StdEncoding.EncodeToString(s.cert.Raw)}
+	output, err := xml.Marshal(sig)
+	if err != nil {
+		return nil, err
+	}
+	sig.SignedInfo = &xlsxSignedInfo{
+		CanonicalizationMethod: xlsxSignatureAlgorithm{Algorithm: signatureCanonicalization},
+		SignatureMethod:        xlsxSignatureAlgorithm{Algorithm: method},
+	}
+	for _, ref := range []xlsxSignatureReference{
+		{URI: "#idPackageObject", Type: "http://www.w3.org/2000/09/xmldsig#Object"},
+		{URI: "#idOfficeObject", Type: "http://www.w3.org/2000/09/xmldsig#Object"},
+		{URI: "#idSignedProperties", Type: "http://uri.etsi.org/01903#SignedProperties", Transforms: &xlsxSignatureTransforms{
+			Transform: []xlsxSignatureTransform{{Algorithm: signatureCanonicalization}},
+		}},
+	} {
+		data, err := canonicalizeXML(output, signatureMatchID(strings.TrimPrefix(ref.URI, "#")))
+		if err != nil {
+			return nil, err
+		}
+		digest := sha256.Sum256(data)
+		ref.DigestMethod, ref.DigestValue = digestMethod, base64.StdEncoding.EncodeToString(digest[:])
+		sig.SignedInfo.Reference = append(sig.SignedInfo.Reference, ref)
+	}
+	if output, err = xml.Marshal(sig); err != nil {
+		return nil, err
+	}
+	signedInfo, err := canonicalizeXML(output, signatureMatchSignedInfo)
+	if err != nil {
+		return nil, err
+	}
+	value, err := signatureValue(s.key, signedInfo)
+	if err != nil {
+		return nil, err
+	}
+	sig.SignatureValue = base64.StdEncoding.EncodeToString(value)
+	return xml.Marshal(sig)
+}
+
+// signatureManifest provides a function to generate the manifest of the
+// digital signature, which contains the digests of the package parts and
+// relationships to be signed.
+func (f *File) signatureManifest(digestMethod xlsxSignatureAlgorithm) (*xlsxSignatureManifest, error) {
+	contentTypes, err := f.contentTypesReader()
+	if err != nil {
+		return nil, err
+	}
+	manifest := &xlsxSignatureManifest{}
+	for _, name := range f.signatureParts() {
+		ref := xlsxSignatureReference{
+			URI:          "/" + name + "?ContentType=" + signatureContentType(contentTypes, name),
+			DigestMethod: digestMethod,
+		}
+		h := sha256.New()
+		if strings.HasSuffix(name, ".rels") {
+			rels, err := f.signatureRelsReader(name)
+			if err != nil {
+				return nil, err
+			}
+			transform := xlsxSignatureTransform{Algorithm: signatureRelationshipTransform}
+			for _, rel := range rels.Relationships {
+				if !signatureExcludedRelationships[rel.Type] {
+					transform.RelationshipReference = append(transform.RelationshipReference, xlsxRelationshipReference{SourceID: rel.ID})
+				}
+			}
+			if len(transform.RelationshipReference) == 0 {
+				continue
+			}
+			ref.Transforms = &xlsxSignatureTransforms{Transform: []xlsxSignatureTransform{
+				transform, {Algorithm: signatureCanonicalization},
+			}}
+			_, _ = h.Write(signatureRelationshipsTransform(rels, transform))
+		} else if !f.signaturePartDigest(name, h) {
+			continue
+		}
+		ref.DigestValue = base64.StdEncoding.EncodeToString(h.Sum(nil))
+		manifest.Reference = append(manifest.Reference, ref)
+	}
+	return manifest, nil
+}
+
+// signatureParts returns the sorted package part paths to be signed, the
+// content types, core properties, extended properties, custom properties and
+// digital signature parts will be excluded.
+func (f *File) signatureParts() []string {
+	var parts []string
+	existing := map[string]bool{}
+	add := func(name string) {
+		if existing[name] || name == defaultXMLPathContentTypes ||
+			strings.HasPrefix(name, "_xmlsignatures/") || strings.HasPrefix(name, "docProps/") {
+			return
+		}
+		existing[name] = true
+		parts = append(parts, name)
+	}
+	for name := range f.streams {
+		add(name)
+	}
+	f.Pkg.Range(func(name, content interface{}) bool {
+		add(name.(string))
+		return true
+	})
+	f.tempFiles.Range(func(name, content interface{}) bool {
+		add(name.(string))
+		return true
+	})
+	sort.Strings(parts)
+	return parts
+}
+
+// signatureContentType returns the content type of the given package part.
+func signatureContentType(contentTypes *xlsxTypes, name string) string {
+	for _, override := range contentTypes.Overrides {
+		if strings.TrimPrefix(override.PartName, "/") == name {
+			return override.ContentType
+		}
+	}
+	ext := strings.TrimPrefix(path.Ext(name), ".")
+	for _, def := range contentTypes.Defaults {
+		if strings.EqualFold(def.Extension, ext) {
+			return def.ContentType
+		}
+	}
+	return ""
+}
+
+// signaturePartDigest provides a function to write the content of the given
+// package part into the hash, it returns false if the part doesn't exist.
+func (f *File) signaturePartDigest(name string, h io.Writer) bool {
+	if stream, ok := f.streams[name]; ok {
+		from, err := stream.rawData.Reader()
+		if err != nil {
+			return false
+		}
+		_, err = io.Copy(h, from)
+		return err == nil
+	}
+	if content, ok := f.Pkg.Load(name); ok {
+		_, _ = h.Write(content.([]byte))
+		return true
+	}
+	file, err := f.readTemp(name)
+	if file == nil || err != nil {
+		return false
+	}
+	defer file.Close()
+	_, err = io.Copy(h, file)
+	return err == nil
+}
+
+// signatureRelsReader provides a function to get the relationships of the
+// package by given relationships part path from the serialized content.
+func (f *File) signatureRelsReader(name string) (*xlsxRelationships, error) {
+	rels := new(xlsxRelationships)
+	if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(f.readXML(name)))).
+		Decode(rels); err != nil && err != io.EOF {
+		return rels, err
+	}
+	return rels, nil
+}
+
+// signatureRelationshipsTransform returns the canonical output of the
+// relationship transform, which contains the relationships selected by the
+// relationship identifiers or types in the transform, sorted by identifier.
+func signatureRelationshipsTransform(rels *xlsxRelationships, transform xlsxSignatureTransform) []byte {
+	IDs, types := map[string]bool{}, map[string]bool{}
+	for _, ref := range transform.RelationshipReference {
+		IDs[ref.SourceID] = true
+	}
+	for _, ref := range transform.RelationshipsGroupReference {
+		types[ref.SourceType] = true
+	}
+	var selected []xlsxRelationship
+	for _, rel := range rels.Relationships {
+		if IDs[rel.ID] || types[rel.Type] {
+			selected = append(selected, rel)
+		}
+	}
+	sort.SliceStable(selected, func(i, j int) bool { return selected[i].ID < selected[j].ID })
+	var buf bytes.Buffer
+	buf.WriteString(`<Relationships xmlns="` + NameSpaceRelationships + `">`)
+	for _, rel := range selected {
+		targetMode := rel.TargetMode
+		if targetMode == "" {
+			targetMode = "Internal"
+		}
+		buf.WriteString(`<Relationship Id="` + c14nEscapeAttr(rel.ID) + `" Target="` + c14nEscapeAttr(rel.Target) +
+			`" TargetMode="` + c14nEscapeAttr(targetMode) + `" Type="` + c14nEscapeAttr(rel.Type) + `"></Relationship>`)
+	}
+	buf.WriteString("</Relationships>")
+	return buf.Bytes()
+}
+
+// signatureValue provides a function to sign the canonicalized SignedInfo
+// element by given private key, the ECDSA signature will be encoded as the
+// concatenation of the R and S values.
+func signatureValue(key crypto.Signer, data []byte) ([]byte, error) {
+	digest := sha256.Sum256(data)
+	value, err := key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	if pub, ok := key.Public().(*ecdsa.PublicKey); ok {
+		var sig struct{ R, S *big.Int }
+		if _, err = asn1.Unmarshal(value, &sig); err != nil {
+			return nil, err
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		value = make([]byte, size*2)
+		sig.R.FillBytes(value[:size])
+		sig.S.FillBytes(value[size:])
+	}
+	return value, nil
+}
+
+// verifySignature provides a function to verify the digital signature by
+// given signature part content and the deserialized signature.
+func (f *File) verifySignature(content []byte, sig *xlsxSignature) Signature {
+	var signature Signature
+	for _, obj := range sig.Object {
+		if obj.SignatureProperties != nil {
+			for _, prop := range obj.SignatureProperties.SignatureProperty {
+				if prop.SignatureTime != nil {
+					signature.SigningTime, _ = time.Parse(time.RFC3339, strings.TrimSpace(prop.SignatureTime.Value))
+				}
+				if prop.SignatureInfoV1 != nil {
+					signature.Comments = prop.SignatureInfoV1.SignatureComments
+				}
+			}
+		}
+		if obj.QualifyingProperties != nil && signature.SigningTime.IsZero() {
+			signature.SigningTime, _ = time.Parse(time.RFC3339,
+				strings.TrimSpace(obj.QualifyingProperties.SignedProperties.SignedSignatureProperties.SigningTime))
+		}
+	}
+	if sig.KeyInfo == nil || len(sig.KeyInfo.X509Data.X509Certificate) == 0 {
+		return signature
+	}
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(sig.KeyInfo.X509Data.X509Certificate[0]), ""))
+	if err != nil {
+		return signature
+	}
+	if signature.Certificate, err = x509.ParseCertificate(der); err != nil {
+		return signature
+	}
+	signature.Signer = signature.Certificate.Subject.CommonName
+	if sig.SignedInfo == nil || sig.SignedInfo.CanonicalizationMethod.Algorithm != signatureCanonicalization {
+		return signature
+	}
+	for _, ref := range sig.SignedInfo.Reference {
+		if !strings.HasPrefix(ref.URI, "#") {
+			return signature
+		}
+		data, err := canonicalizeXML(content, signatureMatchID(strings.TrimPrefix(ref.URI, "#")))
+		if err != nil || len(data) == 0 || !signatureVerifyDigest(ref, data) {
+			return signature
+		}
+	}
+	for _, obj := range sig.Object {
+		if obj.Manifest == nil {
+			continue
+		}
+		for _, ref := range obj.Manifest.Reference {
+			if !f.verifyManifestReference(ref) {
+				return signature
+			}
+		}
+	}
+	signedInfo, err := canonicalizeXML(content, signatureMatchSignedInfo)
+	if err != nil {
+		return signature
+	}
+	signature.Valid = signatureVerifyValue(signature.Certificate, sig.SignedInfo.SignatureMethod.Algorithm, signedInfo, sig.SignatureValue)
+	return signature
+}
+
+// verifyManifestReference provides a function to verify the digest of the
+// package part or relationships in the manifest of the digital signature.
+func (f *File) verifyManifestReference(ref xlsxSignatureReference) bool {
+	name, _, _ := strings.Cut(ref.URI, "?")
+	name, err := url.PathUnescape(strings.TrimPrefix(name, "/"))
+	if err != nil {
+		return false
+	}
+	if ref.Transforms != nil {
+		for _, transform := range ref.Transforms.Transform {
+			if transform.Algorithm == signatureRelationshipTransform {
+				rels, err := f.signatureRelsReader(name)
+				if err != nil {
+					return false
+				}
+				return signatureVerifyDigest(ref, signatureRelationshipsTransform(rels, transform))
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if !f.signaturePartDigest(name, &buf) {
+		return false
+	}
+	return signatureVerifyDigest(ref, buf.Bytes())
+}
+
+// signatureVerifyDigest returns whether the digest value in the reference
+// matches the digest of the given data.
+func signatureVerifyDigest(ref xlsxSignatureReference, data []byte) bool {
+	hash, ok := signatureDigestMethods[ref.DigestMethod.Algorithm]
+	if !ok {
+		return false
+	}
+	expected, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(ref.DigestValue), ""))
+	if err != nil {
+		return false
+	}
+	h := hash.New()
+	_, _ = h.Write(data)
+	return bytes.Equal(h.Sum(nil), expected)
+}
+
+// signatureVerifyValue returns whether the signature value was signed by the
+// private key of the given certificate.
+func signatureVerifyValue(cert *x509.Certificate, method string, data []byte, signatureValue string) bool {
+	hash, ok := signatureMethods[method]
+	if !ok {
+		return false
+	}
+	value, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(signatureValue), ""))
+	if err != nil {
+		return false
+	}
+	h := hash.New()
+	_, _ = h.Write(data)
+	digest := h.Sum(nil)
+	switch pub := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return strings.Contains(method, "#rsa-") && rsa.VerifyPKCS1v15(pub, hash, digest, value) == nil
+	case *ecdsa.PublicKey:
+		size := len(value) / 2
+		return strings.Contains(method, "#ecdsa-") && size > 0 && len(value)%2 == 0 &&
+			ecdsa.Verify(pub, digest, new(big.Int).SetBytes(value[:size]), new(big.Int).SetBytes(value[size:]))
+	}
+	return false
+}
+
+// signatureMatchID returns a function to match the element by the given
+// value of the Id attribute.
+func signatureMatchID(ID string) func(space, local string, attrs []xml.Attr) bool {
+	return func(space, local string, attrs []xml.Attr) bool {
+		for _, attr := range attrs {
+			if attr.Name.Space == "" && attr.Name.Local == "Id" && attr.Value == ID {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// signatureMatchSignedInfo provides a function to match the SignedInfo
+// element of the digital signature.
+func signatureMatchSignedInfo(space, local string, attrs []xml.Attr) bool {
+	return space == NameSpaceXMLDigitalSignature && local == "SignedInfo"
+}
+
+// canonicalizeXML provides a function to serialize the first element matched
+// by the given function and its descendants with the inclusive XML
+// canonicalization without comments.
+func canonicalizeXML(content []byte, match func(space, local string, attrs []xml.Attr) bool) ([]byte, error) {
+	var (
+		buf   bytes.Buffer
+		depth int
+		stack = []c14nFrame{{scope: map[string]string{"xml": NameSpaceXML}}}
+		dec   = xml.NewDecoder(bytes.NewReader(content))
+	)
+	for {
+		token, err := dec.RawToken()
+		if err == io.EOF {
+			return buf.Bytes(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			parent := stack[len(stack)-1]
+			frame := c14nFrame{scope: map[string]string{}, rendered: map[string]string{}}
+			for prefix, uri := range parent.scope {
+				frame.scope[prefix] = uri
+			}
+			var attrs []xml.Attr
+			for _, attr := range t.Attr {
+				if attr.Name.Space == "xmlns" {
+					frame.scope[attr.Name.Local] = attr.Value
+					continue
+				}
+				if attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+					frame.scope[""] = attr.Value
+					continue
+				}
+				attrs = append(attrs, attr)
+			}
+			if depth == 0 && !match(frame.scope[t.Name.Space], t.Name.Local, t.Attr) {
+				stack = append(stack, frame)
+				continue
+			}
+			for prefix, uri := range parent.rendered {
+				frame.rendered[prefix] = uri
+			}
+			var prefixes []string
+			for prefix, uri := range frame.scope {
+				if prefix == "xml" || frame.rendered[prefix] == uri {
+					continue
+				}
+				frame.rendered[prefix] = uri
+				prefixes = append(prefixes, prefix)
+			}
+			sort.Strings(prefixes)
+			sort.SliceStable(attrs, func(i, j int) bool {
+				si, sj := frame.scope[attrs[i].Name.Space], frame.scope[attrs[j].Name.Space]
+				if attrs[i].Name.Space == "" {
+					si = ""
+				}
+				if attrs[j].Name.Space == "" {
+					sj = ""
+				}
+				if si != sj {
+					return si < sj
+				}
+				return attrs[i].Name.Local < attrs[j].Name.Local
+			})
+			buf.WriteString("<" + c14nName(t.Name))
+			for _, prefix := range prefixes {
+				if prefix == "" {
+					buf.WriteString(` xmlns="` + c14nEscapeAttr(frame.scope[prefix]) + `"`)
+					continue
+				}
+				buf.WriteString(` xmlns:` + prefix + `="` + c14nEscapeAttr(frame.scope[prefix]) + `"`)
+			}
+			for _, attr := range attrs {
+				buf.WriteString(" " + c14nName(attr.Name) + `="` + c14nEscapeAttr(attr.Value) + `"`)
+			}
+			buf.WriteString(">")
+			stack = append(stack, frame)
+			depth++
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+			if depth > 0 {
+				buf.WriteString("</" + c14nName(t.Name) + ">")
+				if depth--; depth == 0 {
+					return buf.Bytes(), nil
+				}
+			}
+		case xml.CharData:
+			if depth > 0 {
+				buf.WriteString(c14nEscapeText(string(t)))
+			}
+		}
+	}
+}
+
+// c14nName returns the qualified name of the element or attribute.
+func c14nName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// c14nEscapeText returns the escaped text node by the XML canonicalization
+// rules.
+func c14nEscapeText(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;").Replace(s)
+}
+
+// c14nEscapeAttr returns the escaped attribute value by the XML
+// canonicalization rules.
+func c14nEscapeAttr(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;", "\t", "&#x9;", "\n", "&#xA;", "\r", "&#xD;").Replace(s)
+}
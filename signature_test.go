@@ -0,0 +1,146 @@
+// Copyright 2016 - 2026 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.25.0 or later.
+
+package excelize
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	rsaCert, ecdsaCert := createSelfSignedCert(t, "RSA Signer", rsaKey), createSelfSignedCert(t, "ECDSA Signer", ecdsaKey)
+	signingTime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	f := NewFile()
+	assert.NoError(t, f.SetCellValue("Sheet1", "A1", "Signed"))
+	assert.NoError(t, f.SetCellHyperLink("Sheet1", "A1", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, f.Sign(rsaCert, rsaKey, &SignatureOptions{Comments: "Approved", SigningTime: signingTime}))
+	assert.NoError(t, f.Sign(ecdsaCert, ecdsaKey, nil))
+	signatures, err := f.VerifySignatures()
+	assert.NoError(t, err)
+	assert.Empty(t, signatures)
+	path := filepath.Join("test", "TestSign.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	signatures, err = f.VerifySignatures()
+	assert.NoError(t, err)
+	assert.Len(t, signatures, 2)
+	assert.Equal(t, "RSA Signer", signatures[0].Signer)
+	assert.Equal(t, "Approved", signatures[0].Comments)
+	assert.Equal(t, signingTime, signatures[0].SigningTime)
+	assert.Equal(t, rsaCert.Raw, signatures[0].Certificate.Raw)
+	assert.True(t, signatures[0].Valid)
+	assert.Equal(t, "ECDSA Signer", signatures[1].Signer)
+	assert.False(t, signatures[1].SigningTime.IsZero())
+	assert.True(t, signatures[1].Valid)
+	// Test verify signatures with tampered package part
+	content, ok := f.Pkg.Load("xl/sharedStrings.xml")
+	assert.True(t, ok)
+	f.Pkg.Store("xl/sharedStrings.xml", []byte(strings.Replace(string(content.([]byte)), "Signed", "Modified", 1)))
+	signatures, err = f.VerifySignatures()
+	assert.NoError(t, err)
+	assert.False(t, signatures[0].Valid)
+	assert.False(t, signatures[1].Valid)
+	f.Pkg.Store("xl/sharedStrings.xml", content)
+	// Test verify signatures with tampered relationships
+	rels := f.readXML("xl/worksheets/_rels/sheet1.xml.rels")
+	f.Pkg.Store("xl/worksheets/_rels/sheet1.xml.rels", []byte(strings.Replace(string(rels), "https://github.com/xuri/excelize", "https://example.com", 1)))
+	signatures, err = f.VerifySignatures()
+	assert.NoError(t, err)
+	assert.False(t, signatures[0].Valid)
+	f.Pkg.Store("xl/worksheets/_rels/sheet1.xml.rels", rels)
+	// Test verify signatures with tampered signed properties
+	sig := f.readXML("_xmlsignatures/sig1.xml")
+	f.Pkg.Store("_xmlsignatures/sig1.xml", []byte(strings.Replace(string(sig), "Approved", "Rejected", 1)))
+	signatures, err = f.VerifySignatures()
+	assert.NoError(t, err)
+	assert.Equal(t, "Rejected", signatures[0].Comments)
+	assert.False(t, signatures[0].Valid)
+	assert.True(t, signatures[1].Valid)
+	f.Pkg.Store("_xmlsignatures/sig1.xml", sig)
+	// Test sign the signed workbook again
+	assert.NoError(t, f.Sign(rsaCert, rsaKey, nil))
+	assert.Equal(t, "_xmlsignatures/sig3.xml", f.signers[0].path)
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	signatures, err = f.VerifySignatures()
+	assert.NoError(t, err)
+	assert.Len(t, signatures, 3)
+	assert.True(t, signatures[2].Valid)
+	// Test verify signatures with unsupported charset
+	f.Pkg.Store("_xmlsignatures/sig1.xml", MacintoshCyrillicCharset)
+	_, err = f.VerifySignatures()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+
+	// Test sign workbook with invalid parameters
+	f = NewFile()
+	assert.Equal(t, ErrParameterRequired, f.Sign(nil, rsaKey, nil))
+	assert.Equal(t, ErrParameterRequired, f.Sign(rsaCert, nil, nil))
+	assert.Equal(t, ErrParameterInvalid, f.Sign(rsaCert, ecdsaKey, nil))
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrParameterInvalid, f.Sign(rsaCert, ed25519Key, nil))
+	// Test sign workbook with unsupported charset relationships
+	f.Relationships.Delete(defaultXMLPathRels)
+	f.Pkg.Store(defaultXMLPathRels, MacintoshCyrillicCharset)
+	assert.EqualError(t, f.Sign(rsaCert, rsaKey, nil), "XML syntax error on line 1: invalid UTF-8")
+	_, err = f.VerifySignatures()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestCanonicalizeXML(t *testing.T) {
+	content := []byte(`<?xml version="1.0"?><a:root xmlns:a="urn:a" xmlns="urn:d"><!-- comment --><b xmlns:a="urn:a" c="2" a:b="1" Id="x"><c xmlns="" d="&quot;&#9;"/>&lt;&amp;&gt;</b></a:root>`)
+	data, err := canonicalizeXML(content, signatureMatchID("x"))
+	assert.NoError(t, err)
+	assert.Equal(t, `<b xmlns="urn:d" xmlns:a="urn:a" Id="x" c="2" a:b="1"><c xmlns="" d="&quot;&#x9;"></c>&lt;&amp;&gt;</b>`, string(data))
+	_, err = canonicalizeXML([]byte(`<a><</a>`), signatureMatchSignedInfo)
+	assert.EqualError(t, err, "XML syntax error on line 1: expected element name after <")
+}
+
+func createSelfSignedCert(t *testing.T, name string, key crypto.Signer) *x509.Certificate {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return cert
+}
@@ -29,12 +29,42 @@ type StreamWriter struct {
 	Sheet           string
 	SheetID         int
 	sheetWritten    bool
+	flushed         bool
 	worksheet       *xlsxWorksheet
 	rawData         bufferedWriter
 	rows            int
 	mergeCellsCount int
 	mergeCells      strings.Builder
-	tableParts      string
+	tableParts      []string
+	sheetData       []byte
+	sharedStrings   int
+	sharedLimit     int
+}
+
+// StreamOptions directly maps the settings of the stream writer.
+//
+// Append specifies if keep the existing rows and other elements of the
+// worksheet, such as merged cells, drawings, conditional formats and data
+// validations, the new rows will be streamed after the last existing row.
+//
+// SharedStrings specifies if write the string cell values into the shared
+// string table instead of inline strings, the same string values will be
+// stored only once in the workbook, that can reduce the file size when there
+// are lots of repeated strings in the worksheet. The new string items of the
+// shared string table will be stored in the system temporary file instead of
+// memory until they are needed by other functions or saving the workbook.
+//
+// SharedStringsLimit specifies the maximum number of the unique strings which
+// the stream writer will index for the deduplication. The string values which
+// not exist in the shared string table will be still written into the shared
+// string table after exceeding this limit, but not be indexed to keep the
+// memory usage bounded, so each of them will be stored as a new string item
+// even if the same string value has been written. The default value is 65536,
+// which works only when SharedStrings is true.
+type StreamOptions struct {
+	Append             bool
+	SharedStrings      bool
+	SharedStringsLimit int
 }
 
 // NewStreamWriter returns stream writer struct by given worksheet name used for
@@ -45,8 +75,10 @@ type StreamWriter struct {
 // mode functions and stream mode functions can not be work mixed to writing
 // data on the worksheets. The stream writer will try to use temporary files on
 // disk to reduce the memory usage when in-memory chunks data over 16MB, and
-// you can't get cell value at this time. For example, set data for worksheet
-// of size 102400 rows x 50 columns with numbers and style:
+// you can't get cell value at this time. By default, the existing rows of the
+// worksheet will be discarded, set the Append field of the stream options to
+// keep the existing rows and elements of the worksheet. For example, set data
+// for worksheet of size 102400 rows x 50 columns with numbers and style:
 //
 //	f := excelize.NewFile()
 //	defer func() {
@@ -111,7 +143,18 @@ type StreamWriter struct {
 //	err := sw.SetRow("A1", []interface{}{
 //	    excelize.Cell{Value: 1}},
 //	    excelize.RowOpts{StyleID: styleID, Height: 20, Hidden: false});
-func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
+//
+// Append rows after the existing rows of the worksheet with stream writer,
+// note that the row number of the new rows must be greater than the last
+// existing row:
+//
+//	sw, err := f.NewStreamWriter("Sheet1", excelize.StreamOptions{Append: true})
+//
+// Write the string cell values into the shared string table with stream
+// writer, the repeated strings will be stored only once:
+//
+//	sw, err := f.NewStreamWriter("Sheet1", excelize.StreamOptions{SharedStrings: true})
+func (f *File) NewStreamWriter(sheet string, opts ...StreamOptions) (*StreamWriter, error) {
 	if err := checkSheetName(sheet); err != nil {
 		return nil, err
 	}
@@ -125,23 +168,149 @@ func (f *File) NewStreamWriter(sheet string) (*StreamWriter, error) {
 		SheetID: sheetID,
 		rawData: bufferedWriter{tmpDir: f.options.TmpDir},
 	}
-	var err error
-	sw.worksheet, err = f.workSheetReader(sheet)
-	if err != nil {
+	var (
+		err     error
+		attrs   string
+		options StreamOptions
+	)
+	for _, opt := range opts {
+		options = opt
+	}
+	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+	if options.Append {
+		if attrs, err = sw.readSheet(sheetXMLPath); err != nil {
+			return nil, err
+		}
+	} else if sw.worksheet, err = f.workSheetReader(sheet); err != nil {
 		return nil, err
 	}
+	if options.SharedStrings {
+		if sw.sharedLimit = options.SharedStringsLimit; sw.sharedLimit <= 0 {
+			sw.sharedLimit = defaultSharedStringsLimit
+		}
+		if err = f.sharedStringsLoader(); err != nil {
+			return nil, err
+		}
+		if _, err = f.sharedStringsReader(); err != nil {
+			return nil, err
+		}
+	}
 
-	sheetXMLPath, _ := f.getSheetXMLPath(sheet)
 	if f.streams == nil {
 		f.streams = make(map[string]*StreamWriter)
 	}
 	f.streams[sheetXMLPath] = sw
 
-	_, _ = sw.rawData.WriteString(xml.Header + `<worksheet` + templateNamespaceIDMap)
+	_, _ = sw.rawData.WriteString(xml.Header + `<worksheet` + attrs + templateNamespaceIDMap)
 	bulkAppendFields(&sw.rawData, sw.worksheet, 3, 4)
 	return sw, err
 }
 
+// readSheet provides a function to read the existing worksheet for appending
+// rows by the stream writer. The rows in the sheetData element will be kept
+// as raw XML without deserialization, and the other elements of the worksheet
+// will be deserialized. This function returns the namespace declarations of
+// the worksheet which not exist in the stream writer.
+func (sw *StreamWriter) readSheet(sheetXMLPath string) (string, error) {
+	var (
+		f          = sw.file
+		data       []byte
+		attrs      strings.Builder
+		start, end int
+		depth      int
+	)
+	for _, sheetType := range []string{"xl/chartsheets", "xl/dialogsheet", "xl/macrosheet"} {
+		if strings.HasPrefix(sheetXMLPath, sheetType) {
+			return "", newNotWorksheetError(sw.Sheet)
+		}
+	}
+	if ws, ok := f.Sheet.Load(sheetXMLPath); ok && ws != nil {
+		sheet := ws.(*xlsxWorksheet)
+		f.prepareWorkSheet(sheetXMLPath, sheet)
+		output, _ := xml.Marshal(sheet)
+		data = replaceRelationshipsBytes(f.replaceNameSpaceBytes(sheetXMLPath, output))
+	} else {
+		data = namespaceStrictToTransitional(f.readBytes(sheetXMLPath))
+	}
+	dec := f.xmlNewDecoder(bytes.NewReader(data))
+	for end == 0 {
+		offset := dec.InputOffset()
+		token, err := dec.Token()
+		if err == io.EOF {
+			end = max(start, int(offset))
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			depth++
+			if depth == 1 {
+				for _, attr := range element.Attr {
+					if attr.Name.Space == "xmlns" && !strings.Contains(templateNamespaceIDMap, ` xmlns:`+attr.Name.Local+`="`) {
+						attrs.WriteString(` xmlns:` + attr.Name.Local + `="` + attr.Value + `"`)
+					}
+				}
+			}
+			if depth == 2 && element.Name.Local == "sheetData" {
+				start = int(dec.InputOffset())
+			}
+			if depth == 3 && element.Name.Local == "row" {
+				sw.rows++
+				for _, attr := range element.Attr {
+					if attr.Name.Local == "r" {
+						if sw.rows, err = strconv.Atoi(attr.Value); err != nil {
+							return "", err
+						}
+					}
+				}
+				if err = dec.Skip(); err != nil {
+					return "", err
+				}
+				depth--
+			}
+		case xml.EndElement:
+			if depth == 2 && element.Name.Local == "sheetData" {
+				end = int(offset)
+			}
+			depth--
+		}
+	}
+	sw.sheetData = data[start:end]
+	sw.worksheet = new(xlsxWorksheet)
+	if err := f.xmlNewDecoder(bytes.NewReader(append(append([]byte{}, data[:start]...), data[end:]...))).
+		Decode(sw.worksheet); err != nil && err != io.EOF {
+		return "", err
+	}
+	sw.worksheet.Dimension = nil
+	if sw.worksheet.DecodeAlternateContent != nil {
+		sw.worksheet.AlternateContent = &xlsxAlternateContent{
+			Content: sw.worksheet.DecodeAlternateContent.Content,
+			XMLNSMC: SourceRelationshipCompatibility.Value,
+		}
+		sw.worksheet.DecodeAlternateContent = nil
+	}
+	f.Sheet.Store(sheetXMLPath, sw.worksheet)
+	f.checked.Store(sheetXMLPath, true)
+	if sw.worksheet.MergeCells != nil {
+		for _, mergeCell := range sw.worksheet.MergeCells.Cells {
+			sw.mergeCellsCount++
+			_, _ = sw.mergeCells.WriteString(`<mergeCell ref="`)
+			_, _ = sw.mergeCells.WriteString(mergeCell.Ref)
+			_, _ = sw.mergeCells.WriteString(`"/>`)
+		}
+		sw.worksheet.MergeCells = nil
+	}
+	if sw.worksheet.TableParts != nil {
+		for _, tablePart := range sw.worksheet.TableParts.TableParts {
+			sw.tableParts = append(sw.tableParts, tablePart.RID)
+		}
+		sw.worksheet.TableParts = nil
+	}
+	return attrs.String(), nil
+}
+
 // AddTable creates an Excel table for the StreamWriter using the given
 // cell range and format set. For example, create a table of A1:D5:
 //
@@ -239,13 +408,14 @@ func (sw *StreamWriter) AddTable(table *Table) error {
 	sheetRels := "xl/worksheets/_rels/" + strings.TrimPrefix(sheetPath, "xl/worksheets/") + ".rels"
 	rID := sw.file.addRels(sheetRels, SourceRelationshipTable, sheetRelationshipsTableXML, "")
 
-	sw.tableParts = fmt.Sprintf(`<tableParts count="1"><tablePart r:id="rId%d"></tablePart></tableParts>`, rID)
+	sw.tableParts = append(sw.tableParts, "rId"+strconv.Itoa(rID))
 
 	if err = sw.file.addContentTypePart(tableID, "table"); err != nil {
 		return err
 	}
 	b, _ := xml.Marshal(tbl)
 	sw.file.saveFileList(tableXML, b)
+	sw.file.tableRefs.Store(nil)
 	return err
 }
 
@@ -276,6 +446,7 @@ func (sw *StreamWriter) getRowValues(hRow, hCol, vCol int) (res []string, err er
 		if err := dec.DecodeElement(&row, &startElement); err != nil {
 			return nil, err
 		}
+		var sst *xlsxSST
 		for _, c := range row.C {
 			col, _, err := CellNameToCoordinates(c.R)
 			if err != nil {
@@ -284,7 +455,12 @@ func (sw *StreamWriter) getRowValues(hRow, hCol, vCol int) (res []string, err er
 			if col < hCol || col > vCol {
 				continue
 			}
-			res[col-hCol], _ = c.getValueFrom(sw.file, nil, false)
+			if c.T == "s" && sst == nil {
+				if sst, err = sw.file.sharedStringsReader(); err != nil {
+					return nil, err
+				}
+			}
+			res[col-hCol], _ = c.getValueFrom(sw.file, sst, false)
 		}
 		return res, nil
 	}
@@ -565,6 +741,112 @@ func (sw *StreamWriter) MergeCell(topLeftCell, bottomRightCell string) error {
 	return nil
 }
 
+// SetCellHyperLink provides a function to set cell hyperlink by given cell
+// reference and link URL for the StreamWriter. The hyperlinks will be written
+// when calling the 'Flush' function, so you must call the 'SetCellHyperLink'
+// function before the 'Flush' function. For example, add an external
+// hyperlink to cell A1:
+//
+//	err := sw.SetCellHyperLink("A1", "https://github.com/xuri/excelize", "External")
+//
+// See File.SetCellHyperLink for details on the link type and options.
+func (sw *StreamWriter) SetCellHyperLink(cell, link, linkType string, opts ...HyperlinkOpts) error {
+	if sw.flushed {
+		return newStreamFlushOrderError("SetCellHyperLink")
+	}
+	return sw.file.SetCellHyperLink(sw.Sheet, cell, link, linkType, opts...)
+}
+
+// AddComment provides a function to add comments in a cell for the
+// StreamWriter. Note that you must call the 'AddComment' function before the
+// 'Flush' function. For example, add a comment in cell A1:
+//
+//	err := sw.AddComment(excelize.Comment{
+//	    Cell:   "A1",
+//	    Author: "Excelize",
+//	    Paragraph: []excelize.RichTextRun{
+//	        {Text: "Excelize: ", Font: &excelize.Font{Bold: true}},
+//	        {Text: "This is a comment."},
+//	    },
+//	})
+//
+// See File.AddComment for details on the comment options.
+func (sw *StreamWriter) AddComment(opts Comment) error {
+	if sw.flushed {
+		return newStreamFlushOrderError("AddComment")
+	}
+	return sw.file.AddComment(sw.Sheet, opts)
+}
+
+// AddDataValidation provides a function to set data validation on a range of
+// the worksheet for the StreamWriter. Note that you must call the
+// 'AddDataValidation' function before the 'Flush' function. For example, add
+// a drop-down list on the range B2:B1000:
+//
+//	dv := excelize.NewDataValidation(true)
+//	dv.Sqref = "B2:B1000"
+//	err := dv.SetDropList([]string{"1", "2", "3"})
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = sw.AddDataValidation(dv)
+//
+// See File.AddDataValidation for details on the data validation.
+func (sw *StreamWriter) AddDataValidation(dv *DataValidation) error {
+	if sw.flushed {
+		return newStreamFlushOrderError("AddDataValidation")
+	}
+	return sw.file.AddDataValidation(sw.Sheet, dv)
+}
+
+// SetConditionalFormat provides a function to create conditional formatting
+// rule for cell value for the StreamWriter. Note that you must call the
+// 'SetConditionalFormat' function before the 'Flush' function. For example,
+// highlight the cells in the range C2:C1000 which values greater than 6:
+//
+//	format, err := f.NewConditionalStyle(
+//	    &excelize.Style{
+//	        Font: &excelize.Font{Color: "9A0511"},
+//	        Fill: excelize.Fill{
+//	            Type: "pattern", Color: []string{"FEC7CE"}, Pattern: 1,
+//	        },
+//	    },
+//	)
+//	if err != nil {
+//	    fmt.Println(err)
+//	    return
+//	}
+//	err = sw.SetConditionalFormat("C2:C1000",
+//	    []excelize.ConditionalFormatOptions{
+//	        {Type: "cell", Criteria: ">", Format: &format, Value: "6"},
+//	    },
+//	)
+//
+// See File.SetConditionalFormat for details on the conditional format options.
+func (sw *StreamWriter) SetConditionalFormat(rangeRef string, opts []ConditionalFormatOptions) error {
+	if sw.flushed {
+		return newStreamFlushOrderError("SetConditionalFormat")
+	}
+	return sw.file.SetConditionalFormat(sw.Sheet, rangeRef, opts)
+}
+
+// AddPicture provides a function to add picture in a cell by given cell
+// reference and file path for the StreamWriter. Note that the picture will be
+// positioned based on the column widths and the default row height, and you
+// must call the 'AddPicture' function before the 'Flush' function. For
+// example, insert a picture in cell E2:
+//
+//	err := sw.AddPicture("E2", "image.png", nil)
+//
+// See File.AddPicture for details on the graphic options.
+func (sw *StreamWriter) AddPicture(cell, name string, opts *GraphicOptions) error {
+	if sw.flushed {
+		return newStreamFlushOrderError("AddPicture")
+	}
+	return sw.file.AddPicture(sw.Sheet, cell, name, opts)
+}
+
 // setCellFormula provides a function to set formula of a cell.
 func setCellFormula(c *xlsxC, formula string) {
 	if formula != "" {
@@ -611,9 +893,9 @@ func (sw *StreamWriter) setCellValFunc(c *xlsxC, val interface{}) error {
 	case float64:
 		c.setCellFloat(val, -1, 64)
 	case string:
-		c.setCellValue(val)
+		err = sw.setCellStr(c, val)
 	case []byte:
-		c.setCellValue(string(val))
+		err = sw.setCellStr(c, string(val))
 	case time.Duration:
 		err = sw.setCellDuration(c, val)
 	case time.Time:
@@ -626,8 +908,28 @@ func (sw *StreamWriter) setCellValFunc(c *xlsxC, val interface{}) error {
 		c.T, c.IS = "inlineStr", &xlsxSI{}
 		c.IS.R, err = setRichText(val)
 	default:
-		c.setCellValue(fmt.Sprint(val))
+		err = sw.setCellStr(c, fmt.Sprint(val))
+	}
+	return err
+}
+
+// setCellStr provides a function to set string type value of a cell. The
+// value will be written into the shared string table if the shared strings
+// mode of the stream writer has been enabled, otherwise the value will be
+// written as an inline string. The new value in the shared string table will
+// be indexed for the deduplication only if the number of the unique strings
+// indexed by the stream writer doesn't exceed the limit.
+func (sw *StreamWriter) setCellStr(c *xlsxC, val string) error {
+	if sw.sharedLimit == 0 || c.F != nil {
+		c.setCellValue(val)
+		return nil
+	}
+	key, _ := trimCellValue(val, false)
+	idx, indexed, err := sw.file.setSharedStringItem(key, sw.sharedStrings < sw.sharedLimit)
+	if indexed {
+		sw.sharedStrings++
 	}
+	c.T, c.V = "s", strconv.Itoa(idx)
 	return err
 }
 
@@ -751,7 +1053,8 @@ func (sw *StreamWriter) writeSheetData() {
 			_, _ = sw.rawData.WriteString("</cols>")
 		}
 		_, _ = sw.rawData.WriteString(`<sheetData>`)
-		sw.sheetWritten = true
+		_, _ = sw.rawData.Write(sw.sheetData)
+		sw.sheetData, sw.sheetWritten = nil, true
 	}
 }
 
@@ -769,9 +1072,19 @@ func (sw *StreamWriter) Flush() error {
 		_, _ = mergeCells.WriteString(`</mergeCells>`)
 	}
 	_, _ = sw.rawData.WriteString(mergeCells.String())
-	bulkAppendFields(&sw.rawData, sw.worksheet, 18, 39)
-	_, _ = sw.rawData.WriteString(sw.tableParts)
-	bulkAppendFields(&sw.rawData, sw.worksheet, 41, 41)
+	bulkAppendFields(&sw.rawData, sw.worksheet, 18, 40)
+	if len(sw.tableParts) > 0 {
+		_, _ = sw.rawData.WriteString(`<tableParts count="`)
+		_, _ = sw.rawData.WriteString(strconv.Itoa(len(sw.tableParts)))
+		_, _ = sw.rawData.WriteString(`">`)
+		for _, rID := range sw.tableParts {
+			_, _ = sw.rawData.WriteString(`<tablePart r:id="`)
+			_, _ = sw.rawData.WriteString(rID)
+			_, _ = sw.rawData.WriteString(`"></tablePart>`)
+		}
+		_, _ = sw.rawData.WriteString(`</tableParts>`)
+	}
+	bulkAppendFields(&sw.rawData, sw.worksheet, 42, 42)
 	_, _ = sw.rawData.WriteString(`</worksheet>`)
 	if err := sw.rawData.Flush(); err != nil {
 		return err
@@ -781,18 +1094,21 @@ func (sw *StreamWriter) Flush() error {
 	sw.file.Sheet.Delete(sheetPath)
 	sw.file.checked.Delete(sheetPath)
 	sw.file.Pkg.Delete(sheetPath)
-
+	sw.flushed = true
 	return nil
 }
 
 // bulkAppendFields bulk-appends fields in a worksheet by specified field
-// names order range.
+// names order range. The element name is taken from the XML tag of the field,
+// since some field types such as the extension list and the inner XML don't
+// carry their own element names.
 func bulkAppendFields(w io.Writer, ws *xlsxWorksheet, from, to int) {
 	s := reflect.ValueOf(ws).Elem()
 	enc := xml.NewEncoder(w)
 	for i := 0; i < s.NumField(); i++ {
 		if from <= i && i <= to {
-			_ = enc.Encode(s.Field(i).Interface())
+			name, _, _ := strings.Cut(s.Type().Field(i).Tag.Get("xml"), ",")
+			_ = enc.EncodeElement(s.Field(i).Interface(), xml.StartElement{Name: xml.Name{Local: name}})
 		}
 	}
 }
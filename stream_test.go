@@ -330,6 +330,316 @@ func TestStreamInsertPageBreak(t *testing.T) {
 	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestStreamInsertPageBreak.xlsx")))
 }
 
+func TestStreamWriterAppend(t *testing.T) {
+	f := NewFile()
+	styleID, err := f.NewStyle(&Style{Font: &Font{Bold: true}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Value"}))
+	assert.NoError(t, f.SetCellStyle("Sheet1", "A1", "B1", styleID))
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A2", &[]interface{}{"Total", 10}))
+	assert.NoError(t, f.MergeCell("Sheet1", "C1", "D2"))
+	dv := NewDataValidation(true)
+	dv.Sqref = "B3:B100"
+	dv.SetSqrefDropList("$E$1:$E$3")
+	assert.NoError(t, f.AddDataValidation("Sheet1", dv))
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "B3:B100", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: &format, Value: "6"},
+	}))
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "C3:C100", []ConditionalFormatOptions{
+		{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6", BarSolid: true},
+	}))
+	assert.NoError(t, f.AddPicture("Sheet1", "F1", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, f.AddTable("Sheet1", &Table{Range: "H1:I2"}))
+	path := filepath.Join("test", "TestStreamWriterAppend.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	check := func(f *File) {
+		rows, err := f.GetRows("Sheet1")
+		assert.NoError(t, err)
+		assert.Equal(t, [][]string{{"Name", "Value", "", "", "", "", "", "Column1", "Column2"}, {"Total", "10"}, {"A", "1"}, {"B", "2"}}, rows)
+		style, err := f.GetCellStyle("Sheet1", "B1")
+		assert.NoError(t, err)
+		assert.Equal(t, styleID, style)
+		mergeCells, err := f.GetMergeCells("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, mergeCells, 2)
+		dvs, err := f.GetDataValidations("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, dvs, 1)
+		formats, err := f.GetConditionalFormats("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, formats["B3:B100"], 1)
+		assert.Len(t, formats["C3:C100"], 1)
+		assert.True(t, formats["C3:C100"][0].BarSolid)
+		pics, err := f.GetPictures("Sheet1", "F1")
+		assert.NoError(t, err)
+		assert.Len(t, pics, 1)
+		tables, err := f.GetTables("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, tables, 2)
+		link, target, err := f.GetCellHyperLink("Sheet1", "A3")
+		assert.NoError(t, err)
+		assert.True(t, link)
+		assert.Equal(t, "Sheet1!A1", target)
+	}
+	// Test append rows on the worksheet which not been loaded
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	sw, err := f.NewStreamWriter("Sheet1", StreamOptions{Append: true})
+	assert.NoError(t, err)
+	assert.Equal(t, newStreamSetRowError(2), sw.SetRow("A2", []interface{}{"A"}))
+	assert.NoError(t, sw.SetRow("A3", []interface{}{"A", 1}))
+	assert.NoError(t, sw.MergeCell("C3", "D4"))
+	assert.NoError(t, sw.SetCellHyperLink("A3", "Sheet1!A1", "Location"))
+	assert.NoError(t, sw.SetRow("A4", []interface{}{"B", 2}))
+	assert.NoError(t, sw.AddTable(&Table{Range: "A2:B4"}))
+	assert.NoError(t, sw.Flush())
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	check(f)
+	assert.NoError(t, f.Close())
+
+	// Test append rows on the worksheet which has been loaded
+	f = NewFile()
+	assert.NoError(t, f.SetSheetRow("Sheet1", "A1", &[]interface{}{"Name", "Value"}))
+	sw, err = f.NewStreamWriter("Sheet1", StreamOptions{Append: true})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A2", []interface{}{"A", 1}))
+	assert.NoError(t, sw.Flush())
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"Name", "Value"}, {"A", "1"}}, rows)
+	// Test append rows on the empty worksheet
+	_, err = f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	f.Sheet.Delete("xl/worksheets/sheet2.xml")
+	f.Pkg.Store("xl/worksheets/sheet2.xml", []byte{})
+	sw, err = f.NewStreamWriter("Sheet2", StreamOptions{Append: true})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A1", []interface{}{"A"}))
+	assert.NoError(t, sw.Flush())
+	// Test append rows on the worksheet with prefixed namespace and rows without row number
+	_, err = f.NewSheet("Sheet3")
+	assert.NoError(t, err)
+	f.Sheet.Delete("xl/worksheets/sheet3.xml")
+	f.Pkg.Store("xl/worksheets/sheet3.xml", []byte(`<x:worksheet xmlns:x="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:y="urn:y"><x:sheetData><x:row><x:c t="inlineStr"><x:is><x:t>A</x:t></x:is></x:c></x:row><x:row/></x:sheetData></x:worksheet>`))
+	sw, err = f.NewStreamWriter("Sheet3", StreamOptions{Append: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sw.rows)
+	assert.NoError(t, sw.SetRow("A3", []interface{}{"B"}))
+	assert.NoError(t, sw.Flush())
+	assert.Contains(t, string(f.readBytes("xl/worksheets/sheet3.xml")), ` xmlns:y="urn:y" xmlns=`)
+	rows, err = f.GetRows("Sheet3")
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"A"}, nil, {"B"}}, rows)
+	// Test append rows on the worksheet with alternate content
+	_, err = f.NewSheet("Sheet4")
+	assert.NoError(t, err)
+	f.Sheet.Delete("xl/worksheets/sheet4.xml")
+	alternateContent := `<mc:AlternateContent xmlns:mc="` + SourceRelationshipCompatibility.Value + `"><mc:Choice Requires="x14"><controls><control shapeId="1025" r:id="rId1" name="Button 1"/></controls></mc:Choice></mc:AlternateContent>`
+	f.Pkg.Store("xl/worksheets/sheet4.xml", []byte(`<worksheet xmlns="`+NameSpaceSpreadSheet.Value+`" xmlns:r="`+SourceRelationship.Value+`"><sheetData><row r="1"><c r="A1" t="inlineStr"><is><t>A</t></is></c></row></sheetData>`+alternateContent+`<extLst/></worksheet>`))
+	for r := 2; r <= 3; r++ {
+		sw, err = f.NewStreamWriter("Sheet4", StreamOptions{Append: true})
+		assert.NoError(t, err)
+		cell, err := CoordinatesToCellName(1, r)
+		assert.NoError(t, err)
+		assert.NoError(t, sw.SetRow(cell, []interface{}{r}))
+		assert.NoError(t, sw.Flush())
+		content := string(f.readBytes("xl/worksheets/sheet4.xml"))
+		assert.Contains(t, content, "</sheetData>"+alternateContent+"<extLst></extLst></worksheet>")
+		// Test append rows on the worksheet which has been loaded
+		rows, err = f.GetRows("Sheet4")
+		assert.NoError(t, err)
+		assert.Len(t, rows, r)
+	}
+	// Test append rows on the worksheet with invalid row number
+	f.Pkg.Store("xl/worksheets/sheet2.xml", []byte(`<worksheet><sheetData><row r="A"/></sheetData></worksheet>`))
+	_, err = f.NewStreamWriter("Sheet2", StreamOptions{Append: true})
+	assert.EqualError(t, err, `strconv.Atoi: parsing "A": invalid syntax`)
+	// Test append rows on the worksheet with invalid XML
+	f.Pkg.Store("xl/worksheets/sheet2.xml", []byte(`<worksheet><sheetData><row>`))
+	_, err = f.NewStreamWriter("Sheet2", StreamOptions{Append: true})
+	assert.EqualError(t, err, "XML syntax error on line 1: unexpected EOF")
+	// Test append rows on the worksheet with unsupported charset
+	f.Pkg.Store("xl/worksheets/sheet2.xml", MacintoshCyrillicCharset)
+	_, err = f.NewStreamWriter("Sheet2", StreamOptions{Append: true})
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	// Test append rows on the chart sheet
+	assert.NoError(t, f.AddChartSheet("Chart1", &Chart{Type: Line, Series: []ChartSeries{{Values: "Sheet1!$B$1:$B$2"}}}))
+	_, err = f.NewStreamWriter("Chart1", StreamOptions{Append: true})
+	assert.EqualError(t, err, "sheet Chart1 is not a worksheet")
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamWriterSidecars(t *testing.T) {
+	f := NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetColWidth(1, 3, 20))
+	for r := 1; r <= 10; r++ {
+		assert.NoError(t, sw.SetRow(fmt.Sprintf("A%d", r), []interface{}{"Link", r, r * 2}))
+	}
+	assert.NoError(t, sw.SetCellHyperLink("A1", "https://github.com/xuri/excelize", "External"))
+	assert.NoError(t, sw.SetCellHyperLink("A2", "Sheet1!C10", "Location"))
+	assert.NoError(t, sw.AddComment(Comment{Cell: "B1", Author: "Excelize", Text: "Comment"}))
+	dv := NewDataValidation(true)
+	dv.Sqref = "B2:B10"
+	assert.NoError(t, dv.SetDropList([]string{"1", "2", "3"}))
+	assert.NoError(t, sw.AddDataValidation(dv))
+	format, err := f.NewConditionalStyle(&Style{Font: &Font{Color: "9A0511"}})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetConditionalFormat("C1:C10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: &format, Value: "6"},
+	}))
+	assert.NoError(t, sw.SetConditionalFormat("D1:D10", []ConditionalFormatOptions{
+		{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6", BarSolid: true},
+	}))
+	assert.NoError(t, sw.AddPicture("E2", filepath.Join("test", "images", "excel.png"), nil))
+	assert.NoError(t, sw.Flush())
+	content := string(f.readBytes("xl/worksheets/sheet1.xml"))
+	var idx int
+	for _, element := range []string{"<sheetData>", "<conditionalFormatting", "<dataValidations", "<hyperlinks", "<drawing", "<legacyDrawing", "<x14:conditionalFormattings>", "<x14:dataBar"} {
+		assert.Greater(t, strings.Index(content, element), idx, element)
+		idx = strings.Index(content, element)
+	}
+	assert.True(t, strings.HasSuffix(content, "</ext></extLst></worksheet>"))
+	// Test add the hyperlinks, comments, data validations, conditional formats
+	// and pictures after the stream writer flushed
+	assert.EqualError(t, sw.SetCellHyperLink("A3", "Sheet1!A1", "Location"), "must call the SetCellHyperLink function before the Flush function")
+	assert.EqualError(t, sw.AddComment(Comment{Cell: "B2", Author: "Excelize", Text: "Comment"}), "must call the AddComment function before the Flush function")
+	assert.EqualError(t, sw.AddDataValidation(dv), "must call the AddDataValidation function before the Flush function")
+	assert.EqualError(t, sw.SetConditionalFormat("C1:C10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: ">", Format: &format, Value: "6"},
+	}), "must call the SetConditionalFormat function before the Flush function")
+	assert.EqualError(t, sw.AddPicture("E5", filepath.Join("test", "images", "excel.png"), nil), "must call the AddPicture function before the Flush function")
+	_, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.False(t, ok)
+	path := filepath.Join("test", "TestStreamWriterSidecars.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 10)
+	link, target, err := f.GetCellHyperLink("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "https://github.com/xuri/excelize", target)
+	link, target, err = f.GetCellHyperLink("Sheet1", "A2")
+	assert.NoError(t, err)
+	assert.True(t, link)
+	assert.Equal(t, "Sheet1!C10", target)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 1)
+	assert.Equal(t, "B1", comments[0].Cell)
+	dvs, err := f.GetDataValidations("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, dvs, 1)
+	assert.Equal(t, "B2:B10", dvs[0].Sqref)
+	formats, err := f.GetConditionalFormats("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, formats["C1:C10"], 1)
+	assert.Len(t, formats["D1:D10"], 1)
+	assert.True(t, formats["D1:D10"][0].BarSolid)
+	pics, err := f.GetPictures("Sheet1", "E2")
+	assert.NoError(t, err)
+	assert.Len(t, pics, 1)
+	assert.NoError(t, f.Close())
+}
+
+func TestStreamWriterSharedStrings(t *testing.T) {
+	f := NewFile()
+	_, err := f.NewSheet("Sheet2")
+	assert.NoError(t, err)
+	assert.NoError(t, f.SetCellValue("Sheet2", "A1", "Category 1"))
+	sw, err := f.NewStreamWriter("Sheet1", StreamOptions{SharedStrings: true, SharedStringsLimit: 3})
+	assert.NoError(t, err)
+	for rowID := 1; rowID <= 100; rowID++ {
+		cell, err := CoordinatesToCellName(1, rowID)
+		assert.NoError(t, err)
+		assert.NoError(t, sw.SetRow(cell, []interface{}{
+			fmt.Sprintf("Category %d", rowID%4), []byte("Bytes"), rowID,
+		}))
+		if rowID == 48 {
+			// Test the unique strings over the limit will be written into
+			// the shared string table without deduplication, and the new
+			// string items are stored in the temporary file
+			assert.Len(t, f.SharedStrings.SI, 1)
+			assert.Len(t, f.sharedStringItem, 16)
+			rows, err := f.GetRows("Sheet2")
+			assert.NoError(t, err)
+			assert.Equal(t, [][]string{{"Category 1"}}, rows)
+			// Test add the string into the shared string table while
+			// streaming, the spilled string items will be loaded
+			assert.NoError(t, f.SetCellValue("Sheet2", "A2", "Category 4"))
+			assert.Len(t, f.SharedStrings.SI, 17)
+			assert.Nil(t, f.sharedStringTemp)
+		}
+	}
+	assert.NoError(t, sw.SetRow("A101", []interface{}{
+		"Unique", Cell{Formula: "\"A\"", Value: "A"}, struct{}{},
+	}))
+	assert.NoError(t, sw.Flush())
+	assert.Len(t, f.SharedStrings.SI, 17)
+	assert.Len(t, f.sharedStringItem, 19)
+	for _, cell := range []string{`<c r="A2" t="s"><v>2</v></c>`, `<c r="A4" t="s"><v>4</v></c>`, `<c r="A8" t="s"><v>5</v></c>`,
+		`<c r="A48" t="s"><v>15</v></c>`, `<c r="A52" t="s"><v>4</v></c>`, `<c r="A101" t="s"><v>17</v></c>`} {
+		assert.Contains(t, string(f.readBytes("xl/worksheets/sheet1.xml")), cell)
+	}
+	rows, err := f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 101)
+	assert.Equal(t, []string{"Unique", "A", "{}"}, rows[100])
+	path := filepath.Join("test", "TestStreamWriterSharedStrings.xlsx")
+	assert.NoError(t, f.SaveAs(path))
+	assert.Len(t, f.SharedStrings.SI, 19)
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(path)
+	assert.NoError(t, err)
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, rows, 101)
+	assert.Equal(t, []string{"Category 1", "Bytes", "1"}, rows[0])
+	assert.Equal(t, []string{"Category 0", "Bytes", "4"}, rows[3])
+	assert.Equal(t, []string{"Category 0", "Bytes", "52"}, rows[51])
+	assert.Equal(t, []string{"Unique", "A", "{}"}, rows[100])
+	// Test append rows with shared strings on the existing worksheet
+	sw, err = f.NewStreamWriter("Sheet1", StreamOptions{Append: true, SharedStrings: true})
+	assert.NoError(t, err)
+	assert.NoError(t, sw.SetRow("A102", []interface{}{"Category 2", "Category 0"}))
+	assert.NoError(t, sw.Flush())
+	assert.Len(t, f.SharedStrings.SI, 19)
+	assert.Nil(t, f.sharedStringTemp)
+	rows, err = f.GetRows("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Category 2", "Category 0"}, rows[101])
+	assert.NoError(t, f.Close())
+
+	// Test write shared strings with the invalid temporary directory
+	f = NewFile(Options{TmpDir: filepath.Join("test", "TmpDirNotExist")})
+	sw, err = f.NewStreamWriter("Sheet1", StreamOptions{SharedStrings: true})
+	assert.NoError(t, err)
+	assert.Error(t, sw.SetRow("A1", []interface{}{"A"}))
+	assert.NoError(t, f.Close())
+
+	// Test create stream writer with unsupported charset shared strings table
+	f = NewFile()
+	f.SharedStrings = nil
+	f.Pkg.Store(defaultXMLPathSharedStrings, MacintoshCyrillicCharset)
+	_, err = f.NewStreamWriter("Sheet1", StreamOptions{SharedStrings: true})
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
 func TestNewStreamWriter(t *testing.T) {
 	// Test error exceptions
 	f := NewFile()
@@ -21,6 +21,8 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/xuri/efp"
 )
 
 // stylesReader provides a function to get the pointer to the structure after
@@ -3305,6 +3307,566 @@ func (f *File) GetConditionalFormats(sheet string) (map[string][]ConditionalForm
 	return conditionalFormats, err
 }
 
+// condFmtRule directly maps a conditional formatting rule and the cell ranges
+// which the rule applies to.
+type condFmtRule struct {
+	rule   *xlsxCfRule
+	x14    *decodeX14CfRule
+	ranges [][]int
+}
+
+// condFmtValues directly maps the sorted numeric values and the
+// case-insensitive occurrence counts of the values of the non-empty cells
+// within the ranges of the conditional formatting rules.
+type condFmtValues struct {
+	numbers []float64
+	counts  map[string]int
+}
+
+// GetCellEffectiveStyle provides a function to get the effective style of the
+// cell by given worksheet name and cell reference. This function evaluates
+// the conditional formatting rules which applied to the cell against the
+// current cell values, and merges the styles of the matched rules over the
+// cell style. The fill color of the color scale rules will be interpolated by
+// the cell value. The Length of the DataBar in the result specifies the
+// percentage of the cell width the data bar takes up, and the Index of the
+// Icon specifies the index of the icon in the icon set. The supported rule
+// types are:
+//
+//	cellIs
+//	timePeriod
+//	containsText
+//	notContainsText
+//	beginsWith
+//	endsWith
+//	top10
+//	aboveAverage
+//	duplicateValues
+//	uniqueValues
+//	containsBlanks
+//	notContainsBlanks
+//	containsErrors
+//	notContainsErrors
+//	expression
+//	colorScale
+//	dataBar
+//	iconSet
+//
+// For example, get the effective style of the cell B7 on Sheet1:
+//
+//	style, err := f.GetCellEffectiveStyle("Sheet1", "B7")
+func (f *File) GetCellEffectiveStyle(sheet, cell string) (*CellEffectiveStyle, error) {
+	if _, _, err := CellNameToCoordinates(cell); err != nil {
+		return nil, err
+	}
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	return f.getCellEffectiveStyle(sheet, cell, styleID)
+}
+
+// getCellEffectiveStyle provides a function to get the effective style of the
+// cell by given worksheet name, cell reference and the style ID of the cell.
+func (f *File) getCellEffectiveStyle(sheet, cell string, styleID int) (*CellEffectiveStyle, error) {
+	col, row, err := CellNameToCoordinates(cell)
+	if err != nil {
+		return nil, err
+	}
+	style, err := f.GetStyle(styleID)
+	if err != nil {
+		return nil, err
+	}
+	effective := &CellEffectiveStyle{Style: style}
+	ws, err := f.workSheetReader(sheet)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := f.getCondFmtRules(ws, []int{col, row})
+	if err != nil {
+		return nil, err
+	}
+	ctx := &calcContext{
+		entry:             sheet + "!" + cell,
+		maxCalcIterations: f.options.MaxCalcIterations,
+		iterations:        make(map[string]uint),
+		iterationsCache:   make(map[string]formulaArg),
+	}
+	value, err := f.cellResolver(ctx, sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	var matched []*condFmtRule
+	for _, rule := range rules {
+		ok, err := f.evalCondFmtRule(ctx, ws, sheet, []int{col, row}, value, rule, effective)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, rule)
+			if rule.rule.StopIfTrue {
+				break
+			}
+		}
+	}
+	for i := len(matched) - 1; i >= 0; i-- {
+		if matched[i].rule.DxfID == nil {
+			continue
+		}
+		dxf, err := f.GetConditionalStyle(*matched[i].rule.DxfID)
+		if err != nil {
+			return nil, err
+		}
+		mergeCondFmtStyle(effective.Style, dxf)
+	}
+	return effective, nil
+}
+
+// getCondFmtRules provides a function to get the conditional formatting rules
+// which applied to the cell by given worksheet and cell coordinates, and sort
+// the rules by the priority.
+func (f *File) getCondFmtRules(ws *xlsxWorksheet, cell []int) ([]*condFmtRule, error) {
+	var rules []*condFmtRule
+	for _, cf := range ws.ConditionalFormatting {
+		if ranges := parseCondFmtRanges(cf.SQRef); inCondFmtRanges(cell, ranges) {
+			for _, rule := range cf.CfRule {
+				rules = append(rules, &condFmtRule{rule: rule, ranges: ranges})
+			}
+		}
+	}
+	condFmts, err := f.getX14CondFmts(ws)
+	if err != nil {
+		return rules, err
+	}
+	for _, rule := range rules {
+		if rule.rule.ExtLst == nil {
+			continue
+		}
+		ext := decodeX14ConditionalFormattingExt{}
+		if err = xml.Unmarshal([]byte(rule.rule.ExtLst.Ext), &ext); err != nil {
+			continue
+		}
+		for _, condFmt := range condFmts {
+			for _, x14 := range condFmt.CfRule {
+				if x14.ID == ext.ID {
+					rule.x14 = x14
+				}
+			}
+		}
+	}
+	for _, condFmt := range condFmts {
+		ranges := parseCondFmtRanges(condFmt.Sqref)
+		if !inCondFmtRanges(cell, ranges) {
+			continue
+		}
+		for _, x14 := range condFmt.CfRule {
+			if x14.Type != "iconSet" || x14.IconSet == nil {
+				continue
+			}
+			rule := &xlsxCfRule{Type: x14.Type, Priority: x14.Priority, StopIfTrue: x14.StopIfTrue, IconSet: &xlsxIconSet{
+				IconSet: x14.IconSet.IconSet, ShowValue: x14.IconSet.ShowValue, Reverse: x14.IconSet.Reverse,
+			}}
+			for _, cfvo := range x14.IconSet.Cfvo {
+				rule.IconSet.Cfvo = append(rule.IconSet.Cfvo, &xlsxCfvo{Type: cfvo.Type, Val: cfvo.F, Gte: cfvo.Gte == nil || *cfvo.Gte})
+			}
+			rules = append(rules, &condFmtRule{rule: rule, x14: x14, ranges: ranges})
+		}
+	}
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].rule.Priority < rules[j].rule.Priority })
+	return rules, err
+}
+
+// getX14CondFmts provides a function to get the conditional formats in the
+// extension list of the worksheet.
+func (f *File) getX14CondFmts(ws *xlsxWorksheet) ([]decodeX14ConditionalFormatting, error) {
+	var condFmts []decodeX14ConditionalFormatting
+	if ws.ExtLst == nil {
+		return condFmts, nil
+	}
+	decodeExtLst := new(decodeExtLst)
+	if err := f.xmlNewDecoder(strings.NewReader("<extLst>" + ws.ExtLst.Ext + "</extLst>")).
+		Decode(decodeExtLst); err != nil && err != io.EOF {
+		return condFmts, err
+	}
+	for _, ext := range decodeExtLst.Ext {
+		if ext.URI == ExtURIConditionalFormattings {
+			decodeCondFmts := new(decodeX14ConditionalFormattingRules)
+			_ = f.xmlNewDecoder(strings.NewReader(ext.Content)).Decode(decodeCondFmts)
+			condFmts = append(condFmts, decodeCondFmts.CondFmt...)
+		}
+	}
+	return condFmts, nil
+}
+
+// parseCondFmtRanges provides a function to convert the space-separated cell
+// ranges of conditional formatting into coordinates.
+func parseCondFmtRanges(sqref string) [][]int {
+	var ranges [][]int
+	for _, ref := range strings.Fields(sqref) {
+		if !strings.Contains(ref, ":") {
+			ref += ":" + ref
+		}
+		if coordinates, err := rangeRefToCoordinates(ref); err == nil {
+			_ = sortCoordinates(coordinates)
+			ranges = append(ranges, coordinates)
+		}
+	}
+	return ranges
+}
+
+// inCondFmtRanges returns whether the cell is within the given ranges.
+func inCondFmtRanges(cell []int, ranges [][]int) bool {
+	for _, ref := range ranges {
+		if cellInRange(cell, ref) {
+			return true
+		}
+	}
+	return false
+}
+
+// getCondFmtValues provides a function to get the values of the non-empty
+// cells within the ranges of the conditional formatting rule. The result
+// will be cached by the worksheet name and the ranges until the calculation
+// cache has been cleared.
+func (f *File) getCondFmtValues(ctx *calcContext, ws *xlsxWorksheet, sheet string, rule *condFmtRule) (*condFmtValues, error) {
+	key := sheet + "!" + fmt.Sprint(rule.ranges)
+	if cached, ok := f.condFmtCache.Load(key); ok {
+		return cached.(*condFmtValues), nil
+	}
+	var cells []string
+	for _, r := range ws.SheetData.Row {
+		for _, c := range r.C {
+			col, row, err := CellNameToCoordinates(c.R)
+			if err == nil && inCondFmtRanges([]int{col, row}, rule.ranges) {
+				cells = append(cells, c.R)
+			}
+		}
+	}
+	result := &condFmtValues{counts: make(map[string]int)}
+	for _, cell := range cells {
+		value, err := f.cellResolver(ctx, sheet, cell)
+		if err != nil {
+			return result, err
+		}
+		if value.Type != ArgEmpty && value.Value() != "" {
+			result.counts[strings.ToLower(value.Value())]++
+			if value.Type == ArgNumber && !value.Boolean {
+				result.numbers = append(result.numbers, value.Number)
+			}
+		}
+	}
+	sort.Float64s(result.numbers)
+	f.condFmtCache.Store(key, result)
+	return result, nil
+}
+
+// getCondFmtNumbers provides a function to get the sorted numeric values of
+// the cells within the ranges of the conditional formatting rule.
+func (f *File) getCondFmtNumbers(ctx *calcContext, ws *xlsxWorksheet, sheet string, rule *condFmtRule) ([]float64, error) {
+	result, err := f.getCondFmtValues(ctx, ws, sheet, rule)
+	return result.numbers, err
+}
+
+// evalCondFmtFormula provides a function to evaluate the formula of the
+// conditional formatting rule for the given cell, the relative references in
+// the formula will be shifted by the offset between the cell and the top-left
+// cell of the ranges.
+func (f *File) evalCondFmtFormula(ctx *calcContext, sheet string, cell []int, rule *condFmtRule, formula string) formulaArg {
+	dCol, dRow := cell[0]-rule.ranges[0][0], cell[1]-rule.ranges[0][1]
+	cellName, _ := CoordinatesToCellName(cell[0], cell[1])
+	tokens := f.parseFormulaTokens(sheet, cellName, strings.TrimPrefix(formula, "="))
+	for i, token := range tokens {
+		if token.TType == efp.TokenTypeOperand && token.TSubType == efp.TokenSubTypeRange {
+			if idx := strings.LastIndex(token.TValue, "!"); idx != -1 {
+				tokens[i].TValue = token.TValue[:idx+1] + shiftCell(token.TValue[idx+1:], dCol, dRow)
+				continue
+			}
+			tokens[i].TValue = shiftCell(token.TValue, dCol, dRow)
+		}
+	}
+	result, err := f.evalInfixExp(ctx, sheet, cellName, tokens)
+	if err != nil {
+		return newErrorFormulaArg(formulaErrorVALUE, err.Error())
+	}
+	if list := result.ToList(); result.Type == ArgMatrix && len(list) > 0 {
+		return list[0]
+	}
+	return result
+}
+
+// evalCondFmtRule provides a function to evaluate the conditional formatting
+// rule for the given cell, and set the color scale fill, data bar or icon of
+// the effective style.
+func (f *File) evalCondFmtRule(ctx *calcContext, ws *xlsxWorksheet, sheet string, cell []int, value formulaArg, rule *condFmtRule, effective *CellEffectiveStyle) (bool, error) {
+	isTrue := func(arg formulaArg) bool {
+		return arg.Type == ArgNumber && arg.Number != 0
+	}
+	isNumber := value.Type == ArgNumber && !value.Boolean
+	c := rule.rule
+	switch c.Type {
+	case "cellIs":
+		if len(c.Formula) == 0 {
+			return false, nil
+		}
+		ref, _ := CoordinatesToCellName(rule.ranges[0][0], rule.ranges[0][1])
+		formula := map[string]string{
+			"lessThan":           "%[1]s<(%[2]s)",
+			"lessThanOrEqual":    "%[1]s<=(%[2]s)",
+			"equal":              "%[1]s=(%[2]s)",
+			"notEqual":           "%[1]s<>(%[2]s)",
+			"greaterThanOrEqual": "%[1]s>=(%[2]s)",
+			"greaterThan":        "%[1]s>(%[2]s)",
+			"between":            "AND(%[1]s>=(%[2]s),%[1]s<=(%[3]s))",
+			"notBetween":         "OR(%[1]s<(%[2]s),%[1]s>(%[3]s))",
+		}[c.Operator]
+		if formula == "" || (strings.Contains(formula, "%[3]s") && len(c.Formula) < 2) {
+			return false, nil
+		}
+		args := []interface{}{ref, c.Formula[0], ""}
+		if len(c.Formula) > 1 {
+			args[2] = c.Formula[1]
+		}
+		return isTrue(f.evalCondFmtFormula(ctx, sheet, cell, rule, fmt.Sprintf(formula, args...))), nil
+	case "top10":
+		nums, err := f.getCondFmtNumbers(ctx, ws, sheet, rule)
+		if err != nil || !isNumber || len(nums) == 0 {
+			return false, err
+		}
+		rank := c.Rank
+		if c.Percent {
+			rank = int(float64(len(nums)) * float64(c.Rank) / 100)
+		}
+		rank = max(min(rank, len(nums)), 1)
+		if c.Bottom {
+			return value.Number <= nums[rank-1], nil
+		}
+		return value.Number >= nums[len(nums)-rank], nil
+	case "aboveAverage":
+		nums, err := f.getCondFmtNumbers(ctx, ws, sheet, rule)
+		if err != nil || !isNumber || len(nums) == 0 {
+			return false, err
+		}
+		var sum, squares float64
+		for _, num := range nums {
+			sum += num
+		}
+		avg := sum / float64(len(nums))
+		for _, num := range nums {
+			squares += (num - avg) * (num - avg)
+		}
+		threshold, above := avg, c.AboveAverage == nil || *c.AboveAverage
+		if deviation := float64(c.StdDev) * math.Sqrt(squares/float64(len(nums))); above {
+			threshold += deviation
+		} else {
+			threshold -= deviation
+		}
+		if c.EqualAverage && value.Number == threshold {
+			return true, nil
+		}
+		return (above && value.Number > threshold) || (!above && value.Number < threshold), nil
+	case "duplicateValues", "uniqueValues":
+		result, err := f.getCondFmtValues(ctx, ws, sheet, rule)
+		if err != nil || value.Type == ArgEmpty || value.Value() == "" {
+			return false, err
+		}
+		return (c.Type == "duplicateValues") == (result.counts[strings.ToLower(value.Value())] > 1), nil
+	case "colorScale", "dataBar", "iconSet":
+		nums, err := f.getCondFmtNumbers(ctx, ws, sheet, rule)
+		if err != nil || !isNumber || len(nums) == 0 {
+			return false, err
+		}
+		return f.evalCondFmtScale(ctx, sheet, cell, value.Number, nums, rule, ws, effective), nil
+	}
+	if len(c.Formula) == 0 {
+		return false, nil
+	}
+	return isTrue(f.evalCondFmtFormula(ctx, sheet, cell, rule, c.Formula[0])), nil
+}
+
+// evalCondFmtScale provides a function to evaluate the color scale, data bar
+// and icon set conditional formatting rules for the given cell.
+func (f *File) evalCondFmtScale(ctx *calcContext, sheet string, cell []int, num float64, nums []float64, rule *condFmtRule, ws *xlsxWorksheet, effective *CellEffectiveStyle) bool {
+	thresholds := func(cfvos []*xlsxCfvo) []float64 {
+		var values []float64
+		for _, cfvo := range cfvos {
+			values = append(values, f.getCondFmtCfvoValue(ctx, sheet, cell, cfvo, nums, rule))
+		}
+		return values
+	}
+	c := rule.rule
+	switch c.Type {
+	case "colorScale":
+		if c.ColorScale == nil || len(c.ColorScale.Cfvo) < 2 || len(c.ColorScale.Cfvo) != len(c.ColorScale.Color) {
+			return false
+		}
+		values, i := thresholds(c.ColorScale.Cfvo), 0
+		for i < len(values)-2 && num > values[i+1] {
+			i++
+		}
+		ratio := 0.0
+		if values[i+1] > values[i] {
+			ratio = math.Max(0, math.Min(1, (num-values[i])/(values[i+1]-values[i])))
+		} else if num >= values[i+1] {
+			ratio = 1
+		}
+		effective.Style.Fill = Fill{Type: "pattern", Pattern: 1, Color: []string{
+			interpolateColor(f.getThemeColor(c.ColorScale.Color[i]), f.getThemeColor(c.ColorScale.Color[i+1]), ratio),
+		}}
+	case "dataBar":
+		if c.DataBar == nil || len(c.DataBar.Cfvo) < 2 {
+			return false
+		}
+		values := thresholds(c.DataBar.Cfvo)
+		format := f.extractCondFmtDataBar(c, ws.ExtLst)
+		minLength, maxLength := 10.0, 90.0
+		if c.DataBar.MinLength != 0 || c.DataBar.MaxLength != 0 {
+			minLength, maxLength = float64(c.DataBar.MinLength), float64(c.DataBar.MaxLength)
+		}
+		if rule.x14 != nil && rule.x14.DataBar != nil {
+			minLength, maxLength = float64(rule.x14.DataBar.MinLength), float64(rule.x14.DataBar.MaxLength)
+		}
+		ratio := 1.0
+		if values[1] > values[0] {
+			ratio = math.Max(0, math.Min(1, (num-values[0])/(values[1]-values[0])))
+		}
+		effective.DataBar = &CellDataBar{
+			Length:      minLength + ratio*(maxLength-minLength),
+			Color:       format.BarColor,
+			BorderColor: format.BarBorderColor,
+			Direction:   format.BarDirection,
+			BarOnly:     format.BarOnly,
+			BarSolid:    format.BarSolid,
+		}
+	default:
+		if c.IconSet == nil || len(c.IconSet.Cfvo) < 2 {
+			return false
+		}
+		values, idx := thresholds(c.IconSet.Cfvo), 0
+		for i := 1; i < len(values); i++ {
+			if num > values[i] || (num == values[i] && (c.IconSet.Cfvo[i].Gte || rule.x14 == nil)) {
+				idx = i
+			}
+		}
+		if c.IconSet.Reverse {
+			idx = len(values) - 1 - idx
+		}
+		effective.Icon = &CellIcon{IconStyle: c.IconSet.IconSet, Index: idx}
+		if c.IconSet.ShowValue != nil {
+			effective.Icon.IconsOnly = !*c.IconSet.ShowValue
+		}
+	}
+	return true
+}
+
+// getCondFmtCfvoValue provides a function to get the value of the
+// conditional format value object by given sorted numeric values of the
+// cells within the ranges.
+func (f *File) getCondFmtCfvoValue(ctx *calcContext, sheet string, cell []int, cfvo *xlsxCfvo, nums []float64, rule *condFmtRule) float64 {
+	minVal, maxVal := nums[0], nums[len(nums)-1]
+	value := func() float64 {
+		return f.evalCondFmtFormula(ctx, sheet, cell, rule, cfvo.Val).ToNumber().Number
+	}
+	switch cfvo.Type {
+	case "min":
+		return minVal
+	case "max":
+		return maxVal
+	case "autoMin":
+		return math.Min(0, minVal)
+	case "autoMax":
+		return math.Max(0, maxVal)
+	case "num", "formula":
+		return value()
+	case "percentile":
+		pos := math.Max(0, math.Min(100, value())) / 100 * float64(len(nums)-1)
+		lower := int(math.Floor(pos))
+		if lower+1 >= len(nums) {
+			return nums[lower]
+		}
+		return nums[lower] + (pos-float64(lower))*(nums[lower+1]-nums[lower])
+	}
+	return minVal + (maxVal-minVal)*value()/100
+}
+
+// interpolateColor provides a function to interpolate the color between the
+// given two colors by given ratio.
+func interpolateColor(from, to string, ratio float64) string {
+	parse := func(color string) []float64 {
+		color = strings.TrimPrefix(color, "#")
+		if len(color) == 8 {
+			color = color[2:]
+		}
+		rgb := make([]float64, 3)
+		for i := range rgb {
+			if len(color) >= 2*i+2 {
+				v, _ := strconv.ParseUint(color[2*i:2*i+2], 16, 8)
+				rgb[i] = float64(v)
+			}
+		}
+		return rgb
+	}
+	a, b := parse(from), parse(to)
+	var color string
+	for i := range a {
+		color += fmt.Sprintf("%02X", int(math.Round(a[i]+(b[i]-a[i])*ratio)))
+	}
+	return color
+}
+
+// mergeCondFmtStyle provides a function to merge the conditional format style
+// over the cell style.
+func mergeCondFmtStyle(style, dxf *Style) {
+	if dxf.Fill.Type != "" || len(dxf.Fill.Color) > 0 {
+		style.Fill = dxf.Fill
+	}
+	for _, border := range dxf.Border {
+		idx := -1
+		for i := range style.Border {
+			if style.Border[i].Type == border.Type {
+				idx = i
+			}
+		}
+		if idx == -1 {
+			style.Border = append(style.Border, border)
+			continue
+		}
+		style.Border[idx] = border
+	}
+	if font := dxf.Font; font != nil {
+		if style.Font == nil {
+			style.Font = &Font{}
+		}
+		style.Font.Bold = style.Font.Bold || font.Bold
+		style.Font.Italic = style.Font.Italic || font.Italic
+		style.Font.Strike = style.Font.Strike || font.Strike
+		if font.Underline != "" {
+			style.Font.Underline = font.Underline
+		}
+		if font.Color != "" || font.ColorTheme != nil || font.ColorIndexed != 0 {
+			style.Font.Color, style.Font.ColorTheme = font.Color, font.ColorTheme
+			style.Font.ColorIndexed, style.Font.ColorTint = font.ColorIndexed, font.ColorTint
+		}
+		if font.Family != "" {
+			style.Font.Family = font.Family
+		}
+		if font.Size != 0 {
+			style.Font.Size = font.Size
+		}
+		if font.VertAlign != "" {
+			style.Font.VertAlign = font.VertAlign
+		}
+	}
+	if dxf.Alignment != nil {
+		style.Alignment = dxf.Alignment
+	}
+	if dxf.Protection != nil {
+		style.Protection = dxf.Protection
+	}
+	if dxf.NumFmt != 0 || dxf.CustomNumFmt != nil {
+		style.NumFmt, style.CustomNumFmt = dxf.NumFmt, dxf.CustomNumFmt
+	}
+}
+
 // UnsetConditionalFormat provides a function to unset the conditional format
 // by given worksheet name and range reference.
 func (f *File) UnsetConditionalFormat(sheet, rangeRef string) error {
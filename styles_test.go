@@ -945,3 +945,119 @@ func TestGetStyle(t *testing.T) {
 		assert.Zero(t, style.Fill.Pattern)
 	})
 }
+
+func TestGetCellEffectiveStyle(t *testing.T) {
+	f := NewFile()
+	for row := 1; row <= 10; row++ {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("A%d", row), &[]int{row, row, row, row}))
+		assert.NoError(t, f.SetCellValue("Sheet1", fmt.Sprintf("I%d", row), row))
+	}
+	for row, value := range []int{5, 3, 3, 9, 1} {
+		assert.NoError(t, f.SetSheetRow("Sheet1", fmt.Sprintf("E%d", row+1), &[]int{value, value, value, value}))
+	}
+	fill, err := f.NewConditionalStyle(&Style{Fill: Fill{Type: "pattern", Color: []string{"FF0000"}, Pattern: 1}})
+	assert.NoError(t, err)
+	font, err := f.NewConditionalStyle(&Style{Font: &Font{Bold: true, Color: "0000FF"}, Border: []Border{{Type: "left", Color: "00FF00", Style: 1}}})
+	assert.NoError(t, err)
+	for rangeRef, opts := range map[string][]ConditionalFormatOptions{
+		"A1:A10": {
+			{Type: "cell", Criteria: ">", Value: "8", Format: &fill},
+			{Type: "formula", Criteria: "MOD(A1,2)=0", Format: &font},
+		},
+		"B1:B10": {{Type: "2_color_scale", Criteria: "=", MinType: "min", MaxType: "max", MinColor: "#FFFFFF", MaxColor: "#000000"}},
+		"C1:C10": {{Type: "data_bar", Criteria: "=", MinType: "min", MaxType: "max", BarColor: "#638EC6"}},
+		"D1:D10": {{Type: "icon_set", IconStyle: "3Arrows", ReverseIcons: true}},
+		"E1:E5":  {{Type: "top", Criteria: "=", Value: "2", Format: &fill}},
+		"F1:F5":  {{Type: "average", Criteria: "=", AboveAverage: true, Format: &fill}},
+		"G1:G5":  {{Type: "duplicate", Criteria: "=", Format: &fill}},
+		"H1:H5":  {{Type: "unique", Criteria: "=", Format: &fill}},
+		"I1:I10": {{Type: "icon_set", IconStyle: "3Stars", IconsOnly: true}},
+	} {
+		assert.NoError(t, f.SetConditionalFormat("Sheet1", rangeRef, opts))
+	}
+	for cell, expected := range map[string]bool{"A9": true, "A10": true, "A1": false, "A8": false} {
+		style, err := f.GetCellEffectiveStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, len(style.Style.Fill.Color) == 1 && style.Style.Fill.Color[0] == "FF0000", cell)
+		assert.Nil(t, style.DataBar)
+		assert.Nil(t, style.Icon)
+	}
+	style, err := f.GetCellEffectiveStyle("Sheet1", "A10")
+	assert.NoError(t, err)
+	assert.True(t, style.Style.Font.Bold)
+	assert.Equal(t, "0000FF", style.Style.Font.Color)
+	assert.Equal(t, []Border{{Type: "left", Color: "00FF00", Style: 1}}, style.Style.Border)
+	style, err = f.GetCellEffectiveStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.False(t, style.Style.Font.Bold)
+
+	for cell, expected := range map[string]string{"B1": "FFFFFF", "B5": "8E8E8E", "B10": "000000"} {
+		style, err := f.GetCellEffectiveStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, Fill{Type: "pattern", Pattern: 1, Color: []string{expected}}, style.Style.Fill, cell)
+	}
+	for cell, expected := range map[string]float64{"C1": 10, "C5": 10 + 80*4/9.0, "C10": 90} {
+		style, err := f.GetCellEffectiveStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.InDelta(t, expected, style.DataBar.Length, 1e-9, cell)
+		assert.Equal(t, "638EC6", style.DataBar.Color, cell)
+	}
+	for cell, expected := range map[string]*CellIcon{
+		"D1": {IconStyle: "3Arrows", Index: 2}, "D5": {IconStyle: "3Arrows", Index: 1}, "D8": {IconStyle: "3Arrows", Index: 0},
+		"I1": {IconStyle: "3Stars", IconsOnly: true}, "I3": {IconStyle: "3Stars", Index: 0, IconsOnly: true},
+		"I5": {IconStyle: "3Stars", Index: 1, IconsOnly: true}, "I10": {IconStyle: "3Stars", Index: 2, IconsOnly: true},
+	} {
+		style, err := f.GetCellEffectiveStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, style.Icon, cell)
+	}
+	for col, expected := range map[string][]bool{
+		"E": {true, false, false, true, false},
+		"F": {true, false, false, true, false},
+		"G": {false, true, true, false, false},
+		"H": {true, false, false, true, true},
+	} {
+		for row, matched := range expected {
+			cell := fmt.Sprintf("%s%d", col, row+1)
+			style, err := f.GetCellEffectiveStyle("Sheet1", cell)
+			assert.NoError(t, err)
+			assert.Equal(t, matched, len(style.Style.Fill.Color) == 1, cell)
+		}
+	}
+	// Test the values of the conditional formatting ranges will be cached until
+	// the calculation cache has been cleared
+	_, ok := f.condFmtCache.Load("Sheet1![[5 1 5 5]]")
+	assert.True(t, ok)
+	assert.NoError(t, f.SetCellValue("Sheet1", "E2", 10))
+	_, ok = f.condFmtCache.Load("Sheet1![[5 1 5 5]]")
+	assert.False(t, ok)
+	for cell, expected := range map[string]bool{"E1": false, "E2": true, "E4": true} {
+		style, err := f.GetCellEffectiveStyle("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, len(style.Style.Fill.Color) == 1, cell)
+	}
+	// Test get cell effective style with stop if true rule
+	assert.NoError(t, f.SetConditionalFormat("Sheet1", "A1:A10", []ConditionalFormatOptions{
+		{Type: "cell", Criteria: "between", MinValue: "9", MaxValue: "10", Format: &fill, StopIfTrue: true},
+	}))
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	for _, cf := range ws.(*xlsxWorksheet).ConditionalFormatting {
+		for _, rule := range cf.CfRule {
+			if rule.StopIfTrue {
+				rule.Priority = 0
+			}
+		}
+	}
+	style, err = f.GetCellEffectiveStyle("Sheet1", "A10")
+	assert.NoError(t, err)
+	assert.False(t, style.Style.Font.Bold)
+	assert.Equal(t, []string{"FF0000"}, style.Style.Fill.Color)
+	// Test get cell effective style with invalid cell reference
+	_, err = f.GetCellEffectiveStyle("Sheet1", "A")
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), err)
+	// Test get cell effective style on not exists worksheet
+	_, err = f.GetCellEffectiveStyle("SheetN", "A1")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	assert.NoError(t, f.Close())
+}
@@ -216,6 +216,84 @@ func (f *File) getTables() (map[string][]Table, error) {
 	return tables, nil
 }
 
+// tableRef directly maps the table definition and the name of the worksheet
+// which contains the table.
+type tableRef struct {
+	sheet string
+	table *xlsxTable
+}
+
+// getTableRefs provides a function to get the table definitions of all
+// worksheets in the workbook. The result will be cached until the
+// calculation cache has been cleared, so the returned table definitions
+// should not be modified.
+func (f *File) getTableRefs() []tableRef {
+	if refs := f.tableRefs.Load(); refs != nil {
+		return *refs
+	}
+	refs := []tableRef{}
+	for _, sheetName := range f.GetSheetList() {
+		ws, err := f.workSheetReader(sheetName)
+		if err != nil || ws.TableParts == nil {
+			continue
+		}
+		for _, tbl := range ws.TableParts.TableParts {
+			if tbl == nil {
+				continue
+			}
+			target := f.getSheetRelationshipsTargetByID(sheetName, tbl.RID)
+			content, ok := f.Pkg.Load(strings.ReplaceAll(target, "..", "xl"))
+			if !ok {
+				continue
+			}
+			t := new(xlsxTable)
+			if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+				Decode(t); err != nil && err != io.EOF {
+				continue
+			}
+			refs = append(refs, tableRef{sheet: sheetName, table: t})
+		}
+	}
+	f.tableRefs.Store(&refs)
+	return refs
+}
+
+// getTable provides a function to get the table definition and the name of
+// the worksheet which contains the table by given table name. If the table
+// name is empty, the table which contains the given cell on the worksheet
+// will be returned.
+func (f *File) getTable(sheet, cell, name string) (string, *xlsxTable) {
+	for _, ref := range f.getTableRefs() {
+		if name == "" {
+			if ref.sheet != sheet {
+				continue
+			}
+			if ok, _ := f.checkCellInRangeRef(cell, ref.table.Ref); ok {
+				return ref.sheet, ref.table
+			}
+			continue
+		}
+		if strings.EqualFold(ref.table.DisplayName, name) || strings.EqualFold(ref.table.Name, name) {
+			return ref.sheet, ref.table
+		}
+	}
+	return "", nil
+}
+
+// getColumnNumber returns the column number of the table column by given
+// column number of the first column in the table and the column name, -1 will
+// be returned if the column doesn't exist.
+func (t *xlsxTable) getColumnNumber(col int, name string) int {
+	if t.TableColumns != nil {
+		for i, column := range t.TableColumns.TableColumn {
+			if column != nil && strings.EqualFold(column.Name, name) {
+				return col + i
+			}
+		}
+	}
+	return -1
+}
+
 // countTables provides a function to get table files count storage in the
 // folder xl/tables.
 func (f *File) countTables() int {
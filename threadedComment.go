@@ -0,0 +1,562 @@
+// Copyright 2016 - 2026 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.25.0 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// threadedCommentDateLayout defined the layout of the date time of the
+// threaded comment.
+const threadedCommentDateLayout = "2006-01-02T15:04:05.00"
+
+// threadedCommentLegacyText defined the prefix text of the legacy comment for
+// compatible with the applications which not support threaded comments.
+const threadedCommentLegacyText = "[Threaded comment]\n\nYour version of Excel allows you to read this threaded comment; however, any edits to it will get removed if the file is opened in a newer version of Excel. Learn more: https://go.microsoft.com/fwlink/?linkid=870924\n\nComment:\n    "
+
+// AddThreadedComment provides the method to add threaded comment in a sheet
+// by giving the worksheet name and threaded comment settings. Each cell can
+// only have one threaded comment or comment, an error will return if adding
+// threaded comment on a cell which already exist comment. The author and
+// mentioned persons will be added into the person list of the workbook if
+// not exists, and a legacy comment will be created for compatible with the
+// applications which not support threaded comments. For example, add a
+// threaded comment with a reply and mention in Sheet1!A1:
+//
+//	err := f.AddThreadedComment("Sheet1", excelize.ThreadedComment{
+//	    Cell:   "A1",
+//	    Author: "Excelize",
+//	    Text:   "@Alice please review this value",
+//	    Mentions: []excelize.ThreadedCommentMention{
+//	        {Person: "Alice"},
+//	    },
+//	    Replies: []excelize.ThreadedComment{
+//	        {Author: "Alice", Text: "Looks good to me"},
+//	    },
+//	})
+func (f *File) AddThreadedComment(sheet string, opts ThreadedComment) error {
+	if _, _, err := CellNameToCoordinates(opts.Cell); err != nil {
+		return err
+	}
+	comments, err := f.GetComments(sheet)
+	if err != nil {
+		return err
+	}
+	for _, comment := range comments {
+		if comment.Cell == opts.Cell {
+			return newAddCommentError(opts.Cell)
+		}
+	}
+	threadedCommentsXML, err := f.getSheetThreadedComments(sheet)
+	if err != nil {
+		return err
+	}
+	tc, err := f.threadedCommentsReader(threadedCommentsXML)
+	if err != nil {
+		return err
+	}
+	if tc.getThread(opts.Cell) != -1 {
+		return newAddCommentError(opts.Cell)
+	}
+	persons, err := f.personListReader()
+	if err != nil {
+		return err
+	}
+	thread, err := persons.newThreadedComment(opts.Cell, "", &opts)
+	if err != nil {
+		return err
+	}
+	thread.Done = boolPtr(opts.Done)
+	tc.ThreadedComment = append(tc.ThreadedComment, thread)
+	for _, reply := range opts.Replies {
+		threadedComment, err := persons.newThreadedComment(opts.Cell, thread.ID, &reply)
+		if err != nil {
+			return err
+		}
+		tc.ThreadedComment = append(tc.ThreadedComment, threadedComment)
+	}
+	if err = f.savePersonList(persons); err != nil {
+		return err
+	}
+	return f.saveThreadedComments(sheet, threadedCommentsXML, tc, opts.Cell)
+}
+
+// AddThreadedCommentReply provides the method to add a reply to the threaded
+// comment by giving the worksheet name, cell reference and reply settings.
+// For example, reply to the threaded comment in Sheet1!A1:
+//
+//	err := f.AddThreadedCommentReply("Sheet1", "A1", excelize.ThreadedComment{
+//	    Author: "Bob",
+//	    Text:   "Agreed",
+//	})
+func (f *File) AddThreadedCommentReply(sheet, cell string, reply ThreadedComment) error {
+	threadedCommentsXML, tc, idx, err := f.getThreadedComment(sheet, cell)
+	if err != nil {
+		return err
+	}
+	persons, err := f.personListReader()
+	if err != nil {
+		return err
+	}
+	threadedComment, err := persons.newThreadedComment(cell, tc.ThreadedComment[idx].ID, &reply)
+	if err != nil {
+		return err
+	}
+	pos := idx + 1
+	for i := pos; i < len(tc.ThreadedComment); i++ {
+		if tc.ThreadedComment[i].ParentID == tc.ThreadedComment[idx].ID {
+			pos = i + 1
+		}
+	}
+	tc.ThreadedComment = append(tc.ThreadedComment[:pos],
+		append([]xlsxThreadedComment{threadedComment}, tc.ThreadedComment[pos:]...)...)
+	if err = f.savePersonList(persons); err != nil {
+		return err
+	}
+	return f.saveThreadedComments(sheet, threadedCommentsXML, tc, cell)
+}
+
+// ResolveThreadedComment provides the method to set the resolution state of
+// the threaded comment by giving the worksheet name, cell reference and
+// resolution state. For example, resolve the threaded comment in Sheet1!A1:
+//
+//	err := f.ResolveThreadedComment("Sheet1", "A1", true)
+func (f *File) ResolveThreadedComment(sheet, cell string, done bool) error {
+	threadedCommentsXML, tc, idx, err := f.getThreadedComment(sheet, cell)
+	if err != nil {
+		return err
+	}
+	tc.ThreadedComment[idx].Done = boolPtr(done)
+	output, err := xml.Marshal(tc)
+	f.saveFileList(threadedCommentsXML, output)
+	return err
+}
+
+// DeleteThreadedComment provides the method to delete the threaded comment
+// with all replies and the legacy comment in a sheet by giving the worksheet
+// name and cell reference. For example, delete the threaded comment in
+// Sheet1!A1:
+//
+//	err := f.DeleteThreadedComment("Sheet1", "A1")
+func (f *File) DeleteThreadedComment(sheet, cell string) error {
+	threadedCommentsXML, tc, idx, err := f.getThreadedComment(sheet, cell)
+	if err != nil {
+		return err
+	}
+	ID := tc.ThreadedComment[idx].ID
+	threadedComments := tc.ThreadedComment[:0]
+	for _, threadedComment := range tc.ThreadedComment {
+		if threadedComment.ID != ID && threadedComment.ParentID != ID {
+			threadedComments = append(threadedComments, threadedComment)
+		}
+	}
+	tc.ThreadedComment = threadedComments
+	output, err := xml.Marshal(tc)
+	if err != nil {
+		return err
+	}
+	f.saveFileList(threadedCommentsXML, output)
+	return f.DeleteComment(sheet, cell)
+}
+
+// GetThreadedComments provides the method to get all threaded comments with
+// replies in a worksheet by given worksheet name. For example, get all
+// threaded comments in Sheet1:
+//
+//	threadedComments, err := f.GetThreadedComments("Sheet1")
+func (f *File) GetThreadedComments(sheet string) ([]ThreadedComment, error) {
+	var threadedComments []ThreadedComment
+	threadedCommentsXML, err := f.getSheetThreadedComments(sheet)
+	if err != nil {
+		return threadedComments, err
+	}
+	tc, err := f.threadedCommentsReader(threadedCommentsXML)
+	if err != nil {
+		return threadedComments, err
+	}
+	persons, err := f.personListReader()
+	if err != nil {
+		return threadedComments, err
+	}
+	names := make(map[string]string, len(persons.Person))
+	for _, person := range persons.Person {
+		names[person.ID] = person.DisplayName
+	}
+	threads := make(map[string]int)
+	for _, threadedComment := range tc.ThreadedComment {
+		if threadedComment.ParentID == "" {
+			threads[threadedComment.ID] = len(threadedComments)
+			threadedComments = append(threadedComments, threadedComment.getThreadedComment(names))
+		}
+	}
+	for _, threadedComment := range tc.ThreadedComment {
+		if idx, ok := threads[threadedComment.ParentID]; ok && threadedComment.ParentID != "" {
+			threadedComments[idx].Replies = append(threadedComments[idx].Replies, threadedComment.getThreadedComment(names))
+		}
+	}
+	return threadedComments, err
+}
+
+// GetPersons provides the method to get all persons who authored or be
+// mentioned in the threaded comments of the workbook.
+func (f *File) GetPersons() ([]Person, error) {
+	var persons []Person
+	pl, err := f.personListReader()
+	if err != nil {
+		return persons, err
+	}
+	for _, person := range pl.Person {
+		persons = append(persons, Person{
+			ID:          person.ID,
+			DisplayName: person.DisplayName,
+			UserID:      person.UserID,
+			ProviderID:  person.ProviderID,
+		})
+	}
+	return persons, err
+}
+
+// AddPerson provides the method to add a person into the person list of the
+// workbook, which can be used as author or be mentioned in the threaded
+// comments. The display name is required, and the ID will be generated if it
+// is empty. The user ID defaults to the display name, and the provider ID
+// defaults to "None". For example:
+//
+//	err := f.AddPerson(excelize.Person{DisplayName: "Alice"})
+func (f *File) AddPerson(person Person) error {
+	if person.DisplayName == "" {
+		return ErrParameterRequired
+	}
+	pl, err := f.personListReader()
+	if err != nil {
+		return err
+	}
+	for _, p := range pl.Person {
+		if p.DisplayName == person.DisplayName || (person.ID != "" && p.ID == person.ID) {
+			return ErrParameterInvalid
+		}
+	}
+	if person.ID == "" {
+		if person.ID, err = genGUID(); err != nil {
+			return err
+		}
+	}
+	if person.UserID == "" {
+		person.UserID = person.DisplayName
+	}
+	if person.ProviderID == "" {
+		person.ProviderID = "None"
+	}
+	pl.Person = append(pl.Person, xlsxPerson{
+		DisplayName: person.DisplayName,
+		ID:          person.ID,
+		UserID:      person.UserID,
+		ProviderID:  person.ProviderID,
+	})
+	return f.savePersonList(pl)
+}
+
+// genGUID provides a function to generate a random GUID in registry format.
+func genGUID() (string, error) {
+	b, err := randomBytes(16)
+	if err != nil {
+		return "", err
+	}
+	b[6], b[8] = b[6]&0x0f|0x40, b[8]&0x3f|0x80
+	return fmt.Sprintf("{%X-%X-%X-%X-%X}", b[:4], b[4:6], b[6:8], b[8:10], b[10:]), err
+}
+
+// getSheetThreadedComments provides a function to get the path of the
+// threaded comments part by given worksheet name, the returned path will be
+// empty if the worksheet doesn't have threaded comments.
+func (f *File) getSheetThreadedComments(sheet string) (string, error) {
+	sheetXMLPath, ok := f.getSheetXMLPath(sheet)
+	if !ok {
+		return "", ErrSheetNotExist{sheet}
+	}
+	rels, err := f.relsReader("xl/worksheets/_rels/" + filepath.Base(sheetXMLPath) + ".rels")
+	if err != nil || rels == nil {
+		return "", err
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for _, v := range rels.Relationships {
+		if v.Type == SourceRelationshipThreadedComment {
+			if strings.HasPrefix(v.Target, "/") {
+				return strings.TrimPrefix(v.Target, "/"), err
+			}
+			return "xl" + strings.TrimPrefix(v.Target, ".."), err
+		}
+	}
+	return "", err
+}
+
+// getPersonListPath provides a function to get the path of the persons part
+// in the workbook, the returned path will be empty if the workbook doesn't
+// have persons part.
+func (f *File) getPersonListPath() (string, error) {
+	rels, err := f.relsReader(f.getWorkbookRelsPath())
+	if err != nil || rels == nil {
+		return "", err
+	}
+	rels.mu.Lock()
+	defer rels.mu.Unlock()
+	for _, v := range rels.Relationships {
+		if v.Type == SourceRelationshipPerson {
+			return f.getWorkbookRelsTargetPath(v.Target), err
+		}
+	}
+	return "", err
+}
+
+// threadedCommentsReader provides a function to get the pointer to the
+// structure after deserialization of xl/threadedComments/threadedComment%d.xml.
+func (f *File) threadedCommentsReader(threadedCommentsXML string) (*xlsxThreadedComments, error) {
+	tc := &xlsxThreadedComments{XMLNSX: NameSpaceSpreadSheet.Value}
+	if content, ok := f.Pkg.Load(threadedCommentsXML); ok && content != nil {
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+			Decode(tc); err != nil && err != io.EOF {
+			return nil, err
+		}
+	}
+	return tc, nil
+}
+
+// personListReader provides a function to get the pointer to the structure
+// after deserialization of xl/persons/person.xml.
+func (f *File) personListReader() (*xlsxPersonList, error) {
+	personListXML, err := f.getPersonListPath()
+	if err != nil {
+		return nil, err
+	}
+	pl := &xlsxPersonList{XMLNSX: NameSpaceSpreadSheet.Value}
+	if content, ok := f.Pkg.Load(personListXML); ok && content != nil {
+		if err := f.xmlNewDecoder(bytes.NewReader(namespaceStrictToTransitional(content.([]byte)))).
+			Decode(pl); err != nil && err != io.EOF {
+			return nil, err
+		}
+	}
+	return pl, nil
+}
+
+// savePersonList provides a function to save the person list, the persons
+// part and relationships will be created if not exists.
+func (f *File) savePersonList(pl *xlsxPersonList) error {
+	personListXML, err := f.getPersonListPath()
+	if err != nil {
+		return err
+	}
+	if personListXML == "" {
+		personListXML = "xl/persons/person.xml"
+		f.addRels(f.getWorkbookRelsPath(), SourceRelationshipPerson, "/"+personListXML, "")
+		if err = f.addContentTypePart(0, "person"); err != nil {
+			return err
+		}
+	}
+	output, err := xml.Marshal(pl)
+	f.saveFileList(personListXML, output)
+	return err
+}
+
+// saveThreadedComments provides a function to save the threaded comments of
+// the worksheet, and update the legacy comment of the threaded comment in
+// given cell reference. The threaded comments part and relationships will be
+// created if not exists.
+func (f *File) saveThreadedComments(sheet, threadedCommentsXML string, tc *xlsxThreadedComments, cell string) error {
+	if threadedCommentsXML == "" {
+		var threadedCommentsID int
+		f.Pkg.Range(func(k, v interface{}) bool {
+			if strings.Contains(k.(string), "xl/threadedComments/threadedComment") {
+				threadedCommentsID++
+			}
+			return true
+		})
+		threadedCommentsID++
+		threadedCommentsXML = "xl/threadedComments/threadedComment" + strconv.Itoa(threadedCommentsID) + ".xml"
+		sheetXMLPath, _ := f.getSheetXMLPath(sheet)
+		sheetRels := "xl/worksheets/_rels/" + filepath.Base(sheetXMLPath) + ".rels"
+		f.addRels(sheetRels, SourceRelationshipThreadedComment, "../threadedComments/threadedComment"+strconv.Itoa(threadedCommentsID)+".xml", "")
+		if err := f.addContentTypePart(threadedCommentsID, "threadedComment"); err != nil {
+			return err
+		}
+	}
+	output, err := xml.Marshal(tc)
+	if err != nil {
+		return err
+	}
+	f.saveFileList(threadedCommentsXML, output)
+	idx := tc.getThread(cell)
+	comments, err := f.GetComments(sheet)
+	if err != nil {
+		return err
+	}
+	for _, comment := range comments {
+		if comment.Cell == cell {
+			if err = f.DeleteComment(sheet, cell); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	return f.AddComment(sheet, Comment{
+		Cell:   cell,
+		Author: "tc=" + tc.ThreadedComment[idx].ID,
+		Text:   tc.getLegacyText(idx),
+	})
+}
+
+// getThreadedComment provides a function to get the path of the threaded
+// comments part, the threaded comments and the index of the threaded comment
+// in given cell reference of the worksheet.
+func (f *File) getThreadedComment(sheet, cell string) (string, *xlsxThreadedComments, int, error) {
+	if _, _, err := CellNameToCoordinates(cell); err != nil {
+		return "", nil, -1, err
+	}
+	threadedCommentsXML, err := f.getSheetThreadedComments(sheet)
+	if err != nil {
+		return threadedCommentsXML, nil, -1, err
+	}
+	tc, err := f.threadedCommentsReader(threadedCommentsXML)
+	if err != nil {
+		return threadedCommentsXML, tc, -1, err
+	}
+	idx := tc.getThread(cell)
+	if idx == -1 {
+		return threadedCommentsXML, tc, idx, newNoExistThreadedCommentError(cell)
+	}
+	return threadedCommentsXML, tc, idx, err
+}
+
+// getThread provides a function to get the index of the top-level threaded
+// comment by given cell reference, the returned index will be -1 if not found.
+func (tc *xlsxThreadedComments) getThread(cell string) int {
+	for i, threadedComment := range tc.ThreadedComment {
+		if threadedComment.ParentID == "" && threadedComment.Ref == cell {
+			return i
+		}
+	}
+	return -1
+}
+
+// getLegacyText provides a function to get the legacy comment text of the
+// threaded comment with all replies by given index of the threaded comment.
+func (tc *xlsxThreadedComments) getLegacyText(idx int) string {
+	var text strings.Builder
+	text.WriteString(threadedCommentLegacyText)
+	text.WriteString(tc.ThreadedComment[idx].Text)
+	for _, threadedComment := range tc.ThreadedComment {
+		if threadedComment.ParentID == tc.ThreadedComment[idx].ID {
+			text.WriteString("\nReply:\n    ")
+			text.WriteString(threadedComment.Text)
+		}
+	}
+	return text.String()
+}
+
+// getPersonID provides a function to get the ID of the person by given
+// display name, the person will be added into the person list if not exists.
+func (pl *xlsxPersonList) getPersonID(name string) (string, error) {
+	for _, person := range pl.Person {
+		if person.DisplayName == name {
+			return person.ID, nil
+		}
+	}
+	ID, err := genGUID()
+	if err != nil {
+		return ID, err
+	}
+	pl.Person = append(pl.Person, xlsxPerson{DisplayName: name, ID: ID, UserID: name, ProviderID: "None"})
+	return ID, err
+}
+
+// newThreadedComment provides a function to create a threaded comment by given
+// cell reference, parent threaded comment ID and threaded comment settings.
+func (pl *xlsxPersonList) newThreadedComment(cell, parentID string, opts *ThreadedComment) (xlsxThreadedComment, error) {
+	if opts.Author == "" {
+		opts.Author = "Author"
+	}
+	if opts.Date.IsZero() {
+		opts.Date = time.Now()
+	}
+	threadedComment := xlsxThreadedComment{
+		Ref:      cell,
+		DT:       opts.Date.Format(threadedCommentDateLayout),
+		ParentID: parentID,
+		Text:     opts.Text,
+	}
+	var err error
+	if threadedComment.ID, err = genGUID(); err != nil {
+		return threadedComment, err
+	}
+	if threadedComment.PersonID, err = pl.getPersonID(opts.Author); err != nil {
+		return threadedComment, err
+	}
+	for _, mention := range opts.Mentions {
+		m := xlsxThreadedCommentMention{StartIndex: mention.StartIndex, Length: mention.Length}
+		if m.Length == 0 {
+			pos := strings.Index(opts.Text, "@"+mention.Person)
+			if pos == -1 {
+				return threadedComment, newInvalidThreadedCommentMentionError(mention.Person)
+			}
+			m.StartIndex, m.Length = countUTF16String(opts.Text[:pos]), countUTF16String("@"+mention.Person)
+		}
+		if m.MentionPersonID, err = pl.getPersonID(mention.Person); err != nil {
+			return threadedComment, err
+		}
+		if m.MentionID, err = genGUID(); err != nil {
+			return threadedComment, err
+		}
+		if threadedComment.Mentions == nil {
+			threadedComment.Mentions = &xlsxThreadedCommentMentions{}
+		}
+		threadedComment.Mentions.Mention = append(threadedComment.Mentions.Mention, m)
+	}
+	return threadedComment, err
+}
+
+// getThreadedComment provides a function to convert the threaded comment to
+// the settings by given person display names map.
+func (c *xlsxThreadedComment) getThreadedComment(names map[string]string) ThreadedComment {
+	threadedComment := ThreadedComment{
+		ID:       c.ID,
+		ParentID: c.ParentID,
+		Cell:     c.Ref,
+		Author:   names[c.PersonID],
+		Text:     c.Text,
+	}
+	if c.Done != nil {
+		threadedComment.Done = *c.Done
+	}
+	for _, layout := range []string{threadedCommentDateLayout, time.RFC3339Nano} {
+		if date, err := time.Parse(layout, c.DT); err == nil {
+			threadedComment.Date = date
+			break
+		}
+	}
+	if c.Mentions != nil {
+		for _, mention := range c.Mentions.Mention {
+			threadedComment.Mentions = append(threadedComment.Mentions, ThreadedCommentMention{
+				Person:     names[mention.MentionPersonID],
+				StartIndex: mention.StartIndex,
+				Length:     mention.Length,
+			})
+		}
+	}
+	return threadedComment
+}
@@ -0,0 +1,151 @@
+// Copyright 2016 - 2026 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.25.0 or later.
+
+package excelize
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThreadedComment(t *testing.T) {
+	f := NewFile()
+	date := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{
+		Cell:     "A1",
+		Author:   "Excelize",
+		Text:     "Hi @Alice, please review",
+		Date:     date,
+		Mentions: []ThreadedCommentMention{{Person: "Alice"}},
+		Replies:  []ThreadedComment{{Author: "Alice", Text: "Done", Date: date}},
+	}))
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "B2", Text: "Second"}))
+	assert.NoError(t, f.AddThreadedCommentReply("Sheet1", "A1", ThreadedComment{Author: "Bob", Text: "Thanks"}))
+	assert.NoError(t, f.ResolveThreadedComment("Sheet1", "A1", true))
+
+	// Test add threaded comment on a cell which already exists a threaded comment
+	assert.Equal(t, newAddCommentError("A1"), f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1"}))
+	// Test add threaded comment on a cell which already exists a comment
+	assert.NoError(t, f.AddComment("Sheet1", Comment{Cell: "C3", Text: "Note"}))
+	assert.Equal(t, newAddCommentError("C3"), f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "C3"}))
+	// Test add threaded comment with mentioned person not exists in the text
+	assert.Equal(t, newInvalidThreadedCommentMentionError("Bob"), f.AddThreadedComment("Sheet1", ThreadedComment{
+		Cell: "D4", Text: "Hi", Mentions: []ThreadedCommentMention{{Person: "Bob"}},
+	}))
+	// Test add threaded comment with invalid cell reference
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A"}))
+	// Test threaded comment functions on not exists worksheet
+	assert.EqualError(t, f.AddThreadedComment("SheetN", ThreadedComment{Cell: "A1"}), "sheet SheetN does not exist")
+	assert.EqualError(t, f.AddThreadedCommentReply("SheetN", "A1", ThreadedComment{}), "sheet SheetN does not exist")
+	_, err := f.GetThreadedComments("SheetN")
+	assert.EqualError(t, err, "sheet SheetN does not exist")
+	// Test threaded comment functions on a cell without threaded comment
+	assert.Equal(t, newNoExistThreadedCommentError("E5"), f.AddThreadedCommentReply("Sheet1", "E5", ThreadedComment{}))
+	assert.Equal(t, newNoExistThreadedCommentError("E5"), f.ResolveThreadedComment("Sheet1", "E5", true))
+	assert.Equal(t, newNoExistThreadedCommentError("E5"), f.DeleteThreadedComment("Sheet1", "E5"))
+	assert.Equal(t, newCellNameToCoordinatesError("A", newInvalidCellNameError("A")), f.DeleteThreadedComment("Sheet1", "A"))
+
+	check := func(f *File) {
+		threadedComments, err := f.GetThreadedComments("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, threadedComments, 2)
+		assert.Equal(t, "A1", threadedComments[0].Cell)
+		assert.Equal(t, "Excelize", threadedComments[0].Author)
+		assert.Equal(t, "Hi @Alice, please review", threadedComments[0].Text)
+		assert.Equal(t, date, threadedComments[0].Date)
+		assert.True(t, threadedComments[0].Done)
+		assert.Equal(t, []ThreadedCommentMention{{Person: "Alice", StartIndex: 3, Length: 6}}, threadedComments[0].Mentions)
+		assert.Len(t, threadedComments[0].Replies, 2)
+		assert.Equal(t, "Alice", threadedComments[0].Replies[0].Author)
+		assert.Equal(t, "Done", threadedComments[0].Replies[0].Text)
+		assert.Equal(t, "Bob", threadedComments[0].Replies[1].Author)
+		assert.Equal(t, threadedComments[0].ID, threadedComments[0].Replies[1].ParentID)
+		assert.Equal(t, "Author", threadedComments[1].Author)
+		assert.False(t, threadedComments[1].Done)
+
+		comments, err := f.GetComments("Sheet1")
+		assert.NoError(t, err)
+		assert.Len(t, comments, 3)
+		assert.Equal(t, "tc="+threadedComments[0].ID, comments[1].Author)
+		assert.True(t, strings.HasPrefix(comments[1].Text, "[Threaded comment]"))
+		assert.True(t, strings.HasSuffix(comments[1].Text, "Comment:\n    Hi @Alice, please review\nReply:\n    Done\nReply:\n    Thanks"))
+
+		persons, err := f.GetPersons()
+		assert.NoError(t, err)
+		assert.Len(t, persons, 4)
+		assert.Equal(t, "Excelize", persons[0].DisplayName)
+		assert.Equal(t, "None", persons[0].ProviderID)
+	}
+	check(f)
+	file := filepath.Join("test", "TestThreadedComment.xlsx")
+	assert.NoError(t, f.SaveAs(file))
+	assert.NoError(t, f.Close())
+
+	f, err = OpenFile(file)
+	assert.NoError(t, err)
+	check(f)
+	assert.NoError(t, f.DeleteThreadedComment("Sheet1", "A1"))
+	threadedComments, err := f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, threadedComments, 1)
+	assert.Equal(t, "B2", threadedComments[0].Cell)
+	comments, err := f.GetComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+
+	// Test get threaded comments with unsupported charset
+	f.Pkg.Store("xl/threadedComments/threadedComment1.xml", MacintoshCyrillicCharset)
+	_, err = f.GetThreadedComments("Sheet1")
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.ResolveThreadedComment("Sheet1", "B2", false), "XML syntax error on line 1: invalid UTF-8")
+	f.Pkg.Store("xl/persons/person.xml", MacintoshCyrillicCharset)
+	_, err = f.GetPersons()
+	assert.EqualError(t, err, "XML syntax error on line 1: invalid UTF-8")
+	assert.NoError(t, f.Close())
+}
+
+func TestAddPerson(t *testing.T) {
+	f := NewFile()
+	persons, err := f.GetPersons()
+	assert.NoError(t, err)
+	assert.Empty(t, persons)
+	assert.NoError(t, f.AddPerson(Person{DisplayName: "Alice"}))
+	assert.NoError(t, f.AddPerson(Person{ID: "{00000000-0000-0000-0000-000000000001}", DisplayName: "Bob", UserID: "bob@example.com", ProviderID: "AD"}))
+	assert.Equal(t, ErrParameterRequired, f.AddPerson(Person{}))
+	assert.Equal(t, ErrParameterInvalid, f.AddPerson(Person{DisplayName: "Alice"}))
+	assert.Equal(t, ErrParameterInvalid, f.AddPerson(Person{ID: "{00000000-0000-0000-0000-000000000001}", DisplayName: "Carol"}))
+	persons, err = f.GetPersons()
+	assert.NoError(t, err)
+	assert.Len(t, persons, 2)
+	assert.Equal(t, "Alice", persons[0].UserID)
+	assert.Equal(t, "None", persons[0].ProviderID)
+	assert.Len(t, persons[0].ID, 38)
+	assert.Equal(t, Person{ID: "{00000000-0000-0000-0000-000000000001}", DisplayName: "Bob", UserID: "bob@example.com", ProviderID: "AD"}, persons[1])
+
+	// Test add threaded comment by the existing person
+	assert.NoError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "A1", Author: "Bob", Text: "Hi"}))
+	threadedComments, err := f.GetThreadedComments("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Bob", threadedComments[0].Author)
+	persons, err = f.GetPersons()
+	assert.NoError(t, err)
+	assert.Len(t, persons, 2)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestAddPerson.xlsx")))
+
+	// Test add person with unsupported charset
+	f.Pkg.Store("xl/persons/person.xml", MacintoshCyrillicCharset)
+	assert.EqualError(t, f.AddPerson(Person{DisplayName: "Carol"}), "XML syntax error on line 1: invalid UTF-8")
+	assert.EqualError(t, f.AddThreadedComment("Sheet1", ThreadedComment{Cell: "B1"}), "XML syntax error on line 1: invalid UTF-8")
+}
@@ -15,6 +15,7 @@ import (
 	"bytes"
 	"encoding/xml"
 	"io"
+	"path"
 	"path/filepath"
 	"reflect"
 	"strconv"
@@ -224,6 +225,15 @@ func (f *File) getWorkbookRelsPath() (path string) {
 	return
 }
 
+// getWorkbookRelsTargetPath provides a function to get the part path in the
+// spreadsheet by given relationship target of the workbook.
+func (f *File) getWorkbookRelsTargetPath(target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Join(path.Dir(f.getWorkbookPath()), target)
+}
+
 // deleteWorkbookRels provides a function to delete relationships in
 // xl/_rels/workbook.xml.rels by given type and target.
 func (f *File) deleteWorkbookRels(relType, relTarget string) (string, error) {
@@ -364,30 +374,40 @@ func (f *File) addContentTypePart(index int, contentType string) error {
 		"drawings": f.setContentTypePartImageExtensions,
 	}
 	partNames := map[string]string{
-		"chart":            "/xl/charts/chart" + strconv.Itoa(index) + ".xml",
-		"chartsheet":       "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
-		"comments":         "/xl/comments" + strconv.Itoa(index) + ".xml",
-		"customProperties": "/docProps/custom.xml",
-		"drawings":         "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
-		"table":            "/xl/tables/table" + strconv.Itoa(index) + ".xml",
-		"pivotTable":       "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
-		"pivotCache":       "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
-		"sharedStrings":    "/xl/sharedStrings.xml",
-		"slicer":           "/xl/slicers/slicer" + strconv.Itoa(index) + ".xml",
-		"slicerCache":      "/xl/slicerCaches/slicerCache" + strconv.Itoa(index) + ".xml",
+		"chart":             "/xl/charts/chart" + strconv.Itoa(index) + ".xml",
+		"chartsheet":        "/xl/chartsheets/sheet" + strconv.Itoa(index) + ".xml",
+		"comments":          "/xl/comments" + strconv.Itoa(index) + ".xml",
+		"customProperties":  "/docProps/custom.xml",
+		"drawings":          "/xl/drawings/drawing" + strconv.Itoa(index) + ".xml",
+		"externalLink":      "/xl/externalLinks/externalLink" + strconv.Itoa(index) + ".xml",
+		"metadata":          "/xl/metadata.xml",
+		"table":             "/xl/tables/table" + strconv.Itoa(index) + ".xml",
+		"pivotTable":        "/xl/pivotTables/pivotTable" + strconv.Itoa(index) + ".xml",
+		"pivotCache":        "/xl/pivotCache/pivotCacheDefinition" + strconv.Itoa(index) + ".xml",
+		"pivotCacheRecords": "/xl/pivotCache/pivotCacheRecords" + strconv.Itoa(index) + ".xml",
+		"person":            "/xl/persons/person.xml",
+		"sharedStrings":     "/xl/sharedStrings.xml",
+		"slicer":            "/xl/slicers/slicer" + strconv.Itoa(index) + ".xml",
+		"slicerCache":       "/xl/slicerCaches/slicerCache" + strconv.Itoa(index) + ".xml",
+		"threadedComment":   "/xl/threadedComments/threadedComment" + strconv.Itoa(index) + ".xml",
 	}
 	contentTypes := map[string]string{
-		"chart":            ContentTypeDrawingML,
-		"chartsheet":       ContentTypeSpreadSheetMLChartsheet,
-		"comments":         ContentTypeSpreadSheetMLComments,
-		"customProperties": ContentTypeCustomProperties,
-		"drawings":         ContentTypeDrawing,
-		"table":            ContentTypeSpreadSheetMLTable,
-		"pivotTable":       ContentTypeSpreadSheetMLPivotTable,
-		"pivotCache":       ContentTypeSpreadSheetMLPivotCacheDefinition,
-		"sharedStrings":    ContentTypeSpreadSheetMLSharedStrings,
-		"slicer":           ContentTypeSlicer,
-		"slicerCache":      ContentTypeSlicerCache,
+		"chart":             ContentTypeDrawingML,
+		"chartsheet":        ContentTypeSpreadSheetMLChartsheet,
+		"comments":          ContentTypeSpreadSheetMLComments,
+		"customProperties":  ContentTypeCustomProperties,
+		"drawings":          ContentTypeDrawing,
+		"externalLink":      ContentTypeSpreadSheetMLExternalLink,
+		"metadata":          ContentTypeSpreadSheetMLSheetMetadata,
+		"table":             ContentTypeSpreadSheetMLTable,
+		"pivotTable":        ContentTypeSpreadSheetMLPivotTable,
+		"pivotCache":        ContentTypeSpreadSheetMLPivotCacheDefinition,
+		"pivotCacheRecords": ContentTypeSpreadSheetMLPivotCacheRecords,
+		"person":            ContentTypePerson,
+		"sharedStrings":     ContentTypeSpreadSheetMLSharedStrings,
+		"slicer":            ContentTypeSlicer,
+		"slicerCache":       ContentTypeSlicerCache,
+		"threadedComment":   ContentTypeThreadedComments,
 	}
 	s, ok := setContentType[contentType]
 	if ok {
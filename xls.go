@@ -0,0 +1,1300 @@
+// Copyright 2016 - 2026 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.25.0 or later.
+
+package excelize
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/richardlehane/mscfb"
+)
+
+// Record types of the BIFF8 workbook stream.
+const (
+	xlsRecordFormula       = 0x0006
+	xlsRecordEOF           = 0x000A
+	xlsRecordExternSheet   = 0x0017
+	xlsRecordName          = 0x0018
+	xlsRecordDateMode      = 0x0022
+	xlsRecordExternName    = 0x0023
+	xlsRecordFilePass      = 0x002F
+	xlsRecordFont          = 0x0031
+	xlsRecordContinue      = 0x003C
+	xlsRecordWindow1       = 0x003D
+	xlsRecordColInfo       = 0x007D
+	xlsRecordBoundSheet    = 0x0085
+	xlsRecordPalette       = 0x0092
+	xlsRecordMulRk         = 0x00BD
+	xlsRecordMulBlank      = 0x00BE
+	xlsRecordRString       = 0x00D6
+	xlsRecordXF            = 0x00E0
+	xlsRecordMergeCells    = 0x00E5
+	xlsRecordSST           = 0x00FC
+	xlsRecordLabelSST      = 0x00FD
+	xlsRecordSupBook       = 0x01AE
+	xlsRecordBlank         = 0x0201
+	xlsRecordNumber        = 0x0203
+	xlsRecordLabel         = 0x0204
+	xlsRecordBoolErr       = 0x0205
+	xlsRecordString        = 0x0207
+	xlsRecordRow           = 0x0208
+	xlsRecordArray         = 0x0221
+	xlsRecordRK            = 0x027E
+	xlsRecordFormat        = 0x041E
+	xlsRecordSharedFormula = 0x04BC
+	xlsRecordBOF           = 0x0809
+)
+
+var (
+	// xlsErrorCodes defined the error values of the BIFF8 cells and formula
+	// tokens.
+	xlsErrorCodes = map[byte]string{
+		0x00: formulaErrorNULL,
+		0x07: formulaErrorDIV,
+		0x0F: formulaErrorVALUE,
+		0x17: formulaErrorREF,
+		0x1D: formulaErrorNAME,
+		0x24: formulaErrorNUM,
+		0x2A: formulaErrorNA,
+		0x2B: formulaErrorGETTINGDATA,
+	}
+	// xlsOperators defined the binary operators of the BIFF8 formula tokens.
+	xlsOperators = map[byte]string{
+		0x03: "+", 0x04: "-", 0x05: "*", 0x06: "/", 0x07: "^", 0x08: "&",
+		0x09: "<", 0x0A: "<=", 0x0B: "=", 0x0C: ">=", 0x0D: ">", 0x0E: "<>",
+		0x0F: " ", 0x10: ",", 0x11: ":",
+	}
+	// xlsHorizontalAlignments defined the horizontal alignment types of the
+	// BIFF8 cell formats.
+	xlsHorizontalAlignments = []string{"", "left", "center", "right", "fill", "justify", "centerContinuous", "distributed"}
+	// xlsVerticalAlignments defined the vertical alignment types of the BIFF8
+	// cell formats.
+	xlsVerticalAlignments = []string{"top", "center", "", "justify", "distributed"}
+	// xlsUnderlineTypes defined the underline types of the BIFF8 fonts.
+	xlsUnderlineTypes = map[int]string{0x01: "single", 0x02: "double", 0x21: "single", 0x22: "double"}
+	// errXLSFormula defined the error message on the formula tokens could not
+	// be converted to the formula text.
+	errXLSFormula = errors.New("unsupported formula tokens")
+)
+
+// xlsFunc defined the built-in function of the BIFF8 formula, the args is
+// the number of arguments of the fixed arguments function, -1 for the
+// variable arguments function.
+type xlsFunc struct {
+	name string
+	args int
+}
+
+// xlsFuncs defined the built-in functions of the BIFF8 formula by function
+// index.
+var xlsFuncs = map[int]xlsFunc{
+	0: {"COUNT", -1}, 1: {"IF", -1}, 2: {"ISNA", 1}, 3: {"ISERROR", 1}, 4: {"SUM", -1},
+	5: {"AVERAGE", -1}, 6: {"MIN", -1}, 7: {"MAX", -1}, 8: {"ROW", -1}, 9: {"COLUMN", -1},
+	10: {"NA", 0}, 11: {"NPV", -1}, 12: {"STDEV", -1}, 13: {"DOLLAR", -1}, 14: {"FIXED", -1},
+	15: {"SIN", 1}, 16: {"COS", 1}, 17: {"TAN", 1}, 18: {"ATAN", 1}, 19: {"PI", 0},
+	20: {"SQRT", 1}, 21: {"EXP", 1}, 22: {"LN", 1}, 23: {"LOG10", 1}, 24: {"ABS", 1},
+	25: {"INT", 1}, 26: {"SIGN", 1}, 27: {"ROUND", 2}, 28: {"LOOKUP", -1}, 29: {"INDEX", -1},
+	30: {"REPT", 2}, 31: {"MID", 3}, 32: {"LEN", 1}, 33: {"VALUE", 1}, 34: {"TRUE", 0},
+	35: {"FALSE", 0}, 36: {"AND", -1}, 37: {"OR", -1}, 38: {"NOT", 1}, 39: {"MOD", 2},
+	40: {"DCOUNT", 3}, 41: {"DSUM", 3}, 42: {"DAVERAGE", 3}, 43: {"DMIN", 3}, 44: {"DMAX", 3},
+	45: {"DSTDEV", 3}, 46: {"VAR", -1}, 47: {"DVAR", 3}, 48: {"TEXT", 2}, 49: {"LINEST", -1},
+	50: {"TREND", -1}, 51: {"LOGEST", -1}, 52: {"GROWTH", -1}, 56: {"PV", -1}, 57: {"FV", -1},
+	58: {"NPER", -1}, 59: {"PMT", -1}, 60: {"RATE", -1}, 61: {"MIRR", 3}, 62: {"IRR", -1},
+	63: {"RAND", 0}, 64: {"MATCH", -1}, 65: {"DATE", 3}, 66: {"TIME", 3}, 67: {"DAY", 1},
+	68: {"MONTH", 1}, 69: {"YEAR", 1}, 70: {"WEEKDAY", -1}, 71: {"HOUR", 1}, 72: {"MINUTE", 1},
+	73: {"SECOND", 1}, 74: {"NOW", 0}, 75: {"AREAS", 1}, 76: {"ROWS", 1}, 77: {"COLUMNS", 1},
+	78: {"OFFSET", -1}, 82: {"SEARCH", -1}, 83: {"TRANSPOSE", 1}, 86: {"TYPE", 1}, 97: {"ATAN2", 2},
+	98: {"ASIN", 1}, 99: {"ACOS", 1}, 100: {"CHOOSE", -1}, 101: {"HLOOKUP", -1}, 102: {"VLOOKUP", -1},
+	105: {"ISREF", 1}, 109: {"LOG", -1}, 111: {"CHAR", 1}, 112: {"LOWER", 1}, 113: {"UPPER", 1},
+	114: {"PROPER", 1}, 115: {"LEFT", -1}, 116: {"RIGHT", -1}, 117: {"EXACT", 2}, 118: {"TRIM", 1},
+	119: {"REPLACE", 4}, 120: {"SUBSTITUTE", -1}, 121: {"CODE", 1}, 124: {"FIND", -1}, 125: {"CELL", -1},
+	126: {"ISERR", 1}, 127: {"ISTEXT", 1}, 128: {"ISNUMBER", 1}, 129: {"ISBLANK", 1}, 130: {"T", 1},
+	131: {"N", 1}, 140: {"DATEVALUE", 1}, 141: {"TIMEVALUE", 1}, 142: {"SLN", 3}, 143: {"SYD", 4},
+	144: {"DDB", -1}, 148: {"INDIRECT", -1}, 162: {"CLEAN", 1}, 163: {"MDETERM", 1}, 164: {"MINVERSE", 1},
+	165: {"MMULT", 2}, 167: {"IPMT", -1}, 168: {"PPMT", -1}, 169: {"COUNTA", -1}, 183: {"PRODUCT", -1},
+	184: {"FACT", 1}, 189: {"DPRODUCT", 3}, 190: {"ISNONTEXT", 1}, 193: {"STDEVP", -1}, 194: {"VARP", -1},
+	195: {"DSTDEVP", 3}, 196: {"DVARP", 3}, 197: {"TRUNC", -1}, 198: {"ISLOGICAL", 1}, 199: {"DCOUNTA", 3},
+	204: {"USDOLLAR", -1}, 205: {"FINDB", -1}, 206: {"SEARCHB", -1}, 207: {"REPLACEB", 4}, 208: {"LEFTB", -1},
+	209: {"RIGHTB", -1}, 210: {"MIDB", 3}, 211: {"LENB", 1}, 212: {"ROUNDUP", 2}, 213: {"ROUNDDOWN", 2},
+	214: {"ASC", 1}, 215: {"DBCS", 1}, 216: {"RANK", -1}, 219: {"ADDRESS", -1}, 220: {"DAYS360", -1},
+	221: {"TODAY", 0}, 222: {"VDB", -1}, 227: {"MEDIAN", -1}, 228: {"SUMPRODUCT", -1}, 229: {"SINH", 1},
+	230: {"COSH", 1}, 231: {"TANH", 1}, 232: {"ASINH", 1}, 233: {"ACOSH", 1}, 234: {"ATANH", 1},
+	235: {"DGET", 3}, 244: {"INFO", 1}, 247: {"DB", -1}, 252: {"FREQUENCY", 2}, 261: {"ERROR.TYPE", 1},
+	269: {"AVEDEV", -1}, 270: {"BETADIST", -1}, 271: {"GAMMALN", 1}, 272: {"BETAINV", -1}, 273: {"BINOMDIST", 4},
+	274: {"CHIDIST", 2}, 275: {"CHIINV", 2}, 276: {"COMBIN", 2}, 277: {"CONFIDENCE", 3}, 278: {"CRITBINOM", 3},
+	279: {"EVEN", 1}, 280: {"EXPONDIST", 3}, 281: {"FDIST", 3}, 282: {"FINV", 3}, 283: {"FISHER", 1},
+	284: {"FISHERINV", 1}, 285: {"FLOOR", 2}, 286: {"GAMMADIST", 4}, 287: {"GAMMAINV", 3}, 288: {"CEILING", 2},
+	289: {"HYPGEOMDIST", 4}, 290: {"LOGNORMDIST", 3}, 291: {"LOGINV", 3}, 292: {"NEGBINOMDIST", 3}, 293: {"NORMDIST", 4},
+	294: {"NORMSDIST", 1}, 295: {"NORMINV", 3}, 296: {"NORMSINV", 1}, 297: {"STANDARDIZE", 3}, 298: {"ODD", 1},
+	299: {"PERMUT", 2}, 300: {"POISSON", 3}, 301: {"TDIST", 3}, 302: {"WEIBULL", 4}, 303: {"SUMXMY2", 2},
+	304: {"SUMX2MY2", 2}, 305: {"SUMX2PY2", 2}, 306: {"CHITEST", 2}, 307: {"CORREL", 2}, 308: {"COVAR", 2},
+	309: {"FORECAST", 3}, 310: {"FTEST", 2}, 311: {"INTERCEPT", 2}, 312: {"PEARSON", 2}, 313: {"RSQ", 2},
+	314: {"STEYX", 2}, 315: {"SLOPE", 2}, 316: {"TTEST", 4}, 317: {"PROB", -1}, 318: {"DEVSQ", -1},
+	319: {"GEOMEAN", -1}, 320: {"HARMEAN", -1}, 321: {"SUMSQ", -1}, 322: {"KURT", -1}, 323: {"SKEW", -1},
+	324: {"ZTEST", -1}, 325: {"LARGE", 2}, 326: {"SMALL", 2}, 327: {"QUARTILE", 2}, 328: {"PERCENTILE", 2},
+	329: {"PERCENTRANK", -1}, 330: {"MODE", -1}, 331: {"TRIMMEAN", 2}, 332: {"TINV", 2}, 336: {"CONCATENATE", -1},
+	337: {"POWER", 2}, 342: {"RADIANS", 1}, 343: {"DEGREES", 1}, 344: {"SUBTOTAL", -1}, 345: {"SUMIF", -1},
+	346: {"COUNTIF", 2}, 347: {"COUNTBLANK", 1}, 350: {"ISPMT", 4}, 351: {"DATEDIF", 3}, 352: {"DATESTRING", 1},
+	353: {"NUMBERSTRING", 2}, 354: {"ROMAN", -1}, 358: {"GETPIVOTDATA", -1}, 359: {"HYPERLINK", -1}, 360: {"PHONETIC", 1},
+	361: {"AVERAGEA", -1}, 362: {"MAXA", -1}, 363: {"MINA", -1}, 364: {"STDEVPA", -1}, 365: {"VARPA", -1},
+	366: {"STDEVA", -1}, 367: {"VARA", -1}, 368: {"BAHTTEXT", 1},
+}
+
+// xlsBuffer directly maps the little-endian data of the BIFF8 record, the
+// reading beyond the end of the data will be recorded as an error instead of
+// panic.
+type xlsBuffer struct {
+	data []byte
+	pos  int
+	err  bool
+}
+
+// bytes read the given number of bytes from the buffer.
+func (b *xlsBuffer) bytes(n int) []byte {
+	if n < 0 || b.pos+n > len(b.data) {
+		b.err, b.pos = true, len(b.data)
+		return make([]byte, max(n, 0))
+	}
+	b.pos += n
+	return b.data[b.pos-n : b.pos]
+}
+
+// u8 read an unsigned 8-bit integer from the buffer.
+func (b *xlsBuffer) u8() int { return int(b.bytes(1)[0]) }
+
+// u16 read an unsigned 16-bit integer from the buffer.
+func (b *xlsBuffer) u16() int { return int(binary.LittleEndian.Uint16(b.bytes(2))) }
+
+// u32 read an unsigned 32-bit integer from the buffer.
+func (b *xlsBuffer) u32() int { return int(binary.LittleEndian.Uint32(b.bytes(4))) }
+
+// f64 read a 64-bit floating point number from the buffer.
+func (b *xlsBuffer) f64() float64 {
+	return math.Float64frombits(binary.LittleEndian.Uint64(b.bytes(8)))
+}
+
+// chars read the characters of the unicode string from the buffer, the
+// characters are stored in UTF-16LE when the high byte flag is set, or
+// stored in the low bytes of the UTF-16 code units.
+func (b *xlsBuffer) chars(cch int, highByte bool) string {
+	if !highByte {
+		runes := make([]rune, 0, cch)
+		for _, c := range b.bytes(cch) {
+			runes = append(runes, rune(c))
+		}
+		return string(runes)
+	}
+	units := make([]uint16, cch)
+	data := b.bytes(cch * 2)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(data[i*2:])
+	}
+	return string(utf16.Decode(units))
+}
+
+// str read the unicode string with the given count of characters from the
+// buffer, the formatting runs and phonetic data of the string will be
+// skipped.
+func (b *xlsBuffer) str(cch int) string {
+	flags := b.u8()
+	var runs, ext int
+	if flags&0x08 != 0 {
+		runs = b.u16()
+	}
+	if flags&0x04 != 0 {
+		ext = b.u32()
+	}
+	s := b.chars(cch, flags&0x01 != 0)
+	b.bytes(runs*4 + ext)
+	return s
+}
+
+// xlsRecord directly maps the record of the BIFF8 workbook stream, the data
+// of the continue records are stored in separated chunks.
+type xlsRecord struct {
+	typ    int
+	chunks [][]byte
+}
+
+// data returns the data of the record with the continue records.
+func (r *xlsRecord) data() *xlsBuffer {
+	return &xlsBuffer{data: bytes.Join(r.chunks, nil)}
+}
+
+// xlsFont directly maps the font settings of the BIFF8 workbook.
+type xlsFont struct {
+	name                       string
+	size                       float64
+	bold, italic, strike       bool
+	underline, color, vertical int
+}
+
+// xlsXF directly maps the cell format of the BIFF8 workbook.
+type xlsXF struct {
+	font, numFmt, hAlign, vAlign, rotation, indent int
+	wrap, shrink                                   bool
+	borders                                        [4][2]int
+	pattern, fgColor                               int
+}
+
+// xlsSheet directly maps the sheet information of the BIFF8 workbook.
+type xlsSheet struct {
+	name         string
+	offset       int
+	state, typ   int
+	worksheet    bool
+	sharedFmla   map[[2]int]xlsSharedFormula
+	arrayFmla    map[[2]int]xlsSharedFormula
+	pendingCells []xlsFormulaCell
+}
+
+// xlsSharedFormula directly maps the shared formula or array formula of the
+// BIFF8 worksheet.
+type xlsSharedFormula struct {
+	ref        string
+	rgce, rgcb []byte
+}
+
+// xlsFormulaCell directly maps the formula cell which refers to a shared
+// formula or an array formula in the BIFF8 worksheet.
+type xlsFormulaCell struct {
+	cell, master [2]int
+}
+
+// xlsName directly maps the defined name of the BIFF8 workbook.
+type xlsName struct {
+	name       string
+	flags      int
+	tab        int
+	rgce, rgcb []byte
+}
+
+// xlsSupBook directly maps the supporting link of the BIFF8 workbook.
+type xlsSupBook struct {
+	self        bool
+	externNames []string
+}
+
+// xlsReader directly maps the reader state of the BIFF8 workbook stream.
+type xlsReader struct {
+	f            *File
+	stream       []byte
+	sst          []string
+	formats      map[int]string
+	fonts        []xlsFont
+	xfs          []xlsXF
+	styles       map[int]int
+	palette      []string
+	sheets       []*xlsSheet
+	names        []xlsName
+	supBooks     []xlsSupBook
+	externSheets [][3]int
+	date1904     bool
+	activeTab    int
+}
+
+// getXLSWorkbookStream provides a function to get the BIFF8 workbook stream
+// from the compound file binary by given unzip size limit, returns whether
+// the stream exists. The stream size can't exceed the size limit and the
+// size of the compound file.
+func getXLSWorkbookStream(raw []byte, sizeLimit int64) ([]byte, bool, error) {
+	doc, err := mscfb.New(bytes.NewReader(raw))
+	if err != nil {
+		return nil, false, nil
+	}
+	for entry, err := doc.Next(); err == nil; entry, err = doc.Next() {
+		if strings.EqualFold(entry.Name, "Workbook") || strings.EqualFold(entry.Name, "Book") {
+			if entry.Size > sizeLimit {
+				return nil, true, newUnzipSizeLimitError(sizeLimit)
+			}
+			if entry.Size < 0 || entry.Size > int64(len(raw)) {
+				return nil, true, ErrWorkbookFileFormat
+			}
+			buf := make([]byte, entry.Size)
+			if _, err := io.ReadFull(doc, buf); err != nil {
+				return nil, true, ErrWorkbookFileFormat
+			}
+			return buf, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// openXLS provides a function to read the BIFF8 workbook stream of the legacy
+// XLS file into a spreadsheet, the workbook globals, shared strings, cell
+// values, cell formats, merged cells and formulas with cached values will be
+// converted.
+func openXLS(stream []byte, opts *Options) (*File, error) {
+	x := &xlsReader{
+		f:       NewFile(),
+		stream:  stream,
+		formats: make(map[int]string),
+		styles:  make(map[int]int),
+		palette: append([]string{}, IndexedColorMapping...),
+	}
+	x.f.options = opts
+	if err := x.readGlobals(); err != nil {
+		return nil, err
+	}
+	if err := x.createSheets(); err != nil {
+		return nil, err
+	}
+	for _, sheet := range x.sheets {
+		if !sheet.worksheet {
+			continue
+		}
+		if err := x.readSheet(sheet); err != nil {
+			return nil, err
+		}
+	}
+	x.setDefinedNames()
+	return x.f, x.setSheetsState()
+}
+
+// nextRecord read the record with the continue records at the given offset
+// of the workbook stream, returns the record and the offset of the next
+// record.
+func (x *xlsReader) nextRecord(pos int) (*xlsRecord, int, bool) {
+	var rec *xlsRecord
+	for pos+4 <= len(x.stream) {
+		typ := int(binary.LittleEndian.Uint16(x.stream[pos:]))
+		size := int(binary.LittleEndian.Uint16(x.stream[pos+2:]))
+		if pos+4+size > len(x.stream) || (rec != nil && typ != xlsRecordContinue) {
+			break
+		}
+		if rec == nil {
+			rec = &xlsRecord{typ: typ}
+		}
+		rec.chunks = append(rec.chunks, x.stream[pos+4:pos+4+size])
+		pos += 4 + size
+	}
+	return rec, pos, rec != nil
+}
+
+// readGlobals read the workbook globals substream of the BIFF8 workbook
+// stream.
+func (x *xlsReader) readGlobals() error {
+	rec, pos, ok := x.nextRecord(0)
+	if !ok || rec.typ != xlsRecordBOF {
+		return ErrWorkbookFileFormat
+	}
+	if b := rec.data(); b.u16() != 0x0600 || b.u16() != 0x0005 {
+		return ErrWorkbookFileFormat
+	}
+	for rec, pos, ok = x.nextRecord(pos); ok && rec.typ != xlsRecordEOF; rec, pos, ok = x.nextRecord(pos) {
+		b := rec.data()
+		switch rec.typ {
+		case xlsRecordFilePass:
+			return ErrWorkbookFileFormat
+		case xlsRecordDateMode:
+			x.date1904 = b.u16() == 1
+		case xlsRecordWindow1:
+			b.bytes(10)
+			x.activeTab = b.u16()
+		case xlsRecordBoundSheet:
+			sheet := &xlsSheet{offset: b.u32(), state: b.u8() & 0x03, typ: b.u8()}
+			sheet.name = b.str(b.u8())
+			sheet.worksheet = sheet.typ == 0
+			x.sheets = append(x.sheets, sheet)
+		case xlsRecordFont:
+			x.readFont(b)
+		case xlsRecordFormat:
+			id := b.u16()
+			x.formats[id] = b.str(b.u16())
+		case xlsRecordXF:
+			x.readXF(b)
+		case xlsRecordPalette:
+			for i, count := 0, b.u16(); i < count && 8+i < len(x.palette); i++ {
+				rgb := b.bytes(4)
+				x.palette[8+i] = fmt.Sprintf("%02X%02X%02X", rgb[0], rgb[1], rgb[2])
+			}
+		case xlsRecordSST:
+			x.readSST(rec)
+		case xlsRecordSupBook:
+			b.u16()
+			cch := b.u16()
+			x.supBooks = append(x.supBooks, xlsSupBook{self: cch == 0x0401})
+		case xlsRecordExternName:
+			if len(x.supBooks) > 0 {
+				b.bytes(6)
+				sb := &x.supBooks[len(x.supBooks)-1]
+				sb.externNames = append(sb.externNames, b.str(b.u8()))
+			}
+		case xlsRecordExternSheet:
+			for i, count := 0, b.u16(); i < count; i++ {
+				x.externSheets = append(x.externSheets, [3]int{b.u16(), b.u16(), b.u16()})
+			}
+		case xlsRecordName:
+			x.readName(b)
+		}
+		if b.err {
+			return ErrWorkbookFileFormat
+		}
+	}
+	return nil
+}
+
+// readFont read the FONT record of the BIFF8 workbook globals.
+func (x *xlsReader) readFont(b *xlsBuffer) {
+	font := xlsFont{size: float64(b.u16()) / 20}
+	flags := b.u16()
+	font.italic, font.strike = flags&0x02 != 0, flags&0x08 != 0
+	font.color = b.u16()
+	font.bold = b.u16() >= 700
+	font.vertical = b.u16()
+	font.underline = b.u8()
+	b.bytes(3)
+	font.name = b.str(b.u8())
+	x.fonts = append(x.fonts, font)
+	// The font with index 4 is omitted in the font table
+	if len(x.fonts) == 4 {
+		x.fonts = append(x.fonts, font)
+	}
+}
+
+// readXF read the XF record of the BIFF8 workbook globals.
+func (x *xlsReader) readXF(b *xlsBuffer) {
+	xf := xlsXF{font: b.u16(), numFmt: b.u16()}
+	b.u16()
+	align := b.u8()
+	xf.hAlign, xf.wrap, xf.vAlign = align&0x07, align&0x08 != 0, (align>>4)&0x07
+	xf.rotation = b.u8()
+	indent := b.u8()
+	xf.indent, xf.shrink = indent&0x0F, indent&0x10 != 0
+	b.u8()
+	styles, colors, extra := b.u16(), b.u16(), b.u32()
+	for i := range xf.borders {
+		xf.borders[i][0] = (styles >> (i * 4)) & 0x0F
+	}
+	xf.borders[0][1], xf.borders[1][1] = colors&0x7F, (colors>>7)&0x7F
+	xf.borders[2][1], xf.borders[3][1] = extra&0x7F, (extra>>7)&0x7F
+	xf.pattern = (extra >> 26) & 0x3F
+	xf.fgColor = b.u16() & 0x7F
+	x.xfs = append(x.xfs, xf)
+}
+
+// readSST read the shared strings table of the BIFF8 workbook globals, the
+// characters of a string could be split into the continue records, and each
+// continue record starts with the option flags of the remaining characters.
+func (x *xlsReader) readSST(rec *xlsRecord) {
+	var (
+		chunk int
+		b     = &xlsBuffer{data: rec.chunks[0]}
+		next  = func() bool {
+			if chunk++; chunk >= len(rec.chunks) {
+				return false
+			}
+			b = &xlsBuffer{data: rec.chunks[chunk]}
+			return true
+		}
+		read = func(n int) {
+			for n > 0 {
+				if b.pos == len(b.data) && !next() {
+					return
+				}
+				size := min(n, len(b.data)-b.pos)
+				b.bytes(size)
+				n -= size
+			}
+		}
+	)
+	b.u32()
+	count := b.u32()
+	for i := 0; i < count; i++ {
+		if b.pos+3 > len(b.data) && !next() {
+			return
+		}
+		cch, flags := b.u16(), b.u8()
+		var runs, ext int
+		if flags&0x08 != 0 {
+			runs = b.u16()
+		}
+		if flags&0x04 != 0 {
+			ext = b.u32()
+		}
+		var sb strings.Builder
+		for highByte := flags&0x01 != 0; cch > 0; {
+			if b.pos == len(b.data) {
+				if !next() {
+					break
+				}
+				highByte = b.u8()&0x01 != 0
+			}
+			size := cch
+			if highByte {
+				if size = min(size, (len(b.data)-b.pos)/2); size == 0 {
+					// Skip the odd trailing byte of the 16-bit characters
+					b.pos = len(b.data)
+					continue
+				}
+			} else {
+				size = min(size, len(b.data)-b.pos)
+			}
+			sb.WriteString(b.chars(size, highByte))
+			cch -= size
+		}
+		read(runs*4 + ext)
+		x.sst = append(x.sst, sb.String())
+	}
+}
+
+// readName read the NAME record of the BIFF8 workbook globals.
+func (x *xlsReader) readName(b *xlsBuffer) {
+	name := xlsName{flags: b.u16()}
+	b.u8()
+	cch, cce := b.u8(), b.u16()
+	b.u16()
+	name.tab = b.u16()
+	b.bytes(4)
+	if name.flags&0x20 != 0 {
+		b.u8()
+		name.name = string(rune(b.u8()))
+	} else {
+		name.name = b.str(cch)
+	}
+	name.rgce = b.bytes(cce)
+	name.rgcb = b.data[b.pos:]
+	x.names = append(x.names, name)
+}
+
+// createSheets provides a function to create the worksheets in the
+// spreadsheet by the sheets of the BIFF8 workbook.
+func (x *xlsReader) createSheets() error {
+	var created bool
+	for _, sheet := range x.sheets {
+		if !sheet.worksheet {
+			continue
+		}
+		if !created {
+			created = true
+			if err := x.f.SetSheetName("Sheet1", sheet.name); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := x.f.NewSheet(sheet.name); err != nil {
+			return err
+		}
+	}
+	if !created {
+		return ErrWorkbookFileFormat
+	}
+	if x.date1904 {
+		date1904 := true
+		if err := x.f.SetWorkbookProps(&WorkbookPropsOptions{Date1904: &date1904}); err != nil {
+			return err
+		}
+	}
+	if len(x.fonts) > 0 && x.fonts[0].name != "" {
+		return x.f.SetDefaultFont(x.fonts[0].name)
+	}
+	return nil
+}
+
+// setSheetsState provides a function to set the active sheet and the
+// visibility of the worksheets in the spreadsheet.
+func (x *xlsReader) setSheetsState() error {
+	var idx int
+	for i, sheet := range x.sheets {
+		if !sheet.worksheet {
+			continue
+		}
+		if i == x.activeTab {
+			x.f.SetActiveSheet(idx)
+		}
+		idx++
+	}
+	for _, sheet := range x.sheets {
+		if sheet.worksheet && sheet.state != 0 {
+			if err := x.f.SetSheetVisible(sheet.name, false, sheet.state == 2); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setDefinedNames provides a function to add the defined names of the BIFF8
+// workbook into the spreadsheet, the built-in names, hidden names and the
+// names which could not be converted will be skipped.
+func (x *xlsReader) setDefinedNames() {
+	for _, name := range x.names {
+		if name.flags&0x23 != 0 || len(name.rgce) == 0 {
+			continue
+		}
+		refersTo, err := x.decodeFormula(name.rgce, name.rgcb, 0, 0)
+		if err != nil {
+			continue
+		}
+		definedName := &DefinedName{Name: name.name, RefersTo: refersTo}
+		if name.tab > 0 && name.tab <= len(x.sheets) {
+			definedName.Scope = x.sheets[name.tab-1].name
+		}
+		_ = x.f.SetDefinedName(definedName)
+	}
+}
+
+// readSheet read the worksheet substream of the BIFF8 workbook stream.
+func (x *xlsReader) readSheet(sheet *xlsSheet) error {
+	ws, err := x.f.workSheetReader(sheet.name)
+	if err != nil {
+		return err
+	}
+	sheet.sharedFmla = make(map[[2]int]xlsSharedFormula)
+	sheet.arrayFmla = make(map[[2]int]xlsSharedFormula)
+	var (
+		depth   int
+		pending *xlsxC
+	)
+	for rec, pos, ok := x.nextRecord(sheet.offset); ok; rec, pos, ok = x.nextRecord(pos) {
+		switch rec.typ {
+		case xlsRecordBOF:
+			depth++
+			continue
+		case xlsRecordEOF:
+			if depth--; depth <= 0 {
+				return x.setPendingFormulas(ws, sheet)
+			}
+			continue
+		}
+		if depth != 1 {
+			continue
+		}
+		b := rec.data()
+		if rec.typ == xlsRecordString {
+			if pending != nil {
+				pending.setCachedValue(newStringFormulaArg(b.str(b.u16())))
+			}
+			pending = nil
+			continue
+		}
+		if pending, err = x.readSheetRecord(ws, sheet, rec.typ, b); err != nil {
+			return err
+		}
+		if b.err {
+			return ErrWorkbookFileFormat
+		}
+	}
+	return ErrWorkbookFileFormat
+}
+
+// readSheetRecord read the record of the BIFF8 worksheet substream, returns
+// the formula cell which cached string value is stored in the following
+// STRING record.
+func (x *xlsReader) readSheetRecord(ws *xlsxWorksheet, sheet *xlsSheet, typ int, b *xlsBuffer) (*xlsxC, error) {
+	switch typ {
+	case xlsRecordNumber, xlsRecordRK:
+		c, err := x.prepareCell(ws, b.u16(), b.u16(), b.u16())
+		if err != nil {
+			return nil, err
+		}
+		if typ == xlsRecordNumber {
+			c.setCellFloat(b.f64(), -1, 64)
+			break
+		}
+		c.setCellFloat(decodeRK(b), -1, 64)
+	case xlsRecordMulRk:
+		for row, col := b.u16(), b.u16(); col <= 0xFF && b.pos+6 <= len(b.data)-2; col++ {
+			c, err := x.prepareCell(ws, row, col, b.u16())
+			if err != nil {
+				return nil, err
+			}
+			c.setCellFloat(decodeRK(b), -1, 64)
+		}
+	case xlsRecordBlank:
+		_, err := x.prepareCell(ws, b.u16(), b.u16(), b.u16())
+		return nil, err
+	case xlsRecordMulBlank:
+		for row, col := b.u16(), b.u16(); col <= 0xFF && b.pos+2 <= len(b.data)-2; col++ {
+			if _, err := x.prepareCell(ws, row, col, b.u16()); err != nil {
+				return nil, err
+			}
+		}
+	case xlsRecordLabelSST:
+		row, col, xf, idx := b.u16(), b.u16(), b.u16(), b.u32()
+		var value string
+		if idx < len(x.sst) {
+			value = x.sst[idx]
+		}
+		return nil, x.setCellString(ws, row, col, xf, value)
+	case xlsRecordLabel, xlsRecordRString:
+		row, col, xf := b.u16(), b.u16(), b.u16()
+		return nil, x.setCellString(ws, row, col, xf, b.str(b.u16()))
+	case xlsRecordBoolErr:
+		row, col, xf, value, isErr := b.u16(), b.u16(), b.u16(), b.u8(), b.u8()
+		c, err := x.prepareCell(ws, row, col, xf)
+		if err != nil {
+			return nil, err
+		}
+		if c.T, c.V = setCellBool(value != 0); isErr != 0 {
+			c.setCachedValue(newXLSErrorFormulaArg(value))
+		}
+	case xlsRecordFormula:
+		return x.readFormula(ws, sheet, b)
+	case xlsRecordSharedFormula, xlsRecordArray:
+		r1, r2, c1, c2 := b.u16(), b.u16(), b.u8(), b.u8()
+		if b.bytes(2); typ == xlsRecordArray {
+			b.bytes(4)
+		}
+		ref, err := coordinatesToRangeRef([]int{c1 + 1, r1 + 1, c2 + 1, r2 + 1})
+		if err != nil {
+			return nil, err
+		}
+		fmla := xlsSharedFormula{ref: ref, rgce: b.bytes(b.u16())}
+		fmla.rgcb = b.data[b.pos:]
+		if typ == xlsRecordArray {
+			sheet.arrayFmla[[2]int{r1, c1}] = fmla
+			break
+		}
+		sheet.sharedFmla[[2]int{r1, c1}] = fmla
+	case xlsRecordRow:
+		return nil, x.readRow(sheet, b)
+	case xlsRecordColInfo:
+		return nil, x.readColInfo(sheet, b)
+	case xlsRecordMergeCells:
+		for i, count := 0, b.u16(); i < count && !b.err; i++ {
+			r1, r2, c1, c2 := b.u16(), b.u16(), b.u16(), b.u16()
+			topLeft, err := CoordinatesToCellName(c1+1, r1+1)
+			if err != nil {
+				return nil, err
+			}
+			bottomRight, err := CoordinatesToCellName(c2+1, r2+1)
+			if err != nil {
+				return nil, err
+			}
+			if err = x.f.MergeCell(sheet.name, topLeft, bottomRight); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return nil, nil
+}
+
+// readFormula read the FORMULA record of the BIFF8 worksheet substream, the
+// cached value and the formula of the cell will be converted, and the
+// formula which could not be converted will be skipped.
+func (x *xlsReader) readFormula(ws *xlsxWorksheet, sheet *xlsSheet, b *xlsBuffer) (*xlsxC, error) {
+	row, col, xf := b.u16(), b.u16(), b.u16()
+	value := b.bytes(8)
+	b.bytes(6)
+	rgce := b.bytes(b.u16())
+	c, err := x.prepareCell(ws, row, col, xf)
+	if err != nil || b.err {
+		return nil, err
+	}
+	var pending *xlsxC
+	if value[6] == 0xFF && value[7] == 0xFF {
+		switch value[0] {
+		case 0x00:
+			pending = c
+		case 0x01:
+			c.setCachedValue(newBoolFormulaArg(value[2] != 0))
+		case 0x02:
+			c.setCachedValue(newXLSErrorFormulaArg(int(value[2])))
+		case 0x03:
+			c.setCachedValue(newStringFormulaArg(""))
+		}
+	} else {
+		c.setCellFloat(math.Float64frombits(binary.LittleEndian.Uint64(value)), -1, 64)
+	}
+	if len(rgce) == 5 && rgce[0] == 0x01 {
+		sheet.pendingCells = append(sheet.pendingCells, xlsFormulaCell{
+			cell:   [2]int{row, col},
+			master: [2]int{int(binary.LittleEndian.Uint16(rgce[1:])), int(binary.LittleEndian.Uint16(rgce[3:]))},
+		})
+		return pending, nil
+	}
+	if formula, err := x.decodeFormula(rgce, b.data[b.pos:], row, col); err == nil {
+		c.F = &xlsxF{Content: formula}
+	}
+	return pending, nil
+}
+
+// setPendingFormulas provides a function to set the formulas of the cells
+// which refer to the shared formulas or array formulas, these formulas are
+// stored after the first formula cell which refers to it.
+func (x *xlsReader) setPendingFormulas(ws *xlsxWorksheet, sheet *xlsSheet) error {
+	for _, cell := range sheet.pendingCells {
+		fmla, shared := sheet.sharedFmla[cell.master]
+		if !shared {
+			var ok bool
+			if fmla, ok = sheet.arrayFmla[cell.master]; !ok || cell.cell != cell.master {
+				continue
+			}
+		}
+		formula, err := x.decodeFormula(fmla.rgce, fmla.rgcb, cell.cell[0], cell.cell[1])
+		if err != nil {
+			continue
+		}
+		c, err := x.prepareCell(ws, cell.cell[0], cell.cell[1], -1)
+		if err != nil {
+			return err
+		}
+		if c.F = (&xlsxF{Content: formula}); !shared {
+			c.F.T, c.F.Ref = STCellFormulaTypeArray, fmla.ref
+		}
+	}
+	return nil
+}
+
+// readRow read the ROW record of the BIFF8 worksheet substream.
+func (x *xlsReader) readRow(sheet *xlsSheet, b *xlsBuffer) error {
+	row := b.u16() + 1
+	b.bytes(4)
+	height := b.u16() & 0x7FFF
+	b.bytes(4)
+	flags := b.u16()
+	if flags&0x40 != 0 {
+		if err := x.f.SetRowHeight(sheet.name, row, float64(height)/20); err != nil {
+			return err
+		}
+	}
+	if flags&0x20 != 0 {
+		return x.f.SetRowVisible(sheet.name, row, false)
+	}
+	return nil
+}
+
+// readColInfo read the COLINFO record of the BIFF8 worksheet substream.
+func (x *xlsReader) readColInfo(sheet *xlsSheet, b *xlsBuffer) error {
+	first, last, width := b.u16()+1, min(b.u16()+1, 256), b.u16()
+	b.u16()
+	hidden := b.u16()&0x01 != 0
+	if b.err || first > last {
+		return nil
+	}
+	startCol, err := ColumnNumberToName(first)
+	if err != nil {
+		return err
+	}
+	endCol, err := ColumnNumberToName(last)
+	if err != nil {
+		return err
+	}
+	if err = x.f.SetColWidth(sheet.name, startCol, endCol, float64(width)/256); err != nil || !hidden {
+		return err
+	}
+	return x.f.SetColVisible(sheet.name, startCol+":"+endCol, false)
+}
+
+// prepareCell provides a function to prepare the cell of the worksheet by
+// given zero-based row and column number, and set the cell style by the
+// given XF index, the negative XF index will keep the cell style.
+func (x *xlsReader) prepareCell(ws *xlsxWorksheet, row, col, xf int) (*xlsxC, error) {
+	if col > 0xFF {
+		return nil, ErrWorkbookFileFormat
+	}
+	ws.prepareSheetXML(col+1, row+1)
+	c := &ws.SheetData.Row[row].C[col]
+	if xf < 0 {
+		return c, nil
+	}
+	styleID, err := x.getStyle(xf)
+	c.S = styleID
+	return c, err
+}
+
+// setCellString provides a function to set the string value of the cell by
+// given zero-based row and column number.
+func (x *xlsReader) setCellString(ws *xlsxWorksheet, row, col, xf int, value string) error {
+	c, err := x.prepareCell(ws, row, col, xf)
+	if err != nil {
+		return err
+	}
+	c.T, c.V, err = x.f.setCellString(value)
+	return err
+}
+
+// decodeRK read the RK value from the buffer, the RK value is a 30-bit
+// signed integer or the most significant 30 bits of a floating point number,
+// and could be divided by 100.
+func decodeRK(b *xlsBuffer) float64 {
+	rk := uint32(b.u32())
+	value := math.Float64frombits(uint64(rk&^0x03) << 32)
+	if rk&0x02 != 0 {
+		value = float64(int32(rk) >> 2)
+	}
+	if rk&0x01 != 0 {
+		value /= 100
+	}
+	return value
+}
+
+// newXLSErrorFormulaArg create an error formula argument by given BIFF8 error
+// code.
+func newXLSErrorFormulaArg(code int) formulaArg {
+	if msg, ok := xlsErrorCodes[byte(code)]; ok {
+		return newErrorFormulaArg(msg, msg)
+	}
+	return newErrorFormulaArg(formulaErrorVALUE, formulaErrorVALUE)
+}
+
+// getStyle provides a function to get the style ID in the spreadsheet by
+// given XF index of the BIFF8 workbook, the style will be created on the
+// first use.
+func (x *xlsReader) getStyle(xf int) (int, error) {
+	if styleID, ok := x.styles[xf]; ok {
+		return styleID, nil
+	}
+	var (
+		styleID int
+		err     error
+	)
+	if xf < len(x.xfs) {
+		if style := x.newStyle(x.xfs[xf]); style != nil {
+			styleID, err = x.f.NewStyle(style)
+		}
+	}
+	x.styles[xf] = styleID
+	return styleID, err
+}
+
+// newStyle provides a function to convert the XF of the BIFF8 workbook to
+// the style definition, returns nil for the default cell format.
+func (x *xlsReader) newStyle(xf xlsXF) *Style {
+	var style Style
+	if _, ok := builtInNumFmt[xf.numFmt]; ok && xf.numFmt != 0 {
+		style.NumFmt = xf.numFmt
+	} else if code, ok := x.formats[xf.numFmt]; ok {
+		style.CustomNumFmt = &code
+	}
+	if xf.font > 0 && xf.font < len(x.fonts) && x.fonts[xf.font] != x.fonts[0] {
+		font := x.fonts[xf.font]
+		style.Font = &Font{
+			Bold: font.bold, Italic: font.italic, Strike: font.strike,
+			Underline: xlsUnderlineTypes[font.underline], Family: font.name,
+			Size: font.size, Color: x.getColor(font.color),
+		}
+		if font.vertical == 1 || font.vertical == 2 {
+			style.Font.VertAlign = []string{"superscript", "subscript"}[font.vertical-1]
+		}
+	}
+	if xf.hAlign != 0 || xf.vAlign != 2 || xf.wrap || xf.shrink || xf.indent != 0 || xf.rotation != 0 {
+		style.Alignment = &Alignment{
+			WrapText: xf.wrap, ShrinkToFit: xf.shrink, Indent: xf.indent, TextRotation: xf.rotation,
+		}
+		if xf.hAlign < len(xlsHorizontalAlignments) {
+			style.Alignment.Horizontal = xlsHorizontalAlignments[xf.hAlign]
+		}
+		if xf.vAlign < len(xlsVerticalAlignments) {
+			style.Alignment.Vertical = xlsVerticalAlignments[xf.vAlign]
+		}
+	}
+	for i, typ := range []string{"left", "right", "top", "bottom"} {
+		if xf.borders[i][0] != 0 {
+			style.Border = append(style.Border, Border{
+				Type: typ, Style: xf.borders[i][0], Color: x.getColor(xf.borders[i][1]),
+			})
+		}
+	}
+	if xf.pattern > 0 && xf.pattern < len(styleFillPatterns) {
+		style.Fill = Fill{Type: "pattern", Pattern: xf.pattern}
+		if color := x.getColor(xf.fgColor); color != "" {
+			style.Fill.Color = []string{color}
+		}
+	}
+	if style.NumFmt == 0 && style.CustomNumFmt == nil && style.Font == nil &&
+		style.Alignment == nil && style.Border == nil && style.Fill.Type == "" {
+		return nil
+	}
+	return &style
+}
+
+// getColor provides a function to get the RGB color by given color index of
+// the BIFF8 workbook, returns empty string for the system colors.
+func (x *xlsReader) getColor(idx int) string {
+	if idx < 64 && idx < len(x.palette) {
+		return x.palette[idx]
+	}
+	return ""
+}
+
+// decodeFormula provides a function to convert the parsed expression tokens
+// in reverse-polish notation of the BIFF8 formula to the formula text, the
+// row and column number are used to resolve the relative reference tokens.
+func (x *xlsReader) decodeFormula(rgce, rgcb []byte, row, col int) (string, error) {
+	var (
+		stack []string
+		b     = &xlsBuffer{data: rgce}
+		extra = &xlsBuffer{data: rgcb}
+		pop   = func(n int) []string {
+			if n > len(stack) {
+				b.err = true
+				return make([]string, n)
+			}
+			args := append([]string{}, stack[len(stack)-n:]...)
+			stack = stack[:len(stack)-n]
+			return args
+		}
+	)
+	for b.pos < len(b.data) && !b.err {
+		ptg := b.u8()
+		if op, ok := xlsOperators[byte(ptg)]; ok {
+			args := pop(2)
+			stack = append(stack, args[0]+op+args[1])
+			continue
+		}
+		if ptg >= 0x20 && ptg < 0x80 {
+			ptg = 0x20 | ptg&0x1F
+		}
+		token, err := x.decodeToken(ptg, b, extra, pop, row, col)
+		if err != nil {
+			return "", err
+		}
+		if token != nil {
+			stack = append(stack, *token)
+		}
+	}
+	if b.err || len(stack) != 1 {
+		return "", errXLSFormula
+	}
+	return stack[0], nil
+}
+
+// decodeToken provides a function to convert the parsed expression token of
+// the BIFF8 formula to the formula text, returns nil if the token doesn't
+// produce any operand.
+func (x *xlsReader) decodeToken(ptg int, b, extra *xlsBuffer, pop func(n int) []string, row, col int) (*string, error) {
+	var token string
+	switch ptg {
+	case 0x12:
+		token = "+" + pop(1)[0]
+	case 0x13:
+		token = "-" + pop(1)[0]
+	case 0x14:
+		token = pop(1)[0] + "%"
+	case 0x15:
+		token = "(" + pop(1)[0] + ")"
+	case 0x16:
+	case 0x17:
+		token = "\"" + strings.ReplaceAll(b.str(b.u8()), "\"", "\"\"") + "\""
+	case 0x19:
+		grbit, data := b.u8(), b.u16()
+		if grbit&0x04 != 0 {
+			b.bytes((data + 1) * 2)
+		}
+		if grbit&0x10 == 0 {
+			return nil, nil
+		}
+		token = "SUM(" + pop(1)[0] + ")"
+	case 0x1C:
+		token = newXLSErrorFormulaArg(b.u8()).String
+	case 0x1D:
+		token = strings.ToUpper(strconv.FormatBool(b.u8() != 0))
+	case 0x1E:
+		token = strconv.Itoa(b.u16())
+	case 0x1F:
+		token = strings.ToUpper(strconv.FormatFloat(b.f64(), 'g', -1, 64))
+	case 0x20:
+		b.bytes(7)
+		token = x.decodeArray(extra)
+	case 0x21, 0x22:
+		return x.decodeFunction(ptg, b, pop)
+	case 0x23:
+		idx := b.u32()
+		if idx < 1 || idx > len(x.names) || x.names[idx-1].flags&0x20 != 0 {
+			return nil, errXLSFormula
+		}
+		token = x.names[idx-1].name
+	case 0x24, 0x2C:
+		token = xlsCellRef(b.u16(), b.u16(), row, col, ptg == 0x2C)
+	case 0x25, 0x2D:
+		token = xlsAreaRef(b.u16(), b.u16(), b.u16(), b.u16(), row, col, ptg == 0x2D)
+	case 0x26, 0x27, 0x28:
+		b.bytes(6)
+		if ptg == 0x26 {
+			extra.bytes(extra.u16() * 8)
+		}
+		return nil, nil
+	case 0x29, 0x2E, 0x2F:
+		b.bytes(2)
+		return nil, nil
+	case 0x2A:
+		b.bytes(4)
+		token = formulaErrorREF
+	case 0x2B:
+		b.bytes(8)
+		token = formulaErrorREF
+	case 0x39:
+		return x.decodeNameX(b)
+	case 0x3A, 0x3B, 0x3C, 0x3D:
+		prefix, err := x.getSheetPrefix(b.u16())
+		switch ptg {
+		case 0x3A:
+			token = prefix + xlsCellRef(b.u16(), b.u16(), row, col, false)
+		case 0x3B:
+			token = prefix + xlsAreaRef(b.u16(), b.u16(), b.u16(), b.u16(), row, col, false)
+		case 0x3C:
+			b.bytes(4)
+			token = prefix + formulaErrorREF
+		default:
+			b.bytes(8)
+			token = prefix + formulaErrorREF
+		}
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errXLSFormula
+	}
+	return &token, nil
+}
+
+// decodeFunction provides a function to convert the function token of the
+// BIFF8 formula to the function call formula text.
+func (x *xlsReader) decodeFunction(ptg int, b *xlsBuffer, pop func(n int) []string) (*string, error) {
+	argc, idx := -1, 0
+	if ptg == 0x22 {
+		argc = b.u8() & 0x7F
+	}
+	idx = b.u16() & 0x7FFF
+	fn, ok := xlsFuncs[idx]
+	if argc == -1 {
+		argc = fn.args
+	}
+	if idx == 255 && argc > 0 {
+		args := pop(argc)
+		token := args[0] + "(" + strings.Join(args[1:], ",") + ")"
+		return &token, nil
+	}
+	if !ok || argc < 0 {
+		return nil, errXLSFormula
+	}
+	token := fn.name + "(" + strings.Join(pop(argc), ",") + ")"
+	return &token, nil
+}
+
+// decodeNameX provides a function to convert the external name token of the
+// BIFF8 formula to the name, the add-in functions are stored as the external
+// names.
+func (x *xlsReader) decodeNameX(b *xlsBuffer) (*string, error) {
+	ixti, idx := b.u16(), b.u16()
+	b.u16()
+	if ixti >= len(x.externSheets) || x.externSheets[ixti][0] >= len(x.supBooks) {
+		return nil, errXLSFormula
+	}
+	supBook := x.supBooks[x.externSheets[ixti][0]]
+	if supBook.self {
+		if idx < 1 || idx > len(x.names) {
+			return nil, errXLSFormula
+		}
+		return &x.names[idx-1].name, nil
+	}
+	if idx < 1 || idx > len(supBook.externNames) {
+		return nil, errXLSFormula
+	}
+	return &supBook.externNames[idx-1], nil
+}
+
+// decodeArray provides a function to convert the array constant of the
+// BIFF8 formula to the formula text.
+func (x *xlsReader) decodeArray(b *xlsBuffer) string {
+	cols, rows := b.u8()+1, b.u16()+1
+	var sb strings.Builder
+	sb.WriteString("{")
+	for r := 0; r < rows && !b.err; r++ {
+		if r > 0 {
+			sb.WriteString(";")
+		}
+		for c := 0; c < cols && !b.err; c++ {
+			if c > 0 {
+				sb.WriteString(",")
+			}
+			switch b.u8() {
+			case 0x01:
+				sb.WriteString(strings.ToUpper(strconv.FormatFloat(b.f64(), 'g', -1, 64)))
+			case 0x02:
+				sb.WriteString("\"" + strings.ReplaceAll(b.str(b.u16()), "\"", "\"\"") + "\"")
+			case 0x04:
+				sb.WriteString(strings.ToUpper(strconv.FormatBool(b.bytes(8)[0] != 0)))
+			case 0x10:
+				sb.WriteString(newXLSErrorFormulaArg(int(b.bytes(8)[0])).String)
+			default:
+				b.bytes(8)
+			}
+		}
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// getSheetPrefix provides a function to get the sheet name prefix of the
+// 3D reference by given index of the EXTERNSHEET record, only the references
+// to the sheets of the same workbook are supported.
+func (x *xlsReader) getSheetPrefix(ixti int) (string, error) {
+	if ixti >= len(x.externSheets) {
+		return "", errXLSFormula
+	}
+	xti := x.externSheets[ixti]
+	if xti[0] >= len(x.supBooks) || !x.supBooks[xti[0]].self ||
+		xti[1] >= len(x.sheets) || xti[2] >= len(x.sheets) {
+		return "", errXLSFormula
+	}
+	name := x.sheets[xti[1]].name
+	if xti[2] != xti[1] {
+		name += ":" + x.sheets[xti[2]].name
+	}
+	return escapeSheetName(name) + "!", nil
+}
+
+// xlsCellRef provides a function to convert the row and column fields of the
+// BIFF8 reference token to the cell reference, the row and column offset
+// will be resolved with given base cell for the relative reference tokens.
+func xlsCellRef(row, col, baseRow, baseCol int, relative bool) string {
+	rowRel, colRel := col&0x8000 != 0, col&0x4000 != 0
+	if col &= 0xFF; relative && rowRel {
+		row = (baseRow + int(int16(row))) & 0xFFFF
+	}
+	if relative && colRel {
+		col = (baseCol + int(int8(col))) & 0xFF
+	}
+	return xlsColRef(col, colRel) + xlsRowRef(row, rowRel)
+}
+
+// xlsAreaRef provides a function to convert the fields of the BIFF8 area
+// reference token to the range reference, the entire rows and columns will
+// be converted to the row range and column range reference.
+func xlsAreaRef(r1, r2, c1, c2, baseRow, baseCol int, relative bool) string {
+	if !relative && r1 == 0 && r2 == 0xFFFF {
+		return xlsColRef(c1&0xFF, c1&0x4000 != 0) + ":" + xlsColRef(c2&0xFF, c2&0x4000 != 0)
+	}
+	if !relative && c1&0xFF == 0 && c2&0xFF == 0xFF {
+		return xlsRowRef(r1, c1&0x8000 != 0) + ":" + xlsRowRef(r2, c2&0x8000 != 0)
+	}
+	return xlsCellRef(r1, c1, baseRow, baseCol, relative) + ":" + xlsCellRef(r2, c2, baseRow, baseCol, relative)
+}
+
+// xlsColRef provides a function to convert the zero-based column number to
+// the column reference.
+func xlsColRef(col int, relative bool) string {
+	name, _ := ColumnNumberToName(col + 1)
+	if relative {
+		return name
+	}
+	return "$" + name
+}
+
+// xlsRowRef provides a function to convert the zero-based row number to the
+// row reference.
+func xlsRowRef(row int, relative bool) string {
+	if relative {
+		return strconv.Itoa(row + 1)
+	}
+	return "$" + strconv.Itoa(row+1)
+}
@@ -0,0 +1,400 @@
+package excelize
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// xlsTestSheet defined the sheet substream for building the BIFF8 workbook
+// stream in the tests.
+type xlsTestSheet struct {
+	name       string
+	state, typ uint8
+	records    [][]byte
+}
+
+// xlsTestRecord build the BIFF8 record by given record type and fields, the
+// int fields will be written as the unsigned 16-bit integers, and the string
+// fields will be written as the compressed characters.
+func xlsTestRecord(typ int, fields ...any) []byte {
+	var buf bytes.Buffer
+	for _, field := range fields {
+		switch v := field.(type) {
+		case int:
+			_ = binary.Write(&buf, binary.LittleEndian, uint16(v))
+		case string:
+			buf.WriteString(v)
+		default:
+			_ = binary.Write(&buf, binary.LittleEndian, v)
+		}
+	}
+	record := binary.LittleEndian.AppendUint16(nil, uint16(typ))
+	record = binary.LittleEndian.AppendUint16(record, uint16(buf.Len()))
+	return append(record, buf.Bytes()...)
+}
+
+// xlsTestWorkbook build the compound file of the legacy XLS file by given
+// records of the workbook globals and sheets.
+func xlsTestWorkbook(globals [][]byte, sheets ...xlsTestSheet) []byte {
+	bof := xlsTestRecord(xlsRecordBOF, 0x0600, 0x0005, make([]byte, 12))
+	boundSheet := func(offset int, sheet xlsTestSheet) []byte {
+		return xlsTestRecord(xlsRecordBoundSheet, uint32(offset), sheet.state, sheet.typ,
+			uint8(len(sheet.name)), uint8(0), sheet.name)
+	}
+	offset := len(bof) + len(bytes.Join(globals, nil)) + 4
+	for _, sheet := range sheets {
+		offset += len(boundSheet(0, sheet))
+	}
+	stream := append(bof, bytes.Join(globals, nil)...)
+	var substreams []byte
+	for _, sheet := range sheets {
+		stream = append(stream, boundSheet(offset+len(substreams), sheet)...)
+		substreams = append(substreams, xlsTestRecord(xlsRecordBOF, 0x0600, 0x0010, make([]byte, 12))...)
+		substreams = append(substreams, bytes.Join(sheet.records, nil)...)
+		substreams = append(substreams, xlsTestRecord(xlsRecordEOF)...)
+	}
+	stream = append(append(stream, xlsTestRecord(xlsRecordEOF)...), substreams...)
+	compoundFile := &cfb{
+		paths:   []string{"Root Entry/"},
+		sectors: []sector{{name: "Root Entry", typeID: 5}},
+	}
+	compoundFile.put("Workbook", stream)
+	return compoundFile.write()
+}
+
+// xlsTestFormula build the FORMULA record by given zero-based cell
+// coordinates, the cached value and the parsed expression tokens.
+func xlsTestFormula(row, col int, value []byte, rgce []byte, rgcb ...byte) []byte {
+	return xlsTestRecord(xlsRecordFormula, row, col, 0, value, uint16(0), uint32(0),
+		uint16(len(rgce)), rgce, rgcb)
+}
+
+func TestOpenXLS(t *testing.T) {
+	num := func(v float64) []byte { return binary.LittleEndian.AppendUint64(nil, math.Float64bits(v)) }
+	special := func(typ, value byte) []byte { return []byte{typ, 0, value, 0, 0, 0, 0xFF, 0xFF} }
+	font := func(size, flags, color, weight int, name string) []byte {
+		return xlsTestRecord(xlsRecordFont, size, flags, color, weight, 0, uint8(0), make([]byte, 3),
+			uint8(len(name)), uint8(0), name)
+	}
+	globals := [][]byte{
+		xlsTestRecord(xlsRecordWindow1, make([]byte, 10), 3, make([]byte, 6)),
+		xlsTestRecord(xlsRecordPalette, 1, []byte{0x12, 0x34, 0x56, 0}),
+		font(200, 0, 0x7FFF, 400, "Arial"),
+		font(200, 0, 0x7FFF, 400, "Arial"),
+		font(200, 0, 0x7FFF, 400, "Arial"),
+		font(200, 0, 0x7FFF, 400, "Arial"),
+		font(280, 0x0A, 8, 700, "Calibri"),
+		xlsTestRecord(xlsRecordFormat, 164, 5, uint8(0), "0.000"),
+		// Default cell format
+		xlsTestRecord(xlsRecordXF, 0, 0, 0xFFF5, uint8(0x20), make([]byte, 13)),
+		// Cell format with font, number format, alignment, border and fill
+		xlsTestRecord(xlsRecordXF, 5, 164, 0x0001, uint8(0x1A), uint8(45), uint8(0x12), uint8(0),
+			0x0001, 0x0008, uint32(0x04000000), 0x000D),
+		// Cell format with built-in date number format
+		xlsTestRecord(xlsRecordXF, 0, 14, 0x0001, uint8(0x20), make([]byte, 13)),
+		xlsTestRecord(xlsRecordSST, uint32(3), uint32(3), 5, uint8(0), "Hello", 6, uint8(0), "Wor"),
+		xlsTestRecord(xlsRecordContinue, uint8(1), []byte{'l', 0, 'd', 0, '!', 0},
+			4, uint8(0x0C), 1, uint32(2), "Rich", make([]byte, 6)),
+		xlsTestRecord(xlsRecordSupBook, 4, 0x0401),
+		xlsTestRecord(xlsRecordSupBook, 1, 0x3A01),
+		xlsTestRecord(xlsRecordExternName, 0, uint32(0), uint8(6), uint8(0), "MYFUNC", 2, []byte{0x1C, 0x17}),
+		xlsTestRecord(xlsRecordExternSheet, 2, 0, 1, 1, 1, 0xFFFE, 0xFFFE),
+		xlsTestRecord(xlsRecordName, 0, uint8(0), uint8(5), 7, 0, 0, uint32(0), uint8(0), "Total",
+			[]byte{0x3A, 0, 0, 0, 0, 0, 0}),
+		xlsTestRecord(xlsRecordName, 0, uint8(0), uint8(5), 5, 0, 1, uint32(0), uint8(0), "Local",
+			[]byte{0x24, 1, 0, 1, 0}),
+		xlsTestRecord(xlsRecordName, 0x01, uint8(0), uint8(6), 5, 0, 0, uint32(0), uint8(0), "Hidden",
+			[]byte{0x24, 1, 0, 1, 0}),
+		xlsTestRecord(xlsRecordName, 0x20, uint8(0), uint8(1), 5, 0, 1, uint32(0), uint8(0), uint8(6),
+			[]byte{0x24, 1, 0, 1, 0}),
+		xlsTestRecord(xlsRecordName, 0, uint8(0), uint8(3), 1, 0, 0, uint32(0), uint8(0), "Bad", []byte{0x18}),
+	}
+	sheet1 := []byte{}
+	for _, record := range [][]byte{
+		xlsTestRecord(xlsRecordColInfo, 0, 1, 20*256, 0, 0, 0),
+		xlsTestRecord(xlsRecordColInfo, 2, 2, 10*256, 0, 1, 0),
+		xlsTestRecord(xlsRecordRow, 0, 0, 10, 600, 0, 0, 0x40, 0x0F),
+		xlsTestRecord(xlsRecordRow, 5, 0, 10, 255, 0, 0, 0x20, 0x0F),
+		xlsTestRecord(xlsRecordNumber, 0, 0, 1, 1.5),
+		xlsTestRecord(xlsRecordRK, 0, 1, 0, uint32(100<<2|2)),
+		xlsTestRecord(xlsRecordMulRk, 1, 0, 0, uint32(1<<2|2), 0, uint32(2<<2|2), 1),
+		xlsTestRecord(xlsRecordRK, 1, 2, 0, uint32(123<<2|3)),
+		xlsTestRecord(xlsRecordRK, 1, 3, 0, uint32(0x3FF80000)),
+		xlsTestRecord(xlsRecordLabelSST, 2, 0, 0, uint32(0)),
+		xlsTestRecord(xlsRecordLabelSST, 2, 1, 0, uint32(1)),
+		xlsTestRecord(xlsRecordLabel, 2, 2, 0, 5, uint8(0), "Label"),
+		xlsTestRecord(xlsRecordLabelSST, 2, 3, 0, uint32(2)),
+		xlsTestRecord(xlsRecordRString, 2, 4, 0, 4, uint8(0x09), 1, []byte{'T', 0, 'e', 0, 'x', 0, 't', 0}, make([]byte, 4)),
+		xlsTestRecord(xlsRecordBoolErr, 3, 0, 0, uint8(1), uint8(0)),
+		xlsTestRecord(xlsRecordBoolErr, 3, 1, 0, uint8(0x07), uint8(1)),
+		xlsTestRecord(xlsRecordMulBlank, 4, 0, 2, 2, 1),
+		xlsTestRecord(xlsRecordBlank, 4, 2, 0),
+		xlsTestFormula(0, 2, num(101.5), []byte{0x24, 0, 0, 0, 0xC0, 0x24, 0, 0, 1, 0xC0, 0x03}),
+		xlsTestFormula(0, 3, special(0, 0), []byte{0x17, 1, 0, 'a', 0x17, 1, 0, '"', 0x08}),
+		xlsTestRecord(xlsRecordString, 2, uint8(0), "a\""),
+		xlsTestFormula(0, 4, special(1, 1), []byte{0x1D, 1}),
+		xlsTestFormula(0, 5, special(2, 0x07), []byte{0x1E, 1, 0, 0x1E, 0, 0, 0x06}),
+		xlsTestFormula(0, 6, special(3, 0), []byte{0x23, 1, 0, 0, 0}),
+		xlsTestFormula(0, 7, num(0), []byte{0x39, 1, 0, 1, 0, 0, 0, 0x1E, 1, 0, 0x42, 2, 0xFF, 0}),
+		xlsTestFormula(0, 8, num(3), append(append([]byte{0x1F}, num(2.5)...), 0x15, 0x1E, 0, 0, 0x41, 27, 0)),
+		xlsTestFormula(0, 9, num(1), []byte{0x60, 0, 0, 0, 0, 0, 0, 0},
+			append(append([]byte{1, 1, 0, 0x01}, num(1)...), 0x02, 1, 0, 0, 'a', 0x04, 1, 0, 0, 0, 0, 0, 0, 0,
+				0x10, 0x2A, 0, 0, 0, 0, 0, 0, 0)...),
+		xlsTestFormula(0, 10, num(42), []byte{0x3A, 0, 0, 0, 0, 0, 0}),
+		xlsTestFormula(5, 0, num(1), []byte{0x01, 5, 0, 0, 0}),
+		xlsTestRecord(xlsRecordSharedFormula, 5, 6, uint8(0), uint8(0), uint8(0), uint8(2), 5,
+			[]byte{0x2C, 0xFF, 0xFF, 0, 0xC0}),
+		xlsTestFormula(6, 0, num(1), []byte{0x01, 5, 0, 0, 0}),
+		xlsTestFormula(5, 1, num(1.5), []byte{0x01, 5, 0, 1, 0}),
+		xlsTestRecord(xlsRecordArray, 5, 6, uint8(1), uint8(1), 0, uint32(0), 9,
+			[]byte{0x25, 0, 0, 1, 0, 0, 0, 0, 0}),
+		xlsTestFormula(6, 1, num(2), []byte{0x01, 5, 0, 1, 0}),
+		xlsTestFormula(5, 2, num(7), []byte{0x18, 0x01}),
+		xlsTestFormula(5, 3, num(7), []byte{0x01, 9, 0, 9, 0}),
+		xlsTestRecord(xlsRecordMergeCells, 1, 9, 10, 0, 1),
+		// Embedded chart substream should be skipped
+		xlsTestRecord(xlsRecordBOF, 0x0600, 0x0020, make([]byte, 12)),
+		xlsTestRecord(xlsRecordNumber, 99, 25, 0, 1.0),
+		xlsTestRecord(xlsRecordEOF),
+	} {
+		sheet1 = append(sheet1, record...)
+	}
+	raw := xlsTestWorkbook(globals,
+		xlsTestSheet{name: "Sheet1", records: [][]byte{sheet1}},
+		xlsTestSheet{name: "Sheet2", state: 1, records: [][]byte{xlsTestRecord(xlsRecordNumber, 0, 0, 0, 42.0)}},
+		xlsTestSheet{name: "Chart1", typ: 2},
+		xlsTestSheet{name: "Sheet 3"},
+	)
+	f, err := OpenReader(bytes.NewReader(raw))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Sheet1", "Sheet2", "Sheet 3"}, f.GetSheetList())
+	assert.Equal(t, 2, f.GetActiveSheetIndex())
+	visible, err := f.GetSheetVisible("Sheet2")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	fontName, err := f.GetDefaultFont()
+	assert.NoError(t, err)
+	assert.Equal(t, "Arial", fontName)
+	// Test get cell values
+	for cell, expected := range map[string]string{
+		"A1": "1.500", "B1": "100", "A2": "1", "B2": "2", "C2": "1.23", "D2": "1.5",
+		"A3": "Hello", "B3": "World!", "C3": "Label", "D3": "Rich", "E3": "Text", "A4": "TRUE", "B4": "#DIV/0!",
+		"C1": "101.5", "D1": "a\"", "E1": "TRUE", "F1": "#DIV/0!", "G1": "", "K1": "42",
+		"A6": "1", "B7": "2", "C6": "7", "Z100": "",
+	} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	// Test get cell formulas
+	for cell, expected := range map[string]string{
+		"C1": "A1+B1", "D1": "\"a\"&\"\"\"\"", "E1": "TRUE", "F1": "1/0", "G1": "Total",
+		"H1": "MYFUNC(1)", "I1": "ROUND((2.5),0)", "J1": "{1,\"a\";TRUE,#N/A}", "K1": "Sheet2!$A$1",
+		"A6": "A5", "A7": "A6", "B6": "$A$1:$A$2", "B7": "", "C6": "", "D6": "",
+	} {
+		formula, err := f.GetCellFormula("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, formula, cell)
+	}
+	ws, ok := f.Sheet.Load("xl/worksheets/sheet1.xml")
+	assert.True(t, ok)
+	assert.Equal(t, &xlsxF{T: STCellFormulaTypeArray, Ref: "B6:B7", Content: "$A$1:$A$2"},
+		ws.(*xlsxWorksheet).SheetData.Row[5].C[1].F)
+	// Test get defined names
+	assert.Equal(t, []DefinedName{
+		{Name: "Total", RefersTo: "Sheet2!$A$1", Scope: "Workbook"},
+		{Name: "Local", RefersTo: "$B$2", Scope: "Sheet1"},
+	}, f.GetDefinedName())
+	// Test get merged cells, column and row settings
+	mergeCells, err := f.GetMergeCells("Sheet1")
+	assert.NoError(t, err)
+	assert.Len(t, mergeCells, 1)
+	assert.Equal(t, "A10", mergeCells[0].GetStartAxis())
+	assert.Equal(t, "B11", mergeCells[0].GetEndAxis())
+	width, err := f.GetColWidth("Sheet1", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, width)
+	visible, err = f.GetColVisible("Sheet1", "C")
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	height, err := f.GetRowHeight("Sheet1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 30.0, height)
+	visible, err = f.GetRowVisible("Sheet1", 6)
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	// Test get cell styles
+	styleID, err := f.GetCellStyle("Sheet1", "A1")
+	assert.NoError(t, err)
+	style, err := f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.000", *style.CustomNumFmt)
+	assert.Equal(t, &Font{Bold: true, Italic: true, Strike: true, Family: "Calibri", Size: 14, Color: "123456"}, style.Font)
+	assert.Equal(t, &Alignment{Horizontal: "center", Vertical: "center", WrapText: true, TextRotation: 45, Indent: 2, ShrinkToFit: true}, style.Alignment)
+	assert.Equal(t, []Border{{Type: "left", Color: "123456", Style: 1}}, style.Border)
+	assert.Equal(t, Fill{Type: "pattern", Pattern: 1, Color: []string{"FFFF00"}}, style.Fill)
+	styleID, err = f.GetCellStyle("Sheet1", "A5")
+	assert.NoError(t, err)
+	style, err = f.GetStyle(styleID)
+	assert.NoError(t, err)
+	assert.Equal(t, 14, style.NumFmt)
+	styleID, err = f.GetCellStyle("Sheet1", "C5")
+	assert.NoError(t, err)
+	assert.Zero(t, styleID)
+	assert.NoError(t, f.SaveAs(filepath.Join("test", "TestOpenXLS.xlsx")))
+	assert.NoError(t, f.Close())
+	// Test open workbook with the odd trailing byte of the 16-bit characters
+	// in the shared strings table
+	raw = xlsTestWorkbook([][]byte{
+		xlsTestRecord(xlsRecordSST, uint32(1), uint32(1), 3, uint8(1), "a"),
+		xlsTestRecord(xlsRecordContinue, uint8(1), []byte{'b', 0, 'c', 0}),
+	}, xlsTestSheet{name: "Sheet1", records: [][]byte{xlsTestRecord(xlsRecordLabelSST, 0, 0, 0, uint32(0))}})
+	f, err = OpenReader(bytes.NewReader(raw))
+	assert.NoError(t, err)
+	value, err := f.GetCellValue("Sheet1", "A1")
+	assert.NoError(t, err)
+	assert.Equal(t, "bc", value)
+	assert.NoError(t, f.Close())
+}
+
+func TestOpenXLSError(t *testing.T) {
+	for _, globals := range [][][]byte{
+		// Test open encrypted workbook
+		{xlsTestRecord(xlsRecordFilePass, 0)},
+		// Test open workbook with invalid record
+		{xlsTestRecord(xlsRecordFont, 0)},
+	} {
+		_, err := OpenReader(bytes.NewReader(xlsTestWorkbook(globals, xlsTestSheet{name: "Sheet1"})))
+		assert.Equal(t, ErrWorkbookFileFormat, err)
+	}
+	// Test open workbook without worksheet
+	_, err := OpenReader(bytes.NewReader(xlsTestWorkbook(nil, xlsTestSheet{name: "Chart1", typ: 2})))
+	assert.Equal(t, ErrWorkbookFileFormat, err)
+	// Test open workbook with invalid sheet name
+	_, err = OpenReader(bytes.NewReader(xlsTestWorkbook(nil, xlsTestSheet{name: "Sheet:1"})))
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	_, err = OpenReader(bytes.NewReader(xlsTestWorkbook(nil, xlsTestSheet{name: "Sheet1"}, xlsTestSheet{name: "Sheet:2"})))
+	assert.Equal(t, ErrSheetNameInvalid, err)
+	// Test open workbook with invalid worksheet records
+	for _, record := range [][]byte{
+		xlsTestRecord(xlsRecordNumber, 0),
+		xlsTestRecord(xlsRecordNumber, 0, 0xFFFF, 0, 1.0),
+		xlsTestRecord(xlsRecordNumber, 0, 0x100, 0, 1.0),
+		xlsTestRecord(xlsRecordMergeCells, 1, 0, 0, 0, 0xFFFF),
+	} {
+		_, err = OpenReader(bytes.NewReader(xlsTestWorkbook(nil, xlsTestSheet{name: "Sheet1", records: [][]byte{record}})))
+		assert.Error(t, err)
+	}
+	// Test open workbook with unsupported BIFF version
+	compoundFile := &cfb{paths: []string{"Root Entry/"}, sectors: []sector{{name: "Root Entry", typeID: 5}}}
+	compoundFile.put("Book", xlsTestRecord(xlsRecordBOF, 0x0500, 0x0005, make([]byte, 4)))
+	_, err = OpenReader(bytes.NewReader(compoundFile.write()))
+	assert.Equal(t, ErrWorkbookFileFormat, err)
+	// Test open workbook with the stream size over the unzip size limit
+	_, err = OpenReader(bytes.NewReader(xlsTestWorkbook(nil, xlsTestSheet{name: "Sheet1"})), Options{UnzipSizeLimit: 16, UnzipXMLSizeLimit: 16})
+	assert.EqualError(t, err, newUnzipSizeLimitError(16).Error())
+	// Test open workbook with the stream size over the compound file size
+	raw := xlsTestWorkbook(nil, xlsTestSheet{name: "Sheet1"})
+	idx := bytes.Index(raw, []byte("W\x00o\x00r\x00k\x00b\x00o\x00o\x00k\x00"))
+	assert.NotEqual(t, -1, idx)
+	binary.LittleEndian.PutUint32(raw[idx+120:], 0x7FFFFFFF)
+	_, err = OpenReader(bytes.NewReader(raw))
+	assert.Equal(t, ErrWorkbookFileFormat, err)
+	// Test open workbook with truncated worksheet substream
+	x := &xlsReader{f: NewFile(), stream: xlsTestRecord(xlsRecordBOF, 0x0600, 0x0010)}
+	assert.Equal(t, ErrWorkbookFileFormat, x.readSheet(&xlsSheet{name: "Sheet1"}))
+	assert.Equal(t, ErrSheetNotExist{"SheetN"}, x.readSheet(&xlsSheet{name: "SheetN"}))
+}
+
+func TestOpenXLSSparseSheet(t *testing.T) {
+	rk := func(v int) uint32 { return uint32(v<<2 | 0x02) }
+	f, err := OpenReader(bytes.NewReader(xlsTestWorkbook(nil, xlsTestSheet{name: "Sheet1", records: [][]byte{
+		xlsTestRecord(xlsRecordNumber, 0, 0xFF, 0, 1.0),
+		xlsTestRecord(xlsRecordNumber, 2000, 0, 0, 2.0),
+		// Test the cells in the multiple cells records over the last column
+		xlsTestRecord(xlsRecordMulRk, 2001, 0xFE, 0, rk(3), 0, rk(4), 0, rk(5), 0x100),
+		xlsTestRecord(xlsRecordMulBlank, 2002, 0xFF, 0, 0, 0x100),
+		// Test the row record after the skipped rows
+		xlsTestRecord(xlsRecordRow, 0xFFFF, 0, 0, 0, 0, 0, 0x20, 0),
+	}})))
+	assert.NoError(t, err)
+	ws, err := f.workSheetReader("Sheet1")
+	assert.NoError(t, err)
+	assert.Equal(t, 0x10000, len(ws.SheetData.Row))
+	for _, row := range []int{1, 1999, 2003, 0xFFFE} {
+		assert.Zero(t, cap(ws.SheetData.Row[row].C), row)
+	}
+	assert.Len(t, ws.SheetData.Row[2001].C, 0x100)
+	assert.Len(t, ws.SheetData.Row[2002].C, 0x100)
+	for cell, expected := range map[string]string{"IV1": "1", "A2001": "2", "IU2002": "3", "IV2002": "4"} {
+		value, err := f.GetCellValue("Sheet1", cell)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, value, cell)
+	}
+	visible, err := f.GetRowVisible("Sheet1", 0x10000)
+	assert.NoError(t, err)
+	assert.False(t, visible)
+	assert.NoError(t, f.Close())
+}
+
+func TestDecodeXLSFormula(t *testing.T) {
+	x := &xlsReader{
+		sheets:       []*xlsSheet{{name: "Sheet1"}, {name: "Sheet 2"}},
+		names:        []xlsName{{name: "Name1"}, {name: "Print_Area", flags: 0x20}},
+		supBooks:     []xlsSupBook{{self: true}, {externNames: []string{"ADDIN"}}},
+		externSheets: [][3]int{{0, 0, 1}, {1, 0, 0}, {0, 0, 5}, {2, 0, 0}},
+	}
+	for _, c := range []struct {
+		rgce, rgcb []byte
+		expected   string
+	}{
+		{rgce: []byte{0x1E, 1, 0, 0x12, 0x1E, 2, 0, 0x13, 0x14, 0x05}, expected: "+1*-2%"},
+		{rgce: []byte{0x16, 0x1E, 1, 0, 0x42, 2, 1, 0}, expected: "IF(,1)"},
+		{rgce: []byte{0x25, 0, 0, 0xFF, 0xFF, 0, 0, 1, 0, 0x19, 0x10, 0, 0}, expected: "SUM($A:$B)"},
+		{rgce: []byte{0x25, 1, 0, 2, 0, 0, 0, 0xFF, 0}, expected: "$2:$3"},
+		{rgce: []byte{0x2D, 1, 0, 2, 0, 0xFF, 0xC0, 1, 0xC0}, expected: "J12:L13"},
+		{rgce: []byte{0x19, 0x04, 1, 0, 0, 0, 0, 0, 0x19, 0x40, 0, 0, 0x1E, 1, 0}, expected: "1"},
+		{rgce: []byte{0x26, 0, 0, 0, 0, 1, 0, 0x2A, 0, 0, 0, 0}, rgcb: []byte{1, 0, 0, 0, 0, 0, 0, 0, 0, 0}, expected: "#REF!"},
+		{rgce: []byte{0x27, 0, 0, 0, 0, 0, 0, 0x29, 0, 0, 0x2B, 0, 0, 0, 0, 0, 0, 0, 0}, expected: "#REF!"},
+		{rgce: []byte{0x3B, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0}, expected: "'Sheet1:Sheet 2'!$A$1:$B$1"},
+		{rgce: []byte{0x3C, 0, 0, 0, 0, 0, 0}, expected: "'Sheet1:Sheet 2'!#REF!"},
+		{rgce: []byte{0x3D, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, expected: "'Sheet1:Sheet 2'!#REF!"},
+		{rgce: []byte{0x39, 1, 0, 1, 0, 0, 0}, expected: "ADDIN"},
+		{rgce: []byte{0x39, 0, 0, 1, 0, 0, 0}, expected: "Name1"},
+		{rgce: []byte{0x1C, 0x2B}, expected: "#GETTING_DATA"},
+		{rgce: []byte{0x1C, 0x01}, expected: "#VALUE!"},
+		{rgce: []byte{0x1F, 0, 0, 0, 0, 0, 0x88, 0xD3, 0x40}, expected: "20000"},
+		{rgce: []byte{0x40, 0, 0, 0, 0, 0, 0, 0}, rgcb: []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, expected: "{}"},
+		{rgce: []byte{0x1E, 1, 0, 0x1E, 2, 0, 0x0F}, expected: "1 2"},
+	} {
+		formula, err := x.decodeFormula(c.rgce, c.rgcb, 10, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, formula)
+	}
+	for _, rgce := range [][]byte{
+		{0x01, 0, 0, 0, 0},
+		{0x03},
+		{0x1E, 1},
+		{0x1E, 1, 0, 0x1E, 1, 0},
+		{0x21, 0xFF, 0xFF},
+		{0x22, 1, 0xFF, 0xFF},
+		{0x22, 0, 0xFF, 0},
+		{0x21, 4, 0},
+		{0x23, 2, 0, 0, 0},
+		{0x23, 0, 0, 0, 0},
+		{0x39, 9, 0, 1, 0, 0, 0},
+		{0x39, 0, 0, 9, 0, 0, 0},
+		{0x39, 1, 0, 9, 0, 0, 0},
+		{0x3A, 9, 0, 0, 0, 0, 0},
+		{0x3A, 2, 0, 0, 0, 0, 0},
+		{0x3A, 3, 0, 0, 0, 0, 0},
+	} {
+		_, err := x.decodeFormula(rgce, nil, 0, 0)
+		assert.Equal(t, errXLSFormula, err, rgce)
+	}
+}
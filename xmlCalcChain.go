@@ -122,3 +122,32 @@ type xlsxVolTopicRef struct {
 	R string `xml:"r,attr"`
 	S int    `xml:"s,attr"`
 }
+
+// DependencyNode directly maps the cell or the cell range in the formula
+// dependency graph. The Ref is a cell reference such as A1 or a range
+// reference such as A1:B3, and the Formula is the formula of the cell, which
+// will be empty for the cells without formula and the cell ranges.
+type DependencyNode struct {
+	Sheet   string
+	Ref     string
+	Formula string
+}
+
+// DependencyEdge directly maps the edge in the formula dependency graph, the
+// From and To are the indexes of the nodes, and the From node is a precedent
+// of the To node.
+type DependencyEdge struct {
+	From int
+	To   int
+}
+
+// DependencyGraph directly maps the formula dependency graph of a cell, the
+// first node is the given cell.
+type DependencyGraph struct {
+	index  map[string]int
+	sheets map[string]string
+	ranges []cellRange
+	edges  map[DependencyEdge]bool
+	Nodes  []DependencyNode
+	Edges  []DependencyEdge
+}
@@ -696,6 +696,8 @@ type Chart struct {
 	HoleSize     int
 	GapWidth     *uint
 	Overlap      *int
+	Cell         string
+	Combo        []*Chart
 	order        int
 }
 
@@ -0,0 +1,306 @@
+// Copyright 2016 - 2026 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.25.0 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// decodeChartSpace defines the structure used to deserialize the chartSpace
+// element of the chart part. In order to solve the problem that the prefixed
+// element names of the xlsxChartSpace can't be matched on deserialization,
+// the decodeChartSpace just for deserialization.
+type decodeChartSpace struct {
+	XMLName  xml.Name         `xml:"http://schemas.openxmlformats.org/drawingml/2006/chart chartSpace"`
+	Date1904 *attrValBool     `xml:"date1904"`
+	Chart    decodeChart      `xml:"chart"`
+	SpPr     *decodeChartSpPr `xml:"spPr"`
+	TxPr     *decodeChartText `xml:"txPr"`
+}
+
+// decodeChart defines the structure used to deserialize the c:chart element
+// of the chart part.
+type decodeChart struct {
+	Title            *decodeChartTitle  `xml:"title"`
+	AutoTitleDeleted *attrValBool       `xml:"autoTitleDeleted"`
+	PlotArea         *decodeChartPlot   `xml:"plotArea"`
+	Legend           *decodeChartLegend `xml:"legend"`
+	PlotVisOnly      *attrValBool       `xml:"plotVisOnly"`
+	DispBlanksAs     *attrValString     `xml:"dispBlanksAs"`
+}
+
+// decodeChartTitle defines the structure used to deserialize the c:title
+// element.
+type decodeChartTitle struct {
+	Tx      *decodeChartTx     `xml:"tx"`
+	Layout  *decodeChartLayout `xml:"layout"`
+	Overlay *attrValBool       `xml:"overlay"`
+	SpPr    *decodeChartSpPr   `xml:"spPr"`
+	TxPr    *decodeChartText   `xml:"txPr"`
+}
+
+// decodeChartLayout defines the structure used to deserialize the c:layout
+// element.
+type decodeChartLayout struct {
+	ManualLayout *cManualLayout `xml:"manualLayout"`
+}
+
+// decodeChartTx defines the structure used to deserialize the c:tx element,
+// the text can be specified by the rich text, cell reference or value.
+type decodeChartTx struct {
+	StrRef *cStrRef         `xml:"strRef"`
+	Rich   *decodeChartText `xml:"rich"`
+	V      string           `xml:"v"`
+}
+
+// decodeChartText defines the structure used to deserialize the c:rich and
+// c:txPr element.
+type decodeChartText struct {
+	BodyPr *decodeChartBodyPr `xml:"bodyPr"`
+	P      []decodeChartP     `xml:"p"`
+}
+
+// decodeChartBodyPr defines the structure used to deserialize the a:bodyPr
+// element.
+type decodeChartBodyPr struct {
+	Rot  int    `xml:"rot,attr"`
+	Vert string `xml:"vert,attr"`
+}
+
+// decodeChartP defines the structure used to deserialize the a:p element.
+type decodeChartP struct {
+	PPr *decodeChartPPr `xml:"pPr"`
+	R   []decodeChartR  `xml:"r"`
+}
+
+// decodeChartPPr defines the structure used to deserialize the a:pPr
+// element.
+type decodeChartPPr struct {
+	DefRPr *decodeChartRPr `xml:"defRPr"`
+}
+
+// decodeChartR defines the structure used to deserialize the a:r element.
+type decodeChartR struct {
+	RPr *decodeChartRPr `xml:"rPr"`
+	T   string          `xml:"t"`
+}
+
+// decodeChartRPr defines the structure used to deserialize the a:rPr and
+// a:defRPr element.
+type decodeChartRPr struct {
+	B         *bool                 `xml:"b,attr"`
+	I         *bool                 `xml:"i,attr"`
+	U         string                `xml:"u,attr"`
+	Strike    string                `xml:"strike,attr"`
+	Sz        float64               `xml:"sz,attr"`
+	SolidFill *decodeChartSolidFill `xml:"solidFill"`
+	Latin     *xlsxCTTextFont       `xml:"latin"`
+}
+
+// decodeChartSolidFill defines the structure used to deserialize the
+// a:solidFill element.
+type decodeChartSolidFill struct {
+	SchemeClr *decodeChartColor `xml:"schemeClr"`
+	SrgbClr   *decodeChartColor `xml:"srgbClr"`
+}
+
+// decodeChartColor defines the structure used to deserialize the
+// a:schemeClr and a:srgbClr element.
+type decodeChartColor struct {
+	Val    string      `xml:"val,attr"`
+	LumMod *attrValInt `xml:"lumMod"`
+	LumOff *attrValInt `xml:"lumOff"`
+	Alpha  *attrValInt `xml:"alpha"`
+}
+
+// decodeChartSpPr defines the structure used to deserialize the c:spPr
+// element.
+type decodeChartSpPr struct {
+	NoFill    *xlsxInnerXML         `xml:"noFill"`
+	SolidFill *decodeChartSolidFill `xml:"solidFill"`
+	Ln        *decodeChartLn        `xml:"ln"`
+}
+
+// decodeChartLn defines the structure used to deserialize the a:ln element.
+type decodeChartLn struct {
+	W         int                   `xml:"w,attr"`
+	NoFill    *xlsxInnerXML         `xml:"noFill"`
+	SolidFill *decodeChartSolidFill `xml:"solidFill"`
+	PrstDash  *attrValString        `xml:"prstDash"`
+}
+
+// decodeChartPlot defines the structure used to deserialize the c:plotArea
+// element.
+type decodeChartPlot struct {
+	AreaChart      []*decodeChartGroup `xml:"areaChart"`
+	Area3DChart    []*decodeChartGroup `xml:"area3DChart"`
+	BarChart       []*decodeChartGroup `xml:"barChart"`
+	Bar3DChart     []*decodeChartGroup `xml:"bar3DChart"`
+	BubbleChart    []*decodeChartGroup `xml:"bubbleChart"`
+	DoughnutChart  []*decodeChartGroup `xml:"doughnutChart"`
+	LineChart      []*decodeChartGroup `xml:"lineChart"`
+	Line3DChart    []*decodeChartGroup `xml:"line3DChart"`
+	StockChart     []*decodeChartGroup `xml:"stockChart"`
+	PieChart       []*decodeChartGroup `xml:"pieChart"`
+	Pie3DChart     []*decodeChartGroup `xml:"pie3DChart"`
+	OfPieChart     []*decodeChartGroup `xml:"ofPieChart"`
+	RadarChart     []*decodeChartGroup `xml:"radarChart"`
+	ScatterChart   []*decodeChartGroup `xml:"scatterChart"`
+	Surface3DChart []*decodeChartGroup `xml:"surface3DChart"`
+	SurfaceChart   []*decodeChartGroup `xml:"surfaceChart"`
+	CatAx          []*decodeChartAxis  `xml:"catAx"`
+	ValAx          []*decodeChartAxis  `xml:"valAx"`
+	DateAx         []*decodeChartAxis  `xml:"dateAx"`
+	SerAx          []*decodeChartAxis  `xml:"serAx"`
+	DTable         *decodeChartDTable  `xml:"dTable"`
+	SpPr           *decodeChartSpPr    `xml:"spPr"`
+}
+
+// decodeChartDTable defines the structure used to deserialize the c:dTable
+// element.
+type decodeChartDTable struct {
+	ShowKeys *attrValBool `xml:"showKeys"`
+}
+
+// decodeChartGroup defines the structure used to deserialize the chart
+// group elements in the plot area, such as c:barChart and c:lineChart.
+type decodeChartGroup struct {
+	BarDir        *attrValString         `xml:"barDir"`
+	Grouping      *attrValString         `xml:"grouping"`
+	RadarStyle    *attrValString         `xml:"radarStyle"`
+	ScatterStyle  *attrValString         `xml:"scatterStyle"`
+	OfPieType     *attrValString         `xml:"ofPieType"`
+	Wireframe     *attrValBool           `xml:"wireframe"`
+	VaryColors    *attrValBool           `xml:"varyColors"`
+	Ser           []*decodeChartSeries   `xml:"ser"`
+	DLbls         *decodeChartDLbls      `xml:"dLbls"`
+	DropLines     *decodeChartLines      `xml:"dropLines"`
+	HiLowLines    *decodeChartLines      `xml:"hiLowLines"`
+	UpDownBars    *decodeChartUpDownBars `xml:"upDownBars"`
+	GapWidth      *attrValInt            `xml:"gapWidth"`
+	Overlap       *attrValInt            `xml:"overlap"`
+	SplitPos      *attrValInt            `xml:"splitPos"`
+	FirstSliceAng *attrValInt            `xml:"firstSliceAng"`
+	HoleSize      *attrValInt            `xml:"holeSize"`
+	BubbleScale   *attrValFloat          `xml:"bubbleScale"`
+	Shape         *attrValString         `xml:"shape"`
+	AxID          []*attrValInt          `xml:"axId"`
+}
+
+// decodeChartUpDownBars defines the structure used to deserialize the
+// c:upDownBars element.
+type decodeChartUpDownBars struct {
+	UpBars   *decodeChartLines `xml:"upBars"`
+	DownBars *decodeChartLines `xml:"downBars"`
+}
+
+// decodeChartSeries defines the structure used to deserialize the c:ser
+// element.
+type decodeChartSeries struct {
+	IDx        *attrValInt        `xml:"idx"`
+	Order      *attrValInt        `xml:"order"`
+	Tx         *decodeChartTx     `xml:"tx"`
+	SpPr       *decodeChartSpPr   `xml:"spPr"`
+	Marker     *decodeChartMarker `xml:"marker"`
+	DPt        []*decodeChartDPt  `xml:"dPt"`
+	DLbls      *decodeChartDLbls  `xml:"dLbls"`
+	Cat        *decodeChartData   `xml:"cat"`
+	Val        *decodeChartData   `xml:"val"`
+	XVal       *decodeChartData   `xml:"xVal"`
+	YVal       *decodeChartData   `xml:"yVal"`
+	Smooth     *attrValBool       `xml:"smooth"`
+	BubbleSize *decodeChartData   `xml:"bubbleSize"`
+	Bubble3D   *attrValBool       `xml:"bubble3D"`
+}
+
+// decodeChartMarker defines the structure used to deserialize the c:marker
+// element.
+type decodeChartMarker struct {
+	Symbol *attrValString   `xml:"symbol"`
+	Size   *attrValInt      `xml:"size"`
+	SpPr   *decodeChartSpPr `xml:"spPr"`
+}
+
+// decodeChartDPt defines the structure used to deserialize the c:dPt
+// element.
+type decodeChartDPt struct {
+	IDx  *attrValInt      `xml:"idx"`
+	SpPr *decodeChartSpPr `xml:"spPr"`
+}
+
+// decodeChartData defines the structure used to deserialize the c:cat,
+// c:val, c:xVal and c:yVal element.
+type decodeChartData struct {
+	StrRef *cStrRef   `xml:"strRef"`
+	NumRef *cNumRef   `xml:"numRef"`
+	StrLit *cStrCache `xml:"strLit"`
+	NumLit *cNumCache `xml:"numLit"`
+}
+
+// decodeChartDLbls defines the structure used to deserialize the c:dLbls
+// element.
+type decodeChartDLbls struct {
+	Delete          *attrValBool     `xml:"delete"`
+	NumFmt          *cNumFmt         `xml:"numFmt"`
+	SpPr            *decodeChartSpPr `xml:"spPr"`
+	TxPr            *decodeChartText `xml:"txPr"`
+	DLblPos         *attrValString   `xml:"dLblPos"`
+	ShowLegendKey   *attrValBool     `xml:"showLegendKey"`
+	ShowVal         *attrValBool     `xml:"showVal"`
+	ShowCatName     *attrValBool     `xml:"showCatName"`
+	ShowSerName     *attrValBool     `xml:"showSerName"`
+	ShowPercent     *attrValBool     `xml:"showPercent"`
+	ShowBubbleSize  *attrValBool     `xml:"showBubbleSize"`
+	Separator       *string          `xml:"separator"`
+	ShowLeaderLines *attrValBool     `xml:"showLeaderLines"`
+}
+
+// decodeChartAxis defines the structure used to deserialize the c:catAx,
+// c:valAx, c:dateAx and c:serAx element.
+type decodeChartAxis struct {
+	AxID           *attrValInt       `xml:"axId"`
+	Scaling        *cScaling         `xml:"scaling"`
+	Delete         *attrValBool      `xml:"delete"`
+	AxPos          *attrValString    `xml:"axPos"`
+	MajorGridlines *decodeChartLines `xml:"majorGridlines"`
+	MinorGridlines *decodeChartLines `xml:"minorGridlines"`
+	Title          *decodeChartTitle `xml:"title"`
+	NumFmt         *cNumFmt          `xml:"numFmt"`
+	TickLblPos     *attrValString    `xml:"tickLblPos"`
+	SpPr           *decodeChartSpPr  `xml:"spPr"`
+	TxPr           *decodeChartText  `xml:"txPr"`
+	CrossAx        *attrValInt       `xml:"crossAx"`
+	CrossBetween   *attrValString    `xml:"crossBetween"`
+	MajorUnit      *attrValFloat     `xml:"majorUnit"`
+	TickLblSkip    *attrValInt       `xml:"tickLblSkip"`
+}
+
+// decodeChartLines defines the structure used to deserialize the chart
+// lines content model, such as c:majorGridlines element.
+type decodeChartLines struct {
+	SpPr *decodeChartSpPr `xml:"spPr"`
+}
+
+// decodeChartLegend defines the structure used to deserialize the c:legend
+// element.
+type decodeChartLegend struct {
+	LegendPos   *attrValString           `xml:"legendPos"`
+	LegendEntry []decodeChartLegendEntry `xml:"legendEntry"`
+	Overlay     *attrValBool             `xml:"overlay"`
+	TxPr        *decodeChartText         `xml:"txPr"`
+}
+
+// decodeChartLegendEntry defines the structure used to deserialize the
+// c:legendEntry element.
+type decodeChartLegendEntry struct {
+	IDx    *attrValInt      `xml:"idx"`
+	Delete *attrValBool     `xml:"delete"`
+	TxPr   *decodeChartText `xml:"txPr"`
+}
@@ -24,6 +24,7 @@ type decodeCellAnchor struct {
 	Ext              *decodePositiveSize2D   `xml:"ext"`
 	Sp               *decodeSp               `xml:"sp"`
 	Pic              *decodePic              `xml:"pic"`
+	GraphicFrame     *decodeGraphicFrame     `xml:"graphicFrame"`
 	ClientData       *decodeClientData       `xml:"clientData"`
 	AlternateContent []*xlsxAlternateContent `xml:"AlternateContent"`
 	Content          string                  `xml:",innerxml"`
@@ -61,6 +62,26 @@ type decodeChoice struct {
 type decodeGraphicFrame struct {
 	Macro            string                 `xml:"macro,attr"`
 	NvGraphicFramePr decodeNvGraphicFramePr `xml:"nvGraphicFramePr"`
+	Graphic          *decodeGraphic         `xml:"graphic"`
+}
+
+// decodeGraphic defines the structure used to deserialize the a:graphic
+// element.
+type decodeGraphic struct {
+	GraphicData decodeGraphicData `xml:"graphicData"`
+}
+
+// decodeGraphicData defines the structure used to deserialize the
+// a:graphicData element.
+type decodeGraphicData struct {
+	URI   string            `xml:"uri,attr"`
+	Chart *decodeChartRefer `xml:"chart"`
+}
+
+// decodeChartRefer defines the structure used to deserialize the c:chart
+// element, which specifies the relationship ID of the chart part.
+type decodeChartRefer struct {
+	RID string `xml:"id,attr"`
 }
 
 // decodeNvGraphicFramePr defines the structure used to deserialize the
@@ -0,0 +1,118 @@
+// Copyright 2016 - 2026 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.25.0 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// xlsxExternalLink directly maps the externalLink element. This element is
+// the root element of the external link part, which contains the cached data
+// of the external workbook for the formulas referencing it.
+type xlsxExternalLink struct {
+	XMLName      xml.Name          `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main externalLink"`
+	ExternalBook *xlsxExternalBook `xml:"externalBook"`
+	DdeLink      *xlsxInnerXML     `xml:"ddeLink"`
+	OleLink      *xlsxInnerXML     `xml:"oleLink"`
+	ExtLst       *xlsxExtLst       `xml:"extLst"`
+}
+
+// xlsxExternalBook directly maps the externalBook element. This element
+// specifies the relationship ID of the external workbook path, the sheet
+// names, defined names and cached cell values of the external workbook.
+type xlsxExternalBook struct {
+	RID          string                    `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr,omitempty"`
+	SheetNames   *xlsxExternalSheetNames   `xml:"sheetNames"`
+	DefinedNames *xlsxExternalDefinedNames `xml:"definedNames"`
+	SheetDataSet *xlsxExternalSheetDataSet `xml:"sheetDataSet"`
+}
+
+// xlsxExternalSheetNames directly maps the sheetNames element. This element
+// specifies the sheet names of the external workbook.
+type xlsxExternalSheetNames struct {
+	SheetName []attrValString `xml:"sheetName"`
+}
+
+// xlsxExternalDefinedNames directly maps the definedNames element of the
+// external workbook.
+type xlsxExternalDefinedNames struct {
+	DefinedName []xlsxExternalDefinedName `xml:"definedName"`
+}
+
+// xlsxExternalDefinedName directly maps the definedName element. This element
+// specifies a defined name in the external workbook.
+type xlsxExternalDefinedName struct {
+	Name     string `xml:"name,attr"`
+	RefersTo string `xml:"refersTo,attr,omitempty"`
+	SheetID  *int   `xml:"sheetId,attr"`
+}
+
+// xlsxExternalSheetDataSet directly maps the sheetDataSet element. This
+// element specifies the cached data of the external workbook sheets.
+type xlsxExternalSheetDataSet struct {
+	SheetData []xlsxExternalSheetData `xml:"sheetData"`
+}
+
+// xlsxExternalSheetData directly maps the sheetData element. This element
+// specifies the cached data of a sheet in the external workbook, the sheetId
+// attribute is the zero-based index of the sheet in the sheet names.
+type xlsxExternalSheetData struct {
+	SheetID      int               `xml:"sheetId,attr"`
+	RefreshError bool              `xml:"refreshError,attr,omitempty"`
+	Row          []xlsxExternalRow `xml:"row"`
+}
+
+// xlsxExternalRow directly maps the row element of the external workbook
+// cached data.
+type xlsxExternalRow struct {
+	R    int                `xml:"r,attr"`
+	Cell []xlsxExternalCell `xml:"cell"`
+}
+
+// xlsxExternalCell directly maps the cell element of the external workbook
+// cached data.
+type xlsxExternalCell struct {
+	R  string `xml:"r,attr,omitempty"`
+	T  string `xml:"t,attr,omitempty"`
+	VM *uint  `xml:"vm,attr"`
+	V  string `xml:"v,omitempty"`
+}
+
+// ExternalLink directly maps the settings of the external workbook link. The
+// Index is the one-based index of the external link in the workbook, which
+// used in formulas to reference the external workbook, such as
+// [1]Sheet1!A1. The Target specifies the path of the external workbook. The
+// Cells specifies the cached cell values of the external workbook, which will
+// be used when calculating the formulas referencing the external workbook.
+type ExternalLink struct {
+	Index        int
+	Target       string
+	SheetNames   []string
+	DefinedNames []ExternalLinkDefinedName
+	Cells        []ExternalLinkCell
+}
+
+// ExternalLinkDefinedName directly maps the defined name of the external
+// workbook. The Scope specifies the sheet name for the sheet-level defined
+// name, or empty for the workbook-level defined name.
+type ExternalLinkDefinedName struct {
+	Name     string
+	RefersTo string
+	Scope    string
+}
+
+// ExternalLinkCell directly maps the cached cell value of the external
+// workbook.
+type ExternalLinkCell struct {
+	Sheet string
+	Cell  string
+	Type  CellType
+	Value string
+}
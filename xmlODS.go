@@ -0,0 +1,225 @@
+// Copyright 2016 - 2026 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.25.0 or later.
+
+package excelize
+
+import "encoding/xml"
+
+// Namespaces of the OpenDocument spreadsheet.
+const (
+	NameSpaceODSFO       = "urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0"
+	NameSpaceODSManifest = "urn:oasis:names:tc:opendocument:xmlns:manifest:1.0"
+	NameSpaceODSNumber   = "urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0"
+	NameSpaceODSOF       = "urn:oasis:names:tc:opendocument:xmlns:of:1.2"
+	NameSpaceODSOffice   = "urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+	NameSpaceODSStyle    = "urn:oasis:names:tc:opendocument:xmlns:style:1.0"
+	NameSpaceODSSVG      = "urn:oasis:names:tc:opendocument:xmlns:svg-compatible:1.0"
+	NameSpaceODSTable    = "urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+	NameSpaceODSText     = "urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+	// ContentTypeODS defined the MIME type of the OpenDocument spreadsheet.
+	ContentTypeODS = "application/vnd.oasis.opendocument.spreadsheet"
+)
+
+// odsDocumentContent directly maps the office:document-content element in
+// the content.xml of the OpenDocument spreadsheet.
+type odsDocumentContent struct {
+	XMLName          xml.Name             `xml:"office:document-content"`
+	XMLNSOffice      string               `xml:"xmlns:office,attr"`
+	XMLNSStyle       string               `xml:"xmlns:style,attr"`
+	XMLNSText        string               `xml:"xmlns:text,attr"`
+	XMLNSTable       string               `xml:"xmlns:table,attr"`
+	XMLNSFO          string               `xml:"xmlns:fo,attr"`
+	XMLNSNumber      string               `xml:"xmlns:number,attr"`
+	XMLNSSVG         string               `xml:"xmlns:svg,attr"`
+	XMLNSOF          string               `xml:"xmlns:of,attr"`
+	Version          string               `xml:"office:version,attr"`
+	AutomaticStyles  odsAutomaticStyles   `xml:"office:automatic-styles"`
+	Tables           []odsTable           `xml:"office:body>office:spreadsheet>table:table"`
+	NamedExpressions []odsNamedExpression `xml:"office:body>office:spreadsheet>table:named-expressions>table:named-expression"`
+}
+
+// odsDocumentStyles directly maps the office:document-styles element in the
+// styles.xml of the OpenDocument spreadsheet.
+type odsDocumentStyles struct {
+	XMLName      xml.Name   `xml:"office:document-styles"`
+	XMLNSOffice  string     `xml:"xmlns:office,attr"`
+	XMLNSStyle   string     `xml:"xmlns:style,attr"`
+	XMLNSFO      string     `xml:"xmlns:fo,attr"`
+	Version      string     `xml:"office:version,attr"`
+	DefaultStyle odsStyle   `xml:"office:styles>style:default-style"`
+	Styles       []odsStyle `xml:"office:styles>style:style"`
+}
+
+// odsManifest directly maps the manifest:manifest element in the
+// META-INF/manifest.xml of the OpenDocument spreadsheet.
+type odsManifest struct {
+	XMLName       xml.Name               `xml:"manifest:manifest"`
+	XMLNSManifest string                 `xml:"xmlns:manifest,attr"`
+	Version       string                 `xml:"manifest:version,attr"`
+	FileEntries   []odsManifestFileEntry `xml:"manifest:file-entry"`
+}
+
+// odsManifestFileEntry directly maps the manifest:file-entry element.
+type odsManifestFileEntry struct {
+	FullPath  string `xml:"manifest:full-path,attr"`
+	Version   string `xml:"manifest:version,attr,omitempty"`
+	MediaType string `xml:"manifest:media-type,attr"`
+}
+
+// odsAutomaticStyles directly maps the office:automatic-styles element, the
+// number styles are stored as generic elements.
+type odsAutomaticStyles struct {
+	NumberStyles []odsElement `xml:",any"`
+	Styles       []odsStyle   `xml:"style:style"`
+}
+
+// odsElement directly maps the generic element of the OpenDocument
+// spreadsheet, the element name should be set with the namespace prefix.
+type odsElement struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr   `xml:",any,attr"`
+	Text     string       `xml:",chardata"`
+	Children []odsElement `xml:",any"`
+}
+
+// odsStyle directly maps the style:style and style:default-style elements.
+type odsStyle struct {
+	Name                  string                    `xml:"style:name,attr,omitempty"`
+	Family                string                    `xml:"style:family,attr"`
+	ParentStyleName       string                    `xml:"style:parent-style-name,attr,omitempty"`
+	DataStyleName         string                    `xml:"style:data-style-name,attr,omitempty"`
+	TableProperties       *odsTableProperties       `xml:"style:table-properties"`
+	TableColumnProperties *odsTableColumnProperties `xml:"style:table-column-properties"`
+	TableRowProperties    *odsTableRowProperties    `xml:"style:table-row-properties"`
+	TableCellProperties   *odsTableCellProperties   `xml:"style:table-cell-properties"`
+	ParagraphProperties   *odsParagraphProperties   `xml:"style:paragraph-properties"`
+	TextProperties        *odsTextProperties        `xml:"style:text-properties"`
+}
+
+// odsTableProperties directly maps the style:table-properties element.
+type odsTableProperties struct {
+	Display string `xml:"table:display,attr"`
+}
+
+// odsTableColumnProperties directly maps the style:table-column-properties
+// element.
+type odsTableColumnProperties struct {
+	ColumnWidth string `xml:"style:column-width,attr"`
+}
+
+// odsTableRowProperties directly maps the style:table-row-properties element.
+type odsTableRowProperties struct {
+	RowHeight           string `xml:"style:row-height,attr"`
+	UseOptimalRowHeight string `xml:"style:use-optimal-row-height,attr"`
+}
+
+// odsTableCellProperties directly maps the style:table-cell-properties
+// element.
+type odsTableCellProperties struct {
+	BackgroundColor string `xml:"fo:background-color,attr,omitempty"`
+	BorderLeft      string `xml:"fo:border-left,attr,omitempty"`
+	BorderRight     string `xml:"fo:border-right,attr,omitempty"`
+	BorderTop       string `xml:"fo:border-top,attr,omitempty"`
+	BorderBottom    string `xml:"fo:border-bottom,attr,omitempty"`
+	DiagonalTLBR    string `xml:"style:diagonal-tl-br,attr,omitempty"`
+	DiagonalBLTR    string `xml:"style:diagonal-bl-tr,attr,omitempty"`
+	WrapOption      string `xml:"fo:wrap-option,attr,omitempty"`
+	VerticalAlign   string `xml:"style:vertical-align,attr,omitempty"`
+	RotationAngle   string `xml:"style:rotation-angle,attr,omitempty"`
+	ShrinkToFit     string `xml:"style:shrink-to-fit,attr,omitempty"`
+	TextAlignSource string `xml:"style:text-align-source,attr,omitempty"`
+}
+
+// odsParagraphProperties directly maps the style:paragraph-properties
+// element.
+type odsParagraphProperties struct {
+	TextAlign  string `xml:"fo:text-align,attr,omitempty"`
+	MarginLeft string `xml:"fo:margin-left,attr,omitempty"`
+}
+
+// odsTextProperties directly maps the style:text-properties element.
+type odsTextProperties struct {
+	FontFamily       string `xml:"fo:font-family,attr,omitempty"`
+	FontSize         string `xml:"fo:font-size,attr,omitempty"`
+	FontWeight       string `xml:"fo:font-weight,attr,omitempty"`
+	FontStyle        string `xml:"fo:font-style,attr,omitempty"`
+	Color            string `xml:"fo:color,attr,omitempty"`
+	TextPosition     string `xml:"style:text-position,attr,omitempty"`
+	UnderlineStyle   string `xml:"style:text-underline-style,attr,omitempty"`
+	UnderlineType    string `xml:"style:text-underline-type,attr,omitempty"`
+	UnderlineWidth   string `xml:"style:text-underline-width,attr,omitempty"`
+	UnderlineColor   string `xml:"style:text-underline-color,attr,omitempty"`
+	LineThroughStyle string `xml:"style:text-line-through-style,attr,omitempty"`
+}
+
+// odsTable directly maps the table:table element.
+type odsTable struct {
+	Name             string               `xml:"table:name,attr"`
+	StyleName        string               `xml:"table:style-name,attr,omitempty"`
+	Columns          []odsTableColumn     `xml:"table:table-column"`
+	Rows             []odsTableRow        `xml:"table:table-row"`
+	NamedExpressions []odsNamedExpression `xml:"table:named-expressions>table:named-expression"`
+}
+
+// odsNamedExpression directly maps the table:named-expression element.
+type odsNamedExpression struct {
+	Name            string `xml:"table:name,attr"`
+	BaseCellAddress string `xml:"table:base-cell-address,attr"`
+	Expression      string `xml:"table:expression,attr"`
+}
+
+// odsTableColumn directly maps the table:table-column element.
+type odsTableColumn struct {
+	StyleName            string `xml:"table:style-name,attr,omitempty"`
+	Repeated             int    `xml:"table:number-columns-repeated,attr,omitempty"`
+	Visibility           string `xml:"table:visibility,attr,omitempty"`
+	DefaultCellStyleName string `xml:"table:default-cell-style-name,attr,omitempty"`
+}
+
+// odsTableRow directly maps the table:table-row element.
+type odsTableRow struct {
+	StyleName  string         `xml:"table:style-name,attr,omitempty"`
+	Repeated   int            `xml:"table:number-rows-repeated,attr,omitempty"`
+	Visibility string         `xml:"table:visibility,attr,omitempty"`
+	Cells      []odsTableCell `xml:"table:table-cell"`
+}
+
+// odsTableCell directly maps the table:table-cell and table:covered-table-cell
+// elements, the element name of the covered cell should be set by XMLName.
+type odsTableCell struct {
+	XMLName        xml.Name
+	StyleName      string    `xml:"table:style-name,attr,omitempty"`
+	Repeated       int       `xml:"table:number-columns-repeated,attr,omitempty"`
+	ColumnsSpanned int       `xml:"table:number-columns-spanned,attr,omitempty"`
+	RowsSpanned    int       `xml:"table:number-rows-spanned,attr,omitempty"`
+	Formula        string    `xml:"table:formula,attr,omitempty"`
+	ValueType      string    `xml:"office:value-type,attr,omitempty"`
+	Value          string    `xml:"office:value,attr,omitempty"`
+	DateValue      string    `xml:"office:date-value,attr,omitempty"`
+	TimeValue      string    `xml:"office:time-value,attr,omitempty"`
+	BooleanValue   string    `xml:"office:boolean-value,attr,omitempty"`
+	Paragraphs     []odsText `xml:"text:p"`
+}
+
+// odsText directly maps the text:p element, the content has been encoded with
+// the text:s, text:tab elements.
+type odsText struct {
+	Content string `xml:",innerxml"`
+}
+
+// odsNode directly maps the generic element of the OpenDocument spreadsheet
+// for reading, the element and attributes names are resolved with namespace.
+type odsNode struct {
+	Name     xml.Name
+	Attrs    []xml.Attr
+	Text     string
+	Children []*odsNode
+}
@@ -51,6 +51,20 @@ type xlsxPivotCacheDefinition struct {
 	ExtLst                *xlsxExtLst            `xml:"extLst"`
 }
 
+// xlsxPivotCacheRecords represents the pivotCacheRecords part. This part
+// contains the underlying source data of the pivot cache, each record refers
+// to the shared items of the cache fields by the index.
+type xlsxPivotCacheRecords struct {
+	XMLName xml.Name                `xml:"http://schemas.openxmlformats.org/spreadsheetml/2006/main pivotCacheRecords"`
+	Count   int                     `xml:"count,attr"`
+	R       []*xlsxPivotCacheRecord `xml:"r"`
+}
+
+// xlsxPivotCacheRecord represents a record in the pivot cache records.
+type xlsxPivotCacheRecord struct {
+	X []*xlsxX `xml:"x"`
+}
+
 // xlsxCacheSource represents the description of data source whose data is
 // stored in the pivot cache. The data source refers to the underlying rows or
 // database records that provide the data for a PivotTable. You can create a
@@ -104,7 +118,7 @@ type xlsxCacheField struct {
 	SQLType             int              `xml:"sqlType,attr,omitempty"`
 	Hierarchy           int              `xml:"hierarchy,attr,omitempty"`
 	Level               int              `xml:"level,attr,omitempty"`
-	DatabaseField       bool             `xml:"databaseField,attr,omitempty"`
+	DatabaseField       *bool            `xml:"databaseField,attr"`
 	MappingCount        int              `xml:"mappingCount,attr,omitempty"`
 	MemberPropertyField bool             `xml:"memberPropertyField,attr,omitempty"`
 	SharedItems         *xlsxSharedItems `xml:"sharedItems"`
@@ -161,7 +175,41 @@ type xlsxSharedItem struct {
 type xlsxTuples struct{}
 
 // xlsxFieldGroup represents the collection of properties for a field group.
-type xlsxFieldGroup struct{}
+type xlsxFieldGroup struct {
+	Par        *int            `xml:"par,attr"`
+	Base       *int            `xml:"base,attr"`
+	RangePr    *xlsxRangePr    `xml:"rangePr"`
+	DiscretePr *xlsxDiscretePr `xml:"discretePr"`
+	GroupItems *xlsxGroupItems `xml:"groupItems"`
+}
+
+// xlsxRangePr represents the properties of the range grouping of the field,
+// the field values could be grouped by the numeric range or date time
+// periods.
+type xlsxRangePr struct {
+	AutoStart     *bool   `xml:"autoStart,attr"`
+	AutoEnd       *bool   `xml:"autoEnd,attr"`
+	GroupBy       string  `xml:"groupBy,attr,omitempty"`
+	StartNum      float64 `xml:"startNum,attr,omitempty"`
+	EndNum        float64 `xml:"endNum,attr,omitempty"`
+	StartDate     string  `xml:"startDate,attr,omitempty"`
+	EndDate       string  `xml:"endDate,attr,omitempty"`
+	GroupInterval float64 `xml:"groupInterval,attr,omitempty"`
+}
+
+// xlsxDiscretePr represents the collection of discrete grouping properties
+// of the field, each element maps a shared item to the index of the group
+// item.
+type xlsxDiscretePr struct {
+	Count int      `xml:"count,attr"`
+	X     []*xlsxX `xml:"x"`
+}
+
+// xlsxGroupItems represents the collection of items in the field group.
+type xlsxGroupItems struct {
+	Count int              `xml:"count,attr"`
+	Items []xlsxSharedItem `xml:",any"`
+}
 
 // xlsxCacheHierarchies represents the collection of OLAP hierarchies in the
 // PivotCache.
@@ -220,11 +220,16 @@ type xlsxRowItems struct {
 // xlsxI represents the collection of items in the row region of the
 // PivotTable.
 type xlsxI struct {
+	T string   `xml:"t,attr,omitempty"`
+	R int      `xml:"r,attr,omitempty"`
+	I int      `xml:"i,attr,omitempty"`
 	X []*xlsxX `xml:"x"`
 }
 
 // xlsxX represents an array of indexes to cached shared item values.
-type xlsxX struct{}
+type xlsxX struct {
+	V int `xml:"v,attr,omitempty"`
+}
 
 // xlsxColFields represents the collection of fields that are on the column
 // axis of the PivotTable.
@@ -0,0 +1,211 @@
+// Copyright 2016 - 2026 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.25.0 or later.
+
+package excelize
+
+import (
+	"crypto/x509"
+	"encoding/xml"
+	"time"
+)
+
+// xlsxSignature directly maps the Signature element in the namespace
+// http://www.w3.org/2000/09/xmldsig#. This element is the root of the digital
+// signature part of the package, which contains the signed information, the
+// signature value, the certificate of the signer and the signed objects.
+type xlsxSignature struct {
+	XMLName        xml.Name              `xml:"http://www.w3.org/2000/09/xmldsig# Signature"`
+	ID             string                `xml:"Id,attr,omitempty"`
+	SignedInfo     *xlsxSignedInfo       `xml:"SignedInfo"`
+	SignatureValue string                `xml:"SignatureValue,omitempty"`
+	KeyInfo        *xlsxKeyInfo          `xml:"KeyInfo"`
+	Object         []xlsxSignatureObject `xml:"Object"`
+}
+
+// xlsxSignedInfo directly maps the SignedInfo element. This element contains
+// the canonicalization method, the signature method and the references of
+// the signed objects.
+type xlsxSignedInfo struct {
+	XMLName                xml.Name                 `xml:"http://www.w3.org/2000/09/xmldsig# SignedInfo"`
+	CanonicalizationMethod xlsxSignatureAlgorithm   `xml:"CanonicalizationMethod"`
+	SignatureMethod        xlsxSignatureAlgorithm   `xml:"SignatureMethod"`
+	Reference              []xlsxSignatureReference `xml:"Reference"`
+}
+
+// xlsxSignatureAlgorithm directly maps the element which specifies an
+// algorithm by the Algorithm attribute, such as the CanonicalizationMethod,
+// SignatureMethod, DigestMethod and Transform elements.
+type xlsxSignatureAlgorithm struct {
+	Algorithm string `xml:"Algorithm,attr"`
+}
+
+// xlsxSignatureReference directly maps the Reference element. This element
+// specifies the URI of the signed data, the transforms applied to the data,
+// the digest method and the digest value of the data.
+type xlsxSignatureReference struct {
+	URI          string                   `xml:"URI,attr"`
+	Type         string                   `xml:"Type,attr,omitempty"`
+	Transforms   *xlsxSignatureTransforms `xml:"Transforms"`
+	DigestMethod xlsxSignatureAlgorithm   `xml:"DigestMethod"`
+	DigestValue  string                   `xml:"DigestValue"`
+}
+
+// xlsxSignatureTransforms directly maps the Transforms element.
+type xlsxSignatureTransforms struct {
+	Transform []xlsxSignatureTransform `xml:"Transform"`
+}
+
+// xlsxSignatureTransform directly maps the Transform element. The
+// relationship transform selects the relationships to be signed by the
+// relationship identifiers or relationship types.
+type xlsxSignatureTransform struct {
+	Algorithm                   string                            `xml:"Algorithm,attr"`
+	RelationshipReference       []xlsxRelationshipReference       `xml:"http://schemas.openxmlformats.org/package/2006/digital-signature RelationshipReference"`
+	RelationshipsGroupReference []xlsxRelationshipsGroupReference `xml:"http://schemas.openxmlformats.org/package/2006/digital-signature RelationshipsGroupReference"`
+}
+
+// xlsxRelationshipReference directly maps the RelationshipReference element.
+// This element specifies the identifier of the relationship to be signed.
+type xlsxRelationshipReference struct {
+	SourceID string `xml:"SourceId,attr"`
+}
+
+// xlsxRelationshipsGroupReference directly maps the
+// RelationshipsGroupReference element. This element specifies the type of
+// the relationships to be signed.
+type xlsxRelationshipsGroupReference struct {
+	SourceType string `xml:"SourceType,attr"`
+}
+
+// xlsxKeyInfo directly maps the KeyInfo element. This element contains the
+// X.509 certificates of the signer.
+type xlsxKeyInfo struct {
+	X509Data struct {
+		X509Certificate []string `xml:"X509Certificate"`
+	} `xml:"X509Data"`
+}
+
+// xlsxSignatureObject directly maps the Object element. This element contains
+// the manifest of the signed package parts, the signature properties, or the
+// XAdES qualifying properties.
+type xlsxSignatureObject struct {
+	ID                   string                         `xml:"Id,attr,omitempty"`
+	Manifest             *xlsxSignatureManifest         `xml:"Manifest"`
+	SignatureProperties  *xlsxSignatureProperties       `xml:"SignatureProperties"`
+	QualifyingProperties *xlsxXAdESQualifyingProperties `xml:"http://uri.etsi.org/01903/v1.3.2# QualifyingProperties"`
+}
+
+// xlsxSignatureManifest directly maps the Manifest element. This element
+// contains the references of the signed package parts.
+type xlsxSignatureManifest struct {
+	Reference []xlsxSignatureReference `xml:"Reference"`
+}
+
+// xlsxSignatureProperties directly maps the SignatureProperties element.
+type xlsxSignatureProperties struct {
+	SignatureProperty []xlsxSignatureProperty `xml:"SignatureProperty"`
+}
+
+// xlsxSignatureProperty directly maps the SignatureProperty element. This
+// element contains the signature time or the signature information.
+type xlsxSignatureProperty struct {
+	ID              string               `xml:"Id,attr"`
+	Target          string               `xml:"Target,attr"`
+	SignatureTime   *xlsxSignatureTime   `xml:"http://schemas.openxmlformats.org/package/2006/digital-signature SignatureTime"`
+	SignatureInfoV1 *xlsxSignatureInfoV1 `xml:"http://schemas.microsoft.com/office/2006/digsig SignatureInfoV1"`
+}
+
+// xlsxSignatureTime directly maps the SignatureTime element in the namespace
+// http://schemas.openxmlformats.org/package/2006/digital-signature. This
+// element specifies the date and time of the signature was created.
+type xlsxSignatureTime struct {
+	Format string `xml:"http://schemas.openxmlformats.org/package/2006/digital-signature Format"`
+	Value  string `xml:"http://schemas.openxmlformats.org/package/2006/digital-signature Value"`
+}
+
+// xlsxSignatureInfoV1 directly maps the SignatureInfoV1 element in the
+// namespace http://schemas.microsoft.com/office/2006/digsig. This element
+// specifies the signature information of the spreadsheet applications.
+type xlsxSignatureInfoV1 struct {
+	SetupID                  string `xml:"SetupID"`
+	SignatureText            string `xml:"SignatureText"`
+	SignatureImage           string `xml:"SignatureImage"`
+	SignatureComments        string `xml:"SignatureComments"`
+	WindowsVersion           string `xml:"WindowsVersion"`
+	OfficeVersion            string `xml:"OfficeVersion"`
+	ApplicationVersion       string `xml:"ApplicationVersion"`
+	Monitors                 int    `xml:"Monitors"`
+	HorizontalResolution     int    `xml:"HorizontalResolution"`
+	VerticalResolution       int    `xml:"VerticalResolution"`
+	ColorDepth               int    `xml:"ColorDepth"`
+	SignatureProviderID      string `xml:"SignatureProviderId"`
+	SignatureProviderURL     string `xml:"SignatureProviderUrl"`
+	SignatureProviderDetails int    `xml:"SignatureProviderDetails"`
+	SignatureType            int    `xml:"SignatureType"`
+	ManifestHashAlgorithm    string `xml:"ManifestHashAlgorithm,omitempty"`
+}
+
+// xlsxXAdESQualifyingProperties directly maps the QualifyingProperties
+// element in the namespace http://uri.etsi.org/01903/v1.3.2#. This element
+// contains the XAdES signed properties of the signature.
+type xlsxXAdESQualifyingProperties struct {
+	Target           string                    `xml:"Target,attr"`
+	SignedProperties xlsxXAdESSignedProperties `xml:"http://uri.etsi.org/01903/v1.3.2# SignedProperties"`
+}
+
+// xlsxXAdESSignedProperties directly maps the SignedProperties element. This
+// element contains the signing time and the signing certificate.
+type xlsxXAdESSignedProperties struct {
+	ID                        string `xml:"Id,attr"`
+	SignedSignatureProperties struct {
+		SigningTime        string `xml:"http://uri.etsi.org/01903/v1.3.2# SigningTime"`
+		SigningCertificate struct {
+			Cert []xlsxXAdESCert `xml:"http://uri.etsi.org/01903/v1.3.2# Cert"`
+		} `xml:"http://uri.etsi.org/01903/v1.3.2# SigningCertificate"`
+		SignaturePolicyIdentifier struct {
+			SignaturePolicyImplied string `xml:"http://uri.etsi.org/01903/v1.3.2# SignaturePolicyImplied"`
+		} `xml:"http://uri.etsi.org/01903/v1.3.2# SignaturePolicyIdentifier"`
+	} `xml:"http://uri.etsi.org/01903/v1.3.2# SignedSignatureProperties"`
+}
+
+// xlsxXAdESCert directly maps the Cert element. This element specifies the
+// digest, issuer and serial number of the signing certificate.
+type xlsxXAdESCert struct {
+	CertDigest struct {
+		DigestMethod xlsxSignatureAlgorithm `xml:"http://www.w3.org/2000/09/xmldsig# DigestMethod"`
+		DigestValue  string                 `xml:"http://www.w3.org/2000/09/xmldsig# DigestValue"`
+	} `xml:"http://uri.etsi.org/01903/v1.3.2# CertDigest"`
+	IssuerSerial struct {
+		X509IssuerName   string `xml:"http://www.w3.org/2000/09/xmldsig# X509IssuerName"`
+		X509SerialNumber string `xml:"http://www.w3.org/2000/09/xmldsig# X509SerialNumber"`
+	} `xml:"http://uri.etsi.org/01903/v1.3.2# IssuerSerial"`
+}
+
+// SignatureOptions directly maps the settings of the digital signature. The
+// Comments specifies the purpose for signing the workbook, the SigningTime
+// specifies the signing time, the time of saving the workbook will be used if
+// it is zero.
+type SignatureOptions struct {
+	Comments    string
+	SigningTime time.Time
+}
+
+// Signature directly maps the digital signature of the workbook. The Signer
+// is the common name of the subject of the signing certificate, and the Valid
+// reports whether the signature value and the digests of all signed package
+// parts were verified successfully.
+type Signature struct {
+	Signer      string
+	Comments    string
+	SigningTime time.Time
+	Certificate *x509.Certificate
+	Valid       bool
+}
@@ -381,3 +381,31 @@ type Style struct {
 	CustomNumFmt  *string
 	NegRed        bool
 }
+
+// CellDataBar directly maps the data bar of the cell which evaluated by the
+// conditional formatting rules.
+type CellDataBar struct {
+	Length      float64
+	Color       string
+	BorderColor string
+	Direction   string
+	BarOnly     bool
+	BarSolid    bool
+}
+
+// CellIcon directly maps the icon of the cell which evaluated by the
+// conditional formatting rules.
+type CellIcon struct {
+	IconStyle string
+	Index     int
+	IconsOnly bool
+}
+
+// CellEffectiveStyle directly maps the effective style of the cell, which
+// merges the styles of the matched conditional formatting rules over the cell
+// style.
+type CellEffectiveStyle struct {
+	Style   *Style
+	DataBar *CellDataBar
+	Icon    *CellIcon
+}
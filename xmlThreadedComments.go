@@ -0,0 +1,114 @@
+// Copyright 2016 - 2026 The excelize Authors. All rights reserved. Use of
+// this source code is governed by a BSD-style license that can be found in
+// the LICENSE file.
+//
+// Package excelize providing a set of functions that allow you to write to and
+// read from XLAM / XLSM / XLSX / XLTM / XLTX files. Supports reading and
+// writing spreadsheet documents generated by Microsoft Excel™ 2007 and later.
+// Supports complex components by high compatibility, and provided streaming
+// API for generating or reading data from a worksheet with huge amounts of
+// data. This library needs Go version 1.25.0 or later.
+
+package excelize
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// xlsxThreadedComments directly maps the ThreadedComments element. This
+// element is the root of the threaded comments part, and contains all
+// threaded comments and their replies of a worksheet.
+type xlsxThreadedComments struct {
+	XMLName         xml.Name              `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments ThreadedComments"`
+	XMLNSX          string                `xml:"xmlns:x,attr,omitempty"`
+	ThreadedComment []xlsxThreadedComment `xml:"threadedComment"`
+	ExtLst          *xlsxExtLst           `xml:"extLst"`
+}
+
+// xlsxThreadedComment directly maps the threadedComment element. This element
+// represents a threaded comment or a reply of the threaded comment when the
+// parentId attribute was specified.
+type xlsxThreadedComment struct {
+	Ref      string                       `xml:"ref,attr,omitempty"`
+	DT       string                       `xml:"dT,attr,omitempty"`
+	PersonID string                       `xml:"personId,attr"`
+	ID       string                       `xml:"id,attr"`
+	ParentID string                       `xml:"parentId,attr,omitempty"`
+	Done     *bool                        `xml:"done,attr"`
+	Text     string                       `xml:"text"`
+	Mentions *xlsxThreadedCommentMentions `xml:"mentions"`
+	ExtLst   *xlsxExtLst                  `xml:"extLst"`
+}
+
+// xlsxThreadedCommentMentions directly maps the mentions element. This element
+// specifies a list of the person mentioned in the threaded comment.
+type xlsxThreadedCommentMentions struct {
+	Mention []xlsxThreadedCommentMention `xml:"mention"`
+}
+
+// xlsxThreadedCommentMention directly maps the mention element. This element
+// specifies the position of the mentioned person in the threaded comment
+// text.
+type xlsxThreadedCommentMention struct {
+	MentionPersonID string `xml:"mentionpersonId,attr"`
+	MentionID       string `xml:"mentionId,attr"`
+	StartIndex      int    `xml:"startIndex,attr"`
+	Length          int    `xml:"length,attr"`
+}
+
+// xlsxPersonList directly maps the personList element. This element is the
+// root of the persons part, and contains all authors and mentioned persons of
+// the threaded comments in the workbook.
+type xlsxPersonList struct {
+	XMLName xml.Name     `xml:"http://schemas.microsoft.com/office/spreadsheetml/2018/threadedcomments personList"`
+	XMLNSX  string       `xml:"xmlns:x,attr,omitempty"`
+	Person  []xlsxPerson `xml:"person"`
+	ExtLst  *xlsxExtLst  `xml:"extLst"`
+}
+
+// xlsxPerson directly maps the person element. This element specifies a
+// person who authored or be mentioned in the threaded comment.
+type xlsxPerson struct {
+	DisplayName string      `xml:"displayName,attr"`
+	ID          string      `xml:"id,attr"`
+	UserID      string      `xml:"userId,attr,omitempty"`
+	ProviderID  string      `xml:"providerId,attr,omitempty"`
+	ExtLst      *xlsxExtLst `xml:"extLst"`
+}
+
+// ThreadedComment directly maps the threaded comment settings. The Author
+// specifies the display name of the person in the person list, and the person
+// will be created automatically if it does not exist. The ID and ParentID are
+// read-only and ignored on adding threaded comment.
+type ThreadedComment struct {
+	ID       string
+	ParentID string
+	Cell     string
+	Author   string
+	Text     string
+	Date     time.Time
+	Done     bool
+	Mentions []ThreadedCommentMention
+	Replies  []ThreadedComment
+}
+
+// ThreadedCommentMention directly maps the settings of the person mentioned
+// in the threaded comment. The Person specifies the display name of the
+// mentioned person. The StartIndex and Length specifies the position of the
+// mention in UTF-16 code units of the text, the first "@" followed by the
+// person name in the text will be used if the Length is zero.
+type ThreadedCommentMention struct {
+	Person     string
+	StartIndex int
+	Length     int
+}
+
+// Person directly maps the person who authored or be mentioned in the threaded
+// comments.
+type Person struct {
+	ID          string
+	DisplayName string
+	UserID      string
+	ProviderID  string
+}